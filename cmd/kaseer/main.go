@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/delivery/job"
 	"github.com/ardafirdausr/kaseer/internal/delivery/web"
 )
 
@@ -13,5 +14,8 @@ func main() {
 		log.Fatalf("Failed initiate the app\n%v", err)
 	}
 
+	stopJobs := job.Start(app)
 	web.Start(app)
+	stopJobs()
+	app.Usecases.EventBus.Close()
 }
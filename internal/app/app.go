@@ -2,11 +2,14 @@ package app
 
 import (
 	"log"
+	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type App struct {
+	location     *time.Location
 	repositories *repositories
 	drivers      *drivers
 	services     *services
@@ -21,13 +24,26 @@ func New() (*App, error) {
 		log.Println(".env file not found")
 	}
 
-	drivers, err := newDrivers()
+	// the store timezone cuts the days of reports and the database session
+	timezone := os.Getenv("STORE_TIMEZONE")
+	if timezone == "" {
+		timezone = "Asia/Jakarta"
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Fatal(err.Error())
+		return nil, err
+	}
+
+	drivers, err := newDrivers(location)
 	if err != nil {
 		log.Fatal(err.Error())
 		return nil, err
 	}
 
 	app := new(App)
+	app.location = location
 	app.drivers = drivers
 	app.repositories = newMySQLRepositories(app.drivers.MySQL)
 	app.services = NewServices()
@@ -3,6 +3,7 @@ package app
 import (
 	"database/sql"
 	"log"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal/driver"
 )
@@ -11,8 +12,8 @@ type drivers struct {
 	MySQL *sql.DB
 }
 
-func newDrivers() (*drivers, error) {
-	MySQLConn, err := driver.ConnectToMySQLDB()
+func newDrivers(location *time.Location) (*drivers, error) {
+	MySQLConn, err := driver.ConnectToMySQLDB(location)
 	if err != nil {
 		log.Println(err.Error())
 		return nil, err
@@ -9,17 +9,43 @@ import (
 )
 
 type repositories struct {
-	UserRepository    internal.UserRepository
-	ProductRepository internal.ProductRepository
-	OrderRepository   internal.OrderRepository
-	UnitOfWork        internal.UnitOfWork
+	UserRepository          internal.UserRepository
+	ProductRepository       internal.ProductRepository
+	OrderRepository         internal.OrderRepository
+	VoucherRepository       internal.VoucherRepository
+	CustomerRepository      internal.CustomerRepository
+	LoyaltyRepository       internal.LoyaltyRepository
+	PrepaidCardRepository   internal.PrepaidCardRepository
+	PriceListRepository     internal.PriceListRepository
+	HeldCartRepository      internal.HeldCartRepository
+	StoreSettingRepository  internal.StoreSettingRepository
+	ReportRepository        internal.ReportRepository
+	MarketBasketRepository  internal.MarketBasketRepository
+	PurchaseOrderRepository internal.PurchaseOrderRepository
+	APITokenRepository      internal.APITokenRepository
+	WebhookRepository       internal.WebhookRepository
+	AuditLogRepository      internal.AuditLogRepository
+	UnitOfWork              internal.UnitOfWork
 }
 
 func newMySQLRepositories(DB *sql.DB) *repositories {
 	return &repositories{
-		UserRepository:    mysql.NewUserRepository(DB),
-		ProductRepository: mysql.NewProductRepository(DB),
-		OrderRepository:   mysql.NewOrderRepository(DB),
-		UnitOfWork:        mysql.NewMySQLUnitOfWork(DB),
+		UserRepository:          mysql.NewUserRepository(DB),
+		ProductRepository:       mysql.NewProductRepository(DB),
+		OrderRepository:         mysql.NewOrderRepository(DB),
+		VoucherRepository:       mysql.NewVoucherRepository(DB),
+		CustomerRepository:      mysql.NewCustomerRepository(DB),
+		LoyaltyRepository:       mysql.NewLoyaltyRepository(DB),
+		PrepaidCardRepository:   mysql.NewPrepaidCardRepository(DB),
+		PriceListRepository:     mysql.NewPriceListRepository(DB),
+		HeldCartRepository:      mysql.NewHeldCartRepository(DB),
+		StoreSettingRepository:  mysql.NewStoreSettingRepository(DB),
+		ReportRepository:        mysql.NewReportRepository(DB),
+		MarketBasketRepository:  mysql.NewMarketBasketRepository(DB),
+		PurchaseOrderRepository: mysql.NewPurchaseOrderRepository(DB),
+		APITokenRepository:      mysql.NewAPITokenRepository(DB),
+		WebhookRepository:       mysql.NewWebhookRepository(DB),
+		AuditLogRepository:      mysql.NewAuditLogRepository(DB),
+		UnitOfWork:              mysql.NewMySQLUnitOfWork(DB),
 	}
 }
@@ -1,21 +1,42 @@
 package app
 
 import (
+	"log"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/pkg/event"
 	"github.com/ardafirdausr/kaseer/internal/pkg/storage"
+	"github.com/ardafirdausr/kaseer/internal/pkg/token"
+	"github.com/ardafirdausr/kaseer/internal/pkg/webhook"
 )
 
 type services struct {
-	Storage internal.Storage
+	Storage       internal.Storage
+	Tokenizer     internal.Tokenizer
+	WebhookSender internal.WebhookSender
+	EventBus      internal.EventBus
 }
 
 func NewServices() *services {
 	storageDir := filepath.Join("web", "storage")
 	fileSystemStorage := storage.NewFileSystemStorage(storageDir)
 
+	// an empty secret would let anyone sign tokens
+	tokenSecret := os.Getenv("API_TOKEN_SECRET")
+	if tokenSecret == "" {
+		log.Fatal("API_TOKEN_SECRET is not set")
+	}
+	hmacTokenizer := token.NewHMACTokenizer(tokenSecret)
+
+	httpWebhookSender := webhook.NewHTTPSender(10 * time.Second)
+
 	services := new(services)
 	services.Storage = fileSystemStorage
+	services.Tokenizer = hmacTokenizer
+	services.WebhookSender = httpWebhookSender
+	services.EventBus = event.NewBus()
 	return services
 }
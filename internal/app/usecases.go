@@ -1,23 +1,144 @@
 package app
 
 import (
+	"os"
+	"strings"
+	"time"
+
 	"github.com/ardafirdausr/kaseer/internal"
 	"github.com/ardafirdausr/kaseer/internal/usecase"
 )
 
 type Usecases struct {
-	UserUsecase    internal.UserUsecase
-	ProductUsecase internal.ProductUsecase
-	OrderUsecase   internal.OrderUsecase
+	// EventBus takes the subscribers of the domain events the usecases
+	// publish.
+	EventBus internal.EventBus
+
+	// Location is the timezone of the store, the days of the dates the
+	// deliveries read start at its midnight.
+	Location *time.Location
+
+	// AdminEmails are the emails of the users allowed on the admin pages.
+	AdminEmails []string
+
+	UserUsecase             internal.UserUsecase
+	AccessTokenUsecase      internal.AccessTokenUsecase
+	APITokenUsecase         internal.APITokenUsecase
+	ProductUsecase          internal.ProductUsecase
+	OrderUsecase            internal.OrderUsecase
+	VoucherUsecase          internal.VoucherUsecase
+	CustomerUsecase         internal.CustomerUsecase
+	LoyaltyUsecase          internal.LoyaltyUsecase
+	PrepaidCardUsecase      internal.PrepaidCardUsecase
+	PriceListUsecase        internal.PriceListUsecase
+	HeldCartUsecase         internal.HeldCartUsecase
+	StoreSettingUsecase     internal.StoreSettingUsecase
+	ReportUsecase           internal.ReportUsecase
+	ProductAnalyticsUsecase internal.ProductAnalyticsUsecase
+	MarketBasketUsecase     internal.MarketBasketUsecase
+	ForecastUsecase         internal.ForecastUsecase
+	PurchaseOrderUsecase    internal.PurchaseOrderUsecase
+	WebhookUsecase          internal.WebhookUsecase
+	AuditLogUsecase         internal.AuditLogUsecase
 }
 
 func newUsecases(app *App) *Usecases {
-	userUsecase := usecase.NewUserUsecase(app.repositories.UserRepository, app.services.Storage)
-	productUsecase := usecase.NewProductUsecase(app.repositories.ProductRepository)
-	orderUsecase := usecase.NewOrderUsecase(app.repositories.OrderRepository, app.repositories.ProductRepository, app.repositories.UnitOfWork)
+	userUsecase := usecase.NewUserUsecase(
+		app.repositories.UserRepository,
+		app.repositories.AuditLogRepository,
+		app.services.Storage,
+		app.services.EventBus,
+		app.repositories.UnitOfWork)
+	accessTokenTTL, err := time.ParseDuration(os.Getenv("API_TOKEN_TTL"))
+	if err != nil || accessTokenTTL <= 0 {
+		accessTokenTTL = 24 * time.Hour
+	}
+	accessTokenUsecase := usecase.NewAccessTokenUsecase(
+		app.repositories.UserRepository,
+		app.repositories.AuditLogRepository,
+		app.services.Tokenizer,
+		app.services.EventBus,
+		accessTokenTTL)
+	apiTokenUsecase := usecase.NewAPITokenUsecase(app.repositories.APITokenRepository, app.repositories.UserRepository)
+	productUsecase := usecase.NewProductUsecase(
+		app.repositories.ProductRepository,
+		app.repositories.AuditLogRepository,
+		app.services.EventBus,
+		app.repositories.UnitOfWork)
+	orderUsecase := usecase.NewOrderUsecase(
+		app.repositories.OrderRepository,
+		app.repositories.ProductRepository,
+		app.repositories.VoucherRepository,
+		app.repositories.CustomerRepository,
+		app.repositories.LoyaltyRepository,
+		app.repositories.PrepaidCardRepository,
+		app.repositories.PriceListRepository,
+		app.repositories.StoreSettingRepository,
+		app.repositories.WebhookRepository,
+		app.repositories.AuditLogRepository,
+		app.services.EventBus,
+		app.repositories.UnitOfWork,
+		app.location)
+	voucherUsecase := usecase.NewVoucherUsecase(app.repositories.VoucherRepository, app.location)
+	customerUsecase := usecase.NewCustomerUsecase(app.repositories.CustomerRepository, app.repositories.OrderRepository)
+	loyaltyUsecase := usecase.NewLoyaltyUsecase(app.repositories.LoyaltyRepository)
+	prepaidCardUsecase := usecase.NewPrepaidCardUsecase(app.repositories.PrepaidCardRepository)
+	priceListUsecase := usecase.NewPriceListUsecase(
+		app.repositories.PriceListRepository,
+		app.repositories.ProductRepository,
+		app.repositories.CustomerRepository,
+		app.location)
+	heldCartExpiry, err := time.ParseDuration(os.Getenv("HELD_CART_EXPIRY"))
+	if err != nil || heldCartExpiry <= 0 {
+		heldCartExpiry = 2 * time.Hour
+	}
+	heldCartUsecase := usecase.NewHeldCartUsecase(app.repositories.HeldCartRepository, heldCartExpiry)
+	storeSettingUsecase := usecase.NewStoreSettingUsecase(app.repositories.StoreSettingRepository)
+	reportUsecase := usecase.NewReportUsecase(
+		app.repositories.ReportRepository,
+		app.repositories.OrderRepository,
+		app.location)
+	productAnalyticsUsecase := usecase.NewProductAnalyticsUsecase(app.repositories.ProductRepository, app.location)
+	marketBasketUsecase := usecase.NewMarketBasketUsecase(
+		app.repositories.MarketBasketRepository,
+		app.repositories.UnitOfWork,
+		app.location)
+	forecastUsecase := usecase.NewForecastUsecase(
+		app.repositories.ProductRepository,
+		app.repositories.PurchaseOrderRepository,
+		app.location)
+	purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(
+		app.repositories.PurchaseOrderRepository,
+		app.repositories.ProductRepository,
+		app.services.EventBus,
+		app.repositories.UnitOfWork)
+	webhookUsecase := usecase.NewWebhookUsecase(
+		app.repositories.WebhookRepository,
+		app.services.WebhookSender,
+		app.repositories.UnitOfWork)
+	auditLogUsecase := usecase.NewAuditLogUsecase(app.repositories.AuditLogRepository)
 	return &Usecases{
-		UserUsecase:    userUsecase,
-		ProductUsecase: productUsecase,
-		OrderUsecase:   orderUsecase,
+		EventBus:                app.services.EventBus,
+		Location:                app.location,
+		AdminEmails:             strings.Split(os.Getenv("ADMIN_EMAILS"), ","),
+		UserUsecase:             userUsecase,
+		AccessTokenUsecase:      accessTokenUsecase,
+		APITokenUsecase:         apiTokenUsecase,
+		ProductUsecase:          productUsecase,
+		OrderUsecase:            orderUsecase,
+		VoucherUsecase:          voucherUsecase,
+		CustomerUsecase:         customerUsecase,
+		LoyaltyUsecase:          loyaltyUsecase,
+		PrepaidCardUsecase:      prepaidCardUsecase,
+		PriceListUsecase:        priceListUsecase,
+		HeldCartUsecase:         heldCartUsecase,
+		StoreSettingUsecase:     storeSettingUsecase,
+		ReportUsecase:           reportUsecase,
+		ProductAnalyticsUsecase: productAnalyticsUsecase,
+		MarketBasketUsecase:     marketBasketUsecase,
+		ForecastUsecase:         forecastUsecase,
+		PurchaseOrderUsecase:    purchaseOrderUsecase,
+		WebhookUsecase:          webhookUsecase,
+		AuditLogUsecase:         auditLogUsecase,
 	}
 }
@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// Start runs the scheduled jobs in the background, the returned function
+// stops them and waits for a running job to give up.
+func Start(app *app.App) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	marketBasketInterval, err := time.ParseDuration(os.Getenv("MARKET_BASKET_REFRESH"))
+	if err != nil || marketBasketInterval <= 0 {
+		marketBasketInterval = 24 * time.Hour
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		every(ctx, marketBasketInterval, "market basket refresh", func(ctx context.Context) error {
+			run, err := app.Usecases.MarketBasketUsecase.RefreshMarketBasket(ctx, entity.MarketBasketParam{})
+			if err == nil {
+				log.Printf("Found %d product associations in %d orders", run.Associations, run.Orders)
+			}
+
+			return err
+		})
+	}()
+
+	webhookInterval, err := time.ParseDuration(os.Getenv("WEBHOOK_INTERVAL"))
+	if err != nil || webhookInterval <= 0 {
+		webhookInterval = 10 * time.Second
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		every(ctx, webhookInterval, "webhook delivery", func(ctx context.Context) error {
+			dispatched, err := app.Usecases.WebhookUsecase.DispatchWebhookEvents(ctx)
+			if err != nil {
+				return err
+			}
+
+			delivered, err := app.Usecases.WebhookUsecase.DeliverWebhooks(ctx)
+			if err == nil && dispatched+delivered > 0 {
+				log.Printf("Dispatched %d webhook events and attempted %d deliveries", dispatched, delivered)
+			}
+
+			return err
+		})
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// every runs the job right away and then after each interval until the
+// context is done, a failed run is logged and tried again next time.
+func every(ctx context.Context, interval time.Duration, name string, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			log.Printf("Failed running the %s: %v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+// APIResponse is the envelope of every successful API response, Meta is
+// only set on listings. Failures are enveloped by the error handler of the
+// server from the error the handler returns.
+type APIResponse struct {
+	Data interface{} `json:"data"`
+	Meta *APIMeta    `json:"meta,omitempty"`
+}
+
+// APIMeta pages a listing. Listings paged by offset count their Total,
+// listings paged by cursor hand out the NextCursor to ask for instead.
+type APIMeta struct {
+	Total      *int  `json:"total,omitempty"`
+	Offset     int   `json:"offset"`
+	Limit      int   `json:"limit"`
+	HasMore    bool  `json:"has_more"`
+	NextCursor int64 `json:"next_cursor,omitempty"`
+}
+
+func responseAPI(c echo.Context, code int, data interface{}) error {
+	return c.JSON(code, APIResponse{Data: data})
+}
+
+func responseAPIPage(c echo.Context, data interface{}, meta APIMeta) error {
+	return c.JSON(http.StatusOK, APIResponse{Data: data, Meta: &meta})
+}
+
+// apiID reads an ID path parameter, an ID that cannot exist is not found.
+func apiID(c echo.Context, name string) (int64, error) {
+	ID, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || ID < 1 {
+		return 0, echo.ErrNotFound
+	}
+
+	return ID, nil
+}
+
+// apiBind binds and validates the payload of an API request.
+func apiBind(c echo.Context, param interface{}) error {
+	if err := c.Bind(param); err != nil {
+		return err
+	}
+
+	return c.Validate(param)
+}
+
+// apiUser is the user the access token authenticated.
+func apiUser(c echo.Context) (*entity.User, error) {
+	user, ok := c.Get("user").(*entity.User)
+	if !ok {
+		return nil, entity.ErrInvalidCredential{Message: "Missing bearer access token"}
+	}
+
+	return user, nil
+}
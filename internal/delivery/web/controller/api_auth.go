@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type AuthAPIController struct {
+	accessTokenUc internal.AccessTokenUsecase
+}
+
+func NewAuthAPIController(ucs *app.Usecases) *AuthAPIController {
+	accessTokenUc := ucs.AccessTokenUsecase
+	return &AuthAPIController{accessTokenUc}
+}
+
+// CreateToken trades the email and password of a user for an access token.
+func (aac AuthAPIController) CreateToken(c echo.Context) error {
+	var credential entity.UserCredential
+	if err := apiBind(c, &credential); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	accessToken, err := aac.accessTokenUc.IssueAccessToken(ctx, credential)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusCreated, accessToken)
+}
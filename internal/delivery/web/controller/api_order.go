@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderAPIController struct {
+	orderUc  internal.OrderUsecase
+	location *time.Location
+}
+
+func NewOrderAPIController(ucs *app.Usecases) *OrderAPIController {
+	orderUc := ucs.OrderUsecase
+	return &OrderAPIController{orderUc, ucs.Location}
+}
+
+// APIOrderRefund is the refunded order with the store credit it was settled
+// with, if any.
+type APIOrderRefund struct {
+	Order       *entity.Order       `json:"order"`
+	StoreCredit *entity.PrepaidCard `json:"store_credit"`
+}
+
+// GetOrders lists the orders with the filters of the order listing, paged
+// by offset or, given a cursor, by order ID.
+func (oac OrderAPIController) GetOrders(c echo.Context) error {
+	query, err := orderQuery(c, oac.location)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	page, err := oac.orderUc.GetOrders(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	meta := APIMeta{
+		Total:      page.Total,
+		Offset:     page.Offset,
+		Limit:      page.Limit,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+	}
+	return responseAPIPage(c, page.Orders, meta)
+}
+
+// GetOrder returns the order with its items and payments.
+func (oac OrderAPIController) GetOrder(c echo.Context) error {
+	orderID, err := apiID(c, "orderId")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	order, err := oac.getOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, order)
+}
+
+func (oac OrderAPIController) getOrder(ctx context.Context, orderID int64) (*entity.Order, error) {
+	order, err := oac.orderUc.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Items, err = oac.orderUc.GetOrderItems(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Payments, err = oac.orderUc.GetOrderPayments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// CreateOrder places an order as the authenticated user. Retries carrying
+// the same Idempotency-Key header get the order of the first request.
+func (oac OrderAPIController) CreateOrder(c echo.Context) error {
+	var param entity.CreateOrderParam
+	if err := c.Bind(&param); err != nil {
+		return err
+	}
+
+	param.IdempotencyKey = c.Request().Header.Get("Idempotency-Key")
+	user, err := apiUser(c)
+	if err != nil {
+		return err
+	}
+	param.CashierID = &user.ID
+	param.CashierName = user.Name
+
+	if err := c.Validate(&param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	order, err := oac.orderUc.Create(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusCreated, order)
+}
+
+func (oac OrderAPIController) VoidOrder(c echo.Context) error {
+	orderID, err := apiID(c, "orderId")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := oac.orderUc.VoidOrder(ctx, orderID); err != nil {
+		return err
+	}
+
+	order, err := oac.getOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, order)
+}
+
+func (oac OrderAPIController) RefundOrder(c echo.Context) error {
+	orderID, err := apiID(c, "orderId")
+	if err != nil {
+		return err
+	}
+
+	var param entity.RefundOrderParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	storeCredit, err := oac.orderUc.RefundOrder(ctx, orderID, param)
+	if err != nil {
+		return err
+	}
+
+	order, err := oac.getOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, APIOrderRefund{order, storeCredit})
+}
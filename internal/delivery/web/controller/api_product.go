@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type ProductAPIController struct {
+	productUc internal.ProductUsecase
+}
+
+func NewProductAPIController(ucs *app.Usecases) *ProductAPIController {
+	productUc := ucs.ProductUsecase
+	return &ProductAPIController{productUc}
+}
+
+// GetProducts searches the catalog with the filters of the product listing,
+// paged by offset and limit.
+func (pac ProductAPIController) GetProducts(c echo.Context) error {
+	query, err := productQuery(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	page, err := pac.productUc.SearchProducts(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	meta := APIMeta{
+		Total:   &page.Total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+		HasMore: page.HasMore,
+	}
+	return responseAPIPage(c, page.Products, meta)
+}
+
+func (pac ProductAPIController) GetProductCategories(c echo.Context) error {
+	ctx := c.Request().Context()
+	categories, err := pac.productUc.GetProductCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, categories)
+}
+
+func (pac ProductAPIController) GetProduct(c echo.Context) error {
+	productID, err := apiID(c, "productId")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	product, err := pac.productUc.GetProductByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, product)
+}
+
+func (pac ProductAPIController) CreateProduct(c echo.Context) error {
+	var param entity.CreateProductParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	product, err := pac.productUc.CreateProduct(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusCreated, product)
+}
+
+// UpdateProduct replaces every field of the product.
+func (pac ProductAPIController) UpdateProduct(c echo.Context) error {
+	productID, err := apiID(c, "productId")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if _, err := pac.productUc.GetProductByID(ctx, productID); err != nil {
+		return err
+	}
+
+	var param entity.UpdateProductParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	if _, err := pac.productUc.UpdateProduct(ctx, productID, param); err != nil {
+		return err
+	}
+
+	product, err := pac.productUc.GetProductByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, product)
+}
+
+func (pac ProductAPIController) DeleteProduct(c echo.Context) error {
+	productID, err := apiID(c, "productId")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	isDeleted, err := pac.productUc.DeleteProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return entity.ErrNotFound{Message: "Product not found"}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportAPIController struct {
+	reportUc           internal.ReportUsecase
+	productAnalyticsUc internal.ProductAnalyticsUsecase
+}
+
+func NewReportAPIController(ucs *app.Usecases) *ReportAPIController {
+	reportUc := ucs.ReportUsecase
+	productAnalyticsUc := ucs.ProductAnalyticsUsecase
+	return &ReportAPIController{reportUc, productAnalyticsUc}
+}
+
+func (rac ReportAPIController) GetSalesReport(c echo.Context) error {
+	var param entity.SalesReportParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	report, err := rac.reportUc.GetSalesReport(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, report)
+}
+
+func (rac ReportAPIController) GetSalesHeatmap(c echo.Context) error {
+	var param entity.SalesHeatmapParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	heatmap, err := rac.reportUc.GetSalesHeatmap(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, heatmap)
+}
+
+// GetStockValuation values the stock on hand, the products are paged by
+// cursor and the summary covers all of them.
+func (rac ReportAPIController) GetStockValuation(c echo.Context) error {
+	var query entity.StockValuationQuery
+	if err := apiBind(c, &query); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	valuation, err := rac.reportUc.GetStockValuation(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	meta := APIMeta{
+		Limit:      valuation.Limit,
+		HasMore:    valuation.HasMore,
+		NextCursor: valuation.NextCursor,
+	}
+	return responseAPIPage(c, valuation, meta)
+}
+
+func (rac ReportAPIController) GetProductAnalytics(c echo.Context) error {
+	var param entity.ProductAnalyticsParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	analytics, err := rac.productAnalyticsUc.GetProductAnalytics(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, analytics)
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type APITokenController struct {
+	apiTokenUc internal.APITokenUsecase
+}
+
+func NewAPITokenController(ucs *app.Usecases) *APITokenController {
+	return &APITokenController{apiTokenUc: ucs.APITokenUsecase}
+}
+
+// createAPIToken issues a token to a user and shows it, the only time it is
+// shown, rather than redirecting with it in a flash.
+func (atc APITokenController) createAPIToken(c echo.Context, owner *entity.User, backURL string) error {
+	var param entity.CreateAPITokenParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var issued *entity.IssuedAPIToken
+	if err == nil {
+		issued, err = atc.apiTokenUc.CreateAPIToken(ctx, owner.ID, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, backURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Owner":    owner,
+		"Token":    issued.Token,
+		"APIToken": issued.APIToken,
+		"BackURL":  backURL,
+	}
+	return renderPage(c, "api_token_created", "API Token", data)
+}
+
+func (atc APITokenController) revokeAPIToken(c echo.Context, owner *entity.User, backURL string) error {
+	sess, _ := session.Get("kaseer", c)
+
+	tid := c.Param("tokenId")
+	tokenID, err := strconv.ParseInt(tid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	err = atc.apiTokenUc.RevokeAPIToken(ctx, owner.ID, tokenID)
+	if en, ok := err.(entity.ErrNotFound); ok {
+		sess.AddFlash(en.Message, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, backURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success revoking the API token", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, backURL)
+}
+
+func (atc APITokenController) CreateUserAPIToken(c echo.Context) error {
+	user, ok := c.Get("user").(*entity.User)
+	if !ok {
+		return echo.ErrInternalServerError
+	}
+
+	return atc.createAPIToken(c, user, "/profile")
+}
+
+func (atc APITokenController) RevokeUserAPIToken(c echo.Context) error {
+	user, ok := c.Get("user").(*entity.User)
+	if !ok {
+		return echo.ErrInternalServerError
+	}
+
+	return atc.revokeAPIToken(c, user, "/profile")
+}
+
+func (atc APITokenController) ShowAllServiceAccounts(c echo.Context) error {
+	ctx := c.Request().Context()
+	serviceAccounts, err := atc.apiTokenUc.GetServiceAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"ServiceAccounts": serviceAccounts}
+	return renderPage(c, "service_accounts", "Service Accounts", data)
+}
+
+func (atc APITokenController) CreateServiceAccount(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.CreateServiceAccountParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var serviceAccount *entity.User
+	if err == nil {
+		serviceAccount, err = atc.apiTokenUc.CreateServiceAccount(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/service-accounts")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success creating service account %s", serviceAccount.Name)
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/service-accounts/%d", serviceAccount.ID))
+}
+
+func (atc APITokenController) getServiceAccount(c echo.Context) (*entity.User, error) {
+	uid := c.Param("userId")
+	userID, err := strconv.ParseInt(uid, 10, 64)
+	if err != nil {
+		return nil, echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	serviceAccount, err := atc.apiTokenUc.GetServiceAccount(ctx, userID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, echo.ErrNotFound
+	}
+
+	return serviceAccount, err
+}
+
+func (atc APITokenController) ShowServiceAccountDetail(c echo.Context) error {
+	serviceAccount, err := atc.getServiceAccount(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	apiTokens, err := atc.apiTokenUc.GetAPITokens(ctx, serviceAccount.ID)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"ServiceAccount": serviceAccount,
+		"APITokens":      apiTokens,
+		"Scopes":         entity.APIScopes,
+	}
+	return renderPage(c, "service_account_detail", serviceAccount.Name, data)
+}
+
+func (atc APITokenController) CreateServiceAccountAPIToken(c echo.Context) error {
+	serviceAccount, err := atc.getServiceAccount(c)
+	if err != nil {
+		return err
+	}
+
+	return atc.createAPIToken(c, serviceAccount, fmt.Sprintf("/service-accounts/%d", serviceAccount.ID))
+}
+
+func (atc APITokenController) RevokeServiceAccountAPIToken(c echo.Context) error {
+	serviceAccount, err := atc.getServiceAccount(c)
+	if err != nil {
+		return err
+	}
+
+	return atc.revokeAPIToken(c, serviceAccount, fmt.Sprintf("/service-accounts/%d", serviceAccount.ID))
+}
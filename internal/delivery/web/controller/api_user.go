@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type UserAPIController struct {
+	userUc internal.UserUsecase
+}
+
+func NewUserAPIController(ucs *app.Usecases) *UserAPIController {
+	userUc := ucs.UserUsecase
+	return &UserAPIController{userUc}
+}
+
+func (uac UserAPIController) GetUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+	users, err := uac.userUc.GetAllUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, users)
+}
+
+func (uac UserAPIController) GetUser(c echo.Context) error {
+	userID, err := apiID(c, "userId")
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	user, err := uac.userUc.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, user)
+}
+
+// GetCurrentUser returns the user the access token was issued to.
+func (uac UserAPIController) GetCurrentUser(c echo.Context) error {
+	user, err := apiUser(c)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, user)
+}
+
+// UpdateCurrentUser changes the name and email of the authenticated user,
+// the photo is kept.
+func (uac UserAPIController) UpdateCurrentUser(c echo.Context) error {
+	user, err := apiUser(c)
+	if err != nil {
+		return err
+	}
+
+	if user.Email == "staff@mail.com" {
+		return echo.NewHTTPError(http.StatusForbidden, "Cannot update this demo account")
+	}
+
+	var param entity.UpdateUserParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+	param.PhotoUrl = user.PhotoUrl
+
+	ctx := c.Request().Context()
+	if _, err := uac.userUc.UpdateUser(ctx, user.ID, param); err != nil {
+		return err
+	}
+
+	user, err = uac.userUc.GetUserByID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return responseAPI(c, http.StatusOK, user)
+}
+
+func (uac UserAPIController) UpdateCurrentUserPassword(c echo.Context) error {
+	user, err := apiUser(c)
+	if err != nil {
+		return err
+	}
+
+	if user.Email == "staff@mail.com" {
+		return echo.NewHTTPError(http.StatusForbidden, "Cannot update this demo account")
+	}
+
+	// the new password would log in to an access token beyond the scopes of
+	// the personal API token
+	if _, ok := c.Get("api_token").(*entity.APIToken); ok {
+		return echo.NewHTTPError(http.StatusForbidden, "The password cannot be changed with a personal API token")
+	}
+
+	var param entity.UpdateUserPasswordParam
+	if err := apiBind(c, &param); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if _, err := uac.userUc.UpdateUserPassword(ctx, user.ID, param.Password); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/export"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+const auditLogsPerPage = 50
+
+type AuditLogController struct {
+	auditLogUc internal.AuditLogUsecase
+	userUc     internal.UserUsecase
+	location   *time.Location
+}
+
+func NewAuditLogController(ucs *app.Usecases) *AuditLogController {
+	return &AuditLogController{
+		auditLogUc: ucs.AuditLogUsecase,
+		userUc:     ucs.UserUsecase,
+		location:   ucs.Location,
+	}
+}
+
+func (alc AuditLogController) ShowAuditLogs(c echo.Context) error {
+	format, err := exportFormat(c)
+	var query entity.AuditLogQuery
+	if err == nil {
+		query, err = auditLogQuery(c, alc.location)
+	}
+
+	if err == nil {
+		query.Limit = auditLogsPerPage
+		query.Offset = pageOffset(c, auditLogsPerPage)
+		if format != "" {
+			query.Limit = exportBatchSize
+			query.Offset = 0
+		}
+	}
+
+	ctx := c.Request().Context()
+	var page *entity.AuditLogPage
+	if err == nil {
+		page, err = alc.auditLogUc.GetAuditLogs(ctx, query)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/audit-logs")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if format != "" {
+		return alc.exportAuditLogs(c, format, query, page)
+	}
+
+	users, err := alc.userUc.GetAllUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	pageNumber := page.Offset/page.Limit + 1
+	data := echo.Map{
+		"Logs":        page.Logs,
+		"Total":       *page.Total,
+		"Filter":      c.QueryParams(),
+		"Users":       users,
+		"Actions":     entity.AuditActions,
+		"EntityTypes": []string{entity.AuditEntityProduct, entity.AuditEntityUser, entity.AuditEntityOrder},
+		"Page":        pageNumber,
+		"PrevURL":     "",
+		"NextURL":     "",
+		"ExportURLs":  exportURLs(c),
+	}
+	if pageNumber > 1 {
+		data["PrevURL"] = pageURL(c, pageNumber-1)
+	}
+
+	if page.HasMore {
+		data["NextURL"] = pageURL(c, pageNumber+1)
+	}
+
+	return renderPage(c, "audit_logs", "Audit Log", data)
+}
+
+// exportAuditLogs streams the filtered logs from their first page, reading
+// the following pages by cursor as the previous ones are sent.
+func (alc AuditLogController) exportAuditLogs(c echo.Context, format export.Format, query entity.AuditLogQuery, page *entity.AuditLogPage) error {
+	ctx := c.Request().Context()
+	today := time.Now().In(alc.location).Format("2006-01-02")
+	columns := []string{"Date", "Actor", "Action", "Entity", "Entity ID", "Changes", "IP Address", "User Agent"}
+	return streamExport(c, format, "audit-log-"+today, "Audit Log "+today, columns, func(w export.Writer) error {
+		for {
+			for _, auditLog := range page.Logs {
+				err := w.WriteRow(
+					auditLog.CreatedAt,
+					auditLog.ActorName,
+					auditLog.Action,
+					auditLog.EntityType,
+					auditLog.EntityID,
+					auditLog.ChangeSummary(),
+					auditLog.IPAddress,
+					auditLog.UserAgent,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if !page.HasMore {
+				return nil
+			}
+
+			query.Cursor = page.NextCursor
+			var err error
+			page, err = alc.auditLogUc.GetAuditLogs(ctx, query)
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// auditLogQuery reads the audit log filters from the query string, dates
+// are whole days in the store's timezone.
+func auditLogQuery(c echo.Context, location *time.Location) (entity.AuditLogQuery, error) {
+	query := entity.AuditLogQuery{
+		Keyword:    strings.TrimSpace(c.QueryParam("keyword")),
+		Action:     c.QueryParam("action"),
+		EntityType: c.QueryParam("entity_type"),
+	}
+	errs := map[string]string{}
+
+	if value := c.QueryParam("entity_id"); value != "" {
+		entityID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs["EntityID"] = "Entity ID must be a number"
+		} else {
+			query.EntityID = &entityID
+		}
+	}
+
+	if value := c.QueryParam("actor_id"); value != "" {
+		actorID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs["ActorID"] = "Actor must be a user ID"
+		} else {
+			query.ActorID = &actorID
+		}
+	}
+
+	if value := c.QueryParam("start_date"); value != "" {
+		startDate, err := time.ParseInLocation("2006-01-02", value, location)
+		if err != nil {
+			errs["StartDate"] = "Start date must be formatted as YYYY-MM-DD"
+		} else {
+			query.StartDate = &startDate
+		}
+	}
+
+	if value := c.QueryParam("end_date"); value != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", value, location)
+		if err != nil {
+			errs["EndDate"] = "End date must be formatted as YYYY-MM-DD"
+		} else {
+			endDate = endDate.AddDate(0, 0, 1)
+			query.EndDate = &endDate
+		}
+	}
+
+	if len(errs) > 0 {
+		return query, entity.ErrValidation{
+			Message: "Invalid audit log filter",
+			Errors:  errs,
+		}
+	}
+
+	return query, nil
+}
@@ -1,9 +1,16 @@
 package controller
 
 import (
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/export"
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
 )
@@ -59,3 +66,127 @@ func responseErrorJson(c echo.Context, code int, message string, errs interface{
 	}
 	return c.JSON(code, payload)
 }
+
+// pageOffset turns the page query parameter of a listing into an offset.
+func pageOffset(c echo.Context, perPage int) int {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		return 0
+	}
+
+	return (page - 1) * perPage
+}
+
+// pageURL links to another page of the current listing, keeping its filters.
+func pageURL(c echo.Context, page int) string {
+	return listingURL(c, map[string]string{"page": strconv.Itoa(page)})
+}
+
+// listingURL links to the current listing with some of its query parameters
+// replaced, an empty value removes the parameter.
+func listingURL(c echo.Context, replace map[string]string) string {
+	params := url.Values{}
+	for key, values := range c.QueryParams() {
+		params[key] = values
+	}
+
+	for key, value := range replace {
+		if value == "" {
+			params.Del(key)
+			continue
+		}
+
+		params.Set(key, value)
+	}
+	return c.Request().URL.Path + "?" + params.Encode()
+}
+
+// validationMessage flattens a validation error into one flash message.
+func validationMessage(ev entity.ErrValidation) string {
+	reasons := []string{}
+	for _, reason := range ev.Errors {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	return strings.Join(append([]string{ev.Message}, reasons...), ". ")
+}
+
+// exportBatchSize is how many rows an export reads at a time, the largest
+// page the listings hand out.
+const exportBatchSize = 100
+
+// exportFormat reads the format query parameter of a report, no format asks
+// for the report itself rather than an export of it.
+func exportFormat(c echo.Context) (export.Format, error) {
+	value := c.QueryParam("format")
+	if value == "" {
+		return "", nil
+	}
+
+	format, err := export.ParseFormat(value)
+	if err != nil {
+		return "", entity.ErrValidation{
+			Message: "Invalid export format",
+			Errors:  map[string]string{"Format": "Format must be csv, xlsx or pdf"},
+		}
+	}
+
+	return format, nil
+}
+
+// exportURLs links to the exports of the current report, keeping its filters.
+func exportURLs(c echo.Context) map[string]string {
+	urls := map[string]string{}
+	for _, format := range []export.Format{export.FormatCSV, export.FormatXLSX, export.FormatPDF} {
+		urls[string(format)] = listingURL(c, map[string]string{"format": string(format), "page": "", "cursor": ""})
+	}
+
+	return urls
+}
+
+// streamExport sends a report as a download. The response is started before
+// rows is called, so anything that can be refused must be checked before.
+// Flushing the writer handed to rows also sends what was written so far.
+func streamExport(c echo.Context, format export.Format, name string, title string, columns []string, rows func(w export.Writer) error) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, format.ContentType())
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", format.Filename(name)))
+	res.WriteHeader(http.StatusOK)
+
+	w, err := export.NewWriter(format, res, title)
+	if err == nil {
+		err = w.WriteHeader(columns...)
+	}
+
+	if err == nil {
+		err = rows(responseExportWriter{w, res})
+	}
+
+	if err == nil {
+		err = w.Close()
+	}
+
+	// the status is already sent, the error can only cut the download short
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	res.Flush()
+	return nil
+}
+
+type responseExportWriter struct {
+	export.Writer
+	res *echo.Response
+}
+
+func (rew responseExportWriter) Flush() error {
+	if err := rew.Writer.Flush(); err != nil {
+		return err
+	}
+
+	rew.res.Flush()
+	return nil
+}
@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type CustomerController struct {
+	customerUc  internal.CustomerUsecase
+	loyaltyUc   internal.LoyaltyUsecase
+	priceListUc internal.PriceListUsecase
+}
+
+func NewCustomerController(ucs *app.Usecases) *CustomerController {
+	customerUc := ucs.CustomerUsecase
+	loyaltyUc := ucs.LoyaltyUsecase
+	priceListUc := ucs.PriceListUsecase
+	return &CustomerController{customerUc, loyaltyUc, priceListUc}
+}
+
+func (cc CustomerController) ShowAllCustomers(c echo.Context) error {
+	ctx := c.Request().Context()
+	customers, err := cc.customerUc.GetAllCustomers(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Customers": customers}
+	return renderPage(c, "customers", "All Customers", data)
+}
+
+func (cc CustomerController) ShowCreateCustomerForm(c echo.Context) error {
+	ctx := c.Request().Context()
+	groups, err := cc.customerUc.GetAllGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Groups": groups}
+	return renderPage(c, "customer_create", "Create Customer", data)
+}
+
+func (cc CustomerController) ShowCustomerDetail(c echo.Context) error {
+	cid := c.Param("customerId")
+	customerID, err := strconv.ParseInt(cid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	customer, err := cc.customerUc.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	orders, err := cc.customerUc.GetCustomerOrders(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	lifetimeValue, err := cc.customerUc.GetCustomerLifetimeValue(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	pointsBalance, err := cc.loyaltyUc.GetCustomerBalance(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	pointsEntries, err := cc.loyaltyUc.GetCustomerEntries(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	groups, err := cc.customerUc.GetAllGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Customer":      customer,
+		"Orders":        orders,
+		"LifetimeValue": lifetimeValue,
+		"PointsBalance": pointsBalance,
+		"PointsEntries": pointsEntries,
+		"Groups":        groups,
+	}
+	return renderPage(c, "customer_detail", customer.Name, data)
+}
+
+func (cc CustomerController) SearchCustomersData(c echo.Context) error {
+	ctx := c.Request().Context()
+	keyword := c.QueryParam("q")
+	customers, err := cc.customerUc.SearchCustomers(ctx, keyword)
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	return responseJson(c, http.StatusOK, "Success", customers)
+}
+
+func (cc CustomerController) GetCustomerPointsData(c echo.Context) error {
+	cid := c.Param("customerId")
+	customerID, err := strconv.ParseInt(cid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	pointsBalance, err := cc.loyaltyUc.GetCustomerBalance(ctx, customerID)
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	return responseJson(c, http.StatusOK, "Success", pointsBalance)
+}
+
+// GetCustomerPricesData returns the price list items the order screen uses to
+// reprice the cart for the customer.
+func (cc CustomerController) GetCustomerPricesData(c echo.Context) error {
+	cid := c.Param("customerId")
+	customerID, err := strconv.ParseInt(cid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	items, err := cc.priceListUc.GetCustomerPrices(ctx, customerID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return responseJson(c, http.StatusNotFound, "Customer not found", nil)
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	return responseJson(c, http.StatusOK, "Success", items)
+}
+
+func (cc CustomerController) CreateCustomer(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.CreateCustomerParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/customers/create")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	// the "no group" option of the group select binds to 0
+	if param.CustomerGroupID != nil && *param.CustomerGroupID == 0 {
+		param.CustomerGroupID = nil
+	}
+
+	ctx := c.Request().Context()
+	customer, err := cc.customerUc.CreateCustomer(ctx, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/customers/create")
+	}
+
+	if eae, ok := err.(entity.ErrItemAlreadyExists); ok {
+		msg := fmt.Sprintf("Failed creating customer. %s", eae.Message)
+		sess.AddFlash(msg, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/customers/create")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success creating \"%s\"", customer.Name)
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/customers")
+}
+
+// QuickCreateCustomer is the JSON variant of CreateCustomer used by the order screen.
+func (cc CustomerController) QuickCreateCustomer(c echo.Context) error {
+	var param entity.CreateCustomerParam
+	if err := c.Bind(&param); err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed processing data", nil)
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusBadRequest, "Invalid data", nil)
+	}
+
+	ctx := c.Request().Context()
+	customer, err := cc.customerUc.CreateCustomer(ctx, param)
+	if eae, ok := err.(entity.ErrItemAlreadyExists); ok {
+		return responseErrorJson(c, http.StatusBadRequest, eae.Message, nil)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed creating data", nil)
+	}
+
+	return responseJson(c, http.StatusCreated, "Success creating customer", customer)
+}
+
+func (cc CustomerController) UpdateCustomerGroup(c echo.Context) error {
+	cid := c.Param("customerId")
+	customerID, err := strconv.ParseInt(cid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	redirectURL := fmt.Sprintf("/customers/%d", customerID)
+
+	var param entity.UpdateCustomerGroupParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	if param.CustomerGroupID != nil && *param.CustomerGroupID == 0 {
+		param.CustomerGroupID = nil
+	}
+
+	ctx := c.Request().Context()
+	_, err = cc.customerUc.UpdateCustomerGroup(ctx, customerID, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, redirectURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success updating customer group", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, redirectURL)
+}
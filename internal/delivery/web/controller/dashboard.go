@@ -1,23 +1,198 @@
 package controller
 
 import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/ardafirdausr/kaseer/internal"
 	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/sse"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// dashboardHistory is how many messages a reconnecting dashboard can
+	// catch up on, dashboardBuffer how far one can fall behind before it
+	// is dropped.
+	dashboardHistory = 100
+	dashboardBuffer  = 32
+
+	// dashboardHeartbeat keeps the idle streams from being closed by the
+	// proxies, dashboardRetry is how soon the browsers reconnect.
+	dashboardHeartbeat = 15 * time.Second
+	dashboardRetry     = 3 * time.Second
+)
+
 type DashboardController struct {
 	productUc internal.ProductUsecase
 	orderUc   internal.OrderUsecase
+	reportUc  internal.ReportUsecase
+	hub       *sse.Hub
 }
 
 func NewDashboardController(ucs *app.Usecases) *DashboardController {
-	return &DashboardController{
+	dc := &DashboardController{
 		productUc: ucs.ProductUsecase,
 		orderUc:   ucs.OrderUsecase,
+		reportUc:  ucs.ReportUsecase,
+		hub:       sse.NewHub(dashboardHistory, dashboardBuffer),
 	}
+	ucs.EventBus.SubscribeAsync(entity.EventOrderCreated, dc.broadcastOrder)
+	ucs.EventBus.SubscribeAsync(entity.EventOrderVoided, dc.broadcastKPIs)
+	ucs.EventBus.SubscribeAsync(entity.EventOrderRefunded, dc.broadcastKPIs)
+	return dc
 }
 
 func (dc DashboardController) ShowDashboard(c echo.Context) error {
-	return renderPage(c, "dashboard", "Dashboard", nil)
+	data := echo.Map{
+		"BestsellerExportURLs": map[string]string{
+			"csv":  "/products/bestseller?format=csv",
+			"xlsx": "/products/bestseller?format=xlsx",
+			"pdf":  "/products/bestseller?format=pdf",
+		},
+	}
+	return renderPage(c, "dashboard", "Dashboard", data)
+}
+
+// StreamDashboard pushes the new orders and the updated figures to the
+// dashboard as server-sent events. A reconnecting browser sends the ID of
+// the last event it got and is sent the ones it missed, then the current
+// figures in any case.
+func (dc DashboardController) StreamDashboard(c echo.Context) error {
+	lastID, _ := strconv.ParseUint(c.Request().Header.Get("Last-Event-ID"), 10, 64)
+	client, missed := dc.hub.Subscribe(lastID)
+	defer dc.hub.Unsubscribe(client)
+
+	// the stream outlives the write timeout of the server
+	res := c.Response()
+	err := http.NewResponseController(res.Writer).SetWriteDeadline(time.Time{})
+	if err != nil && err != http.ErrNotSupported {
+		log.Println(err)
+	}
+
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := res.Write([]byte("retry: " + strconv.FormatInt(dashboardRetry.Milliseconds(), 10) + "\n\n")); err != nil {
+		return nil
+	}
+
+	for _, message := range missed {
+		if _, err := message.WriteTo(res); err != nil {
+			return nil
+		}
+	}
+
+	ctx := c.Request().Context()
+	if message, err := dc.kpisMessage(ctx); err != nil {
+		log.Println(err)
+	} else if _, err := message.WriteTo(res); err != nil {
+		return nil
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(dashboardHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := res.Write([]byte(": heartbeat\n\n")); err != nil {
+				return nil
+			}
+		case message, ok := <-client.C:
+			if !ok {
+				return nil
+			}
+
+			if _, err := message.WriteTo(res); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// Close ends the dashboard streams, so they do not hold the server up when
+// it shuts down.
+func (dc DashboardController) Close() {
+	dc.hub.Close()
+}
+
+// broadcastOrder runs on the event bus, it works the figures out once for
+// every connected dashboard.
+func (dc DashboardController) broadcastOrder(ctx context.Context, event entity.Event) error {
+	order := event.Data.(entity.OrderCreatedEvent).Order
+	dashboardOrder := entity.DashboardOrder{
+		ID:            order.ID,
+		InvoiceNumber: order.InvoiceNumber,
+		CashierName:   order.CashierName,
+		Total:         order.Total,
+		CreatedAt:     order.CreatedAt,
+	}
+	for _, item := range order.Items {
+		dashboardOrder.Items += item.Quantity
+	}
+
+	message, err := sse.NewMessage("order", dashboardOrder)
+	if err != nil {
+		return err
+	}
+	dc.hub.Publish(message)
+	return dc.broadcastKPIs(ctx, event)
+}
+
+// broadcastKPIs sends the figures again after an order changes them, the
+// voided and refunded orders take their sales off.
+func (dc DashboardController) broadcastKPIs(ctx context.Context, event entity.Event) error {
+	if dc.hub.Clients() < 1 {
+		return nil
+	}
+
+	message, err := dc.kpisMessage(ctx)
+	if err != nil {
+		return err
+	}
+
+	dc.hub.Publish(message)
+	return nil
+}
+
+func (dc DashboardController) kpisMessage(ctx context.Context) (sse.Message, error) {
+	kpis := entity.DashboardKPIs{UpdatedAt: time.Now()}
+	totalOrders, err := dc.orderUc.GetTotalOrderCount(ctx)
+	if err != nil {
+		return sse.Message{}, err
+	}
+	kpis.TotalOrders = totalOrders
+
+	today, err := dc.reportUc.GetSalesReport(ctx, entity.SalesReportParam{Period: entity.ReportPeriodToday})
+	if err != nil {
+		return sse.Message{}, err
+	}
+	kpis.TodayOrders = today.Summary.Orders
+	kpis.TodayNetSales = today.Summary.NetSales
+
+	month, err := dc.reportUc.GetSalesReport(ctx, entity.SalesReportParam{Period: entity.ReportPeriodThisMonth})
+	if err != nil {
+		return sse.Message{}, err
+	}
+	kpis.MonthNetSales = month.Summary.NetSales
+
+	bestsellers, err := dc.productUc.GetBestSellerProducts(ctx)
+	if err != nil {
+		return sse.Message{}, err
+	}
+	kpis.Bestsellers = bestsellers
+
+	return sse.NewMessage("kpis", kpis)
 }
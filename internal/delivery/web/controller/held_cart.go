@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type HeldCartController struct {
+	heldCartUc internal.HeldCartUsecase
+}
+
+func NewHeldCartController(ucs *app.Usecases) *HeldCartController {
+	heldCartUc := ucs.HeldCartUsecase
+	return &HeldCartController{heldCartUc}
+}
+
+func (hcc HeldCartController) GetHeldCartsData(c echo.Context) error {
+	register := c.QueryParam("register")
+	if register == "" {
+		return responseErrorJson(c, http.StatusBadRequest, "Register is required", nil)
+	}
+
+	ctx := c.Request().Context()
+	heldCarts, err := hcc.heldCartUc.GetHeldCarts(ctx, register)
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success", heldCarts)
+}
+
+func (hcc HeldCartController) HoldCart(c echo.Context) error {
+	var param entity.HoldCartParam
+	if err := c.Bind(&param); err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed processing data", nil)
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusBadRequest, "Invalid data", nil)
+	}
+
+	ctx := c.Request().Context()
+	heldCart, err := hcc.heldCartUc.HoldCart(ctx, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed holding cart", nil)
+	}
+
+	return responseJson(c, http.StatusCreated, "Success holding cart", heldCart)
+}
+
+func (hcc HeldCartController) ResumeHeldCart(c echo.Context) error {
+	hcid := c.Param("heldCartId")
+	heldCartID, err := strconv.ParseInt(hcid, 10, 64)
+	if err != nil {
+		return responseJson(c, http.StatusNotFound, "Held cart not found", nil)
+	}
+
+	ctx := c.Request().Context()
+	heldCart, err := hcc.heldCartUc.ResumeHeldCart(ctx, heldCartID)
+	if enf, ok := err.(entity.ErrNotFound); ok {
+		return responseJson(c, http.StatusNotFound, enf.Message, nil)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed resuming cart", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success resuming cart", heldCart)
+}
+
+func (hcc HeldCartController) DiscardHeldCart(c echo.Context) error {
+	hcid := c.Param("heldCartId")
+	heldCartID, err := strconv.ParseInt(hcid, 10, 64)
+	if err != nil {
+		return responseJson(c, http.StatusNotFound, "Held cart not found", nil)
+	}
+
+	ctx := c.Request().Context()
+	isDeleted, err := hcc.heldCartUc.DiscardHeldCart(ctx, heldCartID)
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed discarding cart", nil)
+	}
+
+	if !isDeleted {
+		return responseJson(c, http.StatusNotFound, "Held cart not found", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success discarding cart", nil)
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type LoyaltyController struct {
+	loyaltyUc internal.LoyaltyUsecase
+}
+
+func NewLoyaltyController(ucs *app.Usecases) *LoyaltyController {
+	loyaltyUc := ucs.LoyaltyUsecase
+	return &LoyaltyController{loyaltyUc}
+}
+
+func (lc LoyaltyController) ShowLoyaltySetting(c echo.Context) error {
+	ctx := c.Request().Context()
+	setting, err := lc.loyaltyUc.GetLoyaltySetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	multipliers, err := lc.loyaltyUc.GetAllMultipliers(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Setting":     setting,
+		"Multipliers": multipliers,
+	}
+	return renderPage(c, "loyalty", "Loyalty Program", data)
+}
+
+func (lc LoyaltyController) UpdateLoyaltySetting(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.UpdateLoyaltySettingParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/loyalty")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	if err := lc.loyaltyUc.UpdateLoyaltySetting(ctx, param); err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success updating loyalty program", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/loyalty")
+}
+
+func (lc LoyaltyController) SaveMultiplier(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.SaveLoyaltyMultiplierParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/loyalty")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	if err := lc.loyaltyUc.SaveMultiplier(ctx, param); err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success saving category multiplier", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/loyalty")
+}
+
+func (lc LoyaltyController) DeleteMultiplier(c echo.Context) error {
+	mid := c.Param("multiplierId")
+	multiplierID, err := strconv.ParseInt(mid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	isDeleted, err := lc.loyaltyUc.DeleteMultiplier(ctx, multiplierID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return echo.ErrInternalServerError
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	sess.AddFlash("Success deleting category multiplier", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/loyalty")
+}
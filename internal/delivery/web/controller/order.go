@@ -1,103 +1,269 @@
 package controller
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal"
 	"github.com/ardafirdausr/kaseer/internal/app"
 	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/export"
+	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
 )
 
+const ordersPerPage = 20
+
 type OrderController struct {
-	orderUc   internal.OrderUsecase
-	productUc internal.ProductUsecase
+	orderUc        internal.OrderUsecase
+	productUc      internal.ProductUsecase
+	storeSettingUc internal.StoreSettingUsecase
+	userUc         internal.UserUsecase
+	marketBasketUc internal.MarketBasketUsecase
+	location       *time.Location
 }
 
 func NewOrderController(ucs *app.Usecases) *OrderController {
 	orderUc := ucs.OrderUsecase
 	productUc := ucs.ProductUsecase
-	return &OrderController{orderUc, productUc}
+	storeSettingUc := ucs.StoreSettingUsecase
+	userUc := ucs.UserUsecase
+	marketBasketUc := ucs.MarketBasketUsecase
+	return &OrderController{orderUc, productUc, storeSettingUc, userUc, marketBasketUc, ucs.Location}
 }
 
 func (oc OrderController) ShowAllOrders(c echo.Context) error {
+	format, err := exportFormat(c)
+	var query entity.OrderQuery
+	if err == nil {
+		query, err = orderQuery(c, oc.location)
+	}
+
+	if err == nil {
+		query.Limit = ordersPerPage
+		query.Cursor = 0
+		query.Offset = pageOffset(c, ordersPerPage)
+		if format != "" {
+			query.Limit = exportBatchSize
+			query.Offset = 0
+		}
+	}
+
 	ctx := c.Request().Context()
-	orders, err := oc.orderUc.GetAllOrders(ctx)
+	var page *entity.OrderPage
+	if err == nil {
+		page, err = oc.orderUc.GetOrders(ctx, query)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/orders")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if format != "" {
+		return oc.exportOrders(c, format, query, page)
+	}
+
+	cashiers, err := oc.userUc.GetAllUsers(ctx)
 	if err != nil {
 		return err
 	}
 
-	data := echo.Map{"Orders": orders}
+	pageNumber := page.Offset/page.Limit + 1
+	data := echo.Map{
+		"Orders":     page.Orders,
+		"Total":      *page.Total,
+		"Filter":     c.QueryParams(),
+		"Cashiers":   cashiers,
+		"Page":       pageNumber,
+		"PrevURL":    "",
+		"NextURL":    "",
+		"ExportURLs": exportURLs(c),
+	}
+	if pageNumber > 1 {
+		data["PrevURL"] = pageURL(c, pageNumber-1)
+	}
+
+	if page.HasMore {
+		data["NextURL"] = pageURL(c, pageNumber+1)
+	}
+
 	return renderPage(c, "orders", "All Orders", data)
 }
 
-func (oc OrderController) ShowCreateOrderForm(c echo.Context) error {
+// exportOrders streams the filtered orders from their first page, reading
+// the following pages by cursor as the previous ones are sent.
+func (oc OrderController) exportOrders(c echo.Context, format export.Format, query entity.OrderQuery, page *entity.OrderPage) error {
+	ctx := c.Request().Context()
+	today := time.Now().In(oc.location).Format("2006-01-02")
+	columns := []string{"Invoice", "Date", "Cashier", "Status", "Discount", "Total"}
+	return streamExport(c, format, "orders-"+today, "Orders "+today, columns, func(w export.Writer) error {
+		for {
+			for _, order := range page.Orders {
+				err := w.WriteRow(order.Reference(), order.CreatedAt, order.CashierName, order.Status, order.Discount, order.Total)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if !page.HasMore {
+				return nil
+			}
+
+			query.Cursor = page.NextCursor
+			var err error
+			page, err = oc.orderUc.GetOrders(ctx, query)
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func (oc OrderController) GetOrdersData(c echo.Context) error {
+	query, err := orderQuery(c, oc.location)
 	ctx := c.Request().Context()
-	products, err := oc.productUc.GetAllProducts(ctx)
+	var page *entity.OrderPage
+	if err == nil {
+		page, err = oc.orderUc.GetOrders(ctx, query)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
 	if err != nil {
-		return err
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
 	}
 
-	data := echo.Map{"Products": products}
-	return renderPage(c, "order_create", "Create New Order", data)
+	return responseJson(c, http.StatusOK, "Success", page)
 }
 
-func (oc OrderController) GetTotalOrdersData(c echo.Context) error {
-	ctx := c.Request().Context()
-	orderType := c.FormValue("type")
-	var totalOrderCount int
-	switch orderType {
-	case "day":
-		dailyOrder, err := oc.orderUc.GetDailyOrderCount(ctx)
+// orderQuery reads the order listing filters from the query string. Dates
+// are whole days in the store's timezone, so the end date is included.
+func orderQuery(c echo.Context, location *time.Location) (entity.OrderQuery, error) {
+	query := entity.OrderQuery{
+		Status:        strings.TrimSpace(c.QueryParam("status")),
+		InvoiceNumber: strings.TrimSpace(c.QueryParam("invoice_number")),
+	}
+	errs := map[string]string{}
+
+	if value := c.QueryParam("start_date"); value != "" {
+		startDate, err := time.ParseInLocation("2006-01-02", value, location)
 		if err != nil {
-			return echo.ErrInternalServerError
+			errs["StartDate"] = "Start date must be formatted as YYYY-MM-DD"
+		} else {
+			query.StartDate = &startDate
 		}
+	}
 
-		totalOrderCount = dailyOrder
-	default:
-		totalOrder, err := oc.orderUc.GetTotalOrderCount(ctx)
+	if value := c.QueryParam("end_date"); value != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", value, location)
 		if err != nil {
-			return echo.ErrInternalServerError
+			errs["EndDate"] = "End date must be formatted as YYYY-MM-DD"
+		} else {
+			endDate = endDate.AddDate(0, 0, 1)
+			query.EndDate = &endDate
 		}
+	}
 
-		totalOrderCount = totalOrder
+	if value := c.QueryParam("cashier_id"); value != "" {
+		cashierID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs["CashierID"] = "Cashier must be a user ID"
+		} else {
+			query.CashierID = &cashierID
+		}
 	}
 
-	return responseJson(c, http.StatusOK, "Success", totalOrderCount)
-}
+	if value := c.QueryParam("min_total"); value != "" {
+		minTotal, err := strconv.Atoi(value)
+		if err != nil {
+			errs["MinTotal"] = "Minimum total must be a number"
+		} else {
+			query.MinTotal = &minTotal
+		}
+	}
 
-func (oc OrderController) GetLatestIncomeData(c echo.Context) error {
-	ctx := c.Request().Context()
-	orderType := c.FormValue("type")
-	var totalIncome int
-	switch orderType {
-	case "day":
-		dailyIncome, err := oc.orderUc.GetLastDayIncome(ctx)
+	if value := c.QueryParam("max_total"); value != "" {
+		maxTotal, err := strconv.Atoi(value)
+		if err != nil {
+			errs["MaxTotal"] = "Maximum total must be a number"
+		} else {
+			query.MaxTotal = &maxTotal
+		}
+	}
+
+	if value := c.QueryParam("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			errs["Offset"] = "Offset must be a number"
+		} else {
+			query.Offset = offset
+		}
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
 		if err != nil {
-			return echo.ErrInternalServerError
+			errs["Limit"] = "Limit must be a number"
+		} else {
+			query.Limit = limit
 		}
-		totalIncome = dailyIncome
-	case "month":
-		monthlyIncome, err := oc.orderUc.GetLastMonthIncome(ctx)
+	}
+
+	if value := c.QueryParam("cursor"); value != "" {
+		cursor, err := strconv.ParseInt(value, 10, 64)
 		if err != nil {
-			return echo.ErrInternalServerError
+			errs["Cursor"] = "Cursor must be an order ID"
+		} else {
+			query.Cursor = cursor
 		}
+	}
 
-		totalIncome = monthlyIncome
+	if len(errs) > 0 {
+		return query, entity.ErrValidation{
+			Message: "Invalid order filter",
+			Errors:  errs,
+		}
 	}
 
-	return responseJson(c, http.StatusOK, "Success", totalIncome)
+	return query, nil
 }
 
-func (oc OrderController) GetAnnualIncomeData(c echo.Context) error {
+func (oc OrderController) ShowCreateOrderForm(c echo.Context) error {
 	ctx := c.Request().Context()
-	annualIncomes, err := oc.orderUc.GetAnnualIncome(ctx)
+	categories, err := oc.productUc.GetProductCategories(ctx)
 	if err != nil {
 		return err
 	}
 
-	return responseJson(c, http.StatusOK, "Success", annualIncomes)
+	data := echo.Map{"Categories": categories}
+	return renderPage(c, "order_create", "Create New Order", data)
+}
+
+func (oc OrderController) GetTotalOrdersData(c echo.Context) error {
+	ctx := c.Request().Context()
+	totalOrderCount, err := oc.orderUc.GetTotalOrderCount(ctx)
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	return responseJson(c, http.StatusOK, "Success", totalOrderCount)
 }
 
 func (oc OrderController) GetOrderDetailData(c echo.Context) error {
@@ -116,12 +282,82 @@ func (oc OrderController) GetOrderDetailData(c echo.Context) error {
 	return responseJson(c, http.StatusOK, "Success", orderItems)
 }
 
+func (oc OrderController) ShowOrderReceipt(c echo.Context) error {
+	paramOrderID := c.Param("orderId")
+	orderID, err := strconv.ParseInt(paramOrderID, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	order, err := oc.orderUc.GetOrderByID(ctx, orderID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return echo.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	items, err := oc.orderUc.GetOrderItems(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	payments, err := oc.orderUc.GetOrderPayments(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	setting, err := oc.storeSettingUc.GetStoreSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Order":    order,
+		"Items":    items,
+		"Payments": payments,
+		"Setting":  setting,
+	}
+	return renderPage(c, "order_receipt", order.Reference(), data)
+}
+
+func (oc OrderController) FindOrderByInvoiceNumber(c echo.Context) error {
+	invoiceNumber := strings.TrimSpace(c.QueryParam("invoice_number"))
+	if invoiceNumber == "" {
+		return c.Redirect(http.StatusSeeOther, "/orders")
+	}
+
+	ctx := c.Request().Context()
+	order, err := oc.orderUc.GetOrderByInvoiceNumber(ctx, invoiceNumber)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(fmt.Sprintf("Invoice \"%s\" not found", invoiceNumber), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/orders")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/orders/%d/receipt", order.ID))
+}
+
 func (oc OrderController) CreateOrder(c echo.Context) error {
 	var orderParam entity.CreateOrderParam
 	if err := c.Bind(&orderParam); err != nil {
 		return responseJson(c, http.StatusInternalServerError, "Failed processing data", nil)
 	}
 
+	// retries of the same request carry the same key and get the same order
+	orderParam.IdempotencyKey = c.Request().Header.Get("Idempotency-Key")
+	if user, ok := c.Get("user").(*entity.User); ok {
+		orderParam.CashierID = &user.ID
+		orderParam.CashierName = user.Name
+	}
+
 	err := c.Validate(&orderParam)
 	if ev, ok := err.(entity.ErrValidation); ok {
 		return responseJson(c, http.StatusBadRequest, "Invalid data", ev.Errors)
@@ -137,9 +373,103 @@ func (oc OrderController) CreateOrder(c echo.Context) error {
 		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
 	}
 
+	if eae, ok := err.(entity.ErrItemAlreadyExists); ok {
+		return responseErrorJson(c, http.StatusConflict, eae.Message, nil)
+	}
+
 	if err != nil {
 		return responseJson(c, http.StatusInternalServerError, "Failed creating data", nil)
 	}
 
 	return responseJson(c, http.StatusCreated, "Success creating order", order)
 }
+
+func (oc OrderController) VoidOrder(c echo.Context) error {
+	void := func(ctx context.Context, orderID int64) (*entity.PrepaidCard, error) {
+		return nil, oc.orderUc.VoidOrder(ctx, orderID)
+	}
+	return oc.reverseOrder(c, void, "voiding")
+}
+
+func (oc OrderController) RefundOrder(c echo.Context) error {
+	var param entity.RefundOrderParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	refund := func(ctx context.Context, orderID int64) (*entity.PrepaidCard, error) {
+		return oc.orderUc.RefundOrder(ctx, orderID, param)
+	}
+	return oc.reverseOrder(c, refund, "refunding")
+}
+
+func (oc OrderController) reverseOrder(
+	c echo.Context,
+	reverse func(context.Context, int64) (*entity.PrepaidCard, error),
+	action string) error {
+	paramOrderID := c.Param("orderId")
+	orderID, err := strconv.ParseInt(paramOrderID, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	ctx := c.Request().Context()
+	storeCredit, err := reverse(ctx, orderID)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		reasons := []string{}
+		for _, reason := range ev.Errors {
+			reasons = append(reasons, reason)
+		}
+
+		msg := fmt.Sprintf("Failed %s order #%d. %s", action, orderID, strings.Join(reasons, ". "))
+		sess.AddFlash(msg, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/orders")
+	}
+
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return echo.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success %s order #%d", action, orderID)
+	if storeCredit != nil {
+		msg = fmt.Sprintf("%s. Store credit %s issued worth %d", msg, storeCredit.Number, storeCredit.Value)
+	}
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/orders")
+}
+
+// GetSuggestionsData suggests products for the cart, product_ids is a comma
+// separated list of the IDs of the products in the cart.
+func (oc OrderController) GetSuggestionsData(c echo.Context) error {
+	productIDs := []int64{}
+	if value := c.QueryParam("product_ids"); value != "" {
+		for _, pid := range strings.Split(value, ",") {
+			productID, err := strconv.ParseInt(strings.TrimSpace(pid), 10, 64)
+			if err != nil {
+				errs := map[string]string{"ProductIDs": "Product IDs must be a comma separated list of product IDs"}
+				return responseErrorJson(c, http.StatusBadRequest, "Invalid cart", errs)
+			}
+
+			productIDs = append(productIDs, productID)
+		}
+	}
+
+	ctx := c.Request().Context()
+	suggestions, err := oc.marketBasketUc.GetSuggestions(ctx, productIDs)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success", suggestions)
+}
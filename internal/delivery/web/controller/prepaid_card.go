@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+type PrepaidCardController struct {
+	prepaidCardUc internal.PrepaidCardUsecase
+}
+
+func NewPrepaidCardController(ucs *app.Usecases) *PrepaidCardController {
+	prepaidCardUc := ucs.PrepaidCardUsecase
+	return &PrepaidCardController{prepaidCardUc}
+}
+
+func (pcc PrepaidCardController) ShowAllCards(c echo.Context) error {
+	ctx := c.Request().Context()
+	cards, err := pcc.prepaidCardUc.GetAllCards(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Cards": cards}
+	return renderPage(c, "prepaid_cards", "Gift Cards & Store Credit", data)
+}
+
+func (pcc PrepaidCardController) ShowCardDetail(c echo.Context) error {
+	pcid := c.Param("cardId")
+	cardID, err := strconv.ParseInt(pcid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	card, err := pcc.prepaidCardUc.GetCardByID(ctx, cardID)
+	if err != nil {
+		return err
+	}
+
+	transactions, err := pcc.prepaidCardUc.GetCardTransactions(ctx, cardID)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Card":         card,
+		"Transactions": transactions,
+	}
+	return renderPage(c, "prepaid_card_detail", "Card "+card.Number, data)
+}
+
+// GetCardBalanceData looks up a card by its number so the order screen can
+// show the balance before the card is used as a tender.
+func (pcc PrepaidCardController) GetCardBalanceData(c echo.Context) error {
+	ctx := c.Request().Context()
+	card, err := pcc.prepaidCardUc.GetCardByNumber(ctx, c.QueryParam("number"))
+	if enf, ok := err.(entity.ErrNotFound); ok {
+		return responseErrorJson(c, http.StatusNotFound, enf.Message, nil)
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	return responseJson(c, http.StatusOK, "Success", card)
+}
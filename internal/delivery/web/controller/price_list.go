@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type PriceListController struct {
+	priceListUc internal.PriceListUsecase
+	customerUc  internal.CustomerUsecase
+	productUc   internal.ProductUsecase
+}
+
+func NewPriceListController(ucs *app.Usecases) *PriceListController {
+	priceListUc := ucs.PriceListUsecase
+	customerUc := ucs.CustomerUsecase
+	productUc := ucs.ProductUsecase
+	return &PriceListController{priceListUc, customerUc, productUc}
+}
+
+func (plc PriceListController) ShowAllPriceLists(c echo.Context) error {
+	ctx := c.Request().Context()
+	priceLists, err := plc.priceListUc.GetAllPriceLists(ctx)
+	if err != nil {
+		return err
+	}
+
+	groups, err := plc.customerUc.GetAllGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"PriceLists": priceLists,
+		"Groups":     groups,
+	}
+	return renderPage(c, "price_lists", "Price Lists", data)
+}
+
+func (plc PriceListController) ShowPriceListDetail(c echo.Context) error {
+	plid := c.Param("priceListId")
+	priceListID, err := strconv.ParseInt(plid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	priceList, err := plc.priceListUc.GetPriceListByID(ctx, priceListID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return echo.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	items, err := plc.priceListUc.GetPriceListItems(ctx, priceListID)
+	if err != nil {
+		return err
+	}
+
+	products, err := plc.productUc.GetAllProducts(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"PriceList": priceList,
+		"Items":     items,
+		"Products":  products,
+	}
+	return renderPage(c, "price_list_detail", priceList.Name, data)
+}
+
+func (plc PriceListController) CreateCustomerGroup(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.CreateCustomerGroupParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/price-lists")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	group, err := plc.customerUc.CreateGroup(ctx, param)
+	if eae, ok := err.(entity.ErrItemAlreadyExists); ok {
+		msg := fmt.Sprintf("Failed creating customer group. %s", eae.Message)
+		sess.AddFlash(msg, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/price-lists")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success creating customer group \"%s\"", group.Name)
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/price-lists")
+}
+
+func (plc PriceListController) CreatePriceList(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.SavePriceListParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/price-lists")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	priceList, err := plc.priceListUc.CreatePriceList(ctx, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/price-lists")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success creating \"%s\"", priceList.Name)
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/price-lists/%d", priceList.ID))
+}
+
+func (plc PriceListController) DeletePriceList(c echo.Context) error {
+	plid := c.Param("priceListId")
+	priceListID, err := strconv.ParseInt(plid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	isDeleted, err := plc.priceListUc.DeletePriceList(ctx, priceListID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return echo.ErrInternalServerError
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	sess.AddFlash("Success deleting price list", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/price-lists")
+}
+
+func (plc PriceListController) SavePriceListItem(c echo.Context) error {
+	plid := c.Param("priceListId")
+	priceListID, err := strconv.ParseInt(plid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	redirectURL := fmt.Sprintf("/price-lists/%d", priceListID)
+
+	var param entity.SavePriceListItemParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err = c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, redirectURL)
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	err = plc.priceListUc.SavePriceListItem(ctx, priceListID, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, redirectURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success saving price", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, redirectURL)
+}
+
+func (plc PriceListController) DeletePriceListItem(c echo.Context) error {
+	plid := c.Param("priceListId")
+	priceListID, err := strconv.ParseInt(plid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	iid := c.Param("itemId")
+	itemID, err := strconv.ParseInt(iid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	isDeleted, err := plc.priceListUc.DeletePriceListItem(ctx, priceListID, itemID)
+	if err != nil {
+		return err
+	}
+
+	if !isDeleted {
+		return echo.ErrNotFound
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	sess.AddFlash("Success deleting price", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/price-lists/%d", priceListID))
+}
+
+func (plc PriceListController) ImportPriceListItems(c echo.Context) error {
+	plid := c.Param("priceListId")
+	priceListID, err := strconv.ParseInt(plid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	redirectURL := fmt.Sprintf("/price-lists/%d", priceListID)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		sess.AddFlash("Failed importing prices. Choose a CSV file to upload", "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, redirectURL)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+	defer file.Close()
+
+	ctx := c.Request().Context()
+	count, err := plc.priceListUc.ImportPriceListItems(ctx, priceListID, file)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		reasons := []string{}
+		for key, reason := range ev.Errors {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", key, reason))
+		}
+		sort.Strings(reasons)
+
+		msg := fmt.Sprintf("Failed importing prices. %s", strings.Join(reasons, ". "))
+		sess.AddFlash(msg, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, redirectURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success importing %d prices", count)
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, redirectURL)
+}
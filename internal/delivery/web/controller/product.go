@@ -5,14 +5,19 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal"
 	"github.com/ardafirdausr/kaseer/internal/app"
 	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/export"
 	"github.com/labstack/echo-contrib/session"
 	"github.com/labstack/echo/v4"
 )
 
+const productsPerPage = 20
+
 type ProductController struct {
 	productUc internal.ProductUsecase
 }
@@ -23,23 +28,168 @@ func NewProductController(ucs *app.Usecases) *ProductController {
 }
 
 func (pc ProductController) ShowAllProducts(c echo.Context) error {
+	query, err := productQuery(c)
 	ctx := c.Request().Context()
-	products, err := pc.productUc.GetAllProducts(ctx)
+	var page *entity.ProductPage
+	if err == nil {
+		query.Limit = productsPerPage
+		query.Offset = pageOffset(c, productsPerPage)
+		page, err = pc.productUc.SearchProducts(ctx, query)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/products")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	categories, err := pc.productUc.GetProductCategories(ctx)
 	if err != nil {
 		return err
 	}
 
-	data := echo.Map{"Products": products}
+	// a sorted column links to its opposite direction, starting over from
+	// the first page
+	sortURLs := map[string]string{}
+	for _, column := range []string{"code", "name", "price", "stock"} {
+		columnSort := column
+		if query.Sort == column {
+			columnSort = "-" + column
+		}
+		sortURLs[column] = listingURL(c, map[string]string{"sort": columnSort, "page": ""})
+	}
+
+	pageNumber := page.Offset/page.Limit + 1
+	data := echo.Map{
+		"Products":   page.Products,
+		"Total":      page.Total,
+		"Filter":     c.QueryParams(),
+		"Categories": categories,
+		"SortURLs":   sortURLs,
+		"Page":       pageNumber,
+		"PrevURL":    "",
+		"NextURL":    "",
+	}
+	if pageNumber > 1 {
+		data["PrevURL"] = pageURL(c, pageNumber-1)
+	}
+
+	if page.HasMore {
+		data["NextURL"] = pageURL(c, pageNumber+1)
+	}
+
 	return renderPage(c, "products", "All Products", data)
 }
 
+func (pc ProductController) SearchProductsData(c echo.Context) error {
+	query, err := productQuery(c)
+	ctx := c.Request().Context()
+	var page *entity.ProductPage
+	if err == nil {
+		page, err = pc.productUc.SearchProducts(ctx, query)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success", page)
+}
+
+// productQuery reads the product search from the query string, ids is a
+// comma separated list of product IDs.
+func productQuery(c echo.Context) (entity.ProductQuery, error) {
+	query := entity.ProductQuery{
+		Keyword:  strings.TrimSpace(c.QueryParam("q")),
+		Category: c.QueryParam("category"),
+		Sort:     c.QueryParam("sort"),
+	}
+	errs := map[string]string{}
+
+	if value := c.QueryParam("in_stock"); value != "" {
+		inStock, err := strconv.ParseBool(value)
+		if err != nil {
+			errs["InStock"] = "In stock must be true or false"
+		} else {
+			query.InStock = inStock
+		}
+	}
+
+	if value := c.QueryParam("ids"); value != "" {
+		for _, pid := range strings.Split(value, ",") {
+			productID, err := strconv.ParseInt(strings.TrimSpace(pid), 10, 64)
+			if err != nil {
+				errs["IDs"] = "IDs must be a comma separated list of product IDs"
+				break
+			}
+
+			query.IDs = append(query.IDs, productID)
+		}
+	}
+
+	if value := c.QueryParam("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			errs["Offset"] = "Offset must be a number"
+		} else {
+			query.Offset = offset
+		}
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			errs["Limit"] = "Limit must be a number"
+		} else {
+			query.Limit = limit
+		}
+	}
+
+	if len(errs) > 0 {
+		return query, entity.ErrValidation{
+			Message: "Invalid product filter",
+			Errors:  errs,
+		}
+	}
+
+	return query, nil
+}
+
 func (pc ProductController) GetBestSellerProductsData(c echo.Context) error {
+	format, err := exportFormat(c)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
 	ctx := c.Request().Context()
 	products, err := pc.productUc.GetBestSellerProducts(ctx)
 	if err != nil {
 		return err
 	}
 
+	if format != "" {
+		today := time.Now().Format("2006-01-02")
+		columns := []string{"Code", "Name", "Sold"}
+		return streamExport(c, format, "best-sellers-"+today, "Best Sellers "+today, columns, func(w export.Writer) error {
+			for _, product := range products {
+				if err := w.WriteRow(product.Code, product.Name, product.Sale); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
 	return responseJson(c, http.StatusOK, "Success", products)
 }
 
@@ -175,3 +325,39 @@ func (pc ProductController) DeleteProduct(c echo.Context) error {
 	sess.Save(c.Request(), c.Response())
 	return c.Redirect(http.StatusSeeOther, "/products")
 }
+
+func (pc ProductController) ShowNegativeStockSales(c echo.Context) error {
+	ctx := c.Request().Context()
+	sales, err := pc.productUc.GetNegativeStockSales(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Sales": sales}
+	return renderPage(c, "negative_stock_sales", "Negative Stock Sales", data)
+}
+
+func (pc ProductController) ReconcileNegativeStockSale(c echo.Context) error {
+	sid := c.Param("saleId")
+	saleID, err := strconv.ParseInt(sid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	isReconciled, err := pc.productUc.ReconcileNegativeStockSale(ctx, saleID)
+	if err != nil {
+		return err
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	if !isReconciled {
+		sess.AddFlash("Failed reconciling sale. The sale is already reconciled", "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/products/negative-stock")
+	}
+
+	sess.AddFlash("Success reconciling sale", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/products/negative-stock")
+}
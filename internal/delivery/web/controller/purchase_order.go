@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type PurchaseOrderController struct {
+	purchaseOrderUc internal.PurchaseOrderUsecase
+	forecastUc      internal.ForecastUsecase
+	productUc       internal.ProductUsecase
+}
+
+func NewPurchaseOrderController(ucs *app.Usecases) *PurchaseOrderController {
+	purchaseOrderUc := ucs.PurchaseOrderUsecase
+	forecastUc := ucs.ForecastUsecase
+	productUc := ucs.ProductUsecase
+	return &PurchaseOrderController{purchaseOrderUc, forecastUc, productUc}
+}
+
+func (poc PurchaseOrderController) ShowAllPurchaseOrders(c echo.Context) error {
+	ctx := c.Request().Context()
+	purchaseOrders, err := poc.purchaseOrderUc.GetPurchaseOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"PurchaseOrders": purchaseOrders}
+	return renderPage(c, "purchase_orders", "Purchase Orders", data)
+}
+
+func (poc PurchaseOrderController) ShowPurchaseOrderDetail(c echo.Context) error {
+	poid := c.Param("purchaseOrderId")
+	purchaseOrderID, err := strconv.ParseInt(poid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	purchaseOrder, err := poc.purchaseOrderUc.GetPurchaseOrder(ctx, purchaseOrderID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return echo.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"PurchaseOrder": purchaseOrder}
+	return renderPage(c, "purchase_order_detail", purchaseOrder.Reference(), data)
+}
+
+// ShowReorderPlan lists the forecast reorder quantities as the lines of a
+// purchase order draft.
+func (poc PurchaseOrderController) ShowReorderPlan(c echo.Context) error {
+	var param entity.ReorderParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var plan *entity.ReorderPlan
+	if err == nil {
+		plan, err = poc.forecastUc.GetReorderPlan(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/purchase-orders/reorder")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	categories, err := poc.productUc.GetProductCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Plan":       plan,
+		"Categories": categories,
+	}
+	return renderPage(c, "reorder_plan", "Reorder", data)
+}
+
+func (poc PurchaseOrderController) CreatePurchaseOrder(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.SavePurchaseOrderParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var purchaseOrder *entity.PurchaseOrder
+	if err == nil {
+		purchaseOrder, err = poc.purchaseOrderUc.CreatePurchaseOrder(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/purchase-orders/reorder")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success drafting purchase order %s", purchaseOrder.Reference())
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/purchase-orders/%d", purchaseOrder.ID))
+}
+
+func (poc PurchaseOrderController) UpdatePurchaseOrderStatus(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	poid := c.Param("purchaseOrderId")
+	purchaseOrderID, err := strconv.ParseInt(poid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	var param entity.UpdatePurchaseOrderStatusParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	redirectURL := fmt.Sprintf("/purchase-orders/%d", purchaseOrderID)
+	err = c.Validate(&param)
+	ctx := c.Request().Context()
+	if err == nil {
+		err = poc.purchaseOrderUc.UpdatePurchaseOrderStatus(ctx, purchaseOrderID, param)
+	}
+
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return echo.ErrNotFound
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, redirectURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash(fmt.Sprintf("Purchase order is %s", param.Status), "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, redirectURL)
+}
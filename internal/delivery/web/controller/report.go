@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/export"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportController struct {
+	reportUc           internal.ReportUsecase
+	productUc          internal.ProductUsecase
+	productAnalyticsUc internal.ProductAnalyticsUsecase
+	marketBasketUc     internal.MarketBasketUsecase
+}
+
+func NewReportController(ucs *app.Usecases) *ReportController {
+	reportUc := ucs.ReportUsecase
+	productUc := ucs.ProductUsecase
+	productAnalyticsUc := ucs.ProductAnalyticsUsecase
+	marketBasketUc := ucs.MarketBasketUsecase
+	return &ReportController{reportUc, productUc, productAnalyticsUc, marketBasketUc}
+}
+
+func (rc ReportController) ShowSalesReport(c echo.Context) error {
+	var param entity.SalesReportParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	format, err := exportFormat(c)
+	if err == nil {
+		err = c.Validate(&param)
+	}
+
+	ctx := c.Request().Context()
+	var report *entity.SalesReport
+	if err == nil {
+		report, err = rc.reportUc.GetSalesReport(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/reports/sales")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if format != "" {
+		name := fmt.Sprintf("sales-%s-%s", report.StartDate, report.EndDate)
+		title := fmt.Sprintf("Sales Report %s to %s (%s)", report.StartDate, report.EndDate, report.Timezone)
+		columns := []string{"Period", "Orders", "Gross Sales", "Discounts", "Refunds", "Net Sales"}
+		return streamExport(c, format, name, title, columns, func(w export.Writer) error {
+			for _, period := range append(report.Periods, &report.Summary) {
+				err := w.WriteRow(period.Label, period.Orders, period.GrossSales, period.Discounts, period.Refunds, period.NetSales)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	data := echo.Map{
+		"Report":     report,
+		"ExportURLs": exportURLs(c),
+	}
+	return renderPage(c, "sales_report", "Sales Report", data)
+}
+
+func (rc ReportController) GetSalesReportData(c echo.Context) error {
+	var param entity.SalesReportParam
+	if err := c.Bind(&param); err != nil {
+		return responseJson(c, http.StatusBadRequest, "Invalid data", nil)
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var report *entity.SalesReport
+	if err == nil {
+		report, err = rc.reportUc.GetSalesReport(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success", report)
+}
+
+func (rc ReportController) GetSalesHeatmapData(c echo.Context) error {
+	var param entity.SalesHeatmapParam
+	if err := c.Bind(&param); err != nil {
+		return responseJson(c, http.StatusBadRequest, "Invalid data", nil)
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var heatmap *entity.SalesHeatmap
+	if err == nil {
+		heatmap, err = rc.reportUc.GetSalesHeatmap(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success", heatmap)
+}
+
+func (rc ReportController) ShowStockValuation(c echo.Context) error {
+	var query entity.StockValuationQuery
+	if err := c.Bind(&query); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	format, err := exportFormat(c)
+	if format != "" {
+		query.Cursor = 0
+		query.Limit = exportBatchSize
+	}
+
+	ctx := c.Request().Context()
+	var valuation *entity.StockValuation
+	if err == nil {
+		valuation, err = rc.reportUc.GetStockValuation(ctx, query)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/reports/stock")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if format != "" {
+		return rc.exportStockValuation(c, format, query, valuation)
+	}
+
+	categories, err := rc.productUc.GetProductCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Valuation":  valuation,
+		"Categories": categories,
+		"Filter":     c.QueryParams(),
+		"NextURL":    "",
+		"ExportURLs": exportURLs(c),
+	}
+	if valuation.HasMore {
+		data["NextURL"] = listingURL(c, map[string]string{"cursor": strconv.FormatInt(valuation.NextCursor, 10)})
+	}
+
+	return renderPage(c, "stock_valuation", "Stock Valuation", data)
+}
+
+// exportStockValuation streams the valuation from its first page, reading
+// the following pages as the previous ones are sent.
+func (rc ReportController) exportStockValuation(c echo.Context, format export.Format, query entity.StockValuationQuery, valuation *entity.StockValuation) error {
+	ctx := c.Request().Context()
+	today := time.Now().Format("2006-01-02")
+	name := "stock-valuation-" + today
+	title := "Stock Valuation " + today
+	if query.Category != "" {
+		title += " - " + query.Category
+	}
+
+	columns := []string{"Code", "Name", "Category", "Stock", "Price", "Value"}
+	return streamExport(c, format, name, title, columns, func(w export.Writer) error {
+		summary := valuation.Summary
+		for {
+			for _, item := range valuation.Items {
+				err := w.WriteRow(item.Code, item.Name, item.Category, item.Stock, item.Price, item.Value)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if !valuation.HasMore {
+				break
+			}
+
+			query.Cursor = valuation.NextCursor
+			var err error
+			valuation, err = rc.reportUc.GetStockValuation(ctx, query)
+			if err != nil {
+				return err
+			}
+		}
+
+		return w.WriteRow("", "Total", "", summary.Units, "", summary.Value)
+	})
+}
+
+func (rc ReportController) ShowProductAnalytics(c echo.Context) error {
+	var param entity.ProductAnalyticsParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	format, err := exportFormat(c)
+	if err == nil {
+		err = c.Validate(&param)
+	}
+
+	ctx := c.Request().Context()
+	var analytics *entity.ProductAnalytics
+	if err == nil {
+		analytics, err = rc.productAnalyticsUc.GetProductAnalytics(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess, _ := session.Get("kaseer", c)
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/reports/products")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if format != "" {
+		name := fmt.Sprintf("product-performance-%s-%s", analytics.StartDate, analytics.EndDate)
+		title := fmt.Sprintf("Product Performance %s to %s, by %s", analytics.StartDate, analytics.EndDate, analytics.RankBy)
+		columns := []string{"Code", "Name", "Class", "Quantity", "Revenue", "Margin", "Revenue Share", "Stock", "Days of Cover"}
+		return streamExport(c, format, name, title, columns, func(w export.Writer) error {
+			for _, product := range analytics.Products {
+				var daysOfCover interface{}
+				if product.DaysOfCover != nil {
+					daysOfCover = math.Round(*product.DaysOfCover*10) / 10
+				}
+
+				share := math.Round(product.RevenueShare*1000) / 10
+				err := w.WriteRow(product.Code, product.Name, product.Class, product.Quantity, product.Revenue, product.Margin, share, product.Stock, daysOfCover)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	data := echo.Map{
+		"Analytics":  analytics,
+		"ExportURLs": exportURLs(c),
+	}
+	return renderPage(c, "product_analytics", "Product Performance", data)
+}
+
+func (rc ReportController) GetProductAnalyticsData(c echo.Context) error {
+	var param entity.ProductAnalyticsParam
+	if err := c.Bind(&param); err != nil {
+		return responseJson(c, http.StatusBadRequest, "Invalid data", nil)
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var analytics *entity.ProductAnalytics
+	if err == nil {
+		analytics, err = rc.productAnalyticsUc.GetProductAnalytics(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		return responseErrorJson(c, http.StatusBadRequest, ev.Message, ev.Errors)
+	}
+
+	if err != nil {
+		return responseJson(c, http.StatusInternalServerError, "Failed getting data", nil)
+	}
+
+	return responseJson(c, http.StatusOK, "Success", analytics)
+}
+
+func (rc ReportController) ShowMarketBasket(c echo.Context) error {
+	ctx := c.Request().Context()
+	basket, err := rc.marketBasketUc.GetMarketBasket(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Basket": basket}
+	return renderPage(c, "market_basket", "Frequently Bought Together", data)
+}
+
+// RefreshMarketBasket runs the analysis now instead of waiting for the
+// scheduled job, over the period given by the form.
+func (rc ReportController) RefreshMarketBasket(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.MarketBasketParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var run *entity.MarketBasketRun
+	if err == nil {
+		run, err = rc.marketBasketUc.RefreshMarketBasket(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/reports/basket")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Found %d product associations in %d orders", run.Associations, run.Orders)
+	sess.AddFlash(message, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/reports/basket")
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type StoreSettingController struct {
+	storeSettingUc internal.StoreSettingUsecase
+}
+
+func NewStoreSettingController(ucs *app.Usecases) *StoreSettingController {
+	storeSettingUc := ucs.StoreSettingUsecase
+	return &StoreSettingController{storeSettingUc}
+}
+
+func (ssc StoreSettingController) ShowStoreSetting(c echo.Context) error {
+	ctx := c.Request().Context()
+	setting, err := ssc.storeSettingUc.GetStoreSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Setting": setting}
+	return renderPage(c, "store_setting", "Store Settings", data)
+}
+
+func (ssc StoreSettingController) UpdateStoreSetting(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.UpdateStoreSettingParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/settings")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	err = ssc.storeSettingUc.UpdateStoreSetting(ctx, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/settings")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success updating store settings", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/settings")
+}
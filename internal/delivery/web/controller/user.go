@@ -13,11 +13,15 @@ import (
 )
 
 type UserController struct {
-	userUsecase internal.UserUsecase
+	userUsecase     internal.UserUsecase
+	apiTokenUsecase internal.APITokenUsecase
 }
 
 func NewUserController(app *app.Usecases) *UserController {
-	return &UserController{userUsecase: app.UserUsecase}
+	return &UserController{
+		userUsecase:     app.UserUsecase,
+		apiTokenUsecase: app.APITokenUsecase,
+	}
 }
 
 func (uc UserController) ShowLoginForm(c echo.Context) error {
@@ -25,7 +29,22 @@ func (uc UserController) ShowLoginForm(c echo.Context) error {
 }
 
 func (uc UserController) ShowUserProfile(c echo.Context) error {
-	return renderPage(c, "profile", "Profile", nil)
+	user, ok := c.Get("user").(*entity.User)
+	if !ok {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	apiTokens, err := uc.apiTokenUsecase.GetAPITokens(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"APITokens": apiTokens,
+		"Scopes":    entity.APIScopes,
+	}
+	return renderPage(c, "profile", "Profile", data)
 }
 
 func (uc UserController) ShowEditUserPasswordForm(c echo.Context) error {
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type VoucherController struct {
+	voucherUc internal.VoucherUsecase
+}
+
+func NewVoucherController(ucs *app.Usecases) *VoucherController {
+	voucherUc := ucs.VoucherUsecase
+	return &VoucherController{voucherUc}
+}
+
+func (vc VoucherController) ShowAllVouchers(c echo.Context) error {
+	ctx := c.Request().Context()
+	vouchers, err := vc.voucherUc.GetAllVouchers(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{"Vouchers": vouchers}
+	return renderPage(c, "vouchers", "All Vouchers", data)
+}
+
+func (vc VoucherController) ShowGenerateVoucherForm(c echo.Context) error {
+	return renderPage(c, "voucher_create", "Generate Vouchers", nil)
+}
+
+func (vc VoucherController) ShowVoucherDetail(c echo.Context) error {
+	vid := c.Param("voucherId")
+	voucherID, err := strconv.ParseInt(vid, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	voucher, err := vc.voucherUc.GetVoucherByID(ctx, voucherID)
+	if err != nil {
+		return err
+	}
+
+	redemptions, err := vc.voucherUc.GetVoucherRedemptions(ctx, voucherID)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Voucher":     voucher,
+		"Redemptions": redemptions,
+	}
+	return renderPage(c, "voucher_detail", "Voucher Detail", data)
+}
+
+func (vc VoucherController) GenerateVouchers(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.GenerateVoucherParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	err := c.Validate(&param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		if err := sess.Save(c.Request(), c.Response()); err != nil {
+			log.Println(err)
+		}
+		return c.Redirect(http.StatusSeeOther, "/vouchers/create")
+	}
+
+	if err != nil {
+		return echo.ErrInternalServerError
+	}
+
+	ctx := c.Request().Context()
+	codes, err := vc.voucherUc.GenerateVouchers(ctx, param)
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(ev, "error_validation")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/vouchers/create")
+	}
+
+	if eae, ok := err.(entity.ErrItemAlreadyExists); ok {
+		msg := fmt.Sprintf("Failed generating vouchers. %s", eae.Message)
+		sess.AddFlash(msg, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/vouchers/create")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Success generating %d vouchers", len(codes))
+	sess.AddFlash(msg, "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/vouchers")
+}
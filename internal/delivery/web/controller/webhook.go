@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookController struct {
+	webhookUc internal.WebhookUsecase
+}
+
+func NewWebhookController(ucs *app.Usecases) *WebhookController {
+	return &WebhookController{webhookUc: ucs.WebhookUsecase}
+}
+
+func (wc WebhookController) getWebhook(c echo.Context) (*entity.Webhook, error) {
+	wid := c.Param("webhookId")
+	webhookID, err := strconv.ParseInt(wid, 10, 64)
+	if err != nil {
+		return nil, echo.ErrNotFound
+	}
+
+	ctx := c.Request().Context()
+	webhook, err := wc.webhookUc.GetWebhook(ctx, webhookID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, echo.ErrNotFound
+	}
+
+	return webhook, err
+}
+
+func (wc WebhookController) ShowAllWebhooks(c echo.Context) error {
+	ctx := c.Request().Context()
+	webhooks, err := wc.webhookUc.GetWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Webhooks": webhooks,
+		"Events":   entity.WebhookEvents,
+	}
+	return renderPage(c, "webhooks", "Webhooks", data)
+}
+
+func (wc WebhookController) CreateWebhook(c echo.Context) error {
+	sess, _ := session.Get("kaseer", c)
+
+	var param entity.SaveWebhookParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err := c.Validate(&param)
+	ctx := c.Request().Context()
+	var webhook *entity.Webhook
+	if err == nil {
+		webhook, err = wc.webhookUc.CreateWebhook(ctx, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, "/webhooks")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success creating the webhook", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/webhooks/%d", webhook.ID))
+}
+
+func (wc WebhookController) ShowWebhookDetail(c echo.Context) error {
+	webhook, err := wc.getWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	deliveries, err := wc.webhookUc.GetWebhookDeliveries(ctx, webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	data := echo.Map{
+		"Webhook":    webhook,
+		"Deliveries": deliveries,
+		"Events":     entity.WebhookEvents,
+	}
+	return renderPage(c, "webhook_detail", "Webhook", data)
+}
+
+func (wc WebhookController) UpdateWebhook(c echo.Context) error {
+	webhook, err := wc.getWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	backURL := fmt.Sprintf("/webhooks/%d", webhook.ID)
+
+	var param entity.SaveWebhookParam
+	if err := c.Bind(&param); err != nil {
+		return echo.ErrBadRequest
+	}
+
+	err = c.Validate(&param)
+	ctx := c.Request().Context()
+	if err == nil {
+		err = wc.webhookUc.UpdateWebhook(ctx, webhook.ID, param)
+	}
+
+	if ev, ok := err.(entity.ErrValidation); ok {
+		sess.AddFlash(validationMessage(ev), "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, backURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("Success updating the webhook", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, backURL)
+}
+
+func (wc WebhookController) DeleteWebhook(c echo.Context) error {
+	webhook, err := wc.getWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := wc.webhookUc.DeleteWebhook(ctx, webhook.ID); err != nil {
+		return err
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	sess.AddFlash("Success deleting the webhook", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, "/webhooks")
+}
+
+func (wc WebhookController) RedeliverWebhook(c echo.Context) error {
+	webhook, err := wc.getWebhook(c)
+	if err != nil {
+		return err
+	}
+
+	did := c.Param("deliveryId")
+	deliveryID, err := strconv.ParseInt(did, 10, 64)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	sess, _ := session.Get("kaseer", c)
+	backURL := fmt.Sprintf("/webhooks/%d", webhook.ID)
+	ctx := c.Request().Context()
+	err = wc.webhookUc.RedeliverWebhook(ctx, webhook.ID, deliveryID)
+	if en, ok := err.(entity.ErrNotFound); ok {
+		sess.AddFlash(en.Message, "error_message")
+		sess.Save(c.Request(), c.Response())
+		return c.Redirect(http.StatusSeeOther, backURL)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sess.AddFlash("The delivery is queued again", "success_message")
+	sess.Save(c.Request(), c.Response())
+	return c.Redirect(http.StatusSeeOther, backURL)
+}
@@ -7,16 +7,23 @@ import (
 	"github.com/ardafirdausr/kaseer/internal/delivery/web/controller"
 	"github.com/ardafirdausr/kaseer/internal/delivery/web/middleware"
 	"github.com/ardafirdausr/kaseer/internal/delivery/web/server"
+	"github.com/ardafirdausr/kaseer/internal/entity"
 	"github.com/labstack/echo/v4"
 )
 
 func Start(app *app.App) {
 	web := server.New()
+	registerRoutes(web, app.Usecases)
+	server.Start(web)
+}
 
+// registerRoutes registers every route of the web, the JSON API included.
+// Every /api/v1 route must be described by apiSpec as well.
+func registerRoutes(web *echo.Echo, ucs *app.Usecases) {
 	web.Static("/static", "web/assets")
 	web.Static("/storage", "web/storage")
 
-	userController := controller.NewUserController(app.Usecases)
+	userController := controller.NewUserController(ucs)
 
 	// guest routes
 	authGuestRouter := web.Group("/auth", middleware.SessionGuest())
@@ -37,31 +44,207 @@ func Start(app *app.App) {
 	profileRouter.POST("", userController.UpdateUserProfile)
 	profileRouter.POST("/password", userController.UpdateUserPassword)
 
+	// API Token Routes
+	apiTokenController := controller.NewAPITokenController(ucs)
+	profileRouter.POST("/tokens/:tokenId/revoke", apiTokenController.RevokeUserAPIToken)
+	profileRouter.POST("/tokens", apiTokenController.CreateUserAPIToken)
+
+	// Service Account Routes, their tokens act for no one in particular so
+	// only the admin hands them out
+	serviceAccountRouter := authenticatedGroup.Group("/service-accounts", middleware.SessionAdmin(ucs.AdminEmails))
+	serviceAccountRouter.GET("/:userId", apiTokenController.ShowServiceAccountDetail)
+	serviceAccountRouter.GET("", apiTokenController.ShowAllServiceAccounts)
+	serviceAccountRouter.POST("/:userId/tokens/:tokenId/revoke", apiTokenController.RevokeServiceAccountAPIToken)
+	serviceAccountRouter.POST("/:userId/tokens", apiTokenController.CreateServiceAccountAPIToken)
+	serviceAccountRouter.POST("", apiTokenController.CreateServiceAccount)
+
+	// Webhook Routes
+	webhookController := controller.NewWebhookController(ucs)
+	webhookRouter := authenticatedGroup.Group("/webhooks", middleware.SessionAdmin(ucs.AdminEmails))
+	webhookRouter.GET("/:webhookId", webhookController.ShowWebhookDetail)
+	webhookRouter.GET("", webhookController.ShowAllWebhooks)
+	webhookRouter.POST("/:webhookId/deliveries/:deliveryId/redeliver", webhookController.RedeliverWebhook)
+	webhookRouter.POST("/:webhookId/delete", webhookController.DeleteWebhook)
+	webhookRouter.POST("/:webhookId", webhookController.UpdateWebhook)
+	webhookRouter.POST("", webhookController.CreateWebhook)
+
+	// Audit Log Routes, the exports included
+	auditLogController := controller.NewAuditLogController(ucs)
+	authenticatedGroup.GET("/audit-logs", auditLogController.ShowAuditLogs, middleware.SessionAdmin(ucs.AdminEmails))
+
 	// Order Routes
-	orderController := controller.NewOrderController(app.Usecases)
+	orderController := controller.NewOrderController(ucs)
 	orderRouter := authenticatedGroup.Group("/orders")
 	orderRouter.GET("/create", orderController.ShowCreateOrderForm)
 	orderRouter.GET("/total", orderController.GetTotalOrdersData)
-	orderRouter.GET("/latest-income", orderController.GetLatestIncomeData)
-	orderRouter.GET("/annual-income", orderController.GetAnnualIncomeData)
+	orderRouter.GET("/invoice", orderController.FindOrderByInvoiceNumber)
+	orderRouter.GET("/data", orderController.GetOrdersData)
+	orderRouter.GET("/suggestions", orderController.GetSuggestionsData)
+	orderRouter.GET("/:orderId/receipt", orderController.ShowOrderReceipt)
 	orderRouter.GET("/:orderId", orderController.GetOrderDetailData)
 	orderRouter.GET("", orderController.ShowAllOrders)
+	orderRouter.POST("/:orderId/void", orderController.VoidOrder)
+	orderRouter.POST("/:orderId/refund", orderController.RefundOrder)
 	orderRouter.POST("", orderController.CreateOrder)
 
+	// Held Cart Routes
+	heldCartController := controller.NewHeldCartController(ucs)
+	heldCartRouter := authenticatedGroup.Group("/held-carts")
+	heldCartRouter.GET("", heldCartController.GetHeldCartsData)
+	heldCartRouter.POST("/:heldCartId/resume", heldCartController.ResumeHeldCart)
+	heldCartRouter.POST("/:heldCartId/delete", heldCartController.DiscardHeldCart)
+	heldCartRouter.POST("", heldCartController.HoldCart)
+
 	// Product Routes
-	productController := controller.NewProductController(app.Usecases)
+	productController := controller.NewProductController(ucs)
 	productRouter := authenticatedGroup.Group("/products")
 	productRouter.GET("/create", productController.ShowCreateProductForm)
 	productRouter.GET("/bestseller", productController.GetBestSellerProductsData)
+	productRouter.GET("/search", productController.SearchProductsData)
+	productRouter.GET("/negative-stock", productController.ShowNegativeStockSales)
 	productRouter.GET("/:productId/edit", productController.ShowEditProductForm)
 	productRouter.GET("", productController.ShowAllProducts)
 	productRouter.POST("/:productId/update", productController.UpdateProduct)
 	productRouter.POST("/:productId/delete", productController.DeleteProduct)
+	productRouter.POST("/negative-stock/:saleId/reconcile", productController.ReconcileNegativeStockSale)
 	productRouter.POST("", productController.CreateProduct)
 
+	// Purchase Order Routes
+	purchaseOrderController := controller.NewPurchaseOrderController(ucs)
+	purchaseOrderRouter := authenticatedGroup.Group("/purchase-orders")
+	purchaseOrderRouter.GET("/reorder", purchaseOrderController.ShowReorderPlan)
+	purchaseOrderRouter.GET("/:purchaseOrderId", purchaseOrderController.ShowPurchaseOrderDetail)
+	purchaseOrderRouter.GET("", purchaseOrderController.ShowAllPurchaseOrders)
+	purchaseOrderRouter.POST("/:purchaseOrderId/status", purchaseOrderController.UpdatePurchaseOrderStatus)
+	purchaseOrderRouter.POST("", purchaseOrderController.CreatePurchaseOrder)
+
+	// Customer Routes
+	customerController := controller.NewCustomerController(ucs)
+	customerRouter := authenticatedGroup.Group("/customers")
+	customerRouter.GET("/create", customerController.ShowCreateCustomerForm)
+	customerRouter.GET("/search", customerController.SearchCustomersData)
+	customerRouter.GET("/:customerId/points", customerController.GetCustomerPointsData)
+	customerRouter.GET("/:customerId/prices", customerController.GetCustomerPricesData)
+	customerRouter.GET("/:customerId", customerController.ShowCustomerDetail)
+	customerRouter.GET("", customerController.ShowAllCustomers)
+	customerRouter.POST("/quick", customerController.QuickCreateCustomer)
+	customerRouter.POST("/:customerId/group", customerController.UpdateCustomerGroup)
+	customerRouter.POST("", customerController.CreateCustomer)
+
+	// Voucher Routes
+	voucherController := controller.NewVoucherController(ucs)
+	voucherRouter := authenticatedGroup.Group("/vouchers")
+	voucherRouter.GET("/create", voucherController.ShowGenerateVoucherForm)
+	voucherRouter.GET("/:voucherId", voucherController.ShowVoucherDetail)
+	voucherRouter.GET("", voucherController.ShowAllVouchers)
+	voucherRouter.POST("", voucherController.GenerateVouchers)
+
+	// Loyalty Routes
+	loyaltyController := controller.NewLoyaltyController(ucs)
+	loyaltyRouter := authenticatedGroup.Group("/loyalty")
+	loyaltyRouter.GET("", loyaltyController.ShowLoyaltySetting)
+	loyaltyRouter.POST("/multipliers/:multiplierId/delete", loyaltyController.DeleteMultiplier)
+	loyaltyRouter.POST("/multipliers", loyaltyController.SaveMultiplier)
+	loyaltyRouter.POST("", loyaltyController.UpdateLoyaltySetting)
+
+	// Store Setting Routes
+	storeSettingController := controller.NewStoreSettingController(ucs)
+	storeSettingRouter := authenticatedGroup.Group("/settings")
+	storeSettingRouter.GET("", storeSettingController.ShowStoreSetting)
+	storeSettingRouter.POST("", storeSettingController.UpdateStoreSetting)
+
+	// Report Routes
+	reportController := controller.NewReportController(ucs)
+	reportRouter := authenticatedGroup.Group("/reports")
+	reportRouter.GET("/sales/data", reportController.GetSalesReportData)
+	reportRouter.GET("/sales", reportController.ShowSalesReport)
+	reportRouter.GET("/heatmap", reportController.GetSalesHeatmapData)
+	reportRouter.GET("/stock", reportController.ShowStockValuation)
+	reportRouter.GET("/products/data", reportController.GetProductAnalyticsData)
+	reportRouter.GET("/products", reportController.ShowProductAnalytics)
+	reportRouter.GET("/basket", reportController.ShowMarketBasket)
+	reportRouter.POST("/basket", reportController.RefreshMarketBasket)
+
+	// Prepaid Card Routes
+	prepaidCardController := controller.NewPrepaidCardController(ucs)
+	prepaidCardRouter := authenticatedGroup.Group("/prepaid-cards")
+	prepaidCardRouter.GET("/balance", prepaidCardController.GetCardBalanceData)
+	prepaidCardRouter.GET("/:cardId", prepaidCardController.ShowCardDetail)
+	prepaidCardRouter.GET("", prepaidCardController.ShowAllCards)
+
+	// Price List Routes
+	priceListController := controller.NewPriceListController(ucs)
+	priceListRouter := authenticatedGroup.Group("/price-lists")
+	priceListRouter.GET("/:priceListId", priceListController.ShowPriceListDetail)
+	priceListRouter.GET("", priceListController.ShowAllPriceLists)
+	priceListRouter.POST("/groups", priceListController.CreateCustomerGroup)
+	priceListRouter.POST("/:priceListId/items/import", priceListController.ImportPriceListItems)
+	priceListRouter.POST("/:priceListId/items/:itemId/delete", priceListController.DeletePriceListItem)
+	priceListRouter.POST("/:priceListId/items", priceListController.SavePriceListItem)
+	priceListRouter.POST("/:priceListId/delete", priceListController.DeletePriceList)
+	priceListRouter.POST("", priceListController.CreatePriceList)
+
+	// API Routes
+	spec := apiSpec()
+	web.GET("/api/openapi.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, spec)
+	})
+	web.GET("/api/docs", func(c echo.Context) error {
+		return c.Render(http.StatusOK, "api_docs", echo.Map{"Title": "Kaseer API"})
+	})
+
+	apiRouter := web.Group("/api/v1")
+	authAPIController := controller.NewAuthAPIController(ucs)
+	apiRouter.POST("/auth/token", authAPIController.CreateToken)
+
+	apiAuthenticatedGroup := apiRouter.Group("", middleware.TokenAuth(ucs.AccessTokenUsecase, ucs.APITokenUsecase))
+
+	// personal API tokens are limited to their scopes
+	productRead := middleware.RequireScope(entity.APIScopeProductsRead)
+	productWrite := middleware.RequireScope(entity.APIScopeProductsWrite)
+	orderRead := middleware.RequireScope(entity.APIScopeOrdersRead)
+	orderWrite := middleware.RequireScope(entity.APIScopeOrdersWrite)
+	userRead := middleware.RequireScope(entity.APIScopeUsersRead)
+	userWrite := middleware.RequireScope(entity.APIScopeUsersWrite)
+	reportRead := middleware.RequireScope(entity.APIScopeReportsRead)
+
+	productAPIController := controller.NewProductAPIController(ucs)
+	productAPIRouter := apiAuthenticatedGroup.Group("/products")
+	productAPIRouter.GET("/categories", productAPIController.GetProductCategories, productRead)
+	productAPIRouter.GET("/:productId", productAPIController.GetProduct, productRead)
+	productAPIRouter.GET("", productAPIController.GetProducts, productRead)
+	productAPIRouter.POST("", productAPIController.CreateProduct, productWrite)
+	productAPIRouter.PUT("/:productId", productAPIController.UpdateProduct, productWrite)
+	productAPIRouter.DELETE("/:productId", productAPIController.DeleteProduct, productWrite)
+
+	orderAPIController := controller.NewOrderAPIController(ucs)
+	orderAPIRouter := apiAuthenticatedGroup.Group("/orders")
+	orderAPIRouter.GET("/:orderId", orderAPIController.GetOrder, orderRead)
+	orderAPIRouter.GET("", orderAPIController.GetOrders, orderRead)
+	orderAPIRouter.POST("/:orderId/void", orderAPIController.VoidOrder, orderWrite)
+	orderAPIRouter.POST("/:orderId/refund", orderAPIController.RefundOrder, orderWrite)
+	orderAPIRouter.POST("", orderAPIController.CreateOrder, orderWrite)
+
+	userAPIController := controller.NewUserAPIController(ucs)
+	userAPIRouter := apiAuthenticatedGroup.Group("/users")
+	userAPIRouter.GET("/me", userAPIController.GetCurrentUser, userRead)
+	userAPIRouter.GET("/:userId", userAPIController.GetUser, userRead)
+	userAPIRouter.GET("", userAPIController.GetUsers, userRead)
+	userAPIRouter.PUT("/me/password", userAPIController.UpdateCurrentUserPassword, userWrite)
+	userAPIRouter.PUT("/me", userAPIController.UpdateCurrentUser, userWrite)
+
+	reportAPIController := controller.NewReportAPIController(ucs)
+	reportAPIRouter := apiAuthenticatedGroup.Group("/reports")
+	reportAPIRouter.GET("/sales", reportAPIController.GetSalesReport, reportRead)
+	reportAPIRouter.GET("/heatmap", reportAPIController.GetSalesHeatmap, reportRead)
+	reportAPIRouter.GET("/stock", reportAPIController.GetStockValuation, reportRead)
+	reportAPIRouter.GET("/products", reportAPIController.GetProductAnalytics, reportRead)
+
 	// Dashboard route
-	dashboardController := controller.NewDashboardController(app.Usecases)
+	dashboardController := controller.NewDashboardController(ucs)
+	authenticatedGroup.GET("/dashboard/stream", dashboardController.StreamDashboard)
 	authenticatedGroup.GET("/dashboard", dashboardController.ShowDashboard)
+	web.Server.RegisterOnShutdown(dashboardController.Close)
 
 	// Health Check Route
 	web.GET("/health", func(c echo.Context) error {
@@ -72,6 +255,4 @@ func Start(app *app.App) {
 	web.GET("/", func(c echo.Context) error {
 		return c.Redirect(http.StatusSeeOther, "/dashboard")
 	})
-
-	server.Start(web)
 }
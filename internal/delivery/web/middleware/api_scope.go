@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+// RequireScope rejects the requests authenticated by a personal API token
+// lacking the scope. Access tokens issued by logging in act as their user
+// and are not limited.
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			apiToken, ok := c.Get("api_token").(*entity.APIToken)
+			if ok && !apiToken.HasScope(scope) {
+				message := fmt.Sprintf("The API token is missing the %s scope", scope)
+				return echo.NewHTTPError(http.StatusForbidden, message)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+// Audit puts the address and the browser of the request on its context for
+// the usecases to record along with the changes they log, the
+// authentication middlewares add the user.
+func Audit() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			actor := entity.AuditActor{
+				IPAddress: c.RealIP(),
+				UserAgent: req.UserAgent(),
+			}
+			c.SetRequest(req.WithContext(entity.ContextWithAuditActor(req.Context(), actor)))
+			return next(c)
+		}
+	}
+}
+
+func setAuditUser(c echo.Context, user *entity.User) {
+	req := c.Request()
+	actor := entity.AuditActorFromContext(req.Context())
+	actor.UserID = &user.ID
+	actor.Name = user.Name
+	c.SetRequest(req.WithContext(entity.ContextWithAuditActor(req.Context(), actor)))
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+// SessionAdmin lets only the users of the admin emails through, it comes
+// after SessionAuth which sets the user. Without admin emails no one is let
+// through.
+func SessionAdmin(adminEmails []string) echo.MiddlewareFunc {
+	admins := map[string]bool{}
+	for _, email := range adminEmails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			admins[email] = true
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get("user").(*entity.User)
+			if !ok {
+				return echo.ErrUnauthorized
+			}
+
+			if user.ServiceAccount || !admins[strings.ToLower(user.Email)] {
+				return echo.NewHTTPError(http.StatusForbidden, "Only the admin can access this page")
+			}
+
+			return next(c)
+		}
+	}
+}
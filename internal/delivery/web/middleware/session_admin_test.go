@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SessionAdmin(t *testing.T) {
+	tests := []struct {
+		name        string
+		adminEmails []string
+		user        *entity.User
+		code        int
+	}{
+		{
+			name:        "admin",
+			adminEmails: []string{"admin@mail.com"},
+			user:        &entity.User{Email: "admin@mail.com"},
+			code:        http.StatusOK,
+		},
+		{
+			name:        "admin of another case and spacing",
+			adminEmails: []string{"owner@mail.com", " Admin@Mail.com"},
+			user:        &entity.User{Email: "admin@MAIL.com"},
+			code:        http.StatusOK,
+		},
+		{
+			name:        "other user",
+			adminEmails: []string{"admin@mail.com"},
+			user:        &entity.User{Email: "cashier@mail.com"},
+			code:        http.StatusForbidden,
+		},
+		{
+			name:        "service account of an admin email",
+			adminEmails: []string{"service@kaseer.local"},
+			user:        &entity.User{Email: "service@kaseer.local", ServiceAccount: true},
+			code:        http.StatusForbidden,
+		},
+		{
+			name:        "no admin emails",
+			adminEmails: []string{""},
+			user:        &entity.User{Email: "admin@mail.com"},
+			code:        http.StatusForbidden,
+		},
+		{
+			name:        "no user",
+			adminEmails: []string{"admin@mail.com"},
+			code:        http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, rec := newTestContext("")
+			if test.user != nil {
+				c.Set("user", test.user)
+			}
+
+			err := SessionAdmin(test.adminEmails)(okHandler)(c)
+			if test.code == http.StatusOK {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+
+			httpErr, ok := err.(*echo.HTTPError)
+			if !assert.True(t, ok) {
+				return
+			}
+
+			assert.Equal(t, test.code, httpErr.Code)
+			if test.code == http.StatusForbidden {
+				assert.Equal(t, "Only the admin can access this page", httpErr.Message)
+			}
+		})
+	}
+}
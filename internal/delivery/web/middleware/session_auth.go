@@ -30,13 +30,14 @@ func SessionAuth() echo.MiddlewareFunc {
 				return echo.ErrUnauthorized
 			}
 
-			user, ok = user.(*entity.User)
+			sessionUser, ok := user.(*entity.User)
 			if !ok {
 				log.Println("Failed to parse user session")
 				return echo.ErrUnauthorized
 			}
 
-			c.Set("user", user)
+			c.Set("user", sessionUser)
+			setAuditUser(c, sessionUser)
 			return next(c)
 		}
 	}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/labstack/echo/v4"
+)
+
+// TokenAuth authenticates the API by the bearer token of the Authorization
+// header, setting the same user the session sets for the web pages. Personal
+// API tokens set their token as well, for RequireScope to check.
+func TokenAuth(accessTokenUsecase internal.AccessTokenUsecase, apiTokenUsecase internal.APITokenUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+			scheme, token, ok := strings.Cut(authorization, " ")
+			token = strings.TrimSpace(token)
+			if !ok || !strings.EqualFold(scheme, entity.AccessTokenTypeBearer) || token == "" {
+				return entity.ErrInvalidCredential{Message: "Missing bearer access token"}
+			}
+
+			ctx := c.Request().Context()
+			if strings.HasPrefix(token, entity.APITokenPrefix) {
+				user, apiToken, err := apiTokenUsecase.AuthenticateAPIToken(ctx, token)
+				if err != nil {
+					return err
+				}
+
+				c.Set("user", user)
+				c.Set("api_token", apiToken)
+				setAuditUser(c, user)
+				return next(c)
+			}
+
+			user, err := accessTokenUsecase.Authenticate(ctx, token)
+			if err != nil {
+				return err
+			}
+
+			c.Set("user", user)
+			setAuditUser(c, user)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testAPIToken = entity.APITokenPrefix + "0123456789abcdef"
+
+func newTestContext(authorization string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	if authorization != "" {
+		req.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	rec := httptest.NewRecorder()
+	return echo.New().NewContext(req, rec), rec
+}
+
+func okHandler(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+func Test_TokenAuth_Failed_WithoutBearerToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "no header", authorization: ""},
+		{name: "basic scheme", authorization: "Basic dXNlcjpwYXNz"},
+		{name: "no token", authorization: "Bearer "},
+		{name: "no scheme", authorization: "token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAccessTokenUsecase := new(mocks.AccessTokenUsecase)
+			mockAPITokenUsecase := new(mocks.APITokenUsecase)
+			c, _ := newTestContext(test.authorization)
+
+			err := TokenAuth(mockAccessTokenUsecase, mockAPITokenUsecase)(okHandler)(c)
+			assert.IsType(t, entity.ErrInvalidCredential{}, err)
+			assert.Nil(t, c.Get("user"))
+			mockAccessTokenUsecase.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+			mockAPITokenUsecase.AssertNotCalled(t, "AuthenticateAPIToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_TokenAuth_Success_WithAccessToken(t *testing.T) {
+	user := &entity.User{ID: 1, Name: "Jane"}
+	mockAccessTokenUsecase := new(mocks.AccessTokenUsecase)
+	mockAccessTokenUsecase.On("Authenticate", mock.Anything, "signed.token").Return(user, nil)
+	mockAPITokenUsecase := new(mocks.APITokenUsecase)
+	c, rec := newTestContext("bearer signed.token")
+
+	err := TokenAuth(mockAccessTokenUsecase, mockAPITokenUsecase)(okHandler)(c)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, user, c.Get("user"))
+	assert.Nil(t, c.Get("api_token"))
+	actor := entity.AuditActorFromContext(c.Request().Context())
+	assert.Equal(t, &user.ID, actor.UserID)
+	assert.Equal(t, "Jane", actor.Name)
+}
+
+func Test_TokenAuth_Success_WithAPIToken(t *testing.T) {
+	user := &entity.User{ID: 2, Name: "Integration", ServiceAccount: true}
+	apiToken := &entity.APIToken{ID: 5, UserID: 2, Scopes: []string{entity.APIScopeProductsRead}}
+	mockAccessTokenUsecase := new(mocks.AccessTokenUsecase)
+	mockAPITokenUsecase := new(mocks.APITokenUsecase)
+	mockAPITokenUsecase.On("AuthenticateAPIToken", mock.Anything, testAPIToken).Return(user, apiToken, nil)
+	c, rec := newTestContext("Bearer " + testAPIToken)
+
+	err := TokenAuth(mockAccessTokenUsecase, mockAPITokenUsecase)(okHandler)(c)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, user, c.Get("user"))
+	assert.Equal(t, apiToken, c.Get("api_token"))
+	mockAccessTokenUsecase.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
+
+func Test_TokenAuth_Failed_WhenTokenIsRejected(t *testing.T) {
+	accessTokenErr := entity.ErrInvalidCredential{Message: "Invalid access token"}
+	apiTokenErr := errors.New("failed get api token")
+	mockAccessTokenUsecase := new(mocks.AccessTokenUsecase)
+	mockAccessTokenUsecase.On("Authenticate", mock.Anything, "signed.token").Return(nil, accessTokenErr)
+	mockAPITokenUsecase := new(mocks.APITokenUsecase)
+	mockAPITokenUsecase.On("AuthenticateAPIToken", mock.Anything, testAPIToken).Return(nil, nil, apiTokenErr)
+
+	tests := []struct {
+		name          string
+		authorization string
+		err           error
+	}{
+		{name: "access token", authorization: "Bearer signed.token", err: accessTokenErr},
+		{name: "API token", authorization: "Bearer " + testAPIToken, err: apiTokenErr},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := newTestContext(test.authorization)
+			err := TokenAuth(mockAccessTokenUsecase, mockAPITokenUsecase)(okHandler)(c)
+			assert.Equal(t, test.err, err)
+			assert.Nil(t, c.Get("user"))
+		})
+	}
+}
+
+func Test_RequireScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiToken *entity.APIToken
+		code     int
+	}{
+		{
+			name: "access token",
+			code: http.StatusOK,
+		},
+		{
+			name:     "API token with the scope",
+			apiToken: &entity.APIToken{Scopes: []string{entity.APIScopeOrdersRead, entity.APIScopeProductsRead}},
+			code:     http.StatusOK,
+		},
+		{
+			name:     "API token without the scope",
+			apiToken: &entity.APIToken{Scopes: []string{entity.APIScopeProductsWrite}},
+			code:     http.StatusForbidden,
+		},
+		{
+			name:     "API token without scopes",
+			apiToken: &entity.APIToken{},
+			code:     http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, rec := newTestContext("")
+			if test.apiToken != nil {
+				c.Set("api_token", test.apiToken)
+			}
+
+			err := RequireScope(entity.APIScopeProductsRead)(okHandler)(c)
+			if test.code == http.StatusOK {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+
+			httpErr, ok := err.(*echo.HTTPError)
+			if assert.True(t, ok) {
+				assert.Equal(t, test.code, httpErr.Code)
+				assert.Equal(t, "The API token is missing the products:read scope", httpErr.Message)
+			}
+		})
+	}
+}
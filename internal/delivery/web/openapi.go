@@ -0,0 +1,554 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/delivery/web/controller"
+	"github.com/ardafirdausr/kaseer/internal/delivery/web/server"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/openapi"
+)
+
+// apiEndpoint describes a route of the API. Path is written the way it is
+// registered, Body and Data are values of the types of the request body and
+// of the data of the response, no Data answers 204 No Content. Scope is the
+// scope personal API tokens need. Session routes are the ones the web pages
+// fetch, authenticated by the session cookie and answering their data along
+// with a message.
+type apiEndpoint struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Description string
+	Scope       string
+	Query       []*openapi.Parameter
+	Body        interface{}
+	Status      int
+	Data        interface{}
+	Paged       bool
+	Public      bool
+	Session     bool
+}
+
+// WebError is the failure of a session route, errors has the message of
+// every invalid field.
+type WebError struct {
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+var pathParamPattern = regexp.MustCompile(`:(\w+)`)
+
+// openAPIPath turns an echo path into an OpenAPI one, :productId becomes
+// {productId}.
+func openAPIPath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+func queryParam(name string, typ string, description string) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: typ}}
+}
+
+func addAPIEndpoint(doc *openapi.Document, endpoint apiEndpoint) {
+	description := endpoint.Description
+	if endpoint.Scope != "" {
+		description = strings.TrimSpace(fmt.Sprintf("%s Personal API tokens need the %s scope.", description, endpoint.Scope))
+	}
+
+	operation := &openapi.Operation{
+		Tags:        []string{endpoint.Tag},
+		Summary:     endpoint.Summary,
+		Description: description,
+		Parameters:  endpoint.Query,
+		Responses:   map[string]*openapi.Response{},
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(endpoint.Path, -1) {
+		operation.Parameters = append(operation.Parameters, &openapi.Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &openapi.Schema{Type: "integer", Format: "int64"},
+		})
+	}
+
+	if endpoint.Body != nil {
+		operation.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  openapi.JSON(doc.SchemaOf(endpoint.Body)),
+		}
+	}
+
+	status := endpoint.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	response := &openapi.Response{Description: http.StatusText(status)}
+	if endpoint.Session {
+		envelope := &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"message": {Type: "string"}},
+			Required:   []string{"message"},
+		}
+		if endpoint.Data != nil {
+			envelope.Properties["data"] = doc.SchemaOf(endpoint.Data)
+			envelope.Required = append(envelope.Required, "data")
+		}
+		response.Content = openapi.JSON(envelope)
+	} else if endpoint.Data != nil {
+		envelope := &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"data": doc.SchemaOf(endpoint.Data)},
+			Required:   []string{"data"},
+		}
+		if endpoint.Paged {
+			envelope.Properties["meta"] = doc.SchemaOf(controller.APIMeta{})
+			envelope.Required = append(envelope.Required, "meta")
+		}
+		response.Content = openapi.JSON(envelope)
+	}
+	operation.Responses[strconv.Itoa(status)] = response
+
+	var apiError interface{} = server.APIError{}
+	if endpoint.Session {
+		apiError = WebError{}
+	}
+	operation.Responses["default"] = &openapi.Response{
+		Description: "Error",
+		Content:     openapi.JSON(doc.SchemaOf(apiError)),
+	}
+
+	switch {
+	case endpoint.Public:
+	case endpoint.Session:
+		operation.Security = []map[string][]string{{"sessionCookie": {}}}
+	default:
+		operation.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	doc.AddOperation(endpoint.Method, openAPIPath(endpoint.Path), operation)
+}
+
+// apiSpec describes the JSON routes registered by registerRoutes, the
+// /api/v1 ones and the ones of the web pages, its test fails when a route is
+// left out.
+func apiSpec() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "Kaseer API",
+		Description: "JSON API of the Kaseer point of sales. Successful responses put the resource in data and the paging of listings in meta, failures are described in error.",
+		Version:     "1.0.0",
+	})
+	doc.Components.SecuritySchemes["bearerAuth"] = &openapi.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "Access token issued by POST /api/v1/auth/token, or a personal API token created on the profile page or for a service account",
+	}
+	doc.Components.SecuritySchemes["sessionCookie"] = &openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "cookie",
+		Name:        "kaseer",
+		Description: "Session of a user logged in at /auth/login, for the routes the web pages fetch",
+	}
+
+	pageQuery := []*openapi.Parameter{
+		queryParam("offset", "integer", "Number of items to skip"),
+		queryParam("limit", "integer", "Number of items of the page"),
+	}
+	productQuery := append([]*openapi.Parameter{
+		queryParam("q", "string", "Keyword matched against the code and the name"),
+		queryParam("category", "string", "Category of the products"),
+		queryParam("in_stock", "boolean", "Only the products with stock left"),
+		queryParam("ids", "string", "Comma separated list of product IDs"),
+		queryParam("sort", "string", "code, name, price or stock, prefixed with - to sort descending"),
+	}, pageQuery...)
+	orderQuery := append([]*openapi.Parameter{
+		queryParam("start_date", "string", "First day of the orders, YYYY-MM-DD"),
+		queryParam("end_date", "string", "Last day of the orders, YYYY-MM-DD"),
+		queryParam("cashier_id", "integer", "User ID of the cashier"),
+		queryParam("status", "string", "completed, voided or refunded"),
+		queryParam("min_total", "integer", "Minimum total"),
+		queryParam("max_total", "integer", "Maximum total"),
+		queryParam("invoice_number", "string", "Start of the invoice number"),
+		queryParam("cursor", "integer", "next_cursor of the previous page"),
+	}, pageQuery...)
+
+	endpoints := []apiEndpoint{
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/auth/token",
+			Tag:     "Auth",
+			Summary: "Issue an access token for an email and password",
+			Body:    entity.UserCredential{},
+			Status:  http.StatusCreated,
+			Data:    entity.AccessToken{},
+			Public:  true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/products",
+			Scope:   entity.APIScopeProductsRead,
+			Tag:     "Products",
+			Summary: "Search the products",
+			Query:   productQuery,
+			Data:    []*entity.Product{},
+			Paged:   true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/products/categories",
+			Scope:   entity.APIScopeProductsRead,
+			Tag:     "Products",
+			Summary: "List the product categories",
+			Data:    []string{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/products/:productId",
+			Scope:   entity.APIScopeProductsRead,
+			Tag:     "Products",
+			Summary: "Get a product",
+			Data:    entity.Product{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/products",
+			Scope:   entity.APIScopeProductsWrite,
+			Tag:     "Products",
+			Summary: "Create a product",
+			Body:    entity.CreateProductParam{},
+			Status:  http.StatusCreated,
+			Data:    entity.Product{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/api/v1/products/:productId",
+			Scope:   entity.APIScopeProductsWrite,
+			Tag:     "Products",
+			Summary: "Replace a product",
+			Body:    entity.UpdateProductParam{},
+			Data:    entity.Product{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/products/:productId",
+			Scope:   entity.APIScopeProductsWrite,
+			Tag:     "Products",
+			Summary: "Delete a product",
+			Status:  http.StatusNoContent,
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/orders",
+			Scope:       entity.APIScopeOrdersRead,
+			Tag:         "Orders",
+			Summary:     "List the orders, newest first",
+			Description: "Pages by offset, or by order ID given the next_cursor of the previous page.",
+			Query:       orderQuery,
+			Data:        []*entity.Order{},
+			Paged:       true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/orders/:orderId",
+			Scope:   entity.APIScopeOrdersRead,
+			Tag:     "Orders",
+			Summary: "Get an order with its items and payments",
+			Data:    entity.Order{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/orders",
+			Scope:       entity.APIScopeOrdersWrite,
+			Tag:         "Orders",
+			Summary:     "Place an order",
+			Description: "Retries sending the same Idempotency-Key header get the order of the first request.",
+			Body:        entity.CreateOrderParam{},
+			Status:      http.StatusCreated,
+			Data:        entity.Order{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/orders/:orderId/void",
+			Scope:   entity.APIScopeOrdersWrite,
+			Tag:     "Orders",
+			Summary: "Void an order",
+			Data:    entity.Order{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/orders/:orderId/refund",
+			Scope:   entity.APIScopeOrdersWrite,
+			Tag:     "Orders",
+			Summary: "Refund an order, in cash or as store credit",
+			Body:    entity.RefundOrderParam{},
+			Data:    controller.APIOrderRefund{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/users",
+			Scope:   entity.APIScopeUsersRead,
+			Tag:     "Users",
+			Summary: "List the users",
+			Data:    []*entity.User{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/users/me",
+			Scope:   entity.APIScopeUsersRead,
+			Tag:     "Users",
+			Summary: "Get the authenticated user",
+			Data:    entity.User{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/users/:userId",
+			Scope:   entity.APIScopeUsersRead,
+			Tag:     "Users",
+			Summary: "Get a user",
+			Data:    entity.User{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/api/v1/users/me",
+			Scope:   entity.APIScopeUsersWrite,
+			Tag:     "Users",
+			Summary: "Update the name and email of the authenticated user",
+			Body:    entity.UpdateUserParam{},
+			Data:    entity.User{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/api/v1/users/me/password",
+			Tag:         "Users",
+			Summary:     "Change the password of the authenticated user",
+			Description: "Only with an access token, personal API tokens are refused whatever their scopes.",
+			Body:        entity.UpdateUserPasswordParam{},
+			Status:      http.StatusNoContent,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/reports/sales",
+			Scope:   entity.APIScopeReportsRead,
+			Tag:     "Reports",
+			Summary: "Report the sales of a period",
+			Query:   doc.QueryParameters(entity.SalesReportParam{}),
+			Data:    entity.SalesReport{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/reports/heatmap",
+			Scope:   entity.APIScopeReportsRead,
+			Tag:     "Reports",
+			Summary: "Lay the orders out by weekday and hour",
+			Query:   doc.QueryParameters(entity.SalesHeatmapParam{}),
+			Data:    entity.SalesHeatmap{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/reports/stock",
+			Scope:   entity.APIScopeReportsRead,
+			Tag:     "Reports",
+			Summary: "Value the stock on hand, paged by cursor",
+			Query:   doc.QueryParameters(entity.StockValuationQuery{}),
+			Data:    entity.StockValuation{},
+			Paged:   true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/reports/products",
+			Scope:   entity.APIScopeReportsRead,
+			Tag:     "Reports",
+			Summary: "Rank the products and classify them ABC",
+			Query:   doc.QueryParameters(entity.ProductAnalyticsParam{}),
+			Data:    entity.ProductAnalytics{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/health",
+			Tag:     "Web",
+			Summary: "Check the server is up",
+			Public:  true,
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders/data",
+			Tag:     "Web",
+			Summary: "List the orders of the orders page",
+			Query:   orderQuery,
+			Data:    entity.OrderPage{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders/total",
+			Tag:     "Web",
+			Summary: "Count the completed orders",
+			Data:    0,
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders/suggestions",
+			Tag:     "Web",
+			Summary: "Suggest the products bought along with the cart",
+			Query:   []*openapi.Parameter{queryParam("product_ids", "string", "Comma separated list of the product IDs of the cart")},
+			Data:    []*entity.ProductSuggestion{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders/:orderId",
+			Tag:     "Web",
+			Summary: "List the items of an order",
+			Data:    []*entity.OrderItem{},
+			Session: true,
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/orders",
+			Tag:         "Web",
+			Summary:     "Place an order at the register",
+			Description: "Retries sending the same Idempotency-Key header get the order of the first request.",
+			Body:        entity.CreateOrderParam{},
+			Status:      http.StatusCreated,
+			Data:        entity.Order{},
+			Session:     true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/held-carts",
+			Tag:     "Web",
+			Summary: "List the carts held at a register",
+			Query:   []*openapi.Parameter{{Name: "register", In: "query", Required: true, Schema: &openapi.Schema{Type: "string"}}},
+			Data:    []*entity.HeldCart{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/held-carts",
+			Tag:     "Web",
+			Summary: "Hold a cart",
+			Body:    entity.HoldCartParam{},
+			Status:  http.StatusCreated,
+			Data:    entity.HeldCart{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/held-carts/:heldCartId/resume",
+			Tag:     "Web",
+			Summary: "Take a held cart back to the register",
+			Data:    entity.HeldCart{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/held-carts/:heldCartId/delete",
+			Tag:     "Web",
+			Summary: "Discard a held cart",
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/products/search",
+			Tag:     "Web",
+			Summary: "Search the products of the register",
+			Query:   productQuery,
+			Data:    entity.ProductPage{},
+			Session: true,
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/products/bestseller",
+			Tag:         "Web",
+			Summary:     "List the best selling products",
+			Description: "Given a format, the list is downloaded as a file instead.",
+			Query:       []*openapi.Parameter{queryParam("format", "string", "csv, xlsx or pdf")},
+			Data:        []*entity.ProductSale{},
+			Session:     true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/customers/search",
+			Tag:     "Web",
+			Summary: "Search the customers",
+			Query:   []*openapi.Parameter{queryParam("q", "string", "Keyword matched against the name, the phone and the email")},
+			Data:    []*entity.Customer{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/customers/:customerId/points",
+			Tag:     "Web",
+			Summary: "Get the loyalty points of a customer",
+			Data:    0,
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/customers/:customerId/prices",
+			Tag:     "Web",
+			Summary: "List the prices of the price list of a customer",
+			Data:    []*entity.PriceListItem{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/customers/quick",
+			Tag:     "Web",
+			Summary: "Add a customer from the register",
+			Body:    entity.CreateCustomerParam{},
+			Status:  http.StatusCreated,
+			Data:    entity.Customer{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/prepaid-cards/balance",
+			Tag:     "Web",
+			Summary: "Get a prepaid card by its number",
+			Query:   []*openapi.Parameter{queryParam("number", "string", "Number of the card")},
+			Data:    entity.PrepaidCard{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/reports/sales/data",
+			Tag:     "Web",
+			Summary: "Report the sales of the sales report page",
+			Query:   doc.QueryParameters(entity.SalesReportParam{}),
+			Data:    entity.SalesReport{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/reports/heatmap",
+			Tag:     "Web",
+			Summary: "Lay the orders out by weekday and hour",
+			Query:   doc.QueryParameters(entity.SalesHeatmapParam{}),
+			Data:    entity.SalesHeatmap{},
+			Session: true,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/reports/products/data",
+			Tag:     "Web",
+			Summary: "Rank the products of the product analytics page",
+			Query:   doc.QueryParameters(entity.ProductAnalyticsParam{}),
+			Data:    entity.ProductAnalytics{},
+			Session: true,
+		},
+	}
+	for _, endpoint := range endpoints {
+		addAPIEndpoint(doc, endpoint)
+	}
+
+	return doc
+}
@@ -0,0 +1,93 @@
+package web
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/app"
+	"github.com/ardafirdausr/kaseer/internal/pkg/event"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// webJSONHandlers answer JSON to the web pages without following the Data
+// suffix of the handlers the pages fetch.
+var webJSONHandlers = []string{
+	"CustomerController.QuickCreateCustomer",
+	"HeldCartController.HoldCart",
+	"HeldCartController.ResumeHeldCart",
+	"HeldCartController.DiscardHeldCart",
+	"OrderController.CreateOrder",
+}
+
+// webJSONPaths answer JSON from handlers registered inline, the spec itself
+// is left out.
+var webJSONPaths = []string{"GET /health"}
+
+func isJSONRoute(route *echo.Route) bool {
+	if strings.HasPrefix(route.Path, "/api/v1/") {
+		return true
+	}
+
+	handler := strings.TrimSuffix(route.Name, "-fm")
+	if strings.HasSuffix(handler, "Data") {
+		return true
+	}
+
+	for _, name := range webJSONHandlers {
+		if strings.HasSuffix(handler, "controller."+name) {
+			return true
+		}
+	}
+
+	for _, path := range webJSONPaths {
+		if route.Method+" "+route.Path == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+func Test_APISpec_DescribesEveryJSONRoute(t *testing.T) {
+	web := echo.New()
+	registerRoutes(web, &app.Usecases{EventBus: event.NewBus()})
+
+	// groups with middleware register catch all routes answering 404
+	notFound := runtime.FuncForPC(reflect.ValueOf(echo.NotFoundHandler).Pointer()).Name()
+
+	spec := apiSpec()
+	apiRoutes, webRoutes := 0, 0
+	for _, route := range web.Routes() {
+		if route.Name == notFound || !isJSONRoute(route) {
+			continue
+		}
+
+		if strings.HasPrefix(route.Path, "/api/v1/") {
+			apiRoutes++
+		} else {
+			webRoutes++
+		}
+		assert.True(t, spec.HasOperation(route.Method, openAPIPath(route.Path)), "%s %s is missing from the spec", route.Method, route.Path)
+	}
+	assert.NotZero(t, apiRoutes)
+	assert.NotZero(t, webRoutes)
+}
+
+func Test_APISpec_CountsOnlyRegisteredRoutes(t *testing.T) {
+	web := echo.New()
+	registerRoutes(web, &app.Usecases{EventBus: event.NewBus()})
+
+	registered := map[string]bool{}
+	for _, route := range web.Routes() {
+		registered[route.Method+" "+openAPIPath(route.Path)] = true
+	}
+
+	for path, item := range apiSpec().Paths {
+		for method := range *item {
+			assert.True(t, registered[strings.ToUpper(method)+" "+path], "%s %s is not registered", method, path)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/getsentry/sentry-go"
+	sentryecho "github.com/getsentry/sentry-go/echo"
+	"github.com/labstack/echo/v4"
+)
+
+// APIError is the envelope of every failed API response. Code is a stable
+// snake_case name of the failure, Fields explains a validation failure per
+// field.
+type APIError struct {
+	Error APIErrorDetail `json:"error"`
+}
+
+type APIErrorDetail struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// newAPIError maps an error returned by a handler or a middleware to its
+// status and envelope, anything unknown is an internal error.
+func newAPIError(err error) (int, APIError) {
+	switch e := err.(type) {
+	case entity.ErrValidation:
+		return http.StatusBadRequest, APIError{APIErrorDetail{"validation_failed", e.Error(), e.Errors}}
+	case *entity.ErrValidation:
+		return http.StatusBadRequest, APIError{APIErrorDetail{"validation_failed", e.Error(), e.Errors}}
+	case entity.ErrNotFound:
+		return http.StatusNotFound, APIError{APIErrorDetail{"not_found", apiMessage(e.Message, http.StatusNotFound), nil}}
+	case entity.ErrItemAlreadyExists:
+		return http.StatusConflict, APIError{APIErrorDetail{"already_exists", apiMessage(e.Message, http.StatusConflict), nil}}
+	case entity.ErrInvalidCredential:
+		return http.StatusUnauthorized, APIError{APIErrorDetail{"invalid_credential", apiMessage(e.Message, http.StatusUnauthorized), nil}}
+	case *echo.HTTPError:
+		message := apiMessage(fmt.Sprint(e.Message), e.Code)
+		if e.Code >= http.StatusInternalServerError {
+			message = http.StatusText(e.Code)
+		}
+		return e.Code, APIError{APIErrorDetail{statusCode(e.Code), message, nil}}
+	default:
+		code := http.StatusInternalServerError
+		return code, APIError{APIErrorDetail{statusCode(code), http.StatusText(code), nil}}
+	}
+}
+
+func apiMessage(message string, code int) string {
+	if message == "" {
+		return http.StatusText(code)
+	}
+
+	return message
+}
+
+// statusCode names a status the way the API names its failures, e.g.
+// "method_not_allowed".
+func statusCode(code int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(code)), " ", "_")
+}
+
+func (che CustomHTTPErrorHandler) handleAPIError(err error, c echo.Context) {
+	code, body := newAPIError(err)
+	if code >= http.StatusInternalServerError {
+		log.Println(err.Error())
+		che.logger.Error(err)
+		if hub := sentryecho.GetHubFromContext(c); hub != nil {
+			hub.WithScope(func(scope *sentry.Scope) {
+				hub.CaptureException(err)
+			})
+		}
+
+		if che.debug {
+			body.Error.Message = err.Error()
+		}
+	}
+
+	if c.Response().Committed {
+		return
+	}
+
+	if code == http.StatusUnauthorized {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, entity.AccessTokenTypeBearer)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		c.NoContent(code)
+		return
+	}
+
+	if err := c.JSON(code, body); err != nil {
+		log.Println(err.Error())
+	}
+}
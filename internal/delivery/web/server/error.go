@@ -3,6 +3,7 @@ package server
 import (
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
 	"github.com/getsentry/sentry-go"
@@ -16,6 +17,11 @@ type CustomHTTPErrorHandler struct {
 }
 
 func (che CustomHTTPErrorHandler) Handler(err error, c echo.Context) {
+	if strings.HasPrefix(c.Request().URL.Path, "/api/") {
+		che.handleAPIError(err, c)
+		return
+	}
+
 	he, ok := err.(*echo.HTTPError)
 	if !ok {
 		he = &echo.HTTPError{
@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"io"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -35,6 +36,28 @@ func (t *HTMLRenderer) Render(w io.Writer, name string, data interface{}, c echo
 func layoutTemplate() *template.Template {
 	funcs := template.FuncMap{
 		"StrContains": strings.Contains,
+		"Decimal":     decimal,
+		"Percent":     percent,
 	}
 	return template.Must(template.New("").Funcs(funcs).ParseGlob("web/views/layouts/*.html"))
 }
+
+// decimal prints a number with one decimal, a nil pointer is printed as "-".
+func decimal(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	case *float64:
+		if v == nil {
+			return "-"
+		}
+		return strconv.FormatFloat(*v, 'f', 1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// percent prints a fraction as a percentage.
+func percent(share float64) string {
+	return strconv.FormatFloat(share*100, 'f', 1, 64) + "%"
+}
@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ardafirdausr/kaseer/internal/delivery/web/middleware"
@@ -26,6 +28,12 @@ func New() *echo.Echo {
 	}
 	e.Debug = isDebuging
 
+	ipExtractor, err := newIPExtractor(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	e.IPExtractor = ipExtractor
+
 	renderer := NewHtmlRenderer()
 	e.Renderer = renderer
 
@@ -46,6 +54,7 @@ func New() *echo.Echo {
 	sessionKey := os.Getenv("SESSION_KEY")
 	store := sessions.NewCookieStore([]byte(sessionKey))
 	e.Use(middleware.Session(store))
+	e.Use(middleware.Audit())
 
 	e.Use(middleware.EchoLogger())
 	e.Use(middleware.Recover())
@@ -53,6 +62,33 @@ func New() *echo.Echo {
 	return e
 }
 
+// newIPExtractor reads the client IP from X-Forwarded-For only behind the
+// trusted proxies, comma separated CIDRs, the IP of the connection is taken
+// otherwise so that clients cannot pass another IP off as theirs.
+func newIPExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	if strings.TrimSpace(trustedProxies) == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// the loopback and private ranges are trusted by default, only the
+	// configured proxies are
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, value := range strings.Split(trustedProxies, ",") {
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
 func Start(e *echo.Echo) {
 	host := os.Getenv("HOST")
 	port := os.Getenv("PORT")
@@ -35,6 +35,14 @@ func (v *CustomValidator) Validate(i interface{}) error {
 				errMessage = fmt.Sprintf("Value of %s must be greater or equal to %s", fieldName, paramValue)
 			case "email":
 				errMessage = fmt.Sprintf("Value of %s must be valid email", fieldName)
+			case "lte":
+				errMessage = fmt.Sprintf("Value of %s must be less or equal to %s", fieldName, paramValue)
+			case "max":
+				errMessage = fmt.Sprintf("Length of %s must be at most %s", fieldName, paramValue)
+			case "oneof":
+				errMessage = fmt.Sprintf("Value of %s must be one of %s", fieldName, paramValue)
+			case "datetime":
+				errMessage = fmt.Sprintf("Value of %s must be a date with format %s", fieldName, paramValue)
 			case "eqfield":
 				errMessage = fmt.Sprintf("Value of %s must be equal with", paramValue)
 			}
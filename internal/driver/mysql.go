@@ -2,6 +2,7 @@ package driver
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -11,18 +12,21 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
-func ConnectToMySQLDB() (*sql.DB, error) {
+// mysqlErrUnknownTimeZone is the MySQL error number of a session time_zone
+// it does not know, a zone name without the time zone tables loaded.
+const mysqlErrUnknownTimeZone = 1298
+
+// ConnectToMySQLDB connects with the session timezone set to loc, so the
+// TIMESTAMP columns convert the same way in both directions. The zone is set
+// by its name to follow daylight saving, MySQL without its time zone tables
+// loaded gets the current offset of loc instead.
+func ConnectToMySQLDB(loc *time.Location) (*sql.DB, error) {
 	retryCount := 0
 	retryLimit := 3
 	retryInterval := 3
+	timeZone := loc.String()
 	var db *sql.DB
 	for retryCount < retryLimit {
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			log.Fatal(err.Error())
-			return nil, err
-		}
-
 		mysqlConfig, err := mysql.NewConnector(&mysql.Config{
 			Addr:         fmt.Sprintf("%s:%s", os.Getenv("MYSQL_HOST"), os.Getenv("MYSQL_PORT")),
 			User:         os.Getenv("MYSQL_USER"),
@@ -31,6 +35,7 @@ func ConnectToMySQLDB() (*sql.DB, error) {
 			ParseTime:    true,
 			Net:          "tcp",
 			Loc:          loc,
+			Params:       map[string]string{"time_zone": fmt.Sprintf("'%s'", timeZone)},
 			Collation:    "utf8mb4_general_ci",
 			Timeout:      10 * time.Second,
 			ReadTimeout:  10 * time.Second,
@@ -55,7 +60,16 @@ func ConnectToMySQLDB() (*sql.DB, error) {
 		db.SetConnMaxLifetime(300)
 		db.SetMaxIdleConns(3)
 		db.SetMaxOpenConns(5)
-		if err = db.Ping(); err != nil {
+		err = db.Ping()
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrUnknownTimeZone && timeZone == loc.String() {
+			db.Close()
+			timeZone = time.Now().In(loc).Format("-07:00")
+			log.Printf("MySQL does not know the %s time zone, using %s instead\n", loc.String(), timeZone)
+			continue
+		}
+
+		if err != nil {
 			log.Fatal(err.Error())
 			return nil, err
 		}
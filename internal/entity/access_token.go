@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+const AccessTokenTypeBearer = "Bearer"
+
+// AccessToken lets the API act as the user until it expires, it is sent in
+// the Authorization header as "Bearer <token>".
+type AccessToken struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      *User     `json:"user"`
+}
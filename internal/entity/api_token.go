@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// APITokenPrefix starts every personal API token, telling them apart from
+// the access tokens issued by logging in to the API.
+const APITokenPrefix = "kst_"
+
+const (
+	APIScopeProductsRead  = "products:read"
+	APIScopeProductsWrite = "products:write"
+	APIScopeOrdersRead    = "orders:read"
+	APIScopeOrdersWrite   = "orders:write"
+	APIScopeUsersRead     = "users:read"
+	APIScopeUsersWrite    = "users:write"
+	APIScopeReportsRead   = "reports:read"
+)
+
+// APIScopes lists the scopes an API token can be granted.
+var APIScopes = []string{
+	APIScopeProductsRead,
+	APIScopeProductsWrite,
+	APIScopeOrdersRead,
+	APIScopeOrdersWrite,
+	APIScopeUsersRead,
+	APIScopeUsersWrite,
+	APIScopeReportsRead,
+}
+
+// APIToken is a long lived token of a user or a service account, only the
+// hash of the token is kept.
+type APIToken struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	TokenHash  string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (token APIToken) IsRevoked() bool {
+	return token.RevokedAt != nil
+}
+
+func (token APIToken) IsExpired(at time.Time) bool {
+	return token.ExpiresAt != nil && !at.Before(*token.ExpiresAt)
+}
+
+func (token APIToken) IsActive(at time.Time) bool {
+	return !token.IsRevoked() && !token.IsExpired(at)
+}
+
+// Status tells whether the token is active, revoked or expired by now.
+func (token APIToken) Status() string {
+	switch {
+	case token.IsRevoked():
+		return "revoked"
+	case token.IsExpired(time.Now()):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+func (token APIToken) HasScope(scope string) bool {
+	for _, s := range token.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (token APIToken) ScopeList() string {
+	return strings.Join(token.Scopes, ", ")
+}
+
+type CreateAPITokenParam struct {
+	Name          string   `form:"name" json:"name" validate:"required,max=50"`
+	Scopes        []string `form:"scopes" json:"scopes" validate:"required,min=1,dive,oneof=products:read products:write orders:read orders:write users:read users:write reports:read"`
+	ExpiresInDays int      `form:"expires_in_days" json:"expires_in_days" validate:"min=0,max=365"`
+}
+
+type SaveAPITokenParam struct {
+	UserID    int64
+	Name      string
+	Prefix    string
+	TokenHash string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// IssuedAPIToken carries the token of a new API token, shown to its owner
+// once since only its hash is kept.
+type IssuedAPIToken struct {
+	Token    string    `json:"token"`
+	APIToken *APIToken `json:"api_token"`
+}
+
+type CreateServiceAccountParam struct {
+	Name string `form:"name" json:"name" validate:"required,max=50"`
+}
@@ -0,0 +1,169 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	AuditActionProductCreated  = "product.created"
+	AuditActionProductUpdated  = "product.updated"
+	AuditActionProductDeleted  = "product.deleted"
+	AuditActionProfileUpdated  = "user.profile_updated"
+	AuditActionPasswordChanged = "user.password_changed"
+	AuditActionLoggedIn        = "user.logged_in"
+	AuditActionOrderVoided     = "order.voided"
+	AuditActionOrderRefunded   = "order.refunded"
+)
+
+// AuditActions lists the recorded actions in the order the audit log page
+// offers them.
+var AuditActions = []string{
+	AuditActionProductCreated,
+	AuditActionProductUpdated,
+	AuditActionProductDeleted,
+	AuditActionProfileUpdated,
+	AuditActionPasswordChanged,
+	AuditActionLoggedIn,
+	AuditActionOrderVoided,
+	AuditActionOrderRefunded,
+}
+
+const (
+	AuditEntityProduct = "product"
+	AuditEntityUser    = "user"
+	AuditEntityOrder   = "order"
+)
+
+// AuditLog records who did what to which entity, and from where. The logs
+// are only ever appended, never updated nor deleted.
+type AuditLog struct {
+	ID         int64                  `json:"id"`
+	ActorID    *int64                 `json:"actor_id"`
+	ActorName  string                 `json:"actor_name"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int64                  `json:"entity_id"`
+	Changes    map[string]AuditChange `json:"changes"`
+	IPAddress  string                 `json:"ip_address"`
+	UserAgent  string                 `json:"user_agent"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditChange is a field before and after the action, nil when the entity
+// did not exist before or does not anymore after.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ChangedFields lists the fields of the changes in alphabetical order.
+func (l AuditLog) ChangedFields() []string {
+	fields := make([]string, 0, len(l.Changes))
+	for field := range l.Changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ChangeSummary puts the changes on one line, as they are exported.
+func (l AuditLog) ChangeSummary() string {
+	changes := []string{}
+	for _, field := range l.ChangedFields() {
+		change := l.Changes[field]
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", field, change.BeforeText(), change.AfterText()))
+	}
+
+	return strings.Join(changes, "; ")
+}
+
+func (c AuditChange) BeforeText() string {
+	return auditValueText(c.Before)
+}
+
+func (c AuditChange) AfterText() string {
+	return auditValueText(c.After)
+}
+
+// auditValueText writes a missing value as an empty string, the strings as
+// they are and anything else as JSON.
+func auditValueText(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+
+	text, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(text)
+}
+
+type CreateAuditLogParam struct {
+	ActorID    *int64
+	ActorName  string
+	Action     string
+	EntityType string
+	EntityID   int64
+	Changes    map[string]AuditChange
+	IPAddress  string
+	UserAgent  string
+}
+
+// AuditLogQuery narrows and pages the audit log, zero values leave a
+// criterion out. Keyword looks for the actor name, the IP address and the
+// changes. A non-zero Cursor pages by log ID instead of Offset.
+type AuditLogQuery struct {
+	Keyword    string
+	Action     string
+	EntityType string
+	EntityID   *int64
+	ActorID    *int64
+	StartDate  *time.Time
+	EndDate    *time.Time // exclusive
+	Cursor     int64
+	Offset     int
+	Limit      int
+}
+
+// AuditLogPage is one page of the audit log, newest first. Total is only
+// counted for offset pages.
+type AuditLogPage struct {
+	Logs       []*AuditLog `json:"logs"`
+	Total      *int        `json:"total,omitempty"`
+	Offset     int         `json:"offset"`
+	Limit      int         `json:"limit"`
+	HasMore    bool        `json:"has_more"`
+	NextCursor int64       `json:"next_cursor,omitempty"`
+}
+
+// AuditActor is who makes the request and from where. The delivery puts it
+// on the context of the request for the usecases to record.
+type AuditActor struct {
+	UserID    *int64
+	Name      string
+	IPAddress string
+	UserAgent string
+}
+
+type auditActorKey struct{}
+
+func ContextWithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext returns the actor of the request, the zero actor
+// when the context has none such as in the scheduled jobs.
+func AuditActorFromContext(ctx context.Context) AuditActor {
+	actor, _ := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor
+}
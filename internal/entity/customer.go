@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+type Customer struct {
+	ID              int64     `json:"id"`
+	CustomerGroupID *int64    `json:"customer_group_id"`
+	Name            string    `json:"name"`
+	Phone           string    `json:"phone"`
+	Email           string    `json:"email"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (c Customer) InGroup(groupID int64) bool {
+	return c.CustomerGroupID != nil && *c.CustomerGroupID == groupID
+}
+
+type CustomerLifetimeValue struct {
+	OrderCount  int        `json:"order_count"`
+	TotalSpent  int        `json:"total_spent"`
+	LastOrderAt *time.Time `json:"last_order_at"`
+}
+
+type CreateCustomerParam struct {
+	CustomerGroupID *int64 `json:"customer_group_id" form:"customer_group_id"`
+	Name            string `json:"name" form:"name" validate:"required,max=50"`
+	Phone           string `json:"phone" form:"phone" validate:"max=20"`
+	Email           string `json:"email" form:"email" validate:"omitempty,email"`
+	Notes           string `json:"notes" form:"notes"`
+}
+
+type UpdateCustomerGroupParam struct {
+	CustomerGroupID *int64 `form:"customer_group_id"`
+}
+
+// CustomerGroup gathers customers sharing the same price lists, e.g.
+// wholesale buyers.
+type CustomerGroup struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateCustomerGroupParam struct {
+	Name string `form:"name" validate:"required,max=50"`
+}
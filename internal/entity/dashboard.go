@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// DashboardKPIs are the figures of the dashboard cards, pushed to the live
+// dashboards each time an order is placed.
+type DashboardKPIs struct {
+	TotalOrders   int            `json:"total_orders"`
+	TodayOrders   int            `json:"today_orders"`
+	TodayNetSales int            `json:"today_net_sales"`
+	MonthNetSales int            `json:"month_net_sales"`
+	Bestsellers   []*ProductSale `json:"bestsellers"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// DashboardOrder is a newly placed order as the live dashboards list it.
+type DashboardOrder struct {
+	ID            int64     `json:"id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	CashierName   string    `json:"cashier_name"`
+	Items         int       `json:"items"`
+	Total         int       `json:"total"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,67 @@
+package entity
+
+import "time"
+
+const (
+	EventOrderCreated   = "order.created"
+	EventOrderVoided    = "order.voided"
+	EventOrderRefunded  = "order.refunded"
+	EventProductUpdated = "product.updated"
+	EventStockChanged   = "product.stock_changed"
+	EventUserLoggedIn   = "user.logged_in"
+)
+
+// Event is a domain event, published once the change it tells about is
+// committed. Data holds the event data type matching the Name.
+type Event struct {
+	Name       string
+	OccurredAt time.Time
+	Data       interface{}
+}
+
+type OrderCreatedEvent struct {
+	Order *Order
+}
+
+// OrderReversedEvent is the data of both the voided and the refunded order
+// events, StoreCredit is the card a refund was settled with, if any.
+type OrderReversedEvent struct {
+	Order       *Order
+	StoreCredit *PrepaidCard
+}
+
+// ProductUpdatedEvent holds the product as it was before the update and as
+// it is after.
+type ProductUpdatedEvent struct {
+	Before *Product
+	After  *Product
+}
+
+// The reasons the stock of a product changes.
+const (
+	StockChangeSale          = "sale"
+	StockChangeVoid          = "void"
+	StockChangeRefund        = "refund"
+	StockChangePurchaseOrder = "purchase_order"
+	StockChangeAdjustment    = "adjustment"
+)
+
+// StockChangedEvent tells by how much the stock of a product changed, a
+// sale is a negative change. ReferenceID is the order or the purchase order
+// behind the change, if any.
+type StockChangedEvent struct {
+	ProductID   int64
+	Change      int
+	Reason      string
+	ReferenceID *int64
+}
+
+const (
+	LoginChannelWeb = "web"
+	LoginChannelAPI = "api"
+)
+
+type UserLoggedInEvent struct {
+	User    *User
+	Channel string
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// Demand is forecast from the daily sales of the history, smoothed either by
+// a moving average or by exponential smoothing, and spread over the days
+// ahead by how the weekdays of the history sold.
+const (
+	ForecastMethodMovingAverage        = "moving_average"
+	ForecastMethodExponentialSmoothing = "exponential_smoothing"
+)
+
+// ReorderParam asks for the stock to order so it lasts CoverDays after the
+// order arrives LeadTimeDays from today, forecast from the sales of the
+// last HistoryDays days.
+type ReorderParam struct {
+	Method       string `query:"method" validate:"omitempty,oneof=moving_average exponential_smoothing"`
+	HistoryDays  int    `query:"history_days" validate:"omitempty,gte=14,lte=365"`
+	LeadTimeDays int    `query:"lead_time_days" validate:"omitempty,gte=1,lte=90"`
+	CoverDays    int    `query:"cover_days" validate:"omitempty,gte=1,lte=180"`
+	Category     string `query:"category"`
+}
+
+// DailyProductSales is what a product sold in completed orders on a day of
+// the store timezone.
+type DailyProductSales struct {
+	ProductID int64
+	Date      time.Time
+	Quantity  int
+}
+
+// ProductForecast is the demand forecast of a product. LeadTimeDemand is
+// what sells before the order arrives and Demand what sells until the cover
+// runs out, the suggested quantity is the demand the stock and the open
+// purchase orders do not cover yet. RunsOutBeforeDelivery warns that the
+// stock alone does not last the lead time.
+type ProductForecast struct {
+	ProductID          int64   `json:"product_id"`
+	Code               string  `json:"code"`
+	Name               string  `json:"name"`
+	Category           string  `json:"category"`
+	Stock              int     `json:"stock"`
+	OnOrder            int     `json:"on_order"`
+	Cost               int     `json:"cost"`
+	AverageDailySales  float64 `json:"average_daily_sales"`
+	ForecastDailySales float64 `json:"forecast_daily_sales"`
+	LeadTimeDemand     float64 `json:"lead_time_demand"`
+	Demand             float64 `json:"demand"`
+	SuggestedQuantity  int     `json:"suggested_quantity"`
+
+	RunsOutBeforeDelivery bool `json:"runs_out_before_delivery"`
+}
+
+type ReorderPlan struct {
+	Method       string             `json:"method"`
+	HistoryDays  int                `json:"history_days"`
+	LeadTimeDays int                `json:"lead_time_days"`
+	CoverDays    int                `json:"cover_days"`
+	Category     string             `json:"category"`
+	Timezone     string             `json:"timezone"`
+	Products     []*ProductForecast `json:"products"`
+	Cost         int                `json:"cost"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// HeldCart is an unfinished order parked at a register so the cashier can
+// serve the next customer. Its items do not reserve stock.
+type HeldCart struct {
+	ID           int64            `json:"id"`
+	Register     string           `json:"register"`
+	Label        string           `json:"label"`
+	CustomerID   *int64           `json:"customer_id"`
+	CustomerName string           `json:"customer_name"`
+	Total        int              `json:"total"`
+	Cart         CreateOrderParam `json:"cart"`
+	ExpiresAt    time.Time        `json:"expires_at"`
+	CreatedAt    time.Time        `json:"created_at"`
+}
+
+func (hc HeldCart) IsExpired(now time.Time) bool {
+	return !now.Before(hc.ExpiresAt)
+}
+
+type HoldCartParam struct {
+	Register string           `json:"register" validate:"required,max=50"`
+	Label    string           `json:"label" validate:"required,max=50"`
+	Cart     CreateOrderParam `json:"cart"`
+}
+
+type CreateHeldCartParam struct {
+	Register  string
+	Label     string
+	Cart      CreateOrderParam
+	ExpiresAt time.Time
+}
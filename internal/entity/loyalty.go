@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+const (
+	LoyaltyEntryTypeEarn     = "earn"
+	LoyaltyEntryTypeRedeem   = "redeem"
+	LoyaltyEntryTypeClawback = "clawback"
+	LoyaltyEntryTypeRestore  = "restore"
+)
+
+// LoyaltyEntry is a single movement in a customer's points ledger. Earned and
+// restored points are positive, redeemed and clawed back points are negative,
+// so the balance is the sum of all entries.
+type LoyaltyEntry struct {
+	ID         int64     `json:"id"`
+	CustomerID int64     `json:"customer_id"`
+	OrderID    *int64    `json:"order_id"`
+	Type       string    `json:"type"`
+	Points     int       `json:"points"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type LoyaltySetting struct {
+	SpendPerPoint int       `json:"spend_per_point"`
+	PointValue    int       `json:"point_value"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type LoyaltyMultiplier struct {
+	ID         int64     `json:"id"`
+	Category   string    `json:"category"`
+	Multiplier float64   `json:"multiplier"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// LoyaltyOrderPoints holds the net points earned and redeemed by one order.
+type LoyaltyOrderPoints struct {
+	Earned   int `json:"earned"`
+	Redeemed int `json:"redeemed"`
+}
+
+type UpdateLoyaltySettingParam struct {
+	SpendPerPoint int `form:"spend_per_point" validate:"numeric,gte=0"`
+	PointValue    int `form:"point_value" validate:"numeric,gte=0"`
+}
+
+type SaveLoyaltyMultiplierParam struct {
+	Category   string  `form:"category" validate:"required,max=50"`
+	Multiplier float64 `form:"multiplier" validate:"required,gt=0"`
+}
+
+type CreateLoyaltyEntryParam struct {
+	CustomerID int64
+	OrderID    *int64
+	Type       string
+	Points     int
+}
@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// MarketBasketParam asks for the products bought together in the completed
+// orders between two dates, both included, the last 90 days without dates.
+// A pair is only kept when it was bought together in MinOrders orders.
+type MarketBasketParam struct {
+	StartDate string `form:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02"`
+	MinOrders int    `form:"min_orders" validate:"omitempty,gte=1"`
+}
+
+// ProductPairCount is the number of orders that have both products, the
+// pair is given once with the lower product ID first.
+type ProductPairCount struct {
+	ProductID           int64
+	AssociatedProductID int64
+	Orders              int
+}
+
+// ProductAssociation is the rule that an order with the product also has
+// the associated product. Support is the share of all the orders that have
+// both, confidence the share of the orders with the product that also have
+// the associated one, and lift how much more likely the associated product
+// is bought with the product than on its own.
+type ProductAssociation struct {
+	ProductID             int64   `json:"product_id"`
+	ProductCode           string  `json:"product_code"`
+	ProductName           string  `json:"product_name"`
+	AssociatedProductID   int64   `json:"associated_product_id"`
+	AssociatedProductCode string  `json:"associated_product_code"`
+	AssociatedProductName string  `json:"associated_product_name"`
+	Orders                int     `json:"orders"`
+	Support               float64 `json:"support"`
+	Confidence            float64 `json:"confidence"`
+	Lift                  float64 `json:"lift"`
+}
+
+type MarketBasketRun struct {
+	ID           int64     `json:"id"`
+	StartDate    string    `json:"start_date"`
+	EndDate      string    `json:"end_date"`
+	MinOrders    int       `json:"min_orders"`
+	Orders       int       `json:"orders"`
+	Associations int       `json:"associations"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CreateMarketBasketRunParam struct {
+	StartDate    string
+	EndDate      string
+	MinOrders    int
+	Orders       int
+	Associations int
+}
+
+// MarketBasket is the latest run with its strongest rules, Run is nil when
+// the analysis has not run yet.
+type MarketBasket struct {
+	Run          *MarketBasketRun      `json:"run"`
+	Associations []*ProductAssociation `json:"associations"`
+}
+
+// ProductSuggestion is a product to offer with the cart because it is often
+// bought with the cart product BecauseOfProductID.
+type ProductSuggestion struct {
+	ID                   int64   `json:"id"`
+	Code                 string  `json:"code"`
+	Name                 string  `json:"name"`
+	Price                int     `json:"price"`
+	Stock                int     `json:"stock"`
+	BecauseOfProductID   int64   `json:"because_of_product_id"`
+	BecauseOfProductName string  `json:"because_of_product_name"`
+	Confidence           float64 `json:"confidence"`
+	Lift                 float64 `json:"lift"`
+}
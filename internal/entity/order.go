@@ -1,12 +1,58 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	OrderStatusCompleted = "completed"
+	OrderStatusVoided    = "voided"
+	OrderStatusRefunded  = "refunded"
+)
+
+const (
+	PaymentMethodCash        = "cash"
+	PaymentMethodPoints      = "points"
+	PaymentMethodGiftCard    = "gift_card"
+	PaymentMethodStoreCredit = "store_credit"
+)
 
 type Order struct {
-	ID        int64        `json:"id,omitempty"`
-	Total     int          `json:"total"`
-	CreatedAt time.Time    `json:"created_at,omitempty"`
-	Items     []*OrderItem `json:"order_items"`
+	ID            int64        `json:"id,omitempty"`
+	InvoiceNumber string       `json:"invoice_number"`
+	CustomerID    *int64       `json:"customer_id"`
+	CashierID     *int64       `json:"cashier_id"`
+	CashierName   string       `json:"cashier_name,omitempty"`
+	Status        string       `json:"status"`
+	Total         int          `json:"total"`
+	Discount      int          `json:"discount"`
+	CreatedAt     time.Time    `json:"created_at,omitempty"`
+	Items         []*OrderItem `json:"order_items"`
+
+	PrepaidCards []*PrepaidCard  `json:"prepaid_cards,omitempty"`
+	Payments     []*OrderPayment `json:"payments,omitempty"`
+
+	// RequestHash fingerprints the payload the order was created from so a
+	// retried request can be told apart from a different one reusing its
+	// idempotency key.
+	RequestHash string `json:"-"`
+}
+
+// IsCompleted reports whether the order still counts as a sale, i.e. it has
+// not been voided or refunded.
+func (o Order) IsCompleted() bool {
+	return o.Status == OrderStatusCompleted
+}
+
+// Reference is the number the order is known by, orders created before
+// invoice numbers were issued fall back to their ID.
+func (o Order) Reference() string {
+	if o.InvoiceNumber != "" {
+		return o.InvoiceNumber
+	}
+
+	return fmt.Sprintf("#%d", o.ID)
 }
 
 type OrderItem struct {
@@ -21,20 +67,94 @@ type OrderItem struct {
 	CreatedAt    time.Time `json:"created_at,omitempty"`
 }
 
-type AnnualIncome struct {
-	Year   int    `json:"year"`
-	Month  string `json:"month"`
-	Income int    `json:"income"`
+// CreateOrderParam places an order of the items, their subtotals and the
+// total are priced from the products rather than taken from the request.
+type CreateOrderParam struct {
+	Total        int                     `json:"-"`
+	Discount     int                     `json:"-"`
+	VoucherCode  string                  `json:"voucher_code,omitempty"`
+	CustomerID   *int64                  `json:"customer_id,omitempty"`
+	RedeemPoints int                     `json:"redeem_points,omitempty" validate:"gte=0"`
+	Items        []*CreateOrderItemParam `json:"order_items" validate:"required"`
+
+	PrepaidPayments []*CreateOrderPrepaidPaymentParam `json:"prepaid_payments,omitempty" validate:"dive"`
+
+	// AllowNegativeStock confirms selling the products under the warn policy
+	// beyond their remaining stock.
+	AllowNegativeStock bool `json:"allow_negative_stock,omitempty"`
+
+	IdempotencyKey string `json:"-" validate:"max=64"`
+	RequestHash    string `json:"-"`
+	InvoiceNumber  string `json:"-"`
+	CashierID      *int64 `json:"-"`
+	CashierName    string `json:"-"`
+}
+
+// OrderQuery narrows and pages the order listing, zero values leave a
+// criterion out. A non-zero Cursor pages by order ID instead of Offset so
+// deep pages stay cheap on a large table.
+type OrderQuery struct {
+	StartDate     *time.Time
+	EndDate       *time.Time // exclusive
+	CashierID     *int64
+	Status        string
+	MinTotal      *int
+	MaxTotal      *int
+	InvoiceNumber string // prefix
+	Cursor        int64
+	Offset        int
+	Limit         int
 }
 
-type CreateOrderParam struct {
-	Total int                     `json:"total,omitempty"`
-	Items []*CreateOrderItemParam `json:"order_items" validate:"required"`
+// OrderPage is one page of the order listing, newest first. Total is only
+// counted for offset pages.
+type OrderPage struct {
+	Orders     []*Order `json:"orders"`
+	Total      *int     `json:"total,omitempty"`
+	Offset     int      `json:"offset"`
+	Limit      int      `json:"limit"`
+	HasMore    bool     `json:"has_more"`
+	NextCursor int64    `json:"next_cursor,omitempty"`
 }
 
 type CreateOrderItemParam struct {
 	ProductID int64 `json:"product_id" validate:"required"`
-	Quantity  int   `json:"quantity" validate:"required"`
-	Subtotal  int   `json:"subtotal,omitempty"`
-	OrderId   int64
+	Quantity  int   `json:"quantity" validate:"required,gt=0"`
+	Subtotal  int   `json:"-"`
+	OrderId   int64 `json:"-"`
+}
+
+// CreateOrderPrepaidPaymentParam pays part of an order with a prepaid card.
+// A zero amount spends as much of the card as the order still needs.
+type CreateOrderPrepaidPaymentParam struct {
+	Number string `json:"number" validate:"required"`
+	Amount int    `json:"amount,omitempty" validate:"gte=0"`
+}
+
+type OrderPayment struct {
+	ID        int64     `json:"id"`
+	OrderID   int64     `json:"order_id"`
+	Method    string    `json:"method"`
+	Amount    int       `json:"amount"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateOrderPaymentParam struct {
+	Method    string
+	Amount    int
+	Reference string
+}
+
+type RefundOrderParam struct {
+	StoreCredit bool `json:"store_credit" form:"store_credit"`
+}
+
+// OrderHeatmapCell sums the orders placed in one hour of a weekday, weekdays
+// count from Monday as 0.
+type OrderHeatmapCell struct {
+	Weekday int `json:"weekday"`
+	Hour    int `json:"hour"`
+	Orders  int `json:"orders"`
+	Revenue int `json:"revenue"`
 }
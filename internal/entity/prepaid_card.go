@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+const (
+	PrepaidCardTypeGiftCard    = "gift_card"
+	PrepaidCardTypeStoreCredit = "store_credit"
+)
+
+const (
+	PrepaidTransactionTypeIssue  = "issue"
+	PrepaidTransactionTypeRedeem = "redeem"
+	PrepaidTransactionTypeRefund = "refund"
+	PrepaidTransactionTypeCancel = "cancel"
+)
+
+// PrepaidCard is a balance that can be spent as a payment tender, either a
+// gift card sold over the counter or store credit issued from a refund.
+type PrepaidCard struct {
+	ID         int64     `json:"id"`
+	Number     string    `json:"number"`
+	Type       string    `json:"type"`
+	CustomerID *int64    `json:"customer_id"`
+	OrderID    *int64    `json:"order_id"`
+	Value      int       `json:"value"`
+	Balance    int       `json:"balance"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// IsUsed reports whether part of the issued value has been spent.
+func (pc PrepaidCard) IsUsed() bool {
+	return pc.Balance < pc.Value
+}
+
+type PrepaidTransaction struct {
+	ID            int64     `json:"id"`
+	PrepaidCardID int64     `json:"prepaid_card_id"`
+	OrderID       *int64    `json:"order_id"`
+	Type          string    `json:"type"`
+	Amount        int       `json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type CreatePrepaidCardParam struct {
+	Number     string
+	Type       string
+	CustomerID *int64
+	OrderID    *int64
+	Value      int
+}
+
+type CreatePrepaidTransactionParam struct {
+	PrepaidCardID int64
+	OrderID       *int64
+	Type          string
+	Amount        int
+}
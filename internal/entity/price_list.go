@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// PriceList overrides product prices for the customers of a group, optionally
+// only between its start and end dates.
+type PriceList struct {
+	ID                int64      `json:"id"`
+	CustomerGroupID   int64      `json:"customer_group_id"`
+	CustomerGroupName string     `json:"customer_group_name"`
+	Name              string     `json:"name"`
+	StartsOn          *time.Time `json:"starts_on"`
+	EndsOn            *time.Time `json:"ends_on"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// PriceListItem is the price of a product when at least MinQuantity of it is
+// bought, so several items of the same product make up quantity breaks.
+type PriceListItem struct {
+	ID          int64     `json:"id"`
+	PriceListID int64     `json:"price_list_id"`
+	ProductID   int64     `json:"product_id"`
+	ProductCode string    `json:"product_code"`
+	ProductName string    `json:"product_name"`
+	MinQuantity int       `json:"min_quantity"`
+	Price       int       `json:"price"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SavePriceListParam struct {
+	CustomerGroupID int64  `form:"customer_group_id" validate:"required"`
+	Name            string `form:"name" validate:"required,max=50"`
+	StartDate       string `form:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate         string `form:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type CreatePriceListParam struct {
+	CustomerGroupID int64
+	Name            string
+	StartsOn        *time.Time
+	EndsOn          *time.Time
+}
+
+type SavePriceListItemParam struct {
+	ProductID   int64 `form:"product_id" validate:"required"`
+	MinQuantity int   `form:"min_quantity" validate:"required,numeric,gt=0"`
+	Price       int   `form:"price" validate:"required,numeric,gt=0"`
+}
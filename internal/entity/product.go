@@ -3,13 +3,18 @@ package entity
 import "time"
 
 type Product struct {
-	ID        int64     `json:"id"`
-	Code      string    `json:"code"`
-	Name      string    `json:"name"`
-	Price     int       `json:"price"`
-	Stock     int       `json:"stock"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         int64  `json:"id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Price      int    `json:"price"`
+	Stock      int    `json:"stock"`
+	Category   string `json:"category"`
+	IsGiftCard bool   `json:"is_gift_card"`
+	// StockPolicy overrides the store negative-stock policy when it is set.
+	StockPolicy string    `json:"stock_policy"`
+	Cost        int       `json:"cost"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type ProductSale struct {
@@ -19,16 +24,66 @@ type ProductSale struct {
 	Sale int    `json:"sale"`
 }
 
+// ProductQuery searches and pages the catalog, zero values leave a criterion
+// out. Sort names a column, prefixed with "-" to sort it descending.
+type ProductQuery struct {
+	Keyword  string
+	Category string
+	InStock  bool
+	IDs      []int64
+	Sort     string
+	Offset   int
+	Limit    int
+}
+
+type ProductPage struct {
+	Products []*Product `json:"products"`
+	Total    int        `json:"total"`
+	Offset   int        `json:"offset"`
+	Limit    int        `json:"limit"`
+	HasMore  bool       `json:"has_more"`
+}
+
 type CreateProductParam struct {
-	Code  string `json:"code" form:"code" validate:"required"`
-	Name  string `json:"name" form:"name" validate:"required"`
-	Price int    `json:"price" form:"price" validate:"required,numeric,gt=0"`
-	Stock int    `json:"stock" form:"stock" validate:"required,numeric,gte=0"`
+	Code        string `json:"code" form:"code" validate:"required"`
+	Name        string `json:"name" form:"name" validate:"required"`
+	Price       int    `json:"price" form:"price" validate:"required,numeric,gt=0"`
+	Stock       int    `json:"stock" form:"stock" validate:"required,numeric,gte=0"`
+	Category    string `json:"category" form:"category" validate:"max=50"`
+	IsGiftCard  bool   `json:"is_gift_card" form:"is_gift_card"`
+	StockPolicy string `json:"stock_policy" form:"stock_policy" validate:"omitempty,oneof=block warn allow"`
+	Cost        int    `json:"cost" form:"cost" validate:"numeric,gte=0"`
 }
 
 type UpdateProductParam struct {
-	Code  string `form:"code"`
-	Name  string `form:"name"`
-	Price int    `form:"price" validate:"numeric,gt=0"`
-	Stock int    `form:"stock" validate:"numeric,gte=0"`
+	Code        string `json:"code" form:"code" validate:"required"`
+	Name        string `json:"name" form:"name" validate:"required"`
+	Price       int    `json:"price" form:"price" validate:"numeric,gt=0"`
+	Stock       int    `json:"stock" form:"stock" validate:"numeric,gte=0"`
+	Category    string `json:"category" form:"category" validate:"max=50"`
+	IsGiftCard  bool   `json:"is_gift_card" form:"is_gift_card"`
+	StockPolicy string `json:"stock_policy" form:"stock_policy" validate:"omitempty,oneof=block warn allow"`
+	Cost        int    `json:"cost" form:"cost" validate:"numeric,gte=0"`
+}
+
+// NegativeStockSale records a product sold beyond its recorded stock, so the
+// stock count can be reconciled later.
+type NegativeStockSale struct {
+	ID           int64      `json:"id"`
+	OrderID      int64      `json:"order_id"`
+	ProductID    int64      `json:"product_id"`
+	ProductCode  string     `json:"product_code"`
+	ProductName  string     `json:"product_name"`
+	Quantity     int        `json:"quantity"`
+	Shortfall    int        `json:"shortfall"`
+	StockAfter   int        `json:"stock_after"`
+	ReconciledAt *time.Time `json:"reconciled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type CreateNegativeStockSaleParam struct {
+	ProductID  int64
+	Quantity   int
+	Shortfall  int
+	StockAfter int
 }
@@ -0,0 +1,89 @@
+package entity
+
+import "time"
+
+const (
+	ProductRankQuantity = "quantity"
+	ProductRankRevenue  = "revenue"
+	ProductRankMargin   = "margin"
+)
+
+// Products are classed by their share of the revenue: A takes the products
+// that bring in the first 80%, B the next 15% and C the rest.
+const (
+	ABCClassA = "A"
+	ABCClassB = "B"
+	ABCClassC = "C"
+)
+
+// ProductAnalyticsParam asks for the performance of the products between two
+// dates, both included, the last 30 days without dates. Dead stock is the
+// stock of products with no sale in the last DeadStockDays days.
+type ProductAnalyticsParam struct {
+	StartDate     string `query:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02"`
+	EndDate       string `query:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02"`
+	RankBy        string `query:"rank_by" validate:"omitempty,oneof=quantity revenue margin"`
+	DeadStockDays int    `query:"dead_stock_days" validate:"omitempty,gte=1,lte=365"`
+	Limit         int    `query:"limit" validate:"omitempty,gte=1"`
+}
+
+// ProductPerformance is what a product sold over the period. Revenue is the
+// sum of the order lines before order discounts, and the margin is counted
+// against the current cost of the product.
+type ProductPerformance struct {
+	ProductID int64  `json:"product_id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Stock     int    `json:"stock"`
+	Price     int    `json:"price"`
+	Cost      int    `json:"cost"`
+	Quantity  int    `json:"quantity"`
+	Revenue   int    `json:"revenue"`
+	Margin    int    `json:"margin"`
+
+	// RevenueShare and CumulativeShare are fractions of the revenue of all
+	// the products, the cumulative share counts the products ranked above.
+	RevenueShare    float64 `json:"revenue_share"`
+	CumulativeShare float64 `json:"cumulative_share"`
+	Class           string  `json:"class"`
+
+	// DaysOfCover is how many days the stock lasts at the average daily
+	// sales of the period, nil when the product did not sell.
+	AverageDailySales float64  `json:"average_daily_sales"`
+	DaysOfCover       *float64 `json:"days_of_cover"`
+}
+
+// DeadStockProduct is a product with stock left that has not sold since
+// LastSoldAt, which is nil when it never sold.
+type DeadStockProduct struct {
+	ProductID  int64      `json:"product_id"`
+	Code       string     `json:"code"`
+	Name       string     `json:"name"`
+	Category   string     `json:"category"`
+	Stock      int        `json:"stock"`
+	Price      int        `json:"price"`
+	Cost       int        `json:"cost"`
+	LastSoldAt *time.Time `json:"last_sold_at"`
+}
+
+type ABCClassSummary struct {
+	Class        string  `json:"class"`
+	Products     int     `json:"products"`
+	Revenue      int     `json:"revenue"`
+	RevenueShare float64 `json:"revenue_share"`
+}
+
+type ProductAnalytics struct {
+	StartDate     string                `json:"start_date"`
+	EndDate       string                `json:"end_date"`
+	Timezone      string                `json:"timezone"`
+	Days          int                   `json:"days"`
+	RankBy        string                `json:"rank_by"`
+	Revenue       int                   `json:"revenue"`
+	Margin        int                   `json:"margin"`
+	Products      []*ProductPerformance `json:"products"`
+	Classes       []*ABCClassSummary    `json:"classes"`
+	DeadStockDays int                   `json:"dead_stock_days"`
+	DeadStock     []*DeadStockProduct   `json:"dead_stock"`
+}
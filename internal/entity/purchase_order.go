@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// A purchase order starts as a draft, is ordered from the supplier and is
+// received, which puts its quantities into stock. Drafts and ordered
+// purchase orders can be cancelled.
+const (
+	PurchaseOrderStatusDraft     = "draft"
+	PurchaseOrderStatusOrdered   = "ordered"
+	PurchaseOrderStatusReceived  = "received"
+	PurchaseOrderStatusCancelled = "cancelled"
+)
+
+type PurchaseOrder struct {
+	ID        int64                `json:"id"`
+	Supplier  string               `json:"supplier"`
+	Status    string               `json:"status"`
+	Notes     string               `json:"notes"`
+	Total     int                  `json:"total"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Items     []*PurchaseOrderItem `json:"items,omitempty"`
+}
+
+// IsOpen reports whether the purchase order is still to be received, its
+// quantities count as on order.
+func (po PurchaseOrder) IsOpen() bool {
+	return po.Status == PurchaseOrderStatusDraft || po.Status == PurchaseOrderStatusOrdered
+}
+
+// CanBecome reports whether the purchase order can move to the status.
+func (po PurchaseOrder) CanBecome(status string) bool {
+	switch status {
+	case PurchaseOrderStatusOrdered:
+		return po.Status == PurchaseOrderStatusDraft
+	case PurchaseOrderStatusReceived:
+		return po.Status == PurchaseOrderStatusOrdered
+	case PurchaseOrderStatusCancelled:
+		return po.IsOpen()
+	default:
+		return false
+	}
+}
+
+func (po PurchaseOrder) Reference() string {
+	return fmt.Sprintf("PO-%05d", po.ID)
+}
+
+// PurchaseOrderItem is a product to buy, its cost is the product cost when
+// the purchase order was drafted.
+type PurchaseOrderItem struct {
+	ID              int64  `json:"id"`
+	PurchaseOrderID int64  `json:"purchase_order_id"`
+	ProductID       int64  `json:"product_id"`
+	ProductCode     string `json:"product_code"`
+	ProductName     string `json:"product_name"`
+	Quantity        int    `json:"quantity"`
+	Cost            int    `json:"cost"`
+	Subtotal        int    `json:"subtotal"`
+}
+
+// SavePurchaseOrderParam drafts a purchase order from a form that repeats
+// product_id and quantity for every line, lines without a quantity are left
+// out.
+type SavePurchaseOrderParam struct {
+	Supplier   string  `form:"supplier" validate:"max=100"`
+	Notes      string  `form:"notes" validate:"max=255"`
+	ProductIDs []int64 `form:"product_id"`
+	Quantities []int   `form:"quantity"`
+}
+
+type CreatePurchaseOrderParam struct {
+	Supplier string
+	Notes    string
+	Items    []*CreatePurchaseOrderItemParam
+}
+
+type CreatePurchaseOrderItemParam struct {
+	ProductID int64
+	Quantity  int
+	Cost      int
+}
+
+type UpdatePurchaseOrderStatusParam struct {
+	Status string `form:"status" validate:"required,oneof=ordered received cancelled"`
+}
@@ -0,0 +1,113 @@
+package entity
+
+import "time"
+
+const (
+	ReportGranularityHour  = "hour"
+	ReportGranularityDay   = "day"
+	ReportGranularityWeek  = "week"
+	ReportGranularityMonth = "month"
+)
+
+const (
+	ReportPeriodToday        = "today"
+	ReportPeriodThisMonth    = "this_month"
+	ReportPeriodLast30Days   = "last_30_days"
+	ReportPeriodLast12Months = "last_12_months"
+)
+
+// SalesReportParam asks for a sales report between two dates, both included,
+// split into periods of the given granularity. Without dates the report
+// covers Period, which is counted back from today in the store timezone and
+// defaults to the last 30 days.
+type SalesReportParam struct {
+	StartDate   string `query:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02"`
+	EndDate     string `query:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02"`
+	Period      string `query:"period" validate:"omitempty,oneof=today this_month last_30_days last_12_months"`
+	Granularity string `query:"granularity" validate:"omitempty,oneof=hour day week month"`
+}
+
+// SalesPeriod sums the orders placed in [Start, End). Voided orders are left
+// out, refunded orders count as sales and as refunds of the period they were
+// placed in.
+type SalesPeriod struct {
+	Label      string    `json:"label"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Orders     int       `json:"orders"`
+	GrossSales int       `json:"gross_sales"`
+	Discounts  int       `json:"discounts"`
+	Refunds    int       `json:"refunds"`
+	NetSales   int       `json:"net_sales"`
+}
+
+type SalesReport struct {
+	StartDate   string         `json:"start_date"`
+	EndDate     string         `json:"end_date"`
+	Period      string         `json:"period,omitempty"`
+	Granularity string         `json:"granularity"`
+	Timezone    string         `json:"timezone"`
+	Start       time.Time      `json:"start"`
+	End         time.Time      `json:"end"`
+	Periods     []*SalesPeriod `json:"periods"`
+	Summary     SalesPeriod    `json:"summary"`
+}
+
+// StockValuationQuery pages the stock valuation by product ID, Cursor is the
+// ID of the last product of the previous page.
+type StockValuationQuery struct {
+	Category string `query:"category"`
+	Cursor   int64  `query:"cursor"`
+	Limit    int    `query:"limit"`
+}
+
+// StockValue is what the stock on hand of a product is worth at its selling
+// price. Stock sold below zero is worth nothing rather than a negative value.
+type StockValue struct {
+	ProductID int64  `json:"product_id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Stock     int    `json:"stock"`
+	Price     int    `json:"price"`
+	Value     int    `json:"value"`
+}
+
+type StockValuationSummary struct {
+	Products int `json:"products"`
+	Units    int `json:"units"`
+	Value    int `json:"value"`
+}
+
+// StockValuation is one page of the valuation, the summary covers all the
+// products of the category and is only given on the first page.
+type StockValuation struct {
+	Category   string                 `json:"category,omitempty"`
+	Items      []*StockValue          `json:"items"`
+	Summary    *StockValuationSummary `json:"summary,omitempty"`
+	Limit      int                    `json:"limit"`
+	HasMore    bool                   `json:"has_more"`
+	NextCursor int64                  `json:"next_cursor,omitempty"`
+}
+
+// SalesHeatmapParam asks for the heatmap of the orders between two dates,
+// both included. Without dates it covers the last four weeks.
+type SalesHeatmapParam struct {
+	StartDate string `query:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02"`
+	EndDate   string `query:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02"`
+}
+
+// SalesHeatmap lays the orders out by weekday and hour of the day in the
+// store timezone, Orders[0][9] holds the orders placed on Mondays from 9:00
+// to 9:59. Voided orders are left out, refunded orders count as traffic but
+// not as revenue.
+type SalesHeatmap struct {
+	StartDate  string     `json:"start_date"`
+	EndDate    string     `json:"end_date"`
+	Timezone   string     `json:"timezone"`
+	Weekdays   []string   `json:"weekdays"`
+	Orders     [7][24]int `json:"orders"`
+	Revenue    [7][24]int `json:"revenue"`
+	MaxOrders  int        `json:"max_orders"`
+	MaxRevenue int        `json:"max_revenue"`
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Negative-stock policies decide what happens when a product is sold beyond
+// its remaining stock. Block rejects the order, warn asks the cashier to
+// confirm the sale and allow sells without asking.
+const (
+	StockPolicyBlock = "block"
+	StockPolicyWarn  = "warn"
+	StockPolicyAllow = "allow"
+)
+
+// Invoice sequences restart from one every day or every year.
+const (
+	InvoiceResetDaily  = "daily"
+	InvoiceResetYearly = "yearly"
+)
+
+type StoreSetting struct {
+	StoreName      string `json:"store_name"`
+	StoreCode      string `json:"store_code"`
+	InvoicePattern string `json:"invoice_pattern"`
+	InvoiceReset   string `json:"invoice_reset"`
+	StockPolicy    string `json:"stock_policy"`
+	// LowStockThreshold is the stock at or below which a product is low on
+	// stock, zero leaves low stock unreported.
+	LowStockThreshold int       `json:"low_stock_threshold"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// InvoicePeriod names the sequence the invoice numbers issued at the date are
+// counted in.
+func (s StoreSetting) InvoicePeriod(date time.Time) string {
+	if s.InvoiceReset == InvoiceResetYearly {
+		return date.Format("2006")
+	}
+
+	return date.Format("20060102")
+}
+
+// InvoiceNumber fills the invoice pattern placeholders, {store}, {YYYYMMDD},
+// {YYYY}, {YY}, {MM}, {DD} and {seq}, for the date and sequence number.
+func (s StoreSetting) InvoiceNumber(date time.Time, seq int) string {
+	replacer := strings.NewReplacer(
+		"{store}", s.StoreCode,
+		"{YYYYMMDD}", date.Format("20060102"),
+		"{YYYY}", date.Format("2006"),
+		"{YY}", date.Format("06"),
+		"{MM}", date.Format("01"),
+		"{DD}", date.Format("02"),
+		"{seq}", fmt.Sprintf("%04d", seq),
+	)
+	return replacer.Replace(s.InvoicePattern)
+}
+
+type UpdateStoreSettingParam struct {
+	StoreName         string `json:"store_name" form:"store_name" validate:"required,max=100"`
+	StoreCode         string `json:"store_code" form:"store_code" validate:"required,max=10"`
+	InvoicePattern    string `json:"invoice_pattern" form:"invoice_pattern" validate:"required,max=50"`
+	InvoiceReset      string `json:"invoice_reset" form:"invoice_reset" validate:"required,oneof=daily yearly"`
+	StockPolicy       string `json:"stock_policy" form:"stock_policy" validate:"required,oneof=block warn allow"`
+	LowStockThreshold int    `json:"low_stock_threshold" form:"low_stock_threshold" validate:"min=0"`
+}
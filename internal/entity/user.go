@@ -3,27 +3,28 @@ package entity
 import "time"
 
 type User struct {
-	ID        int64
-	Name      string `form:"name"`
-	Email     string `form:"email"`
-	PhotoUrl  *string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             int64     `json:"id"`
+	Name           string    `json:"name" form:"name"`
+	Email          string    `json:"email" form:"email"`
+	PhotoUrl       *string   `json:"photo_url"`
+	Password       string    `json:"-"`
+	ServiceAccount bool      `json:"service_account"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 type UserCredential struct {
-	Email    string `form:"email" validate:"required,email"`
-	Password string `form:"password" validate:"required"`
+	Email    string `json:"email" form:"email" validate:"required,email"`
+	Password string `json:"password" form:"password" validate:"required"`
 }
 
 type UpdateUserParam struct {
-	Name     string  `db:"name" validate:"required"`
-	Email    string  `db:"email" validate:"required,email"`
-	PhotoUrl *string `db:"omitempty,photo_url"`
+	Name     string  `json:"name" db:"name" validate:"required"`
+	Email    string  `json:"email" db:"email" validate:"required,email"`
+	PhotoUrl *string `json:"-" db:"omitempty,photo_url"`
 }
 
 type UpdateUserPasswordParam struct {
-	Password             string `db:"password" form:"password" validate:"required"`
-	PasswordConfirmation string `form:"password_confirmation" validate:"required,eqfield=Password"`
+	Password             string `json:"password" db:"password" form:"password" validate:"required"`
+	PasswordConfirmation string `json:"password_confirmation" form:"password_confirmation" validate:"required,eqfield=Password"`
 }
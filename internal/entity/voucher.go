@@ -0,0 +1,82 @@
+package entity
+
+import "time"
+
+const (
+	VoucherValueTypeFixed   = "fixed"
+	VoucherValueTypePercent = "percent"
+)
+
+type Voucher struct {
+	ID                    int64     `json:"id"`
+	Code                  string    `json:"code"`
+	ValueType             string    `json:"value_type"`
+	Value                 int       `json:"value"`
+	MinSpend              int       `json:"min_spend"`
+	StartsAt              time.Time `json:"starts_at"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	UsageLimit            int       `json:"usage_limit"`
+	UsageLimitPerCustomer int       `json:"usage_limit_per_customer"`
+	UsedCount             int       `json:"used_count"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// LastDay is the last day the voucher is valid, ExpiresAt itself is the
+// first moment it is no longer valid.
+func (v Voucher) LastDay() time.Time {
+	return v.ExpiresAt.Add(-time.Nanosecond)
+}
+
+// Discount returns the amount taken off an order with the given total.
+func (v Voucher) Discount(total int) int {
+	discount := v.Value
+	if v.ValueType == VoucherValueTypePercent {
+		discount = total * v.Value / 100
+	}
+
+	if discount > total {
+		return total
+	}
+
+	return discount
+}
+
+type VoucherRedemption struct {
+	ID         int64     `json:"id"`
+	VoucherID  int64     `json:"voucher_id"`
+	OrderID    int64     `json:"order_id"`
+	CustomerID *int64    `json:"customer_id"`
+	Discount   int       `json:"discount"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GenerateVoucherParam struct {
+	Prefix                string `form:"prefix" validate:"max=10"`
+	Quantity              int    `form:"quantity" validate:"required,numeric,gt=0,lte=1000"`
+	ValueType             string `form:"value_type" validate:"required,oneof=fixed percent"`
+	Value                 int    `form:"value" validate:"required,numeric,gt=0"`
+	MinSpend              int    `form:"min_spend" validate:"numeric,gte=0"`
+	StartDate             string `form:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate               string `form:"end_date" validate:"required,datetime=2006-01-02"`
+	UsageLimit            int    `form:"usage_limit" validate:"numeric,gte=0"`
+	UsageLimitPerCustomer int    `form:"usage_limit_per_customer" validate:"numeric,gte=0"`
+}
+
+type CreateVoucherParam struct {
+	Code                  string
+	ValueType             string
+	Value                 int
+	MinSpend              int
+	StartsAt              time.Time
+	ExpiresAt             time.Time
+	UsageLimit            int
+	UsageLimitPerCustomer int
+}
+
+type CreateVoucherRedemptionParam struct {
+	VoucherID  int64
+	OrderID    int64
+	CustomerID *int64
+	Discount   int
+}
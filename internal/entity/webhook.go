@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	WebhookEventOrderCreated    = "order.created"
+	WebhookEventOrderVoided     = "order.voided"
+	WebhookEventOrderRefunded   = "order.refunded"
+	WebhookEventProductStockLow = "product.stock_low"
+)
+
+// WebhookEvents lists the events a webhook can subscribe to.
+var WebhookEvents = []string{
+	WebhookEventOrderCreated,
+	WebhookEventOrderVoided,
+	WebhookEventOrderRefunded,
+	WebhookEventProductStockLow,
+}
+
+// A delivery is pending until the receiver answers with a 2xx status, or
+// failed once its attempts run out.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook subscribes a URL to events, the deliveries are signed with its
+// secret.
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (w Webhook) Subscribes(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Webhook) EventList() string {
+	return strings.Join(w.Events, ", ")
+}
+
+type SaveWebhookParam struct {
+	URL    string   `form:"url" json:"url" validate:"required,url,max=255"`
+	Events []string `form:"events" json:"events" validate:"required,min=1,dive,oneof=order.created order.voided order.refunded product.stock_low"`
+	Active bool     `form:"active" json:"active"`
+}
+
+type CreateWebhookParam struct {
+	SaveWebhookParam
+	Secret string
+}
+
+// WebhookEvent is an event of the outbox, recorded in the transaction of the
+// change it tells about and dispatched to the webhooks afterwards.
+type WebhookEvent struct {
+	ID           int64      `json:"id"`
+	Type         string     `json:"type"`
+	Payload      []byte     `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DispatchedAt *time.Time `json:"dispatched_at"`
+}
+
+type CreateWebhookEventParam struct {
+	Type    string
+	Payload []byte
+}
+
+// OrderEventData is the data of the order events, StoreCredit is the card
+// issued by a refund settled as store credit.
+type OrderEventData struct {
+	Order       *Order       `json:"order"`
+	StoreCredit *PrepaidCard `json:"store_credit,omitempty"`
+}
+
+type StockLowEventData struct {
+	Product   *Product `json:"product"`
+	Threshold int      `json:"threshold"`
+}
+
+// WebhookDelivery is an event queued for a webhook, Body is the request body
+// sent on every attempt.
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	WebhookID      int64      `json:"webhook_id"`
+	WebhookURL     string     `json:"webhook_url"`
+	WebhookSecret  string     `json:"-"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Body           string     `json:"body"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	Error          string     `json:"error"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type CreateWebhookDeliveryParam struct {
+	WebhookID int64
+	EventID   int64
+	EventType string
+	Body      string
+}
+
+// UpdateWebhookDeliveryParam records the outcome of an attempt.
+type UpdateWebhookDeliveryParam struct {
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	ResponseBody   string
+	Error          string
+}
+
+// WebhookRequest is a signed delivery attempt.
+type WebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/ardafirdausr/kaseer/internal/entity"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -15,6 +17,27 @@ type OrderRepository struct {
 	mock.Mock
 }
 
+// CountOrders provides a mock function with given fields: ctx, query
+func (_m *OrderRepository) CountOrders(ctx context.Context, query entity.OrderQuery) (int, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, entity.OrderQuery) int); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.OrderQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, param
 func (_m *OrderRepository) Create(ctx context.Context, param entity.CreateOrderParam) (*entity.Order, error) {
 	ret := _m.Called(ctx, param)
@@ -52,22 +75,36 @@ func (_m *OrderRepository) CreateOrderItems(ctx context.Context, orderId int64,
 	return r0
 }
 
-// GetAllOrders provides a mock function with given fields: ctx
-func (_m *OrderRepository) GetAllOrders(ctx context.Context) ([]*entity.Order, error) {
-	ret := _m.Called(ctx)
+// CreatePayments provides a mock function with given fields: ctx, orderID, params
+func (_m *OrderRepository) CreatePayments(ctx context.Context, orderID int64, params []*entity.CreateOrderPaymentParam) error {
+	ret := _m.Called(ctx, orderID, params)
 
-	var r0 []*entity.Order
-	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Order); ok {
-		r0 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*entity.CreateOrderPaymentParam) error); ok {
+		r0 = rf(ctx, orderID, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCustomerLifetimeValue provides a mock function with given fields: ctx, customerID
+func (_m *OrderRepository) GetCustomerLifetimeValue(ctx context.Context, customerID int64) (*entity.CustomerLifetimeValue, error) {
+	ret := _m.Called(ctx, customerID)
+
+	var r0 *entity.CustomerLifetimeValue
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.CustomerLifetimeValue); ok {
+		r0 = rf(ctx, customerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.Order)
+			r0 = ret.Get(0).(*entity.CustomerLifetimeValue)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, customerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,22 +112,22 @@ func (_m *OrderRepository) GetAllOrders(ctx context.Context) ([]*entity.Order, e
 	return r0, r1
 }
 
-// GetAnnualIncome provides a mock function with given fields: ctx
-func (_m *OrderRepository) GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIncome, error) {
-	ret := _m.Called(ctx)
+// GetOrderByID provides a mock function with given fields: ctx, ID
+func (_m *OrderRepository) GetOrderByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	ret := _m.Called(ctx, ID)
 
-	var r0 []*entity.AnnualIncome
-	if rf, ok := ret.Get(0).(func(context.Context) []*entity.AnnualIncome); ok {
-		r0 = rf(ctx)
+	var r0 *entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Order); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.AnnualIncome)
+			r0 = ret.Get(0).(*entity.Order)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,20 +135,22 @@ func (_m *OrderRepository) GetAnnualIncome(ctx context.Context) ([]*entity.Annua
 	return r0, r1
 }
 
-// GetDailyOrderCount provides a mock function with given fields: ctx
-func (_m *OrderRepository) GetDailyOrderCount(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetOrderByIDForUpdate provides a mock function with given fields: ctx, ID
+func (_m *OrderRepository) GetOrderByIDForUpdate(ctx context.Context, ID int64) (*entity.Order, error) {
+	ret := _m.Called(ctx, ID)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	var r0 *entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Order); ok {
+		r0 = rf(ctx, ID)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -119,20 +158,22 @@ func (_m *OrderRepository) GetDailyOrderCount(ctx context.Context) (int, error)
 	return r0, r1
 }
 
-// GetLastDayIncome provides a mock function with given fields: ctx
-func (_m *OrderRepository) GetLastDayIncome(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetOrderByIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, key string) (*entity.Order, error) {
+	ret := _m.Called(ctx, key)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	var r0 *entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Order); ok {
+		r0 = rf(ctx, key)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -140,20 +181,45 @@ func (_m *OrderRepository) GetLastDayIncome(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
-// GetLastMonthIncome provides a mock function with given fields: ctx
-func (_m *OrderRepository) GetLastMonthIncome(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetOrderByInvoiceNumber provides a mock function with given fields: ctx, invoiceNumber
+func (_m *OrderRepository) GetOrderByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entity.Order, error) {
+	ret := _m.Called(ctx, invoiceNumber)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	var r0 *entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Order); ok {
+		r0 = rf(ctx, invoiceNumber)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Order)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, invoiceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderHeatmap provides a mock function with given fields: ctx, start, end
+func (_m *OrderRepository) GetOrderHeatmap(ctx context.Context, start time.Time, end time.Time) ([]*entity.OrderHeatmapCell, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []*entity.OrderHeatmapCell
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*entity.OrderHeatmapCell); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OrderHeatmapCell)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -184,6 +250,75 @@ func (_m *OrderRepository) GetOrderItemsByID(ctx context.Context, ID int64) ([]*
 	return r0, r1
 }
 
+// GetOrders provides a mock function with given fields: ctx, query
+func (_m *OrderRepository) GetOrders(ctx context.Context, query entity.OrderQuery) ([]*entity.Order, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, entity.OrderQuery) []*entity.Order); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.OrderQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrdersByCustomerID provides a mock function with given fields: ctx, customerID
+func (_m *OrderRepository) GetOrdersByCustomerID(ctx context.Context, customerID int64) ([]*entity.Order, error) {
+	ret := _m.Called(ctx, customerID)
+
+	var r0 []*entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*entity.Order); ok {
+		r0 = rf(ctx, customerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Order)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPaymentsByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *OrderRepository) GetPaymentsByOrderID(ctx context.Context, orderID int64) ([]*entity.OrderPayment, error) {
+	ret := _m.Called(ctx, orderID)
+
+	var r0 []*entity.OrderPayment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*entity.OrderPayment); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OrderPayment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalOrderCount provides a mock function with given fields: ctx
 func (_m *OrderRepository) GetTotalOrderCount(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -204,3 +339,38 @@ func (_m *OrderRepository) GetTotalOrderCount(ctx context.Context) (int, error)
 
 	return r0, r1
 }
+
+// NextInvoiceSequence provides a mock function with given fields: ctx, period
+func (_m *OrderRepository) NextInvoiceSequence(ctx context.Context, period string) (int, error) {
+	ret := _m.Called(ctx, period)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, period)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatusByID provides a mock function with given fields: ctx, ID, status
+func (_m *OrderRepository) UpdateStatusByID(ctx context.Context, ID int64, status string) error {
+	ret := _m.Called(ctx, ID, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, ID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
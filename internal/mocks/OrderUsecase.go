@@ -38,22 +38,22 @@ func (_m *OrderUsecase) Create(ctx context.Context, param entity.CreateOrderPara
 	return r0, r1
 }
 
-// GetAllOrders provides a mock function with given fields: ctx
-func (_m *OrderUsecase) GetAllOrders(ctx context.Context) ([]*entity.Order, error) {
-	ret := _m.Called(ctx)
+// GetOrderByID provides a mock function with given fields: ctx, ID
+func (_m *OrderUsecase) GetOrderByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	ret := _m.Called(ctx, ID)
 
-	var r0 []*entity.Order
-	if rf, ok := ret.Get(0).(func(context.Context) []*entity.Order); ok {
-		r0 = rf(ctx)
+	var r0 *entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Order); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.Order)
+			r0 = ret.Get(0).(*entity.Order)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,22 +61,22 @@ func (_m *OrderUsecase) GetAllOrders(ctx context.Context) ([]*entity.Order, erro
 	return r0, r1
 }
 
-// GetAnnualIncome provides a mock function with given fields: ctx
-func (_m *OrderUsecase) GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIncome, error) {
-	ret := _m.Called(ctx)
+// GetOrderByInvoiceNumber provides a mock function with given fields: ctx, invoiceNumber
+func (_m *OrderUsecase) GetOrderByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entity.Order, error) {
+	ret := _m.Called(ctx, invoiceNumber)
 
-	var r0 []*entity.AnnualIncome
-	if rf, ok := ret.Get(0).(func(context.Context) []*entity.AnnualIncome); ok {
-		r0 = rf(ctx)
+	var r0 *entity.Order
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Order); ok {
+		r0 = rf(ctx, invoiceNumber)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.AnnualIncome)
+			r0 = ret.Get(0).(*entity.Order)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, invoiceNumber)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,20 +84,22 @@ func (_m *OrderUsecase) GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIn
 	return r0, r1
 }
 
-// GetDailyOrderCount provides a mock function with given fields: ctx
-func (_m *OrderUsecase) GetDailyOrderCount(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetOrderItems provides a mock function with given fields: ctx, orderID
+func (_m *OrderUsecase) GetOrderItems(ctx context.Context, orderID int64) ([]*entity.OrderItem, error) {
+	ret := _m.Called(ctx, orderID)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	var r0 []*entity.OrderItem
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*entity.OrderItem); ok {
+		r0 = rf(ctx, orderID)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OrderItem)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,20 +107,22 @@ func (_m *OrderUsecase) GetDailyOrderCount(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
-// GetLastDayIncome provides a mock function with given fields: ctx
-func (_m *OrderUsecase) GetLastDayIncome(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetOrderPayments provides a mock function with given fields: ctx, orderID
+func (_m *OrderUsecase) GetOrderPayments(ctx context.Context, orderID int64) ([]*entity.OrderPayment, error) {
+	ret := _m.Called(ctx, orderID)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	var r0 []*entity.OrderPayment
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*entity.OrderPayment); ok {
+		r0 = rf(ctx, orderID)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OrderPayment)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -126,8 +130,31 @@ func (_m *OrderUsecase) GetLastDayIncome(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
-// GetLastMonthIncome provides a mock function with given fields: ctx
-func (_m *OrderUsecase) GetLastMonthIncome(ctx context.Context) (int, error) {
+// GetOrders provides a mock function with given fields: ctx, query
+func (_m *OrderUsecase) GetOrders(ctx context.Context, query entity.OrderQuery) (*entity.OrderPage, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *entity.OrderPage
+	if rf, ok := ret.Get(0).(func(context.Context, entity.OrderQuery) *entity.OrderPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.OrderPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.OrderQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalOrderCount provides a mock function with given fields: ctx
+func (_m *OrderUsecase) GetTotalOrderCount(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
 
 	var r0 int
@@ -147,22 +174,22 @@ func (_m *OrderUsecase) GetLastMonthIncome(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
-// GetOrderItems provides a mock function with given fields: ctx, orderID
-func (_m *OrderUsecase) GetOrderItems(ctx context.Context, orderID int64) ([]*entity.OrderItem, error) {
-	ret := _m.Called(ctx, orderID)
+// RefundOrder provides a mock function with given fields: ctx, ID, param
+func (_m *OrderUsecase) RefundOrder(ctx context.Context, ID int64, param entity.RefundOrderParam) (*entity.PrepaidCard, error) {
+	ret := _m.Called(ctx, ID, param)
 
-	var r0 []*entity.OrderItem
-	if rf, ok := ret.Get(0).(func(context.Context, int64) []*entity.OrderItem); ok {
-		r0 = rf(ctx, orderID)
+	var r0 *entity.PrepaidCard
+	if rf, ok := ret.Get(0).(func(context.Context, int64, entity.RefundOrderParam) *entity.PrepaidCard); ok {
+		r0 = rf(ctx, ID, param)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.OrderItem)
+			r0 = ret.Get(0).(*entity.PrepaidCard)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = rf(ctx, orderID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, entity.RefundOrderParam) error); ok {
+		r1 = rf(ctx, ID, param)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -170,23 +197,16 @@ func (_m *OrderUsecase) GetOrderItems(ctx context.Context, orderID int64) ([]*en
 	return r0, r1
 }
 
-// GetTotalOrderCount provides a mock function with given fields: ctx
-func (_m *OrderUsecase) GetTotalOrderCount(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// VoidOrder provides a mock function with given fields: ctx, ID
+func (_m *OrderUsecase) VoidOrder(ctx context.Context, ID int64) error {
+	ret := _m.Called(ctx, ID)
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
-	} else {
-		r0 = ret.Get(0).(int)
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, ID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/ardafirdausr/kaseer/internal/entity"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -15,6 +17,27 @@ type ProductRepository struct {
 	mock.Mock
 }
 
+// CountProducts provides a mock function with given fields: ctx, query
+func (_m *ProductRepository) CountProducts(ctx context.Context, query entity.ProductQuery) (int, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductQuery) int); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, param
 func (_m *ProductRepository) Create(ctx context.Context, param entity.CreateProductParam) (*entity.Product, error) {
 	ret := _m.Called(ctx, param)
@@ -38,6 +61,20 @@ func (_m *ProductRepository) Create(ctx context.Context, param entity.CreateProd
 	return r0, r1
 }
 
+// CreateNegativeStockSales provides a mock function with given fields: ctx, orderID, params
+func (_m *ProductRepository) CreateNegativeStockSales(ctx context.Context, orderID int64, params []*entity.CreateNegativeStockSaleParam) error {
+	ret := _m.Called(ctx, orderID, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*entity.CreateNegativeStockSaleParam) error); ok {
+		r0 = rf(ctx, orderID, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DecrementProductByIDs provides a mock function with given fields: ctx, IDDecrementMap
 func (_m *ProductRepository) DecrementProductByIDs(ctx context.Context, IDDecrementMap map[int64]int) error {
 	ret := _m.Called(ctx, IDDecrementMap)
@@ -52,6 +89,27 @@ func (_m *ProductRepository) DecrementProductByIDs(ctx context.Context, IDDecrem
 	return r0
 }
 
+// DecrementStockByID provides a mock function with given fields: ctx, ID, quantity
+func (_m *ProductRepository) DecrementStockByID(ctx context.Context, ID int64, quantity int) (bool, error) {
+	ret := _m.Called(ctx, ID, quantity)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) bool); ok {
+		r0 = rf(ctx, ID, quantity)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, ID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteByID provides a mock function with given fields: ctx, ID
 func (_m *ProductRepository) DeleteByID(ctx context.Context, ID int64) (bool, error) {
 	ret := _m.Called(ctx, ID)
@@ -119,6 +177,75 @@ func (_m *ProductRepository) GetBestSellerProducts(ctx context.Context) ([]*enti
 	return r0, r1
 }
 
+// GetDailySales provides a mock function with given fields: ctx, start, end
+func (_m *ProductRepository) GetDailySales(ctx context.Context, start time.Time, end time.Time) ([]*entity.DailyProductSales, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []*entity.DailyProductSales
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*entity.DailyProductSales); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.DailyProductSales)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadStock provides a mock function with given fields: ctx, since
+func (_m *ProductRepository) GetDeadStock(ctx context.Context, since time.Time) ([]*entity.DeadStockProduct, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 []*entity.DeadStockProduct
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*entity.DeadStockProduct); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.DeadStockProduct)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNegativeStockSales provides a mock function with given fields: ctx
+func (_m *ProductRepository) GetNegativeStockSales(ctx context.Context) ([]*entity.NegativeStockSale, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.NegativeStockSale
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.NegativeStockSale); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.NegativeStockSale)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductByCode provides a mock function with given fields: ctx, code
 func (_m *ProductRepository) GetProductByCode(ctx context.Context, code string) (*entity.Product, error) {
 	ret := _m.Called(ctx, code)
@@ -165,6 +292,75 @@ func (_m *ProductRepository) GetProductByID(ctx context.Context, ID int64) (*ent
 	return r0, r1
 }
 
+// GetProductByIDForUpdate provides a mock function with given fields: ctx, ID
+func (_m *ProductRepository) GetProductByIDForUpdate(ctx context.Context, ID int64) (*entity.Product, error) {
+	ret := _m.Called(ctx, ID)
+
+	var r0 *entity.Product
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.Product); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductCategories provides a mock function with given fields: ctx
+func (_m *ProductRepository) GetProductCategories(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductPerformance provides a mock function with given fields: ctx, start, end
+func (_m *ProductRepository) GetProductPerformance(ctx context.Context, start time.Time, end time.Time) ([]*entity.ProductPerformance, error) {
+	ret := _m.Called(ctx, start, end)
+
+	var r0 []*entity.ProductPerformance
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*entity.ProductPerformance); ok {
+		r0 = rf(ctx, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ProductPerformance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductsByIDs provides a mock function with given fields: ctx, IDs
 func (_m *ProductRepository) GetProductsByIDs(ctx context.Context, IDs ...int64) ([]*entity.Product, error) {
 	_va := make([]interface{}, len(IDs))
@@ -195,6 +391,78 @@ func (_m *ProductRepository) GetProductsByIDs(ctx context.Context, IDs ...int64)
 	return r0, r1
 }
 
+// IncrementProductByIDs provides a mock function with given fields: ctx, IDIncrementMap
+func (_m *ProductRepository) IncrementProductByIDs(ctx context.Context, IDIncrementMap map[int64]int) error {
+	ret := _m.Called(ctx, IDIncrementMap)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[int64]int) error); ok {
+		r0 = rf(ctx, IDIncrementMap)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReconcileNegativeStockSaleByID provides a mock function with given fields: ctx, ID, date
+func (_m *ProductRepository) ReconcileNegativeStockSaleByID(ctx context.Context, ID int64, date time.Time) (bool, error) {
+	ret := _m.Called(ctx, ID, date)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) bool); ok {
+		r0 = rf(ctx, ID, date)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, ID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchProducts provides a mock function with given fields: ctx, query
+func (_m *ProductRepository) SearchProducts(ctx context.Context, query entity.ProductQuery) ([]*entity.Product, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []*entity.Product
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductQuery) []*entity.Product); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubtractStockByID provides a mock function with given fields: ctx, ID, quantity
+func (_m *ProductRepository) SubtractStockByID(ctx context.Context, ID int64, quantity int) error {
+	ret := _m.Called(ctx, ID, quantity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) error); ok {
+		r0 = rf(ctx, ID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateByID provides a mock function with given fields: ctx, ID, param
 func (_m *ProductRepository) UpdateByID(ctx context.Context, ID int64, param entity.UpdateProductParam) (bool, error) {
 	ret := _m.Called(ctx, ID, param)
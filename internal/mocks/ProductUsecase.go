@@ -105,6 +105,29 @@ func (_m *ProductUsecase) GetBestSellerProducts(ctx context.Context) ([]*entity.
 	return r0, r1
 }
 
+// GetNegativeStockSales provides a mock function with given fields: ctx
+func (_m *ProductUsecase) GetNegativeStockSales(ctx context.Context) ([]*entity.NegativeStockSale, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.NegativeStockSale
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.NegativeStockSale); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.NegativeStockSale)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductByCode provides a mock function with given fields: ctx, code
 func (_m *ProductUsecase) GetProductByCode(ctx context.Context, code string) (*entity.Product, error) {
 	ret := _m.Called(ctx, code)
@@ -151,6 +174,73 @@ func (_m *ProductUsecase) GetProductByID(ctx context.Context, ID int64) (*entity
 	return r0, r1
 }
 
+// GetProductCategories provides a mock function with given fields: ctx
+func (_m *ProductUsecase) GetProductCategories(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileNegativeStockSale provides a mock function with given fields: ctx, ID
+func (_m *ProductUsecase) ReconcileNegativeStockSale(ctx context.Context, ID int64) (bool, error) {
+	ret := _m.Called(ctx, ID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchProducts provides a mock function with given fields: ctx, query
+func (_m *ProductUsecase) SearchProducts(ctx context.Context, query entity.ProductQuery) (*entity.ProductPage, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *entity.ProductPage
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ProductQuery) *entity.ProductPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ProductQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateProduct provides a mock function with given fields: ctx, ID, param
 func (_m *ProductUsecase) UpdateProduct(ctx context.Context, ID int64, param entity.UpdateProductParam) (bool, error) {
 	ret := _m.Called(ctx, ID, param)
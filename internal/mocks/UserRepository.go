@@ -15,6 +15,75 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CreateServiceAccount provides a mock function with given fields: ctx, name, email
+func (_m *UserRepository) CreateServiceAccount(ctx context.Context, name string, email string) (*entity.User, error) {
+	ret := _m.Called(ctx, name, email)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.User); ok {
+		r0 = rf(ctx, name, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllUsers provides a mock function with given fields: ctx
+func (_m *UserRepository) GetAllUsers(ctx context.Context) ([]*entity.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.User
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetServiceAccounts provides a mock function with given fields: ctx
+func (_m *UserRepository) GetServiceAccounts(ctx context.Context) ([]*entity.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.User
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	ret := _m.Called(ctx, email)
@@ -61,6 +130,29 @@ func (_m *UserRepository) GetUserByID(ctx context.Context, ID int64) (*entity.Us
 	return r0, r1
 }
 
+// GetUserByIDForUpdate provides a mock function with given fields: ctx, ID
+func (_m *UserRepository) GetUserByIDForUpdate(ctx context.Context, ID int64) (*entity.User, error) {
+	ret := _m.Called(ctx, ID)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *entity.User); ok {
+		r0 = rf(ctx, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateByID provides a mock function with given fields: ctx, ID, param
 func (_m *UserRepository) UpdateByID(ctx context.Context, ID int64, param entity.UpdateUserParam) (bool, error) {
 	ret := _m.Called(ctx, ID, param)
@@ -7,9 +7,9 @@ import (
 
 	entity "github.com/ardafirdausr/kaseer/internal/entity"
 
-	mock "github.com/stretchr/testify/mock"
-
 	multipart "mime/multipart"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
@@ -17,6 +17,29 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// GetAllUsers provides a mock function with given fields: ctx
+func (_m *UserUsecase) GetAllUsers(ctx context.Context) ([]*entity.User, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*entity.User
+	if rf, ok := ret.Get(0).(func(context.Context) []*entity.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByCredential provides a mock function with given fields: ctx, credential
 func (_m *UserUsecase) GetUserByCredential(ctx context.Context, credential entity.UserCredential) (*entity.User, error) {
 	ret := _m.Called(ctx, credential)
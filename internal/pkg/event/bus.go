@@ -0,0 +1,130 @@
+package event
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// queueSize is how many events an asynchronous subscriber can fall behind
+// before the new ones are dropped rather than holding the publisher up.
+const queueSize = 256
+
+type subscriber struct {
+	handler internal.EventHandler
+	// queue is nil for the synchronous subscribers
+	queue chan entity.Event
+}
+
+// Bus is an in-process event bus, the events are lost when the process
+// stops so anything that must not miss one keeps its own record.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]*subscriber
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: map[string][]*subscriber{}}
+}
+
+func (b *Bus) Subscribe(name string, handler internal.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[name] = append(b.subscribers[name], &subscriber{handler: handler})
+}
+
+// SubscribeAsync runs the handler in a goroutine of its own, with a context
+// outliving the request which published the event.
+func (b *Bus) SubscribeAsync(name string, handler internal.EventHandler) {
+	sub := &subscriber{handler: handler, queue: make(chan entity.Event, queueSize)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	b.subscribers[name] = append(b.subscribers[name], sub)
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for event := range sub.queue {
+			handle(context.Background(), sub.handler, event)
+		}
+	}()
+}
+
+func (b *Bus) Publish(ctx context.Context, event entity.Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	// the synchronous subscribers run once the lock is released, so they
+	// can publish and subscribe in turn
+	handlers := []internal.EventHandler{}
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return
+	}
+
+	for _, sub := range b.subscribers[event.Name] {
+		if sub.queue == nil {
+			handlers = append(handlers, sub.handler)
+			continue
+		}
+
+		select {
+		case sub.queue <- event:
+		default:
+			log.Printf("Dropped the %s event, a subscriber is falling behind", event.Name)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handle(ctx, handler, event)
+	}
+}
+
+// Close stops taking events and waits for the asynchronous subscribers to
+// handle the events already queued.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+
+	b.closed = true
+	for _, subs := range b.subscribers {
+		for _, sub := range subs {
+			if sub.queue != nil {
+				close(sub.queue)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+// handle keeps a failing or panicking subscriber from taking the publisher
+// or the other subscribers down.
+func handle(ctx context.Context, handler internal.EventHandler, event entity.Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Subscriber of the %s event panicked: %v", event.Name, r)
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		log.Printf("Subscriber of the %s event failed: %v", event.Name, err)
+	}
+}
@@ -0,0 +1,193 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bus_Publish_RunsSubscribersInOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []error
+	}{
+		{
+			name: "all succeed",
+			errs: []error{nil, nil, nil},
+		},
+		{
+			name: "the first fails",
+			errs: []error{errors.New("failed"), nil, nil},
+		},
+		{
+			name: "every one fails",
+			errs: []error{errors.New("failed"), errors.New("failed"), errors.New("failed")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := NewBus()
+			defer bus.Close()
+
+			calls := []int{}
+			for i, err := range test.errs {
+				i, err := i, err
+				bus.Subscribe("order.created", func(ctx context.Context, event entity.Event) error {
+					calls = append(calls, i)
+					return err
+				})
+			}
+
+			bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+			assert.Equal(t, []int{0, 1, 2}, calls)
+		})
+	}
+}
+
+func Test_Bus_Publish_SurvivesPanickingSubscriber(t *testing.T) {
+	bus := NewBus()
+	defer bus.Close()
+
+	called := false
+	bus.Subscribe("order.created", func(ctx context.Context, event entity.Event) error {
+		panic("boom")
+	})
+	bus.Subscribe("order.created", func(ctx context.Context, event entity.Event) error {
+		called = true
+		return nil
+	})
+
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+	assert.True(t, called)
+}
+
+func Test_Bus_Publish_PassesContextAndStampsTime(t *testing.T) {
+	type key struct{}
+	bus := NewBus()
+	defer bus.Close()
+
+	var received entity.Event
+	var value interface{}
+	bus.Subscribe("order.created", func(ctx context.Context, event entity.Event) error {
+		received = event
+		value = ctx.Value(key{})
+		return nil
+	})
+	bus.Subscribe("order.voided", func(ctx context.Context, event entity.Event) error {
+		t.Error("subscriber of another event called")
+		return nil
+	})
+
+	ctx := context.WithValue(context.TODO(), key{}, "request")
+	bus.Publish(ctx, entity.Event{Name: "order.created", Data: 1})
+	assert.Equal(t, "request", value)
+	assert.Equal(t, 1, received.Data)
+	assert.False(t, received.OccurredAt.IsZero())
+}
+
+func Test_Bus_SubscribeAsync(t *testing.T) {
+	bus := NewBus()
+	defer bus.Close()
+
+	received := make(chan entity.Event, 2)
+	bus.SubscribeAsync("order.created", func(ctx context.Context, event entity.Event) error {
+		received <- event
+		return errors.New("failed")
+	})
+
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created", Data: 1})
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created", Data: 2})
+	for _, want := range []int{1, 2} {
+		select {
+		case event := <-received:
+			assert.Equal(t, want, event.Data)
+		case <-time.After(time.Second):
+			t.Fatal("event not delivered")
+		}
+	}
+}
+
+func Test_Bus_Publish_DropsEventsOfFullQueue(t *testing.T) {
+	bus := NewBus()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled int32
+	bus.SubscribeAsync("order.created", func(ctx context.Context, event entity.Event) error {
+		if atomic.AddInt32(&handled, 1) == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	})
+
+	// the first event holds the subscriber, the next ones fill its queue
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+	<-started
+	for i := 0; i < queueSize+10; i++ {
+		bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+	}
+
+	close(release)
+	bus.Close()
+	assert.Equal(t, int32(queueSize+1), atomic.LoadInt32(&handled))
+}
+
+func Test_Bus_Close(t *testing.T) {
+	bus := NewBus()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled int32
+	bus.SubscribeAsync("order.created", func(ctx context.Context, event entity.Event) error {
+		if atomic.AddInt32(&handled, 1) == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	})
+	syncCalled := false
+	bus.Subscribe("order.created", func(ctx context.Context, event entity.Event) error {
+		syncCalled = true
+		return nil
+	})
+
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+	<-started
+	syncCalled = false
+
+	closed := make(chan struct{})
+	go func() {
+		bus.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("Close returned before the running handler finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-closed
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handled))
+
+	// the events published after closing reach no subscriber
+	bus.Publish(context.TODO(), entity.Event{Name: "order.created"})
+	assert.False(t, syncCalled)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&handled))
+
+	// closing twice is harmless and subscribing afterwards is ignored
+	bus.Close()
+	bus.SubscribeAsync("order.created", func(ctx context.Context, event entity.Event) error {
+		t.Error("subscriber added after closing called")
+		return nil
+	})
+}
@@ -0,0 +1,41 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) WriteHeader(columns ...string) error {
+	return cw.writer.Write(columns)
+}
+
+func (cw *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatValue(value)
+		// spreadsheets would run text starting like a formula
+		if _, ok := value.(string); ok && record[i] != "" && strings.ContainsRune("=+-@", rune(record[i][0])) {
+			record[i] = "'" + record[i]
+		}
+	}
+
+	return cw.writer.Write(record)
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+func (cw *csvWriter) Close() error {
+	return cw.Flush()
+}
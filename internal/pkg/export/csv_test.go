@@ -0,0 +1,47 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CSVWriter_QuotesAndEscapesValues(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatCSV, &buf, "Orders")
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteHeader("Name", "Note", "Total", "Created At"))
+	assert.Nil(t, writer.WriteRow("Kopi, Susu", `Say "hi"`, 15000, time.Date(2021, 6, 1, 9, 30, 0, 0, time.UTC)))
+	assert.Nil(t, writer.WriteRow("Teh", "first line\nsecond line", 2.5, nil))
+	assert.Nil(t, writer.Close())
+
+	expected := "Name,Note,Total,Created At\n" +
+		"\"Kopi, Susu\",\"Say \"\"hi\"\"\",15000,2021-06-01 09:30\n" +
+		"Teh,\"first line\nsecond line\",2.5,\n"
+	assert.Equal(t, expected, buf.String())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{
+		{"Name", "Note", "Total", "Created At"},
+		{"Kopi, Susu", `Say "hi"`, "15000", "2021-06-01 09:30"},
+		{"Teh", "first line\nsecond line", "2.5", ""},
+	}, records)
+}
+
+func Test_CSVWriter_EscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatCSV, &buf, "Orders")
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteRow("=SUM(A1:A2)", "+62812", "@cmd", "-1", -1, "plain"))
+	assert.Nil(t, writer.Close())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"'=SUM(A1:A2)", "'+62812", "'@cmd", "'-1", "-1", "plain"}}, records)
+}
@@ -0,0 +1,117 @@
+// Package export renders tabular report results as downloadable documents.
+// Every writer streams its rows to the underlying io.Writer as they come, so
+// an export never has to be held in memory as a whole.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// ParseFormat reads a format as it is given in the format query parameter.
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(value); format {
+	case FormatCSV, FormatXLSX, FormatPDF:
+		return format, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Filename names the download of a report, name is left without extension.
+func (f Format) Filename(name string) string {
+	return name + "." + string(f)
+}
+
+// Writer writes one table. The header is written once before the rows, and
+// the document is only complete once Close returns.
+type Writer interface {
+	WriteHeader(columns ...string) error
+
+	// WriteRow takes strings, integers, floats and times, numbers are kept
+	// as numbers by the formats that have them.
+	WriteRow(values ...interface{}) error
+
+	// Flush pushes the rows written so far down to the underlying writer.
+	Flush() error
+
+	Close() error
+}
+
+// NewWriter starts a document in the given format, title heads the pages of
+// a PDF and names the sheet of a workbook.
+func NewWriter(format Format, w io.Writer, title string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, title)
+	case FormatPDF:
+		return newPDFWriter(w, title), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+const timeLayout = "2006-01-02 15:04"
+
+// formatValue is how a value reads as text.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(timeLayout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(timeLayout)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isNumber tells the values that are written as numbers apart.
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int64, float64:
+		return true
+	default:
+		return false
+	}
+}
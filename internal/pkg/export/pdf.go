@@ -0,0 +1,227 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Pages are A4 landscape, measured in points.
+const (
+	pdfPageWidth   = 842
+	pdfPageHeight  = 595
+	pdfMargin      = 40
+	pdfTitleSize   = 14
+	pdfFontSize    = 9
+	pdfLineHeight  = 14
+	pdfCellPadding = 4
+
+	// pdfCharWidth is the average width of a Helvetica character as a part
+	// of the font size, pdfDigitWidth is the exact width of a digit.
+	pdfCharWidth  = 0.55
+	pdfDigitWidth = 0.556
+)
+
+// The objects that are known up front, the pages and their contents are
+// numbered from pdfFirstPageObject as they are written.
+const (
+	pdfCatalogObject = iota + 1
+	pdfPagesObject
+	pdfFontObject
+	pdfBoldFontObject
+	pdfFirstPageObject
+)
+
+// pdfWriter lays the table out as plain text in Helvetica. A page is written
+// out as soon as it is full, only the offsets of the objects written so far
+// are kept to build the cross reference table at the end.
+type pdfWriter struct {
+	w       *countingWriter
+	title   string
+	columns []string
+	offsets []int64
+	pages   []int
+	content strings.Builder
+	y       float64
+	err     error
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func newPDFWriter(w io.Writer, title string) *pdfWriter {
+	pw := &pdfWriter{
+		w:       &countingWriter{w: w},
+		title:   title,
+		offsets: make([]int64, pdfFirstPageObject),
+	}
+	pw.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+	pw.writeObject(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+	pw.writeObject(pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	pw.writeObject(pdfBoldFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	return pw
+}
+
+func (pw *pdfWriter) WriteHeader(columns ...string) error {
+	pw.columns = columns
+	return pw.err
+}
+
+func (pw *pdfWriter) WriteRow(values ...interface{}) error {
+	if pw.content.Len() == 0 || pw.y < pdfMargin+pdfLineHeight {
+		pw.startPage()
+	}
+
+	pw.writeCells(values, "F1")
+	return pw.err
+}
+
+func (pw *pdfWriter) Flush() error {
+	return pw.err
+}
+
+func (pw *pdfWriter) Close() error {
+	// an empty export still gets a page with the title and header
+	if pw.content.Len() > 0 || len(pw.pages) == 0 {
+		if pw.content.Len() == 0 {
+			pw.startPage()
+		}
+		pw.endPage()
+	}
+
+	kids := make([]string, len(pw.pages))
+	for i, page := range pw.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	pw.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pw.pages)))
+
+	xref := pw.w.n
+	pw.printf("xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets))
+	for _, offset := range pw.offsets[1:] {
+		pw.printf("%010d 00000 n \n", offset)
+	}
+	pw.printf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(pw.offsets), pdfCatalogObject, xref)
+	return pw.err
+}
+
+// startPage writes out the page in progress and begins the next one with the
+// title and the column header.
+func (pw *pdfWriter) startPage() {
+	if pw.content.Len() > 0 {
+		pw.endPage()
+	}
+
+	pw.y = pdfPageHeight - pdfMargin - pdfTitleSize
+	pw.text("F2", pdfTitleSize, pdfMargin, pw.y, pw.title)
+	pw.y -= pdfLineHeight * 2
+
+	if len(pw.columns) > 0 {
+		values := make([]interface{}, len(pw.columns))
+		for i, column := range pw.columns {
+			values[i] = column
+		}
+		pw.writeCells(values, "F2")
+		pw.content.WriteString(fmt.Sprintf("%d %.2f m %d %.2f l S\n", pdfMargin, pw.y+pdfLineHeight-3, pdfPageWidth-pdfMargin, pw.y+pdfLineHeight-3))
+	}
+}
+
+func (pw *pdfWriter) endPage() {
+	page := len(pw.pages) + 1
+	pw.text("F1", pdfFontSize, pdfPageWidth-pdfMargin-50, pdfMargin/2, fmt.Sprintf("Page %d", page))
+
+	contentObject := len(pw.offsets)
+	pageObject := contentObject + 1
+	stream := pw.content.String()
+	pw.writeObject(contentObject, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(stream), stream))
+	pw.writeObject(pageObject, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, pdfBoldFontObject, contentObject))
+	pw.pages = append(pw.pages, pageObject)
+	pw.content.Reset()
+}
+
+// writeCells writes one line of the table, the columns share the width of
+// the page and text that does not fit is cut short. Numbers align right.
+func (pw *pdfWriter) writeCells(values []interface{}, font string) {
+	count := len(pw.columns)
+	if len(values) > count {
+		count = len(values)
+	}
+
+	width := float64(pdfPageWidth-pdfMargin*2) / float64(count)
+	for i, value := range values {
+		x := pdfMargin + width*float64(i)
+		text := fitText(formatValue(value), width-pdfCellPadding)
+		if isNumber(value) {
+			x += width - pdfCellPadding - float64(len(text))*pdfDigitWidth*pdfFontSize
+		}
+
+		pw.text(font, pdfFontSize, x, pw.y, text)
+	}
+	pw.y -= pdfLineHeight
+}
+
+func (pw *pdfWriter) text(font string, size int, x float64, y float64, text string) {
+	pw.content.WriteString(fmt.Sprintf("BT /%s %d Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(text)))
+}
+
+func (pw *pdfWriter) writeObject(number int, body string) {
+	for len(pw.offsets) <= number {
+		pw.offsets = append(pw.offsets, 0)
+	}
+
+	pw.offsets[number] = pw.w.n
+	pw.printf("%d 0 obj\n%s\nendobj\n", number, body)
+}
+
+func (pw *pdfWriter) printf(format string, args ...interface{}) {
+	if pw.err != nil {
+		return
+	}
+
+	_, pw.err = fmt.Fprintf(pw.w, format, args...)
+}
+
+// fitText cuts text down to about the number of characters that fit width.
+func fitText(text string, width float64) string {
+	limit := int(width / (pdfCharWidth * pdfFontSize))
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+
+	if limit < 3 {
+		return string(runes[:limit])
+	}
+
+	return string(runes[:limit-2]) + ".."
+}
+
+// pdfString encodes text for a string literal in WinAnsiEncoding, which
+// matches Latin-1 for the accented letters. Other characters become "?".
+func pdfString(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			sb.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			sb.WriteString(fmt.Sprintf("\\%03o", r))
+		default:
+			sb.WriteByte('?')
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pdfStartXrefPattern = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	pdfStreamPattern    = regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`)
+)
+
+// readPDFXref reads the offsets of the cross reference table the trailer
+// points at, indexed by object number.
+func readPDFXref(t *testing.T, pdf string) []int {
+	t.Helper()
+	match := pdfStartXrefPattern.FindStringSubmatch(pdf)
+	if !assert.NotNil(t, match) {
+		t.FailNow()
+	}
+
+	start, _ := strconv.Atoi(match[1])
+	if !assert.True(t, strings.HasPrefix(pdf[start:], "xref\n")) {
+		t.FailNow()
+	}
+
+	lines := strings.Split(pdf[start:], "\n")
+	var first, size int
+	_, err := fmt.Sscanf(lines[1], "%d %d", &first, &size)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, first)
+	assert.Equal(t, "0000000000 65535 f ", lines[2])
+
+	offsets := make([]int, size)
+	for i := 1; i < size; i++ {
+		entry := lines[2+i]
+		assert.Len(t, entry, 19)
+		assert.True(t, strings.HasSuffix(entry, " 00000 n "))
+		offsets[i], _ = strconv.Atoi(entry[:10])
+	}
+
+	assert.Equal(t, "trailer", lines[2+size])
+	assert.Contains(t, lines[3+size], fmt.Sprintf("/Size %d ", size))
+	return offsets
+}
+
+func Test_PDFWriter_XrefPointsAtTheObjects(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatPDF, &buf, "Orders (June)")
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteHeader("Code", "Total"))
+	for i := 0; i < 80; i++ {
+		assert.Nil(t, writer.WriteRow(fmt.Sprintf("ORD-%03d", i), 15000))
+	}
+	assert.Nil(t, writer.Close())
+
+	pdf := buf.String()
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+
+	offsets := readPDFXref(t, pdf)
+	// the catalog, the page tree, two fonts and a content and a page object
+	// for each of the three pages
+	assert.Len(t, offsets, 11)
+	for number := 1; number < len(offsets); number++ {
+		prefix := fmt.Sprintf("%d 0 obj\n", number)
+		assert.True(t, strings.HasPrefix(pdf[offsets[number]:], prefix), "object %d is not at offset %d", number, offsets[number])
+	}
+
+	assert.True(t, strings.HasPrefix(pdf[offsets[pdfCatalogObject]:], "1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>"))
+	assert.True(t, strings.HasPrefix(pdf[offsets[pdfPagesObject]:], "2 0 obj\n<< /Type /Pages /Kids [6 0 R 8 0 R 10 0 R] /Count 3 >>"))
+
+	streams := pdfStreamPattern.FindAllStringSubmatch(pdf, -1)
+	assert.Len(t, streams, 3)
+	for _, stream := range streams {
+		length, _ := strconv.Atoi(stream[1])
+		assert.Equal(t, length, len(stream[2]))
+		assert.Contains(t, stream[2], `(Orders \(June\)) Tj`)
+		assert.Contains(t, stream[2], "(Code) Tj")
+	}
+	assert.Contains(t, streams[2][2], "(ORD-079) Tj")
+	assert.Contains(t, streams[2][2], "(Page 3) Tj")
+}
+
+func Test_PDFWriter_WritesAPageWhenEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatPDF, &buf, "Orders")
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteHeader("Code", "Total"))
+	assert.Nil(t, writer.Close())
+
+	pdf := buf.String()
+	offsets := readPDFXref(t, pdf)
+	assert.Len(t, offsets, 7)
+	for number := 1; number < len(offsets); number++ {
+		assert.True(t, strings.HasPrefix(pdf[offsets[number]:], fmt.Sprintf("%d 0 obj\n", number)))
+	}
+	assert.Contains(t, pdf, "/Kids [6 0 R] /Count 1")
+}
+
+func Test_pdfString(t *testing.T) {
+	assert.Equal(t, `Kopi \(Susu\) \\ Caf\351 ?`, pdfString("Kopi (Susu) \\ Café 🍵"))
+}
@@ -0,0 +1,171 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The parts of a workbook around its single sheet, the sheet itself is
+// written row by row as the last part of the archive.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxHeaderStyle is the index of the bold cell format in xlsxStyles.
+const xlsxHeaderStyle = 1
+
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+}
+
+func newXLSXWriter(w io.Writer, title string) (*xlsxWriter, error) {
+	archive := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xlsxSheetName(title))},
+	}
+	for _, part := range parts {
+		pw, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := io.WriteString(pw, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{archive: archive, sheet: sheet}, nil
+}
+
+func (xw *xlsxWriter) WriteHeader(columns ...string) error {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+
+	return xw.writeRow(values, xlsxHeaderStyle)
+}
+
+func (xw *xlsxWriter) WriteRow(values ...interface{}) error {
+	return xw.writeRow(values, 0)
+}
+
+func (xw *xlsxWriter) writeRow(values []interface{}, style int) error {
+	xw.row++
+	var sb strings.Builder
+	sb.WriteString(`<row r="` + strconv.Itoa(xw.row) + `">`)
+	for i, value := range values {
+		sb.WriteString(`<c r="` + xlsxColumn(i) + strconv.Itoa(xw.row) + `"`)
+		if style > 0 {
+			sb.WriteString(` s="` + strconv.Itoa(style) + `"`)
+		}
+
+		if isNumber(value) {
+			sb.WriteString(`><v>` + formatValue(value) + `</v></c>`)
+			continue
+		}
+
+		sb.WriteString(` t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(&sb, []byte(formatValue(value)))
+		sb.WriteString(`</t></is></c>`)
+	}
+	sb.WriteString(`</row>`)
+
+	_, err := io.WriteString(xw.sheet, sb.String())
+	return err
+}
+
+func (xw *xlsxWriter) Flush() error {
+	return xw.archive.Flush()
+}
+
+func (xw *xlsxWriter) Close() error {
+	if _, err := io.WriteString(xw.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	return xw.archive.Close()
+}
+
+// xlsxColumn is the letter name of a zero based column index, A to Z then AA.
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+
+	return name
+}
+
+// xlsxSheetName keeps a title within what spreadsheets allow as a sheet name,
+// at most 31 characters and none of []:*?/\.
+func xlsxSheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, title)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+
+	if strings.TrimSpace(name) == "" {
+		name = "Report"
+	}
+
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(name))
+	return sb.String()
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type xlsxTestCell struct {
+	Ref    string `xml:"r,attr"`
+	Type   string `xml:"t,attr"`
+	Style  string `xml:"s,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+type xlsxTestSheet struct {
+	Rows []struct {
+		Ref   string         `xml:"r,attr"`
+		Cells []xlsxTestCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type xlsxTestWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+func readXLSXPart(t *testing.T, archive *zip.Reader, name string, v interface{}) {
+	t.Helper()
+	part, err := archive.Open(name)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	defer part.Close()
+
+	content, err := io.ReadAll(part)
+	assert.Nil(t, err)
+	assert.Nil(t, xml.Unmarshal(content, v))
+}
+
+func Test_XLSXWriter_WritesTheSheetCells(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := NewWriter(FormatXLSX, &buf, "Sales: June/2021")
+	assert.Nil(t, err)
+
+	assert.Nil(t, writer.WriteHeader("Product", "Quantity", "Total", "Sold At"))
+	assert.Nil(t, writer.WriteRow("Kopi <Susu> & Gula", 3, 15000.5, time.Date(2021, 6, 1, 9, 30, 0, 0, time.UTC)))
+	assert.Nil(t, writer.WriteRow("  Teh  ", int64(1), 5000, nil))
+	assert.Nil(t, writer.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	names := []string{}
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/workbook.xml",
+		"xl/worksheets/sheet1.xml",
+	}, names)
+
+	var workbook xlsxTestWorkbook
+	readXLSXPart(t, archive, "xl/workbook.xml", &workbook)
+	if assert.Len(t, workbook.Sheets, 1) {
+		assert.Equal(t, "Sales June2021", workbook.Sheets[0].Name)
+	}
+
+	var sheet xlsxTestSheet
+	readXLSXPart(t, archive, "xl/worksheets/sheet1.xml", &sheet)
+	if !assert.Len(t, sheet.Rows, 3) {
+		return
+	}
+
+	assert.Equal(t, "1", sheet.Rows[0].Ref)
+	assert.Equal(t, []xlsxTestCell{
+		{Ref: "A1", Type: "inlineStr", Style: "1", Inline: "Product"},
+		{Ref: "B1", Type: "inlineStr", Style: "1", Inline: "Quantity"},
+		{Ref: "C1", Type: "inlineStr", Style: "1", Inline: "Total"},
+		{Ref: "D1", Type: "inlineStr", Style: "1", Inline: "Sold At"},
+	}, sheet.Rows[0].Cells)
+
+	assert.Equal(t, "2", sheet.Rows[1].Ref)
+	assert.Equal(t, []xlsxTestCell{
+		{Ref: "A2", Type: "inlineStr", Inline: "Kopi <Susu> & Gula"},
+		{Ref: "B2", Value: "3"},
+		{Ref: "C2", Value: "15000.5"},
+		{Ref: "D2", Type: "inlineStr", Inline: "2021-06-01 09:30"},
+	}, sheet.Rows[1].Cells)
+
+	assert.Equal(t, "3", sheet.Rows[2].Ref)
+	assert.Equal(t, []xlsxTestCell{
+		{Ref: "A3", Type: "inlineStr", Inline: "  Teh  "},
+		{Ref: "B3", Value: "1"},
+		{Ref: "C3", Value: "5000"},
+		{Ref: "D3", Type: "inlineStr"},
+	}, sheet.Rows[2].Cells)
+}
+
+func Test_xlsxColumn(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for index, name := range tests {
+		assert.Equal(t, name, xlsxColumn(index))
+	}
+}
+
+func Test_xlsxSheetName(t *testing.T) {
+	assert.Equal(t, "Report", xlsxSheetName("[]:*?/\\"))
+	assert.Equal(t, "Orders &amp; Refunds", xlsxSheetName("Orders & Refunds"))
+	assert.Equal(t, "Monthly sales of every product ", xlsxSheetName("Monthly sales of every product in the store"))
+}
@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Document is an OpenAPI 3 document. Schemas of named structs are generated
+// from their json tags into the components and referenced from the paths.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is an http scheme, or an apiKey one sent in the header,
+// query or cookie of In by the Name.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of a path by lowercase HTTP method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas:         map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{},
+		},
+	}
+}
+
+// AddOperation describes the method of a path, the path is written the
+// OpenAPI way, e.g. /products/{productId}.
+func (d *Document) AddOperation(method string, path string, operation *Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	(*item)[strings.ToLower(method)] = operation
+}
+
+// HasOperation reports whether the method of a path is described.
+func (d *Document) HasOperation(method string, path string) bool {
+	item, ok := d.Paths[path]
+	if !ok {
+		return false
+	}
+
+	_, ok = (*item)[strings.ToLower(method)]
+	return ok
+}
+
+// JSON is the media type of a body of the given schema.
+func JSON(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema}}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaOf generates the schema of the type of v, a named struct is added
+// to the components once and referenced.
+func (d *Document) SchemaOf(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := d.schemaOf(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+
+		if _, ok := d.Components.Schemas[t.Name()]; !ok {
+			// registered before its fields so a recursive type refers to itself
+			d.Components.Schemas[t.Name()] = &Schema{}
+			*d.Components.Schemas[t.Name()] = *d.structSchema(t)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema describes the fields a struct is encoded with, the ones the
+// validator requires are required.
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := d.structSchema(field.Type)
+			for property, propertySchema := range embedded.Properties {
+				schema.Properties[property] = propertySchema
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = d.fieldSchema(field)
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// QueryParameters describes the fields of a struct bound from the query
+// string by their query tags.
+func (d *Document) QueryParameters(v interface{}) []*Parameter {
+	t := reflect.TypeOf(v)
+	parameters := []*Parameter{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("query")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		parameters = append(parameters, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: isRequired(field),
+			Schema:   d.fieldSchema(field),
+		})
+	}
+
+	return parameters
+}
+
+// fieldSchema is the schema of the type of a field, narrowed to the values
+// a oneof rule of the validator allows.
+func (d *Document) fieldSchema(field reflect.StructField) *Schema {
+	schema := d.schemaOf(field.Type)
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if values, ok := strings.CutPrefix(rule, "oneof="); ok && schema.Type == "string" {
+			schema.Enum = strings.Fields(values)
+		}
+	}
+
+	return schema
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package sse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Message is a server-sent event. A zero ID leaves the id field out, so the
+// message does not move the last event ID of the client.
+type Message struct {
+	ID    uint64
+	Event string
+	Data  []byte
+}
+
+func NewMessage(event string, data interface{}) (Message, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{Event: event, Data: payload}, nil
+}
+
+// WriteTo writes the message in the text/event-stream format.
+func (m Message) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	if m.ID > 0 {
+		fmt.Fprintf(&buf, "id: %d\n", m.ID)
+	}
+
+	if m.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", m.Event)
+	}
+
+	for _, line := range bytes.Split(m.Data, []byte("\n")) {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+
+	buf.WriteString("\n")
+	return buf.WriteTo(w)
+}
+
+// Client receives the messages published to a hub on C, which is closed
+// once the hub drops the client.
+type Client struct {
+	C     <-chan Message
+	queue chan Message
+}
+
+// Hub fans the published messages out to its clients. A client falling more
+// than its buffer behind is dropped instead of holding the others up, it is
+// expected to reconnect and resume from the history.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	history []Message
+	size    int
+	buffer  int
+	lastID  uint64
+	closed  bool
+}
+
+// NewHub keeps the last history messages for the reconnecting clients. The
+// IDs start from the current time, so a client which saw the messages of an
+// earlier process never resumes in the middle of the new ones.
+func NewHub(history, buffer int) *Hub {
+	return &Hub{
+		clients: map[*Client]struct{}{},
+		size:    history,
+		buffer:  buffer,
+		lastID:  uint64(time.Now().UnixNano()),
+	}
+}
+
+// Subscribe adds a client, along with the messages published after lastID
+// which are still in the history. A zero lastID misses nothing.
+func (h *Hub) Subscribe(lastID uint64) (*Client, []Message) {
+	queue := make(chan Message, h.buffer)
+	client := &Client{C: queue, queue: queue}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(queue)
+		return client, nil
+	}
+
+	h.clients[client] = struct{}{}
+	missed := []Message{}
+	if lastID > 0 {
+		for _, message := range h.history {
+			if message.ID > lastID {
+				missed = append(missed, message)
+			}
+		}
+	}
+
+	return client, missed
+}
+
+func (h *Hub) Unsubscribe(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(client)
+}
+
+// Publish numbers the message and sends it to every client.
+func (h *Hub) Publish(message Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	h.lastID++
+	message.ID = h.lastID
+	h.history = append(h.history, message)
+	if len(h.history) > h.size {
+		h.history = h.history[len(h.history)-h.size:]
+	}
+
+	for client := range h.clients {
+		select {
+		case client.queue <- message:
+		default:
+			h.drop(client)
+		}
+	}
+}
+
+// Clients counts the connected clients.
+func (h *Hub) Clients() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Close drops every client and refuses the new ones.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	h.closed = true
+	for client := range h.clients {
+		h.drop(client)
+	}
+}
+
+func (h *Hub) drop(client *Client) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+
+	delete(h.clients, client)
+	close(client.queue)
+}
@@ -0,0 +1,118 @@
+package sse
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Message_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		message Message
+		want    string
+	}{
+		{
+			name:    "numbered event",
+			message: Message{ID: 7, Event: "order", Data: []byte(`{"id":1}`)},
+			want:    "id: 7\nevent: order\ndata: {\"id\":1}\n\n",
+		},
+		{
+			name:    "without ID",
+			message: Message{Event: "kpis", Data: []byte(`{}`)},
+			want:    "event: kpis\ndata: {}\n\n",
+		},
+		{
+			name:    "multiline data",
+			message: Message{Data: []byte("a\nb")},
+			want:    "data: a\ndata: b\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := test.message.WriteTo(&buf)
+			assert.Nil(t, err)
+			assert.Equal(t, test.want, buf.String())
+		})
+	}
+}
+
+func Test_Hub_Subscribe(t *testing.T) {
+	hub := NewHub(10, 10)
+	client, missed := hub.Subscribe(0)
+	assert.Empty(t, missed)
+	assert.Equal(t, 1, hub.Clients())
+
+	hub.Publish(Message{Event: "order", Data: []byte(`1`)})
+	message := <-client.C
+	assert.Equal(t, "order", message.Event)
+	assert.NotZero(t, message.ID)
+
+	hub.Unsubscribe(client)
+	assert.Equal(t, 0, hub.Clients())
+	_, ok := <-client.C
+	assert.False(t, ok)
+
+	// unsubscribing twice does not close the channel twice
+	hub.Unsubscribe(client)
+}
+
+func Test_Hub_Subscribe_ReplaysMissedMessages(t *testing.T) {
+	hub := NewHub(2, 10)
+	first, _ := hub.Subscribe(0)
+	hub.Publish(Message{Data: []byte(`1`)})
+	hub.Publish(Message{Data: []byte(`2`)})
+	hub.Publish(Message{Data: []byte(`3`)})
+	seen := <-first.C
+
+	// the history keeps the last two messages only
+	_, missed := hub.Subscribe(seen.ID)
+	if assert.Len(t, missed, 2) {
+		assert.Equal(t, []byte(`2`), missed[0].Data)
+		assert.Equal(t, []byte(`3`), missed[1].Data)
+	}
+
+	_, missed = hub.Subscribe(missed[1].ID)
+	assert.Empty(t, missed)
+}
+
+func Test_Hub_Publish_DropsSlowClient(t *testing.T) {
+	hub := NewHub(10, 1)
+	slow, _ := hub.Subscribe(0)
+	fast, _ := hub.Subscribe(0)
+
+	hub.Publish(Message{Data: []byte(`1`)})
+	<-fast.C
+	hub.Publish(Message{Data: []byte(`2`)})
+
+	assert.Equal(t, 1, hub.Clients())
+	message, ok := <-slow.C
+	assert.True(t, ok)
+	assert.Equal(t, []byte(`1`), message.Data)
+	_, ok = <-slow.C
+	assert.False(t, ok)
+
+	message = <-fast.C
+	assert.Equal(t, []byte(`2`), message.Data)
+}
+
+func Test_Hub_Close(t *testing.T) {
+	hub := NewHub(10, 10)
+	client, _ := hub.Subscribe(0)
+	hub.Close()
+
+	_, ok := <-client.C
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Clients())
+
+	late, missed := hub.Subscribe(0)
+	assert.Nil(t, missed)
+	_, ok = <-late.C
+	assert.False(t, ok)
+
+	// publishing after closing is ignored
+	hub.Publish(Message{Data: []byte(`1`)})
+}
@@ -0,0 +1,24 @@
+package strings
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// codeAlphabet leaves out characters that are easy to misread (0/O, 1/I/L).
+const codeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+func RandomCode(length int) (string, error) {
+	max := big.NewInt(int64(len(codeAlphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+
+		code[i] = codeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
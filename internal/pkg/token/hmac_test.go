@@ -0,0 +1,66 @@
+package token
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HMACTokenizer_Sign(t *testing.T) {
+	tokenizer := NewHMACTokenizer("secret")
+	expiresAt := time.Unix(1700000000, 0)
+
+	token, err := tokenizer.Sign(42, expiresAt)
+	assert.Nil(t, err)
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	assert.True(t, ok)
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	assert.Nil(t, err)
+	assert.Equal(t, "42.1700000000", string(payload))
+	// echo -n "NDIuMTcwMDAwMDAwMA" | openssl dgst -sha256 -hmac secret -binary | basenc --base64url | tr -d =
+	assert.Equal(t, "YLrB8guyjTb6IwKeyKQ3j9SqVYcMsg8wthwUNtCSq6E", signature)
+}
+
+func Test_HMACTokenizer_Verify(t *testing.T) {
+	tokenizer := NewHMACTokenizer("secret")
+	valid, _ := tokenizer.Sign(42, time.Now().Add(time.Hour))
+	expired, _ := tokenizer.Sign(42, time.Now().Add(-time.Second))
+	otherSecret, _ := NewHMACTokenizer("other").Sign(42, time.Now().Add(time.Hour))
+	encoded, signature, _ := strings.Cut(valid, ".")
+	tampered := base64.RawURLEncoding.EncodeToString([]byte("43.9999999999")) + "." + signature
+
+	sign := func(payload string) string {
+		encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+		return encoded + "." + tokenizer.signature(encoded)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		subject int64
+		err     error
+	}{
+		{name: "valid", token: valid, subject: 42},
+		{name: "expired", token: expired, err: ErrExpiredToken},
+		{name: "signed by another secret", token: otherSecret, err: ErrInvalidToken},
+		{name: "tampered payload", token: tampered, err: ErrInvalidToken},
+		{name: "without signature", token: encoded, err: ErrMalformedToken},
+		{name: "empty", token: "", err: ErrMalformedToken},
+		{name: "payload not base64", token: "!!!." + tokenizer.signature("!!!"), err: ErrMalformedToken},
+		{name: "payload without expiry", token: sign("42"), err: ErrMalformedToken},
+		{name: "subject not a number", token: sign("abc.9999999999"), err: ErrMalformedToken},
+		{name: "expiry not a number", token: sign("42.abc"), err: ErrMalformedToken},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subject, err := tokenizer.Verify(test.token)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.subject, subject)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// maxResponseBody caps how much of a response is read back, receivers are
+// only expected to acknowledge a delivery.
+const maxResponseBody = 4096
+
+// HTTPSender posts webhook deliveries, any answer of the receiver is a
+// response and only failing to get one is an error. Receivers at internal
+// addresses are refused.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return newHTTPSender(timeout, dialPublicOnly)
+}
+
+// newHTTPSender checks the addresses dialed with control, the deliveries do
+// not go through a proxy so it checks the receivers themselves.
+func newHTTPSender(timeout time.Duration, control func(network string, address string, c syscall.RawConn) error) *HTTPSender {
+	dialer := &net.Dialer{Timeout: timeout, Control: control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &HTTPSender{client: &http.Client{Timeout: timeout, Transport: transport}}
+}
+
+func (hs HTTPSender) Send(ctx context.Context, request entity.WebhookRequest) (*entity.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range request.Headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := hs.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.WebhookResponse{StatusCode: res.StatusCode, Body: responseText(body)}, nil
+}
+
+// responseText keeps a response valid UTF-8 for the delivery log, dropping
+// the last character when the size cap cut it in two.
+func responseText(body []byte) string {
+	if len(body) == maxResponseBody {
+		start := len(body) - 1
+		for start > 0 && start > len(body)-utf8.UTFMax && !utf8.RuneStart(body[start]) {
+			start--
+		}
+
+		if !utf8.FullRune(body[start:]) {
+			body = body[:start]
+		}
+	}
+
+	return strings.ToValidUTF8(string(body), "\uFFFD")
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HTTPSender_Send(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{
+			name:   "short body",
+			status: http.StatusOK,
+			body:   "ok",
+			want:   "ok",
+		},
+		{
+			name:   "body over the limit",
+			status: http.StatusInternalServerError,
+			body:   strings.Repeat("a", maxResponseBody+10),
+			want:   strings.Repeat("a", maxResponseBody),
+		},
+		{
+			name:   "character cut by the limit",
+			status: http.StatusOK,
+			body:   strings.Repeat("a", maxResponseBody-1) + "é" + "tail",
+			want:   strings.Repeat("a", maxResponseBody-1),
+		},
+		{
+			name:   "invalid UTF-8",
+			status: http.StatusOK,
+			body:   "ok\xff",
+			want:   "ok�",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var header http.Header
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			sender := newHTTPSender(time.Second, nil)
+			response, err := sender.Send(context.TODO(), entity.WebhookRequest{
+				URL:     server.URL,
+				Headers: map[string]string{"X-Kaseer-Signature": "sha256=abc"},
+				Body:    []byte(`{"id":7}`),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, test.status, response.StatusCode)
+			assert.Equal(t, test.want, response.Body)
+			assert.True(t, utf8.ValidString(response.Body))
+			assert.Equal(t, "sha256=abc", header.Get("X-Kaseer-Signature"))
+			assert.Equal(t, `{"id":7}`, string(body))
+		})
+	}
+}
+
+func Test_HTTPSender_Send_Failed_WhenReceiverIsDown(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	sender := newHTTPSender(time.Second, nil)
+	response, err := sender.Send(context.TODO(), entity.WebhookRequest{URL: server.URL})
+	assert.NotNil(t, err)
+	assert.Nil(t, response)
+}
+
+func Test_HTTPSender_Send_Failed_WhenReceiverIsInternal(t *testing.T) {
+	received := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second)
+	response, err := sender.Send(context.TODO(), entity.WebhookRequest{URL: server.URL})
+	assert.ErrorIs(t, err, ErrInternalTarget)
+	assert.Nil(t, response)
+	assert.False(t, received)
+}
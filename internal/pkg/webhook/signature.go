@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign signs a delivery the way receivers verify it, the HMAC-SHA256 of the
+// timestamp and the body joined by a dot, keyed by the secret and sent as
+// "sha256=<hex>" in the X-Kaseer-Signature header.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Sign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		signature string
+	}{
+		{
+			name:      "JSON body",
+			secret:    "whsec_secret",
+			timestamp: "1700000000",
+			body:      `{"id":7}`,
+			signature: "sha256=85846f9836477bfbb9b5c4b0ea47f2743d4391fa63b36123382b0d49d2736dc1",
+		},
+		{
+			name:      "other timestamp",
+			secret:    "whsec_secret",
+			timestamp: "1700000001",
+			body:      `{"id":7}`,
+			signature: "sha256=ff571cf03f5c1de051b41352ab150284fd6fee1b0f2ff889f707e1f386a917d5",
+		},
+		{
+			name:      "other secret",
+			secret:    "whsec_other",
+			timestamp: "1700000000",
+			body:      `{"id":7}`,
+			signature: "sha256=ffed367d04392dc4deb88fec9ec75d9ab749966e33653664871b90fd39528f4e",
+		},
+		{
+			name:      "empty body",
+			secret:    "whsec_secret",
+			timestamp: "1700000000",
+			body:      "",
+			signature: "sha256=8033fa73e96f16a44d8e65059f6a54806772b875065f5b50bbf4f2cfe53359da",
+		},
+	}
+
+	format := regexp.MustCompile(`^sha256=[0-9a-f]{64}$`)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature := Sign(test.secret, test.timestamp, []byte(test.body))
+			assert.Regexp(t, format, signature)
+			assert.Equal(t, test.signature, signature)
+		})
+	}
+}
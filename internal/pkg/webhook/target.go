@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+)
+
+var ErrInternalTarget = errors.New("webhook target is an internal address")
+
+// IsPublicIP reports whether a webhook may be delivered to the address, the
+// loopback, link-local, private, unspecified and multicast ones reach the
+// store's own network instead of a receiver.
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified()
+}
+
+// CheckHost refuses the host of a webhook URL naming an internal address,
+// the names resolving to one are only known when a delivery dials them.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrInternalTarget
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return ErrInternalTarget
+	}
+
+	return nil
+}
+
+// dialPublicOnly refuses connecting to an internal address, whatever name
+// resolved to it or redirected to it.
+func dialPublicOnly(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return ErrInternalTarget
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckHost(t *testing.T) {
+	tests := []struct {
+		host string
+		err  error
+	}{
+		{host: "example.com"},
+		{host: "93.184.216.34"},
+		{host: "2606:2800:220:1:248:1893:25c8:1946"},
+		{host: "localhost", err: ErrInternalTarget},
+		{host: "LOCALHOST.", err: ErrInternalTarget},
+		{host: "api.localhost", err: ErrInternalTarget},
+		{host: "127.0.0.1", err: ErrInternalTarget},
+		{host: "::1", err: ErrInternalTarget},
+		{host: "0.0.0.0", err: ErrInternalTarget},
+		{host: "10.1.2.3", err: ErrInternalTarget},
+		{host: "172.16.0.1", err: ErrInternalTarget},
+		{host: "192.168.1.10", err: ErrInternalTarget},
+		{host: "169.254.169.254", err: ErrInternalTarget},
+		{host: "fe80::1", err: ErrInternalTarget},
+		{host: "fd00::1", err: ErrInternalTarget},
+		{host: "::ffff:127.0.0.1", err: ErrInternalTarget},
+	}
+
+	for _, test := range tests {
+		t.Run(test.host, func(t *testing.T) {
+			assert.Equal(t, test.err, CheckHost(test.host))
+		})
+	}
+}
+
+func Test_dialPublicOnly(t *testing.T) {
+	assert.Nil(t, dialPublicOnly("tcp4", "93.184.216.34:443", nil))
+	assert.Nil(t, dialPublicOnly("tcp6", net.JoinHostPort("2606:2800:220:1:248:1893:25c8:1946", "443"), nil))
+	assert.Equal(t, ErrInternalTarget, dialPublicOnly("tcp4", "10.0.0.5:80", nil))
+	assert.Equal(t, ErrInternalTarget, dialPublicOnly("tcp6", "[::1]:80", nil))
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
 )
@@ -13,32 +14,189 @@ type UnitOfWork interface {
 }
 
 type UserRepository interface {
+	GetAllUsers(ctx context.Context) ([]*entity.User, error)
 	GetUserByID(ctx context.Context, ID int64) (*entity.User, error)
+	GetUserByIDForUpdate(ctx context.Context, ID int64) (*entity.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetServiceAccounts(ctx context.Context) ([]*entity.User, error)
+	CreateServiceAccount(ctx context.Context, name string, email string) (*entity.User, error)
 	UpdateByID(ctx context.Context, ID int64, param entity.UpdateUserParam) (bool, error)
 	UpdatePasswordByID(ctx context.Context, ID int64, password string) (bool, error)
 }
 
 type ProductRepository interface {
 	GetAllProducts(ctx context.Context) ([]*entity.Product, error)
+	SearchProducts(ctx context.Context, query entity.ProductQuery) ([]*entity.Product, error)
+	CountProducts(ctx context.Context, query entity.ProductQuery) (int, error)
+	GetProductCategories(ctx context.Context) ([]string, error)
 	GetBestSellerProducts(ctx context.Context) ([]*entity.ProductSale, error)
+	GetProductPerformance(ctx context.Context, start time.Time, end time.Time) ([]*entity.ProductPerformance, error)
+	GetDeadStock(ctx context.Context, since time.Time) ([]*entity.DeadStockProduct, error)
+	GetDailySales(ctx context.Context, start time.Time, end time.Time) ([]*entity.DailyProductSales, error)
 	GetProductsByIDs(ctx context.Context, IDs ...int64) ([]*entity.Product, error)
 	GetProductByCode(ctx context.Context, code string) (*entity.Product, error)
 	GetProductByID(ctx context.Context, ID int64) (*entity.Product, error)
+	GetProductByIDForUpdate(ctx context.Context, ID int64) (*entity.Product, error)
 	Create(ctx context.Context, param entity.CreateProductParam) (*entity.Product, error)
 	UpdateByID(ctx context.Context, ID int64, param entity.UpdateProductParam) (bool, error)
 	DecrementProductByIDs(ctx context.Context, IDDecrementMap map[int64]int) error
+	DecrementStockByID(ctx context.Context, ID int64, quantity int) (bool, error)
+	SubtractStockByID(ctx context.Context, ID int64, quantity int) error
+	IncrementProductByIDs(ctx context.Context, IDIncrementMap map[int64]int) error
 	DeleteByID(ctx context.Context, ID int64) (bool, error)
+	GetNegativeStockSales(ctx context.Context) ([]*entity.NegativeStockSale, error)
+	CreateNegativeStockSales(ctx context.Context, orderID int64, params []*entity.CreateNegativeStockSaleParam) error
+	ReconcileNegativeStockSaleByID(ctx context.Context, ID int64, date time.Time) (bool, error)
 }
 
 type OrderRepository interface {
-	GetAllOrders(ctx context.Context) ([]*entity.Order, error)
-	GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIncome, error)
-	GetDailyOrderCount(ctx context.Context) (int, error)
+	GetOrders(ctx context.Context, query entity.OrderQuery) ([]*entity.Order, error)
+	CountOrders(ctx context.Context, query entity.OrderQuery) (int, error)
 	GetTotalOrderCount(ctx context.Context) (int, error)
-	GetLastDayIncome(ctx context.Context) (int, error)
-	GetLastMonthIncome(ctx context.Context) (int, error)
 	GetOrderItemsByID(ctx context.Context, ID int64) ([]*entity.OrderItem, error)
+	GetPaymentsByOrderID(ctx context.Context, orderID int64) ([]*entity.OrderPayment, error)
+	GetOrdersByCustomerID(ctx context.Context, customerID int64) ([]*entity.Order, error)
+	GetCustomerLifetimeValue(ctx context.Context, customerID int64) (*entity.CustomerLifetimeValue, error)
+	GetOrderHeatmap(ctx context.Context, start time.Time, end time.Time) ([]*entity.OrderHeatmapCell, error)
+	GetOrderByID(ctx context.Context, ID int64) (*entity.Order, error)
+	GetOrderByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entity.Order, error)
+	GetOrderByIDForUpdate(ctx context.Context, ID int64) (*entity.Order, error)
+	GetOrderByIdempotencyKey(ctx context.Context, key string) (*entity.Order, error)
+	NextInvoiceSequence(ctx context.Context, period string) (int, error)
 	Create(ctx context.Context, param entity.CreateOrderParam) (*entity.Order, error)
 	CreateOrderItems(ctx context.Context, orderId int64, items []*entity.CreateOrderItemParam) error
+	CreatePayments(ctx context.Context, orderID int64, params []*entity.CreateOrderPaymentParam) error
+	UpdateStatusByID(ctx context.Context, ID int64, status string) error
+}
+
+type VoucherRepository interface {
+	GetAllVouchers(ctx context.Context) ([]*entity.Voucher, error)
+	GetVoucherByID(ctx context.Context, ID int64) (*entity.Voucher, error)
+	GetVouchersByCodes(ctx context.Context, codes ...string) ([]*entity.Voucher, error)
+	GetVoucherByCodeForUpdate(ctx context.Context, code string) (*entity.Voucher, error)
+	GetRedemptionsByVoucherID(ctx context.Context, voucherID int64) ([]*entity.VoucherRedemption, error)
+	CountRedemptionsByCustomerID(ctx context.Context, voucherID int64, customerID int64) (int, error)
+	CreateVouchers(ctx context.Context, params []*entity.CreateVoucherParam) error
+	CreateRedemption(ctx context.Context, param entity.CreateVoucherRedemptionParam) error
+	IncrementUsageByID(ctx context.Context, ID int64) (bool, error)
+}
+
+type CustomerRepository interface {
+	GetAllCustomers(ctx context.Context) ([]*entity.Customer, error)
+	SearchCustomers(ctx context.Context, keyword string, limit int) ([]*entity.Customer, error)
+	GetCustomerByID(ctx context.Context, ID int64) (*entity.Customer, error)
+	GetCustomerByPhone(ctx context.Context, phone string) (*entity.Customer, error)
+	Create(ctx context.Context, param entity.CreateCustomerParam) (*entity.Customer, error)
+	UpdateGroupByID(ctx context.Context, ID int64, customerGroupID *int64) (bool, error)
+	GetAllGroups(ctx context.Context) ([]*entity.CustomerGroup, error)
+	GetGroupByID(ctx context.Context, ID int64) (*entity.CustomerGroup, error)
+	CreateGroup(ctx context.Context, param entity.CreateCustomerGroupParam) (*entity.CustomerGroup, error)
+}
+
+type LoyaltyRepository interface {
+	GetSetting(ctx context.Context) (*entity.LoyaltySetting, error)
+	UpdateSetting(ctx context.Context, param entity.UpdateLoyaltySettingParam) error
+	GetAllMultipliers(ctx context.Context) ([]*entity.LoyaltyMultiplier, error)
+	SaveMultiplier(ctx context.Context, param entity.SaveLoyaltyMultiplierParam) error
+	DeleteMultiplierByID(ctx context.Context, ID int64) (bool, error)
+	GetEntriesByCustomerID(ctx context.Context, customerID int64) ([]*entity.LoyaltyEntry, error)
+	GetBalanceByCustomerID(ctx context.Context, customerID int64) (int, error)
+	GetBalanceByCustomerIDForUpdate(ctx context.Context, customerID int64) (int, error)
+	GetOrderPoints(ctx context.Context, orderID int64) (*entity.LoyaltyOrderPoints, error)
+	CreateEntry(ctx context.Context, param entity.CreateLoyaltyEntryParam) error
+}
+
+type PrepaidCardRepository interface {
+	GetAllCards(ctx context.Context) ([]*entity.PrepaidCard, error)
+	GetCardByID(ctx context.Context, ID int64) (*entity.PrepaidCard, error)
+	GetCardByNumber(ctx context.Context, number string) (*entity.PrepaidCard, error)
+	GetCardByNumberForUpdate(ctx context.Context, number string) (*entity.PrepaidCard, error)
+	GetCardsByOrderID(ctx context.Context, orderID int64) ([]*entity.PrepaidCard, error)
+	GetTransactionsByCardID(ctx context.Context, cardID int64) ([]*entity.PrepaidTransaction, error)
+	GetOrderRedemptions(ctx context.Context, orderID int64) (map[int64]int, error)
+	Create(ctx context.Context, param entity.CreatePrepaidCardParam) (*entity.PrepaidCard, error)
+	CreateTransaction(ctx context.Context, param entity.CreatePrepaidTransactionParam) error
+	IncrementBalanceByID(ctx context.Context, ID int64, amount int) error
+	DecrementBalanceByID(ctx context.Context, ID int64, amount int) (bool, error)
+}
+
+type PriceListRepository interface {
+	GetAllPriceLists(ctx context.Context) ([]*entity.PriceList, error)
+	GetPriceListByID(ctx context.Context, ID int64) (*entity.PriceList, error)
+	Create(ctx context.Context, param entity.CreatePriceListParam) (*entity.PriceList, error)
+	DeleteByID(ctx context.Context, ID int64) (bool, error)
+	GetItemsByPriceListID(ctx context.Context, priceListID int64) ([]*entity.PriceListItem, error)
+	GetActiveItemsByGroupID(ctx context.Context, customerGroupID int64, date time.Time, productIDs ...int64) ([]*entity.PriceListItem, error)
+	SaveItems(ctx context.Context, priceListID int64, params []*entity.SavePriceListItemParam) error
+	DeleteItemByID(ctx context.Context, priceListID int64, ID int64) (bool, error)
+}
+
+type HeldCartRepository interface {
+	GetHeldCartsByRegister(ctx context.Context, register string, now time.Time) ([]*entity.HeldCart, error)
+	GetHeldCartByID(ctx context.Context, ID int64) (*entity.HeldCart, error)
+	Create(ctx context.Context, param entity.CreateHeldCartParam) (*entity.HeldCart, error)
+	DeleteByID(ctx context.Context, ID int64) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type StoreSettingRepository interface {
+	GetSetting(ctx context.Context) (*entity.StoreSetting, error)
+	UpdateSetting(ctx context.Context, param entity.UpdateStoreSettingParam) error
+}
+
+type ReportRepository interface {
+	GetSalesByPeriods(ctx context.Context, periods []*entity.SalesPeriod) ([]*entity.SalesPeriod, error)
+	GetStockValues(ctx context.Context, query entity.StockValuationQuery) ([]*entity.StockValue, error)
+	GetStockValuationSummary(ctx context.Context, category string) (*entity.StockValuationSummary, error)
+}
+
+type MarketBasketRepository interface {
+	CountOrders(ctx context.Context, start time.Time, end time.Time) (int, error)
+	GetProductOrderCounts(ctx context.Context, start time.Time, end time.Time) (map[int64]int, error)
+	GetPairOrderCounts(ctx context.Context, start time.Time, end time.Time, minOrders int) ([]*entity.ProductPairCount, error)
+	ReplaceAssociations(ctx context.Context, associations []*entity.ProductAssociation) error
+	GetAssociations(ctx context.Context, limit int) ([]*entity.ProductAssociation, error)
+	GetSuggestionsByProductIDs(ctx context.Context, productIDs []int64) ([]*entity.ProductSuggestion, error)
+	CreateRun(ctx context.Context, param entity.CreateMarketBasketRunParam) (*entity.MarketBasketRun, error)
+	GetLatestRun(ctx context.Context) (*entity.MarketBasketRun, error)
+}
+
+type PurchaseOrderRepository interface {
+	GetPurchaseOrders(ctx context.Context) ([]*entity.PurchaseOrder, error)
+	GetPurchaseOrderByID(ctx context.Context, ID int64) (*entity.PurchaseOrder, error)
+	GetItemsByPurchaseOrderID(ctx context.Context, purchaseOrderID int64) ([]*entity.PurchaseOrderItem, error)
+	GetOnOrderQuantities(ctx context.Context) (map[int64]int, error)
+	Create(ctx context.Context, param entity.CreatePurchaseOrderParam) (*entity.PurchaseOrder, error)
+	UpdateStatusByID(ctx context.Context, ID int64, from string, to string) (bool, error)
+}
+
+type APITokenRepository interface {
+	GetAPITokensByUserID(ctx context.Context, userID int64) ([]*entity.APIToken, error)
+	GetAPITokenByID(ctx context.Context, ID int64) (*entity.APIToken, error)
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (*entity.APIToken, error)
+	Create(ctx context.Context, param entity.SaveAPITokenParam) (*entity.APIToken, error)
+	RevokeByID(ctx context.Context, userID int64, ID int64) (bool, error)
+	UpdateLastUsedAtByID(ctx context.Context, ID int64, lastUsedAt time.Time) error
+}
+
+type WebhookRepository interface {
+	GetWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	GetWebhookByID(ctx context.Context, ID int64) (*entity.Webhook, error)
+	Create(ctx context.Context, param entity.CreateWebhookParam) (*entity.Webhook, error)
+	UpdateByID(ctx context.Context, ID int64, param entity.SaveWebhookParam) error
+	DeleteByID(ctx context.Context, ID int64) error
+	CreateEvent(ctx context.Context, param entity.CreateWebhookEventParam) error
+	GetUndispatchedEventsForUpdate(ctx context.Context, limit int) ([]*entity.WebhookEvent, error)
+	MarkEventsDispatched(ctx context.Context, IDs []int64, at time.Time) error
+	CreateDeliveries(ctx context.Context, params []*entity.CreateWebhookDeliveryParam) error
+	GetDueDeliveries(ctx context.Context, at time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	GetDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+	GetDeliveryByID(ctx context.Context, ID int64) (*entity.WebhookDelivery, error)
+	UpdateDeliveryByID(ctx context.Context, ID int64, param entity.UpdateWebhookDeliveryParam) error
+}
+
+type AuditLogRepository interface {
+	GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) ([]*entity.AuditLog, error)
+	CountAuditLogs(ctx context.Context, query entity.AuditLogQuery) (int, error)
+	Create(ctx context.Context, param entity.CreateAuditLogParam) error
 }
@@ -0,0 +1,194 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type APITokenRepository struct {
+	DB *sql.DB
+}
+
+func NewAPITokenRepository(DB *sql.DB) *APITokenRepository {
+	return &APITokenRepository{DB: DB}
+}
+
+const apiTokenColumns = "id, user_id, name, prefix, token_hash, scopes, last_used_at, expires_at, revoked_at, created_at"
+
+func scanAPIToken(scanner interface{ Scan(...interface{}) error }) (*entity.APIToken, error) {
+	var apiToken entity.APIToken
+	var scopes string
+	err := scanner.Scan(
+		&apiToken.ID,
+		&apiToken.UserID,
+		&apiToken.Name,
+		&apiToken.Prefix,
+		&apiToken.TokenHash,
+		&scopes,
+		&apiToken.LastUsedAt,
+		&apiToken.ExpiresAt,
+		&apiToken.RevokedAt,
+		&apiToken.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken.Scopes = []string{}
+	if scopes != "" {
+		apiToken.Scopes = strings.Split(scopes, ",")
+	}
+
+	return &apiToken, nil
+}
+
+// GetAPITokensByUserID returns the API tokens of a user, the latest first.
+func (repo APITokenRepository) GetAPITokensByUserID(ctx context.Context, userID int64) ([]*entity.APIToken, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT " + apiTokenColumns + " FROM api_tokens WHERE user_id = ? ORDER BY id DESC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, userID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, userID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiTokens := []*entity.APIToken{}
+	for rows.Next() {
+		apiToken, err := scanAPIToken(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		apiTokens = append(apiTokens, apiToken)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return apiTokens, nil
+}
+
+func (repo APITokenRepository) GetAPITokenByID(ctx context.Context, ID int64) (*entity.APIToken, error) {
+	var row *sql.Row
+	query := "SELECT " + apiTokenColumns + " FROM api_tokens WHERE id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	apiToken, err := scanAPIToken(row)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrNotFound{Message: "API token not found", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return apiToken, nil
+}
+
+func (repo APITokenRepository) GetAPITokenByHash(ctx context.Context, tokenHash string) (*entity.APIToken, error) {
+	var row *sql.Row
+	query := "SELECT " + apiTokenColumns + " FROM api_tokens WHERE token_hash = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, tokenHash)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, tokenHash)
+	}
+
+	apiToken, err := scanAPIToken(row)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrNotFound{Message: "API token not found", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return apiToken, nil
+}
+
+func (repo APITokenRepository) Create(ctx context.Context, param entity.SaveAPITokenParam) (*entity.APIToken, error) {
+	query := "INSERT INTO api_tokens(user_id, name, prefix, token_hash, scopes, expires_at) VALUES(?, ?, ?, ?, ?, ?)"
+	scopes := strings.Join(param.Scopes, ",")
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.UserID, param.Name, param.Prefix, param.TokenHash, scopes, param.ExpiresAt)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.UserID, param.Name, param.Prefix, param.TokenHash, scopes, param.ExpiresAt)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetAPITokenByID(ctx, ID)
+}
+
+// RevokeByID revokes an API token of a user, it reports false when the user
+// has no such token or it was already revoked.
+func (repo APITokenRepository) RevokeByID(ctx context.Context, userID int64, ID int64) (bool, error) {
+	query := "UPDATE api_tokens SET revoked_at = CURRENT_TIMESTAMP() WHERE id = ? AND user_id = ? AND revoked_at IS NULL"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, ID, userID)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, ID, userID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (repo APITokenRepository) UpdateLastUsedAtByID(ctx context.Context, ID int64, lastUsedAt time.Time) error {
+	query := "UPDATE api_tokens SET last_used_at = ? WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, lastUsedAt, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, lastUsedAt, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
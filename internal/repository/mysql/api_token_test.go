@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiTokenRowColumns = []string{"id", "user_id", "name", "prefix", "token_hash", "scopes", "last_used_at", "expires_at", "revoked_at", "created_at"}
+
+func Test_GetAPITokenByHash_Failed_WhenNoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_tokens WHERE token_hash = ?")).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(apiTokenRowColumns))
+
+	apiTokenRepository := NewAPITokenRepository(db)
+	apiToken, err := apiTokenRepository.GetAPITokenByHash(context.TODO(), "hash")
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, apiToken)
+}
+
+func Test_GetAPITokenByHash_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_tokens WHERE token_hash = ?")).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(apiTokenRowColumns).
+			AddRow(1, 2, "Accounting", "kst_a1b2c3d4", "hash", "orders:read,reports:read", nil, nil, nil, now))
+
+	apiTokenRepository := NewAPITokenRepository(db)
+	apiToken, err := apiTokenRepository.GetAPITokenByHash(context.TODO(), "hash")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), apiToken.UserID)
+	assert.Equal(t, []string{entity.APIScopeOrdersRead, entity.APIScopeReportsRead}, apiToken.Scopes)
+	assert.Nil(t, apiToken.LastUsedAt)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateAPIToken_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	param := entity.SaveAPITokenParam{
+		UserID:    2,
+		Name:      "Accounting",
+		Prefix:    "kst_a1b2c3d4",
+		TokenHash: "hash",
+		Scopes:    []string{entity.APIScopeOrdersRead, entity.APIScopeReportsRead},
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_tokens(user_id, name, prefix, token_hash, scopes, expires_at)")).
+		WithArgs(param.UserID, param.Name, param.Prefix, param.TokenHash, "orders:read,reports:read", param.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_tokens WHERE id = ?")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(apiTokenRowColumns).
+			AddRow(1, 2, "Accounting", "kst_a1b2c3d4", "hash", "orders:read,reports:read", nil, nil, nil, time.Now()))
+
+	apiTokenRepository := NewAPITokenRepository(db)
+	apiToken, err := apiTokenRepository.Create(context.TODO(), param)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), apiToken.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_RevokeAPITokenByID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE api_tokens SET revoked_at = CURRENT_TIMESTAMP() WHERE id = ? AND user_id = ? AND revoked_at IS NULL")).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	apiTokenRepository := NewAPITokenRepository(db)
+	revoked, err := apiTokenRepository.RevokeByID(context.TODO(), 2, 1)
+	assert.Nil(t, err)
+	assert.True(t, revoked)
+}
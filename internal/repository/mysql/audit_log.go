@@ -0,0 +1,195 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// AuditLogRepository only appends to the audit log, the table refuses
+// updates and deletes as well.
+type AuditLogRepository struct {
+	DB *sql.DB
+}
+
+func NewAuditLogRepository(DB *sql.DB) *AuditLogRepository {
+	return &AuditLogRepository{DB: DB}
+}
+
+func auditLogQueryConditions(query entity.AuditLogQuery) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	if query.Keyword != "" {
+		keyword := "%" + likeEscaper.Replace(query.Keyword) + "%"
+		conditions = append(conditions, "(actor_name LIKE ? OR ip_address LIKE ? OR changes LIKE ?)")
+		args = append(args, keyword, keyword, keyword)
+	}
+
+	if query.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, query.Action)
+	}
+
+	if query.EntityType != "" {
+		conditions = append(conditions, "entity_type = ?")
+		args = append(args, query.EntityType)
+	}
+
+	if query.EntityID != nil {
+		conditions = append(conditions, "entity_id = ?")
+		args = append(args, *query.EntityID)
+	}
+
+	if query.ActorID != nil {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, *query.ActorID)
+	}
+
+	if query.StartDate != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *query.StartDate)
+	}
+
+	if query.EndDate != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *query.EndDate)
+	}
+
+	if query.Cursor > 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, query.Cursor)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (repo AuditLogRepository) GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) ([]*entity.AuditLog, error) {
+	where, args := auditLogQueryConditions(query)
+	statement := fmt.Sprintf(`
+		SELECT id, actor_id, actor_name, action, entity_type, entity_id, changes, ip_address, user_agent, created_at
+			FROM audit_logs
+			%s
+			ORDER BY id DESC
+			LIMIT ?`, where)
+	args = append(args, query.Limit)
+	if query.Cursor == 0 {
+		statement += " OFFSET ?"
+		args = append(args, query.Offset)
+	}
+
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(statement, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, statement, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	auditLogs := []*entity.AuditLog{}
+	for rows.Next() {
+		var auditLog entity.AuditLog
+		var changes string
+		err := rows.Scan(
+			&auditLog.ID,
+			&auditLog.ActorID,
+			&auditLog.ActorName,
+			&auditLog.Action,
+			&auditLog.EntityType,
+			&auditLog.EntityID,
+			&changes,
+			&auditLog.IPAddress,
+			&auditLog.UserAgent,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		// numbers are kept as written, a price must not turn into 1.5e+06
+		decoder := json.NewDecoder(strings.NewReader(changes))
+		decoder.UseNumber()
+		if err := decoder.Decode(&auditLog.Changes); err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		auditLogs = append(auditLogs, &auditLog)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
+
+func (repo AuditLogRepository) CountAuditLogs(ctx context.Context, query entity.AuditLogQuery) (int, error) {
+	query.Cursor = 0
+	where, args := auditLogQueryConditions(query)
+	statement := fmt.Sprintf("SELECT COUNT(*) FROM audit_logs %s", where)
+	var row *sql.Row
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(statement, args...)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, statement, args...)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Create joins the transaction of the context if any, so the log of a
+// change is kept only when the change is.
+func (repo AuditLogRepository) Create(ctx context.Context, param entity.CreateAuditLogParam) error {
+	changes, err := json.Marshal(param.Changes)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_logs(actor_id, actor_name, action, entity_type, entity_id, changes, ip_address, user_agent)
+			VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+	args := []interface{}{
+		param.ActorID,
+		param.ActorName,
+		param.Action,
+		param.EntityType,
+		param.EntityID,
+		string(changes),
+		param.IPAddress,
+		param.UserAgent,
+	}
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, args...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
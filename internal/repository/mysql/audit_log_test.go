@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var auditLogRowColumns = []string{"id", "actor_id", "actor_name", "action", "entity_type", "entity_id", "changes", "ip_address", "user_agent", "created_at"}
+
+func Test_CreateAuditLog_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var actorID int64 = 2
+	param := entity.CreateAuditLogParam{
+		ActorID:    &actorID,
+		ActorName:  "Jane",
+		Action:     entity.AuditActionProductUpdated,
+		EntityType: entity.AuditEntityProduct,
+		EntityID:   5,
+		Changes:    map[string]entity.AuditChange{"price": {Before: 1000, After: 1200}},
+		IPAddress:  "10.0.0.7",
+		UserAgent:  "Mozilla/5.0",
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_logs(actor_id, actor_name, action, entity_type, entity_id, changes, ip_address, user_agent)")).
+		WithArgs(&actorID, "Jane", param.Action, param.EntityType, int64(5), `{"price":{"before":1000,"after":1200}}`, "10.0.0.7", "Mozilla/5.0").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	auditLogRepository := NewAuditLogRepository(db)
+	err = auditLogRepository.Create(context.TODO(), param)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetAuditLogs_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM audit_logs WHERE (actor_name LIKE ? OR ip_address LIKE ? OR changes LIKE ?) AND entity_type = ? ORDER BY id DESC LIMIT ? OFFSET ?")).
+		WithArgs("%tea%", "%tea%", "%tea%", entity.AuditEntityProduct, 20, 0).
+		WillReturnRows(sqlmock.NewRows(auditLogRowColumns).
+			AddRow(1, 2, "Jane", entity.AuditActionProductUpdated, entity.AuditEntityProduct, 5,
+				`{"name":{"before":"tea","after":"green tea"},"price":{"before":1500000,"after":1750000}}`,
+				"10.0.0.7", "Mozilla/5.0", time.Now()))
+
+	auditLogRepository := NewAuditLogRepository(db)
+	query := entity.AuditLogQuery{Keyword: "tea", EntityType: entity.AuditEntityProduct, Limit: 20}
+	auditLogs, err := auditLogRepository.GetAuditLogs(context.TODO(), query)
+	assert.Nil(t, err)
+	if assert.Len(t, auditLogs, 1) {
+		assert.Equal(t, "green tea", auditLogs[0].Changes["name"].After)
+		assert.Equal(t, json.Number("1500000"), auditLogs[0].Changes["price"].Before)
+		assert.Equal(t, "name: tea -> green tea; price: 1500000 -> 1750000", auditLogs[0].ChangeSummary())
+	}
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
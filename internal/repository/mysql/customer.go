@@ -0,0 +1,309 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type CustomerRepository struct {
+	DB *sql.DB
+}
+
+func NewCustomerRepository(DB *sql.DB) *CustomerRepository {
+	return &CustomerRepository{DB: DB}
+}
+
+func (repo CustomerRepository) GetAllCustomers(ctx context.Context) ([]*entity.Customer, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT id, customer_group_id, name, phone, email, notes, created_at, updated_at FROM customers ORDER BY name ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := []*entity.Customer{}
+	for rows.Next() {
+		var customer entity.Customer
+		var err = rows.Scan(
+			&customer.ID,
+			&customer.CustomerGroupID,
+			&customer.Name,
+			&customer.Phone,
+			&customer.Email,
+			&customer.Notes,
+			&customer.CreatedAt,
+			&customer.UpdatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		customers = append(customers, &customer)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+func (repo CustomerRepository) SearchCustomers(ctx context.Context, keyword string, limit int) ([]*entity.Customer, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT id, customer_group_id, name, phone, email, notes, created_at, updated_at
+			FROM customers
+			WHERE name LIKE ? OR phone LIKE ? OR email LIKE ?
+			ORDER BY name ASC
+			LIMIT ?`
+	pattern := "%" + keyword + "%"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, pattern, pattern, pattern, limit)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, pattern, pattern, pattern, limit)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := []*entity.Customer{}
+	for rows.Next() {
+		var customer entity.Customer
+		var err = rows.Scan(
+			&customer.ID,
+			&customer.CustomerGroupID,
+			&customer.Name,
+			&customer.Phone,
+			&customer.Email,
+			&customer.Notes,
+			&customer.CreatedAt,
+			&customer.UpdatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		customers = append(customers, &customer)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+func (repo CustomerRepository) GetCustomerByID(ctx context.Context, ID int64) (*entity.Customer, error) {
+	var row *sql.Row
+	query := "SELECT id, customer_group_id, name, phone, email, notes, created_at, updated_at FROM customers WHERE id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	var customer entity.Customer
+	var err = row.Scan(
+		&customer.ID,
+		&customer.CustomerGroupID,
+		&customer.Name,
+		&customer.Phone,
+		&customer.Email,
+		&customer.Notes,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Customer not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+func (repo CustomerRepository) GetCustomerByPhone(ctx context.Context, phone string) (*entity.Customer, error) {
+	var row *sql.Row
+	query := "SELECT id, customer_group_id, name, phone, email, notes, created_at, updated_at FROM customers WHERE phone = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, phone)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, phone)
+	}
+
+	var customer entity.Customer
+	var err = row.Scan(
+		&customer.ID,
+		&customer.CustomerGroupID,
+		&customer.Name,
+		&customer.Phone,
+		&customer.Email,
+		&customer.Notes,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Customer not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+func (repo CustomerRepository) Create(ctx context.Context, param entity.CreateCustomerParam) (*entity.Customer, error) {
+	query := "INSERT INTO customers(customer_group_id, name, phone, email, notes) VALUES(?, ?, ?, ?, ?)"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.CustomerGroupID, param.Name, param.Phone, param.Email, param.Notes)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.CustomerGroupID, param.Name, param.Phone, param.Email, param.Notes)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetCustomerByID(ctx, ID)
+}
+
+func (repo CustomerRepository) UpdateGroupByID(ctx context.Context, ID int64, customerGroupID *int64) (bool, error) {
+	query := "UPDATE customers SET customer_group_id = ?, updated_at = CURRENT_TIMESTAMP() WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, customerGroupID, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, customerGroupID, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo CustomerRepository) GetAllGroups(ctx context.Context) ([]*entity.CustomerGroup, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT id, name, created_at FROM customer_groups ORDER BY name ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []*entity.CustomerGroup{}
+	for rows.Next() {
+		var group entity.CustomerGroup
+		if err := rows.Scan(&group.ID, &group.Name, &group.CreatedAt); err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		groups = append(groups, &group)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (repo CustomerRepository) GetGroupByID(ctx context.Context, ID int64) (*entity.CustomerGroup, error) {
+	var row *sql.Row
+	query := "SELECT id, name, created_at FROM customer_groups WHERE id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	var group entity.CustomerGroup
+	err := row.Scan(&group.ID, &group.Name, &group.CreatedAt)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Customer group not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (repo CustomerRepository) CreateGroup(ctx context.Context, param entity.CreateCustomerGroupParam) (*entity.CustomerGroup, error) {
+	query := "INSERT INTO customer_groups(name) VALUES(?)"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.Name)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.Name)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetGroupByID(ctx, ID)
+}
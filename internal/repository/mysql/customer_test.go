@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var customerColumnNames = []string{"ID", "CustomerGroupID", "Name", "Phone", "Email", "Notes", "CreatedAt", "UpdatedAt"}
+
+func Test_GetAllCustomers_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT id, customer_group_id, name, phone, email, notes, created_at, updated_at FROM customers ORDER BY name ASC")
+	mock.ExpectQuery(query).WillReturnError(errors.New("failed get customers"))
+
+	customerRepository := NewCustomerRepository(db)
+	customers, err := customerRepository.GetAllCustomers(ctx)
+	assert.NotNil(t, err)
+	assert.Nil(t, customers)
+}
+
+func Test_SearchCustomers_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows(customerColumnNames).
+		AddRow(1, nil, "Budi", "0812", "budi@mail.com", "", time.Now(), time.Now())
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("WHERE name LIKE ? OR phone LIKE ? OR email LIKE ?")
+	mock.ExpectQuery(query).WithArgs("%bud%", "%bud%", "%bud%", 10).WillReturnRows(rows)
+
+	customerRepository := NewCustomerRepository(db)
+	customers, err := customerRepository.SearchCustomers(ctx, "bud", 10)
+	assert.Nil(t, err)
+	assert.Len(t, customers, 1)
+	assert.Equal(t, "Budi", customers[0].Name)
+}
+
+func Test_GetCustomerByID_Failed_WhenNoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT id, customer_group_id, name, phone, email, notes, created_at, updated_at FROM customers WHERE id = ?")
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(customerColumnNames))
+
+	customerRepository := NewCustomerRepository(db)
+	customer, err := customerRepository.GetCustomerByID(ctx, 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, customer)
+}
+
+func Test_CreateCustomer_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	param := entity.CreateCustomerParam{Name: "Budi", Phone: "0812"}
+	rows := sqlmock.NewRows(customerColumnNames).
+		AddRow(1, nil, "Budi", "0812", "", "", time.Now(), time.Now())
+	ctx := context.TODO()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO customers(customer_group_id, name, phone, email, notes) VALUES(?, ?, ?, ?, ?)")).
+		WithArgs(param.CustomerGroupID, param.Name, param.Phone, param.Email, param.Notes).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM customers WHERE id = ?")).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	customerRepository := NewCustomerRepository(db)
+	customer, err := customerRepository.Create(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), customer.ID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,189 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type HeldCartRepository struct {
+	DB *sql.DB
+}
+
+func NewHeldCartRepository(DB *sql.DB) *HeldCartRepository {
+	return &HeldCartRepository{DB: DB}
+}
+
+const heldCartColumns = `hc.id, hc.register, hc.label, hc.customer_id, COALESCE(c.name, ''),
+	hc.total, hc.cart, hc.expires_at, hc.created_at`
+
+func scanHeldCart(scanner interface{ Scan(...interface{}) error }) (*entity.HeldCart, error) {
+	var heldCart entity.HeldCart
+	var cart []byte
+	err := scanner.Scan(
+		&heldCart.ID,
+		&heldCart.Register,
+		&heldCart.Label,
+		&heldCart.CustomerID,
+		&heldCart.CustomerName,
+		&heldCart.Total,
+		&cart,
+		&heldCart.ExpiresAt,
+		&heldCart.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(cart, &heldCart.Cart); err != nil {
+		return nil, err
+	}
+
+	return &heldCart, nil
+}
+
+// GetHeldCartsByRegister returns the carts of the register that have not
+// expired yet, oldest first.
+func (repo HeldCartRepository) GetHeldCartsByRegister(ctx context.Context, register string, now time.Time) ([]*entity.HeldCart, error) {
+	var rows *sql.Rows
+	var err error
+	query := fmt.Sprintf(`
+		SELECT %s
+			FROM held_carts hc
+			LEFT JOIN customers c ON c.id = hc.customer_id
+			WHERE hc.register = ? AND hc.expires_at > ?
+			ORDER BY hc.created_at ASC, hc.id ASC`, heldCartColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, register, now)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, register, now)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	heldCarts := []*entity.HeldCart{}
+	for rows.Next() {
+		heldCart, err := scanHeldCart(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		heldCarts = append(heldCarts, heldCart)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return heldCarts, nil
+}
+
+func (repo HeldCartRepository) GetHeldCartByID(ctx context.Context, ID int64) (*entity.HeldCart, error) {
+	var row *sql.Row
+	query := fmt.Sprintf(`
+		SELECT %s
+			FROM held_carts hc
+			LEFT JOIN customers c ON c.id = hc.customer_id
+			WHERE hc.id = ?`, heldCartColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	heldCart, err := scanHeldCart(row)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Held cart not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return heldCart, nil
+}
+
+func (repo HeldCartRepository) Create(ctx context.Context, param entity.CreateHeldCartParam) (*entity.HeldCart, error) {
+	cart, err := json.Marshal(param.Cart)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	query := "INSERT INTO held_carts(register, label, customer_id, total, cart, expires_at) VALUES(?, ?, ?, ?, ?, ?)"
+	var res sql.Result
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.Register, param.Label, param.Cart.CustomerID, param.Cart.Total, cart, param.ExpiresAt)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.Register, param.Label, param.Cart.CustomerID, param.Cart.Total, cart, param.ExpiresAt)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetHeldCartByID(ctx, ID)
+}
+
+func (repo HeldCartRepository) DeleteByID(ctx context.Context, ID int64) (bool, error) {
+	query := "DELETE FROM held_carts WHERE id = ?"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, ID)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (repo HeldCartRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := "DELETE FROM held_carts WHERE expires_at <= ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, now)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, now)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var heldCartColumnNames = []string{"id", "register", "label", "customer_id", "name", "total", "cart", "expires_at", "created_at"}
+
+func Test_GetHeldCartsByRegister_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	cart := `{"total":10000,"customer_id":3,"order_items":[{"product_id":1,"quantity":2,"subtotal":10000}]}`
+	query := regexp.QuoteMeta("WHERE hc.register = ? AND hc.expires_at > ?")
+	mock.ExpectQuery(query).
+		WithArgs("Register 1", now).
+		WillReturnRows(sqlmock.NewRows(heldCartColumnNames).
+			AddRow(1, "Register 1", "Blue shirt", 3, "Budi", 10000, cart, now.Add(time.Hour), now))
+
+	heldCartRepository := NewHeldCartRepository(db)
+	heldCarts, err := heldCartRepository.GetHeldCartsByRegister(context.TODO(), "Register 1", now)
+	assert.Nil(t, err)
+	assert.Len(t, heldCarts, 1)
+	assert.Equal(t, "Budi", heldCarts[0].CustomerName)
+	assert.Equal(t, int64(1), heldCarts[0].Cart.Items[0].ProductID)
+	assert.Equal(t, 2, heldCarts[0].Cart.Items[0].Quantity)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetHeldCartByID_Failed_WhenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE hc.id = ?")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(heldCartColumnNames))
+
+	heldCartRepository := NewHeldCartRepository(db)
+	heldCart, err := heldCartRepository.GetHeldCartByID(context.TODO(), 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, heldCart)
+}
@@ -0,0 +1,274 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type LoyaltyRepository struct {
+	DB *sql.DB
+}
+
+func NewLoyaltyRepository(DB *sql.DB) *LoyaltyRepository {
+	return &LoyaltyRepository{DB: DB}
+}
+
+func (repo LoyaltyRepository) GetSetting(ctx context.Context) (*entity.LoyaltySetting, error) {
+	var row *sql.Row
+	query := "SELECT spend_per_point, point_value, updated_at FROM loyalty_settings WHERE id = 1"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query)
+	}
+
+	var setting entity.LoyaltySetting
+	err := row.Scan(&setting.SpendPerPoint, &setting.PointValue, &setting.UpdatedAt)
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Loyalty setting not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+func (repo LoyaltyRepository) UpdateSetting(ctx context.Context, param entity.UpdateLoyaltySettingParam) error {
+	query := "UPDATE loyalty_settings SET spend_per_point = ?, point_value = ?, updated_at = CURRENT_TIMESTAMP() WHERE id = 1"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.SpendPerPoint, param.PointValue)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.SpendPerPoint, param.PointValue)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo LoyaltyRepository) GetAllMultipliers(ctx context.Context) ([]*entity.LoyaltyMultiplier, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT id, category, multiplier, created_at FROM loyalty_multipliers ORDER BY category ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	multipliers := []*entity.LoyaltyMultiplier{}
+	for rows.Next() {
+		var multiplier entity.LoyaltyMultiplier
+		var err = rows.Scan(
+			&multiplier.ID,
+			&multiplier.Category,
+			&multiplier.Multiplier,
+			&multiplier.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		multipliers = append(multipliers, &multiplier)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return multipliers, nil
+}
+
+// SaveMultiplier creates the multiplier of a category or replaces the
+// existing one.
+func (repo LoyaltyRepository) SaveMultiplier(ctx context.Context, param entity.SaveLoyaltyMultiplierParam) error {
+	query := `
+		INSERT INTO loyalty_multipliers(category, multiplier) VALUES(?, ?)
+			ON DUPLICATE KEY UPDATE multiplier = VALUES(multiplier)`
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.Category, param.Multiplier)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.Category, param.Multiplier)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo LoyaltyRepository) DeleteMultiplierByID(ctx context.Context, ID int64) (bool, error) {
+	query := "DELETE FROM loyalty_multipliers WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo LoyaltyRepository) GetEntriesByCustomerID(ctx context.Context, customerID int64) ([]*entity.LoyaltyEntry, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT id, customer_id, order_id, type, points, created_at
+			FROM loyalty_entries
+			WHERE customer_id = ?
+			ORDER BY created_at DESC, id DESC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, customerID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, customerID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*entity.LoyaltyEntry{}
+	for rows.Next() {
+		var entry entity.LoyaltyEntry
+		var err = rows.Scan(
+			&entry.ID,
+			&entry.CustomerID,
+			&entry.OrderID,
+			&entry.Type,
+			&entry.Points,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		entries = append(entries, &entry)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (repo LoyaltyRepository) GetBalanceByCustomerID(ctx context.Context, customerID int64) (int, error) {
+	var row *sql.Row
+	query := "SELECT COALESCE(SUM(points), 0) FROM loyalty_entries WHERE customer_id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, customerID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, customerID)
+	}
+
+	var balance int
+	if err := row.Scan(&balance); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// GetBalanceByCustomerIDForUpdate locks the customer row before summing the
+// ledger, so concurrent redemptions by the same customer are serialized and
+// cannot spend the same points twice.
+func (repo LoyaltyRepository) GetBalanceByCustomerIDForUpdate(ctx context.Context, customerID int64) (int, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return 0, errors.New("failed get transcation context")
+	}
+
+	var lockedID int64
+	row := tx.QueryRowContext(ctx, "SELECT id FROM customers WHERE id = ? FOR UPDATE", customerID)
+	err := row.Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Customer not found",
+			Err:     err,
+		}
+		return 0, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return repo.GetBalanceByCustomerID(ctx, customerID)
+}
+
+func (repo LoyaltyRepository) GetOrderPoints(ctx context.Context, orderID int64) (*entity.LoyaltyOrderPoints, error) {
+	var row *sql.Row
+	query := `
+		SELECT
+			COALESCE(SUM(IF(type IN ('earn', 'clawback'), points, 0)), 0),
+			COALESCE(SUM(IF(type IN ('redeem', 'restore'), -points, 0)), 0)
+			FROM loyalty_entries
+			WHERE order_id = ?`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, orderID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, orderID)
+	}
+
+	var orderPoints entity.LoyaltyOrderPoints
+	if err := row.Scan(&orderPoints.Earned, &orderPoints.Redeemed); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &orderPoints, nil
+}
+
+func (repo LoyaltyRepository) CreateEntry(ctx context.Context, param entity.CreateLoyaltyEntryParam) error {
+	query := "INSERT INTO loyalty_entries(customer_id, order_id, type, points) VALUES(?, ?, ?, ?)"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.CustomerID, param.OrderID, param.Type, param.Points)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.CustomerID, param.OrderID, param.Type, param.Points)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
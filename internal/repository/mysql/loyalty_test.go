@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetBalanceByCustomerID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT COALESCE(SUM(points), 0) FROM loyalty_entries WHERE customer_id = ?")
+	mock.ExpectQuery(query).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(120))
+
+	loyaltyRepository := NewLoyaltyRepository(db)
+	balance, err := loyaltyRepository.GetBalanceByCustomerID(ctx, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 120, balance)
+}
+
+func Test_GetBalanceByCustomerIDForUpdate_Failed_WithoutTransaction(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	loyaltyRepository := NewLoyaltyRepository(db)
+	balance, err := loyaltyRepository.GetBalanceByCustomerIDForUpdate(context.TODO(), 7)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, balance)
+}
+
+func Test_GetBalanceByCustomerIDForUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM customers WHERE id = ? FOR UPDATE")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(points), 0) FROM loyalty_entries WHERE customer_id = ?")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(80))
+
+	uow := NewMySQLUnitOfWork(db)
+	txContext, err := uow.Begin(context.TODO())
+	assert.Nil(t, err)
+
+	loyaltyRepository := NewLoyaltyRepository(db)
+	balance, err := loyaltyRepository.GetBalanceByCustomerIDForUpdate(txContext, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 80, balance)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetOrderPoints_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("FROM loyalty_entries\n\t\t\tWHERE order_id = ?")
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"", ""}).AddRow(5, 100))
+
+	loyaltyRepository := NewLoyaltyRepository(db)
+	orderPoints, err := loyaltyRepository.GetOrderPoints(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.LoyaltyOrderPoints{Earned: 5, Redeemed: 100}, orderPoints)
+}
+
+func Test_CreateEntry_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	orderID := int64(1)
+	param := entity.CreateLoyaltyEntryParam{CustomerID: 7, OrderID: &orderID, Type: entity.LoyaltyEntryTypeEarn, Points: 5}
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("INSERT INTO loyalty_entries(customer_id, order_id, type, points) VALUES(?, ?, ?, ?)")
+	mock.ExpectExec(query).WithArgs(7, 1, "earn", 5).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	loyaltyRepository := NewLoyaltyRepository(db)
+	err = loyaltyRepository.CreateEntry(ctx, param)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,387 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// associationBatchSize keeps the placeholders of one insert well under the
+// limit of a prepared statement.
+const associationBatchSize = 500
+
+type MarketBasketRepository struct {
+	DB *sql.DB
+}
+
+func NewMarketBasketRepository(DB *sql.DB) *MarketBasketRepository {
+	return &MarketBasketRepository{DB: DB}
+}
+
+// CountOrders counts the completed orders in [start, end) with at least one
+// product that is not a gift card, gift cards are left out of the basket.
+func (repo MarketBasketRepository) CountOrders(ctx context.Context, start time.Time, end time.Time) (int, error) {
+	var row *sql.Row
+	query := `
+		SELECT COUNT(DISTINCT oi.order_id)
+			FROM order_items oi
+			JOIN orders o ON o.id = oi.order_id
+			JOIN products p ON p.id = oi.product_id
+			WHERE o.created_at >= ? AND o.created_at < ? AND o.status = 'completed' AND p.is_gift_card = 0`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, start, end)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, start, end)
+	}
+
+	var orders int
+	if err := row.Scan(&orders); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return orders, nil
+}
+
+// GetProductOrderCounts counts the completed orders in [start, end) that
+// have each product, keyed by the product ID.
+func (repo MarketBasketRepository) GetProductOrderCounts(ctx context.Context, start time.Time, end time.Time) (map[int64]int, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT oi.product_id, COUNT(DISTINCT oi.order_id)
+			FROM order_items oi
+			JOIN orders o ON o.id = oi.order_id
+			JOIN products p ON p.id = oi.product_id
+			WHERE o.created_at >= ? AND o.created_at < ? AND o.status = 'completed' AND p.is_gift_card = 0
+			GROUP BY oi.product_id`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, start, end)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, start, end)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[int64]int{}
+	for rows.Next() {
+		var productID int64
+		var orders int
+		var err = rows.Scan(&productID, &orders)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		counts[productID] = orders
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetPairOrderCounts counts the completed orders in [start, end) that have
+// both products of a pair, leaving out the pairs bought together in fewer
+// than minOrders orders.
+func (repo MarketBasketRepository) GetPairOrderCounts(ctx context.Context, start time.Time, end time.Time, minOrders int) ([]*entity.ProductPairCount, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT a.product_id, b.product_id, COUNT(DISTINCT a.order_id) AS orders
+			FROM order_items a
+			JOIN order_items b ON b.order_id = a.order_id AND b.product_id > a.product_id
+			JOIN orders o ON o.id = a.order_id
+			JOIN products pa ON pa.id = a.product_id
+			JOIN products pb ON pb.id = b.product_id
+			WHERE o.created_at >= ? AND o.created_at < ? AND o.status = 'completed'
+				AND pa.is_gift_card = 0 AND pb.is_gift_card = 0
+			GROUP BY a.product_id, b.product_id
+			HAVING orders >= ?
+			ORDER BY a.product_id ASC, b.product_id ASC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, start, end, minOrders)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, start, end, minOrders)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	pairs := []*entity.ProductPairCount{}
+	for rows.Next() {
+		var pair entity.ProductPairCount
+		var err = rows.Scan(&pair.ProductID, &pair.AssociatedProductID, &pair.Orders)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		pairs = append(pairs, &pair)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// ReplaceAssociations drops the rules of the previous run and saves the new
+// ones, it is meant to run in a transaction so the suggestions never see
+// half a run.
+func (repo MarketBasketRepository) ReplaceAssociations(ctx context.Context, associations []*entity.ProductAssociation) error {
+	exec := func(query string, args ...interface{}) error {
+		var err error
+		if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+			_, err = tx.Exec(query, args...)
+		} else {
+			_, err = repo.DB.ExecContext(ctx, query, args...)
+		}
+
+		return err
+	}
+
+	if err := exec("DELETE FROM product_associations"); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	for offset := 0; offset < len(associations); offset += associationBatchSize {
+		batch := associations[offset:]
+		if len(batch) > associationBatchSize {
+			batch = batch[:associationBatchSize]
+		}
+
+		associationParams := []string{}
+		associationVals := []interface{}{}
+		for _, association := range batch {
+			associationParams = append(associationParams, "(?, ?, ?, ?, ?, ?)")
+			associationVals = append(associationVals,
+				association.ProductID,
+				association.AssociatedProductID,
+				association.Orders,
+				association.Support,
+				association.Confidence,
+				association.Lift)
+		}
+
+		query := fmt.Sprintf(`
+			INSERT INTO product_associations(product_id, associated_product_id, orders, support, confidence, lift)
+				VALUES %s`, strings.Join(associationParams, ", "))
+		if err := exec(query, associationVals...); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAssociations returns the strongest rules of the latest run.
+func (repo MarketBasketRepository) GetAssociations(ctx context.Context, limit int) ([]*entity.ProductAssociation, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT pa.product_id, p.code, p.name, pa.associated_product_id, ap.code, ap.name,
+				pa.orders, pa.support, pa.confidence, pa.lift
+			FROM product_associations pa
+			JOIN products p ON p.id = pa.product_id
+			JOIN products ap ON ap.id = pa.associated_product_id
+			ORDER BY pa.lift DESC, pa.confidence DESC, pa.product_id ASC, pa.associated_product_id ASC
+			LIMIT ?`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, limit)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, limit)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	associations := []*entity.ProductAssociation{}
+	for rows.Next() {
+		var association entity.ProductAssociation
+		var err = rows.Scan(
+			&association.ProductID,
+			&association.ProductCode,
+			&association.ProductName,
+			&association.AssociatedProductID,
+			&association.AssociatedProductCode,
+			&association.AssociatedProductName,
+			&association.Orders,
+			&association.Support,
+			&association.Confidence,
+			&association.Lift)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		associations = append(associations, &association)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return associations, nil
+}
+
+// GetSuggestionsByProductIDs returns the products that sell better with the
+// given products than on their own, strongest first. A product can come
+// more than once when it goes with several of the given products, and the
+// given products themselves are left out.
+func (repo MarketBasketRepository) GetSuggestionsByProductIDs(ctx context.Context, productIDs []int64) ([]*entity.ProductSuggestion, error) {
+	if len(productIDs) < 1 {
+		return []*entity.ProductSuggestion{}, nil
+	}
+
+	productParams := strings.TrimSuffix(strings.Repeat("?, ", len(productIDs)), ", ")
+	productVals := []interface{}{}
+	for _, productID := range productIDs {
+		productVals = append(productVals, productID)
+	}
+	args := append(productVals, productVals...)
+
+	var rows *sql.Rows
+	var err error
+	query := fmt.Sprintf(`
+		SELECT ap.id, ap.code, ap.name, ap.price, ap.stock, p.id, p.name, pa.confidence, pa.lift
+			FROM product_associations pa
+			JOIN products p ON p.id = pa.product_id
+			JOIN products ap ON ap.id = pa.associated_product_id
+			WHERE pa.product_id IN (%s) AND pa.associated_product_id NOT IN (%s) AND pa.lift > 1
+			ORDER BY pa.lift DESC, pa.confidence DESC, ap.id ASC`, productParams, productParams)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []*entity.ProductSuggestion{}
+	for rows.Next() {
+		var suggestion entity.ProductSuggestion
+		var err = rows.Scan(
+			&suggestion.ID,
+			&suggestion.Code,
+			&suggestion.Name,
+			&suggestion.Price,
+			&suggestion.Stock,
+			&suggestion.BecauseOfProductID,
+			&suggestion.BecauseOfProductName,
+			&suggestion.Confidence,
+			&suggestion.Lift)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		suggestions = append(suggestions, &suggestion)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+func (repo MarketBasketRepository) CreateRun(ctx context.Context, param entity.CreateMarketBasketRunParam) (*entity.MarketBasketRun, error) {
+	query := `
+		INSERT INTO market_basket_runs(start_date, end_date, min_orders, orders, associations)
+			VALUES(?, ?, ?, ?, ?)`
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.StartDate, param.EndDate, param.MinOrders, param.Orders, param.Associations)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.StartDate, param.EndDate, param.MinOrders, param.Orders, param.Associations)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	run := &entity.MarketBasketRun{
+		ID:           ID,
+		StartDate:    param.StartDate,
+		EndDate:      param.EndDate,
+		MinOrders:    param.MinOrders,
+		Orders:       param.Orders,
+		Associations: param.Associations,
+		CreatedAt:    time.Now(),
+	}
+	return run, nil
+}
+
+func (repo MarketBasketRepository) GetLatestRun(ctx context.Context) (*entity.MarketBasketRun, error) {
+	query := `
+		SELECT id, start_date, end_date, min_orders, orders, associations, created_at
+			FROM market_basket_runs
+			ORDER BY id DESC
+			LIMIT 1`
+	var row *sql.Row
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query)
+	}
+
+	var run entity.MarketBasketRun
+	var startDate, endDate time.Time
+	err := row.Scan(
+		&run.ID,
+		&startDate,
+		&endDate,
+		&run.MinOrders,
+		&run.Orders,
+		&run.Associations,
+		&run.CreatedAt)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Market basket analysis has not run yet",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	run.StartDate = startDate.Format("2006-01-02")
+	run.EndDate = endDate.Format("2006-01-02")
+	return &run, nil
+}
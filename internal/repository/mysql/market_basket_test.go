@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetPairOrderCounts_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta("JOIN order_items b ON b.order_id = a.order_id AND b.product_id > a.product_id")
+	mock.ExpectQuery(query).
+		WithArgs(start, end, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "product_id", "orders"}).
+			AddRow(1, 2, 10).
+			AddRow(1, 3, 4))
+
+	marketBasketRepository := NewMarketBasketRepository(db)
+	pairs, err := marketBasketRepository.GetPairOrderCounts(context.TODO(), start, end, 2)
+	assert.Nil(t, err)
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, int64(2), pairs[0].AssociatedProductID)
+	assert.Equal(t, 10, pairs[0].Orders)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_ReplaceAssociations_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	associations := []*entity.ProductAssociation{
+		{ProductID: 1, AssociatedProductID: 2, Orders: 10, Support: 0.1, Confidence: 0.25, Lift: 1.25},
+		{ProductID: 2, AssociatedProductID: 1, Orders: 10, Support: 0.1, Confidence: 0.5, Lift: 1.25},
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_associations")).
+		WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_associations")).
+		WithArgs(int64(1), int64(2), 10, 0.1, 0.25, 1.25, int64(2), int64(1), 10, 0.1, 0.5, 1.25).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	marketBasketRepository := NewMarketBasketRepository(db)
+	err = marketBasketRepository.ReplaceAssociations(context.TODO(), associations)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetSuggestionsByProductIDs_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("WHERE pa.product_id IN (?, ?) AND pa.associated_product_id NOT IN (?, ?) AND pa.lift > 1")
+	mock.ExpectQuery(query).
+		WithArgs(int64(1), int64(2), int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "price", "stock", "id", "name", "confidence", "lift"}).
+			AddRow(3, "P3", "Cup", 5000, 12, 1, "Coffee", 0.4, 2.5))
+
+	marketBasketRepository := NewMarketBasketRepository(db)
+	suggestions, err := marketBasketRepository.GetSuggestionsByProductIDs(context.TODO(), []int64{1, 2})
+	assert.Nil(t, err)
+	assert.Len(t, suggestions, 1)
+	assert.Equal(t, int64(3), suggestions[0].ID)
+	assert.Equal(t, "Coffee", suggestions[0].BecauseOfProductName)
+	assert.Equal(t, 2.5, suggestions[0].Lift)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetLatestRun_Failed_WhenNotRunYet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM market_basket_runs")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "start_date", "end_date", "min_orders", "orders", "associations", "created_at"}))
+
+	marketBasketRepository := NewMarketBasketRepository(db)
+	run, err := marketBasketRepository.GetLatestRun(context.TODO())
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, run)
+}
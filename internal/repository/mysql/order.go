@@ -3,14 +3,23 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
+	mysqldriver "github.com/go-sql-driver/mysql"
 )
 
+// mysqlErrDuplicateEntry is the MySQL error number of a unique key violation,
+// its message ends with the violated key as 'key' or 'table.key'.
+const mysqlErrDuplicateEntry = 1062
+
+// likeEscaper escapes the LIKE wildcards in user input matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type OrderRepository struct {
 	DB *sql.DB
 }
@@ -19,14 +28,80 @@ func NewOrderRepository(DB *sql.DB) *OrderRepository {
 	return &OrderRepository{DB: DB}
 }
 
-func (repo OrderRepository) GetAllOrders(ctx context.Context) ([]*entity.Order, error) {
+// orderQueryConditions builds the WHERE clause shared by the order listing
+// and its count, cursors are left to the listing.
+func orderQueryConditions(query entity.OrderQuery) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	if query.StartDate != nil {
+		conditions = append(conditions, "o.created_at >= ?")
+		args = append(args, *query.StartDate)
+	}
+
+	if query.EndDate != nil {
+		conditions = append(conditions, "o.created_at < ?")
+		args = append(args, *query.EndDate)
+	}
+
+	if query.CashierID != nil {
+		conditions = append(conditions, "o.cashier_id = ?")
+		args = append(args, *query.CashierID)
+	}
+
+	if query.Status != "" {
+		conditions = append(conditions, "o.status = ?")
+		args = append(args, query.Status)
+	}
+
+	if query.MinTotal != nil {
+		conditions = append(conditions, "o.total >= ?")
+		args = append(args, *query.MinTotal)
+	}
+
+	if query.MaxTotal != nil {
+		conditions = append(conditions, "o.total <= ?")
+		args = append(args, *query.MaxTotal)
+	}
+
+	if query.InvoiceNumber != "" {
+		// a prefix match keeps using the index of the invoice numbers
+		conditions = append(conditions, "o.invoice_number LIKE ?")
+		args = append(args, likeEscaper.Replace(query.InvoiceNumber)+"%")
+	}
+
+	if query.Cursor > 0 {
+		conditions = append(conditions, "o.id < ?")
+		args = append(args, query.Cursor)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (repo OrderRepository) GetOrders(ctx context.Context, query entity.OrderQuery) ([]*entity.Order, error) {
+	where, args := orderQueryConditions(query)
+	statement := fmt.Sprintf(`
+		SELECT o.id, COALESCE(o.invoice_number, ''), o.customer_id, o.cashier_id, COALESCE(u.name, ''), o.status, o.total, o.discount, o.created_at
+			FROM orders o
+			LEFT JOIN users u ON u.id = o.cashier_id
+			%s
+			ORDER BY o.id DESC
+			LIMIT ?`, where)
+	args = append(args, query.Limit)
+	if query.Cursor == 0 {
+		statement += " OFFSET ?"
+		args = append(args, query.Offset)
+	}
+
 	var rows *sql.Rows
 	var err error
-	query := "SELECT * from orders ORDER BY created_at DESC"
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		rows, err = tx.Query(query)
+		rows, err = tx.Query(statement, args...)
 	} else {
-		rows, err = repo.DB.QueryContext(ctx, query)
+		rows, err = repo.DB.QueryContext(ctx, statement, args...)
 	}
 
 	if err != nil {
@@ -40,7 +115,13 @@ func (repo OrderRepository) GetAllOrders(ctx context.Context) ([]*entity.Order,
 		var order entity.Order
 		var err = rows.Scan(
 			&order.ID,
+			&order.InvoiceNumber,
+			&order.CustomerID,
+			&order.CashierID,
+			&order.CashierName,
+			&order.Status,
 			&order.Total,
+			&order.Discount,
 			&order.CreatedAt,
 		)
 		if err != nil {
@@ -58,19 +139,100 @@ func (repo OrderRepository) GetAllOrders(ctx context.Context) ([]*entity.Order,
 	return orders, nil
 }
 
-func (repo OrderRepository) GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIncome, error) {
+func (repo OrderRepository) CountOrders(ctx context.Context, query entity.OrderQuery) (int, error) {
+	query.Cursor = 0
+	where, args := orderQueryConditions(query)
+	statement := fmt.Sprintf("SELECT COUNT(*) FROM orders o %s", where)
+	var row *sql.Row
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(statement, args...)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, statement, args...)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetTotalOrderCount counts the completed orders, the voided and refunded
+// ones are not sales.
+func (repo OrderRepository) GetTotalOrderCount(ctx context.Context) (int, error) {
+	var row *sql.Row
+	query := "SELECT COUNT(*) FROM orders o WHERE o.status = 'completed'"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query)
+	}
+
+	if err := row.Err(); err != nil {
+		return 0, err
+	}
+
+	var val int
+	if err := row.Scan(&val); err != nil {
+		return 0, err
+	}
+
+	return val, nil
+}
+
+func (repo OrderRepository) GetOrderItemsByID(ctx context.Context, ID int64) ([]*entity.OrderItem, error) {
 	var rows *sql.Rows
 	var err error
 	query := `
-		SELECT YEAR(created_at) as year, MONTHNAME(created_at) as mount, SUM(total) as income
-			FROM orders
-			WHERE MONTH(created_at) -12 AND MONTH(created_at)
-			GROUP BY YEAR(created_at), MONTHNAME(created_at), MONTH(created_at)
-			ORDER BY YEAR(created_at) ASC, MONTH(created_at) ASC`
+		SELECT p.id as id, oi.order_id, p.id as product_id, p.code, p.name, p.price, oi.quantity, oi.subtotal, oi.created_at
+				FROM order_items AS oi
+				LEFT JOIN products AS p ON oi.product_id = p.id
+				WHERE oi.order_id = ?`
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		rows, err = tx.Query(query)
+		rows, err = tx.Query(query, ID)
 	} else {
-		rows, err = repo.DB.QueryContext(ctx, query)
+		rows, err = repo.DB.QueryContext(ctx, query, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	orderItems := []*entity.OrderItem{}
+	for rows.Next() {
+		var orderItem entity.OrderItem
+		err := rows.Scan(
+			&orderItem.ID,
+			&orderItem.OrderID,
+			&orderItem.ProductID,
+			&orderItem.ProductCode,
+			&orderItem.ProductName,
+			&orderItem.ProductPrice,
+			&orderItem.Quantity,
+			&orderItem.Subtotal,
+			&orderItem.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+		}
+
+		orderItems = append(orderItems, &orderItem)
+	}
+
+	return orderItems, nil
+}
+
+func (repo OrderRepository) GetPaymentsByOrderID(ctx context.Context, orderID int64) ([]*entity.OrderPayment, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT id, order_id, method, amount, reference, created_at FROM order_payments WHERE order_id = ? ORDER BY id ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, orderID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, orderID)
 	}
 
 	if err != nil {
@@ -79,131 +241,287 @@ func (repo OrderRepository) GetAnnualIncome(ctx context.Context) ([]*entity.Annu
 	}
 	defer rows.Close()
 
-	incomes := []*entity.AnnualIncome{}
+	payments := []*entity.OrderPayment{}
 	for rows.Next() {
-		var income entity.AnnualIncome
-		var err = rows.Scan(&income.Year, &income.Month, &income.Income)
+		var payment entity.OrderPayment
+		var err = rows.Scan(
+			&payment.ID,
+			&payment.OrderID,
+			&payment.Method,
+			&payment.Amount,
+			&payment.Reference,
+			&payment.CreatedAt,
+		)
 		if err != nil {
 			log.Println(err.Error())
 			return nil, err
 		}
 
-		incomes = append(incomes, &income)
+		payments = append(payments, &payment)
 	}
 	if err = rows.Err(); err != nil {
 		log.Println(err.Error())
 		return nil, err
 	}
 
-	return incomes, nil
+	return payments, nil
 }
 
-func (repo OrderRepository) GetDailyOrderCount(ctx context.Context) (int, error) {
-	var row *sql.Row
-	query := "SELECT COUNT(*) FROM orders WHERE DAY(created_At) = DAY(CURRENT_TIMESTAMP())"
+func (repo OrderRepository) GetOrdersByCustomerID(ctx context.Context, customerID int64) ([]*entity.Order, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT id, COALESCE(invoice_number, ''), customer_id, status, total, discount, created_at
+			FROM orders
+			WHERE customer_id = ?
+			ORDER BY created_at DESC`
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		row = tx.QueryRow(query)
+		rows, err = tx.Query(query, customerID)
 	} else {
-		row = repo.DB.QueryRowContext(ctx, query)
+		rows, err = repo.DB.QueryContext(ctx, query, customerID)
 	}
 
-	if err := row.Err(); err != nil {
-		return 0, err
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
 	}
+	defer rows.Close()
 
-	var val int
-	if err := row.Scan(&val); err != nil {
-		return 0, err
+	orders := []*entity.Order{}
+	for rows.Next() {
+		var order entity.Order
+		var err = rows.Scan(
+			&order.ID,
+			&order.InvoiceNumber,
+			&order.CustomerID,
+			&order.Status,
+			&order.Total,
+			&order.Discount,
+			&order.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		orders = append(orders, &order)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
 	}
 
-	return val, nil
+	return orders, nil
 }
 
-func (repo OrderRepository) GetTotalOrderCount(ctx context.Context) (int, error) {
+func (repo OrderRepository) GetCustomerLifetimeValue(ctx context.Context, customerID int64) (*entity.CustomerLifetimeValue, error) {
 	var row *sql.Row
-	query := "SELECT COUNT(*) FROM orders"
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(total), 0), MAX(created_at)
+			FROM orders
+			WHERE customer_id = ?`
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		row = tx.QueryRow(query)
+		row = tx.QueryRow(query, customerID)
 	} else {
-		row = repo.DB.QueryRowContext(ctx, query)
+		row = repo.DB.QueryRowContext(ctx, query, customerID)
 	}
 
-	if err := row.Err(); err != nil {
-		return 0, err
+	var lifetimeValue entity.CustomerLifetimeValue
+	var lastOrderAt sql.NullTime
+	err := row.Scan(&lifetimeValue.OrderCount, &lifetimeValue.TotalSpent, &lastOrderAt)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
 	}
 
-	var val int
-	if err := row.Scan(&val); err != nil {
-		return 0, err
+	if lastOrderAt.Valid {
+		lifetimeValue.LastOrderAt = &lastOrderAt.Time
 	}
 
-	return val, nil
+	return &lifetimeValue, nil
 }
 
-func (repo OrderRepository) GetLastDayIncome(ctx context.Context) (int, error) {
-	var row *sql.Row
+// GetOrderHeatmap groups the orders placed in [start, end) by weekday and
+// hour. Both are read in the session timezone, which is the store's.
+func (repo OrderRepository) GetOrderHeatmap(ctx context.Context, start time.Time, end time.Time) ([]*entity.OrderHeatmapCell, error) {
+	var rows *sql.Rows
+	var err error
 	query := `
-		SELECT SUM(total)
+		SELECT WEEKDAY(created_at) AS weekday, HOUR(created_at) AS hour,
+				COUNT(id),
+				COALESCE(SUM(CASE WHEN status = 'completed' THEN total ELSE 0 END), 0)
 			FROM orders
-			WHERE DAY(created_At) = DAY(CURRENT_TIMESTAMP())
-			GROUP BY DAY(created_At)`
+			WHERE created_at >= ? AND created_at < ? AND status != 'voided'
+			GROUP BY weekday, hour`
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		row = tx.QueryRow(query)
+		rows, err = tx.Query(query, start, end)
 	} else {
-		row = repo.DB.QueryRowContext(ctx, query)
+		rows, err = repo.DB.QueryContext(ctx, query, start, end)
 	}
 
-	var val int
-	err := row.Scan(&val)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	cells := []*entity.OrderHeatmapCell{}
+	for rows.Next() {
+		var cell entity.OrderHeatmapCell
+		var err = rows.Scan(&cell.Weekday, &cell.Hour, &cell.Orders, &cell.Revenue)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		cells = append(cells, &cell)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return cells, nil
+}
+
+func (repo OrderRepository) GetOrderByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	var row *sql.Row
+	query := "SELECT id, COALESCE(invoice_number, ''), customer_id, status, total, discount, created_at FROM orders WHERE id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	var order entity.Order
+	var err = row.Scan(
+		&order.ID,
+		&order.InvoiceNumber,
+		&order.CustomerID,
+		&order.Status,
+		&order.Total,
+		&order.Discount,
+		&order.CreatedAt,
+	)
 	if err == sql.ErrNoRows {
-		return 0, nil
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Order not found",
+			Err:     err,
+		}
+		return nil, err
 	}
 
 	if err != nil {
-		return 0, err
+		log.Println(err.Error())
+		return nil, err
 	}
 
-	return val, nil
+	return &order, nil
 }
 
-func (repo OrderRepository) GetLastMonthIncome(ctx context.Context) (int, error) {
+func (repo OrderRepository) GetOrderByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entity.Order, error) {
 	var row *sql.Row
-	query := `
-		SELECT SUM(total)
-			FROM orders
-			WHERE MONTH(created_At) = MONTH(CURRENT_TIMESTAMP())
-			GROUP BY MONTH(created_At)`
+	query := "SELECT id, invoice_number, customer_id, status, total, discount, created_at FROM orders WHERE invoice_number = ?"
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		row = tx.QueryRow(query)
+		row = tx.QueryRow(query, invoiceNumber)
 	} else {
-		row = repo.DB.QueryRowContext(ctx, query)
+		row = repo.DB.QueryRowContext(ctx, query, invoiceNumber)
 	}
 
-	var val int
-	err := row.Scan(&val)
+	var order entity.Order
+	var err = row.Scan(
+		&order.ID,
+		&order.InvoiceNumber,
+		&order.CustomerID,
+		&order.Status,
+		&order.Total,
+		&order.Discount,
+		&order.CreatedAt,
+	)
 	if err == sql.ErrNoRows {
-		return 0, nil
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Order not found",
+			Err:     err,
+		}
+		return nil, err
 	}
 
 	if err != nil {
-		return 0, err
+		log.Println(err.Error())
+		return nil, err
 	}
 
-	return val, nil
+	return &order, nil
 }
 
-func (repo OrderRepository) GetOrderItemsByID(ctx context.Context, ID int64) ([]*entity.OrderItem, error) {
-	var rows *sql.Rows
-	var err error
-	query := `
-		SELECT p.id as id, oi.order_id, p.id as product_id, p.code, p.name, p.price, oi.quantity, oi.subtotal, oi.created_at
-				FROM order_items AS oi
-				LEFT JOIN products AS p ON oi.product_id = p.id
-				WHERE oi.order_id = ?`
+// GetOrderByIDForUpdate locks the order row until the surrounding transaction
+// ends, so an order cannot be voided or refunded twice concurrently.
+func (repo OrderRepository) GetOrderByIDForUpdate(ctx context.Context, ID int64) (*entity.Order, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return nil, errors.New("failed get transcation context")
+	}
+
+	query := "SELECT id, COALESCE(invoice_number, ''), customer_id, status, total, discount, created_at FROM orders WHERE id = ? FOR UPDATE"
+	row := tx.QueryRowContext(ctx, query, ID)
+
+	var order entity.Order
+	var err = row.Scan(
+		&order.ID,
+		&order.InvoiceNumber,
+		&order.CustomerID,
+		&order.Status,
+		&order.Total,
+		&order.Discount,
+		&order.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Order not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// GetOrderByIdempotencyKey returns the order created by the request that sent
+// the key, along with the hash of that request.
+func (repo OrderRepository) GetOrderByIdempotencyKey(ctx context.Context, key string) (*entity.Order, error) {
+	var row *sql.Row
+	query := "SELECT id, COALESCE(invoice_number, ''), customer_id, status, total, discount, request_hash, created_at FROM orders WHERE idempotency_key = ?"
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		rows, err = tx.Query(query, ID)
+		row = tx.QueryRow(query, key)
 	} else {
-		rows, err = repo.DB.QueryContext(ctx, query, ID)
+		row = repo.DB.QueryRowContext(ctx, query, key)
+	}
+
+	var order entity.Order
+	var err = row.Scan(
+		&order.ID,
+		&order.InvoiceNumber,
+		&order.CustomerID,
+		&order.Status,
+		&order.Total,
+		&order.Discount,
+		&order.RequestHash,
+		&order.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Order not found",
+			Err:     err,
+		}
+		return nil, err
 	}
 
 	if err != nil {
@@ -211,38 +529,57 @@ func (repo OrderRepository) GetOrderItemsByID(ctx context.Context, ID int64) ([]
 		return nil, err
 	}
 
-	orderItems := []*entity.OrderItem{}
-	for rows.Next() {
-		var orderItem entity.OrderItem
-		err := rows.Scan(
-			&orderItem.ID,
-			&orderItem.OrderID,
-			&orderItem.ProductID,
-			&orderItem.ProductCode,
-			&orderItem.ProductName,
-			&orderItem.ProductPrice,
-			&orderItem.Quantity,
-			&orderItem.Subtotal,
-			&orderItem.CreatedAt,
-		)
-		if err != nil {
-			log.Println(err.Error())
-		}
+	return &order, nil
+}
 
-		orderItems = append(orderItems, &orderItem)
+// NextInvoiceSequence takes the next number of the invoice sequence of the
+// period. The sequence row stays locked until the transaction ends, so the
+// number is given back when the order is rolled back and no gap is left.
+func (repo OrderRepository) NextInvoiceSequence(ctx context.Context, period string) (int, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return 0, errors.New("failed get transcation context")
 	}
 
-	return orderItems, nil
+	query := "INSERT INTO invoice_sequences(period, last_number) VALUES(?, 1) ON DUPLICATE KEY UPDATE last_number = last_number + 1"
+	if _, err := tx.ExecContext(ctx, query, period); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var seq int
+	query = "SELECT last_number FROM invoice_sequences WHERE period = ?"
+	if err := tx.QueryRowContext(ctx, query, period).Scan(&seq); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return seq, nil
 }
 
 func (repo OrderRepository) Create(ctx context.Context, param entity.CreateOrderParam) (*entity.Order, error) {
-	query := "INSERT INTO orders(total) VALUES(?)"
+	var idempotencyKey *string
+	if param.IdempotencyKey != "" {
+		idempotencyKey = &param.IdempotencyKey
+	}
+
+	query := "INSERT INTO orders(invoice_number, customer_id, cashier_id, total, discount, idempotency_key, request_hash) VALUES(?, ?, ?, ?, ?, ?, ?)"
 	var res sql.Result
 	var err error
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		res, err = tx.Exec(query, param.Total)
+		res, err = tx.Exec(query, param.InvoiceNumber, param.CustomerID, param.CashierID, param.Total, param.Discount, idempotencyKey, param.RequestHash)
 	} else {
-		res, err = repo.DB.ExecContext(ctx, query, param.Total)
+		res, err = repo.DB.ExecContext(ctx, query, param.InvoiceNumber, param.CustomerID, param.CashierID, param.Total, param.Discount, idempotencyKey, param.RequestHash)
+	}
+
+	// an invoice number taken by another order is not a retry of this one
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry &&
+		strings.HasSuffix(mysqlErr.Message, "idempotency_key'") {
+		return nil, entity.ErrItemAlreadyExists{
+			Message: "Order with this idempotency key already exists",
+			Err:     err,
+		}
 	}
 
 	if err != nil {
@@ -257,9 +594,15 @@ func (repo OrderRepository) Create(ctx context.Context, param entity.CreateOrder
 	}
 
 	order := &entity.Order{
-		ID:        ID,
-		Total:     param.Total,
-		CreatedAt: time.Now(),
+		ID:            ID,
+		InvoiceNumber: param.InvoiceNumber,
+		CustomerID:    param.CustomerID,
+		CashierID:     param.CashierID,
+		Status:        entity.OrderStatusCompleted,
+		Total:         param.Total,
+		Discount:      param.Discount,
+		CreatedAt:     time.Now(),
+		RequestHash:   param.RequestHash,
 	}
 	return order, nil
 }
@@ -288,3 +631,54 @@ func (repo OrderRepository) CreateOrderItems(ctx context.Context, orderID int64,
 
 	return nil
 }
+
+func (repo OrderRepository) CreatePayments(ctx context.Context, orderID int64, params []*entity.CreateOrderPaymentParam) error {
+	createPaymentParams := []string{}
+	createPaymentVals := []interface{}{}
+	for _, param := range params {
+		createPaymentParams = append(createPaymentParams, "(?, ?, ?, ?)")
+		createPaymentVals = append(createPaymentVals, orderID, param.Method, param.Amount, param.Reference)
+	}
+	createPaymentParamQuery := strings.Join(createPaymentParams, ", ")
+
+	query := fmt.Sprintf("INSERT INTO order_payments(order_id, method, amount, reference) VALUES %s", createPaymentParamQuery)
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, createPaymentVals...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, createPaymentVals...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatusByID records when the order was voided or refunded along with
+// the status, the reports count the refunds by that time.
+func (repo OrderRepository) UpdateStatusByID(ctx context.Context, ID int64, status string) error {
+	query := "UPDATE orders SET status = ? WHERE id = ?"
+	switch status {
+	case entity.OrderStatusVoided:
+		query = "UPDATE orders SET status = ?, voided_at = CURRENT_TIMESTAMP WHERE id = ?"
+	case entity.OrderStatusRefunded:
+		query = "UPDATE orders SET status = ?, refunded_at = CURRENT_TIMESTAMP WHERE id = ?"
+	}
+
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, status, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, status, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
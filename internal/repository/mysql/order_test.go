@@ -9,10 +9,11 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ardafirdausr/kaseer/internal/entity"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_GetAllOrders_Failed(t *testing.T) {
+func Test_GetOrders_Failed(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -20,16 +21,16 @@ func Test_GetAllOrders_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	query := regexp.QuoteMeta("SELECT * from orders ORDER BY created_at DESC")
+	query := regexp.QuoteMeta("ORDER BY o.id DESC")
 	mock.ExpectQuery(query).WillReturnError(errors.New("failed get orders"))
 
 	OrderRepository := NewOrderRepository(db)
-	orders, err := OrderRepository.GetAllOrders(ctx)
+	orders, err := OrderRepository.GetOrders(ctx, entity.OrderQuery{Limit: 20})
 	assert.NotNil(t, err)
 	assert.Nil(t, orders)
 }
 
-func Test_GetAllOrders_Success(t *testing.T) {
+func Test_GetOrders_Success_WithOffset(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -37,67 +38,75 @@ func Test_GetAllOrders_Success(t *testing.T) {
 	defer db.Close()
 
 	var eOrders = sqlmock.
-		NewRows([]string{"ID", "Total", "CreatedAt"}).
-		AddRow(1, 20000, time.Now())
+		NewRows([]string{"ID", "InvoiceNumber", "CustomerID", "CashierID", "CashierName", "Status", "Total", "Discount", "CreatedAt"}).
+		AddRow(1, "INV/KSR/20260101/0001", nil, 1, "Arda", "completed", 20000, 0, time.Now())
 	ctx := context.TODO()
-	query := regexp.QuoteMeta("SELECT * from orders ORDER BY created_at DESC")
-	mock.ExpectQuery(query).WillReturnRows(eOrders)
+	query := regexp.QuoteMeta("FROM orders o LEFT JOIN users u ON u.id = o.cashier_id ORDER BY o.id DESC LIMIT ? OFFSET ?")
+	mock.ExpectQuery(query).WithArgs(20, 40).WillReturnRows(eOrders)
 
 	OrderRepository := NewOrderRepository(db)
-	aOrders, err := OrderRepository.GetAllOrders(ctx)
+	aOrders, err := OrderRepository.GetOrders(ctx, entity.OrderQuery{Limit: 20, Offset: 40})
 	assert.Nil(t, err)
-	assert.ObjectsAreEqualValues(eOrders, aOrders)
+	assert.Len(t, aOrders, 1)
+	assert.Equal(t, "Arda", aOrders[0].CashierName)
 }
 
-func Test_GetAnnualIncome_Failed(t *testing.T) {
+func Test_GetOrders_Success_WithFiltersAndCursor(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
 
+	startDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 1, 0)
+	cashierID := int64(2)
+	minTotal, maxTotal := 10000, 50000
+	orderQuery := entity.OrderQuery{
+		StartDate:     &startDate,
+		EndDate:       &endDate,
+		CashierID:     &cashierID,
+		Status:        entity.OrderStatusCompleted,
+		MinTotal:      &minTotal,
+		MaxTotal:      &maxTotal,
+		InvoiceNumber: "0_1",
+		Cursor:        100,
+		Limit:         20,
+	}
+
 	ctx := context.TODO()
-	query := regexp.QuoteMeta(`
-		SELECT YEAR(created_at) as year, MONTHNAME(created_at) as mount, SUM(total) as income
-			FROM orders
-			WHERE MONTH(created_at) -12 AND MONTH(created_at)
-			GROUP BY YEAR(created_at), MONTHNAME(created_at), MONTH(created_at)
-			ORDER BY YEAR(created_at) ASC, MONTH(created_at) ASC`)
-	mock.ExpectQuery(query).WillReturnError(errors.New("failed get anual income"))
+	query := regexp.QuoteMeta("WHERE o.created_at >= ? AND o.created_at < ? AND o.cashier_id = ? AND o.status = ? AND o.total >= ? AND o.total <= ? AND o.invoice_number LIKE ? AND o.id < ? ORDER BY o.id DESC LIMIT ?")
+	mock.ExpectQuery(query).
+		WithArgs(startDate, endDate, cashierID, entity.OrderStatusCompleted, minTotal, maxTotal, `0\_1%`, int64(100), 20).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "InvoiceNumber", "CustomerID", "CashierID", "CashierName", "Status", "Total", "Discount", "CreatedAt"}))
 
 	OrderRepository := NewOrderRepository(db)
-	income, err := OrderRepository.GetAnnualIncome(ctx)
-	assert.NotNil(t, err)
-	assert.Nil(t, income)
+	aOrders, err := OrderRepository.GetOrders(ctx, orderQuery)
+	assert.Nil(t, err)
+	assert.Empty(t, aOrders)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
-func Test_GetAnnualIncome_Success(t *testing.T) {
+func Test_CountOrders_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
 
-	var eIncome = sqlmock.
-		NewRows([]string{"Year", "Month", "Income"}).
-		AddRow(2021, "January", 1400000).
-		AddRow(2021, "February", 2000000)
 	ctx := context.TODO()
-	query := regexp.QuoteMeta(`
-		SELECT YEAR(created_at) as year, MONTHNAME(created_at) as mount, SUM(total) as income
-			FROM orders
-			WHERE MONTH(created_at) -12 AND MONTH(created_at)
-			GROUP BY YEAR(created_at), MONTHNAME(created_at), MONTH(created_at)
-			ORDER BY YEAR(created_at) ASC, MONTH(created_at) ASC`)
-	mock.ExpectQuery(query).WillReturnRows(eIncome)
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders o WHERE o.status = ?")
+	mock.ExpectQuery(query).
+		WithArgs(entity.OrderStatusVoided).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
 
 	OrderRepository := NewOrderRepository(db)
-	aIncome, err := OrderRepository.GetAnnualIncome(ctx)
+	count, err := OrderRepository.CountOrders(ctx, entity.OrderQuery{Status: entity.OrderStatusVoided, Cursor: 10})
 	assert.Nil(t, err)
-	assert.ObjectsAreEqualValues(eIncome, aIncome)
+	assert.Equal(t, 3, count)
 }
 
-func Test_GetDailyOrderCount_Failed(t *testing.T) {
+func Test_GetTotalOrderCount_Failed(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -105,16 +114,16 @@ func Test_GetDailyOrderCount_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders WHERE DAY(created_At) = DAY(CURRENT_TIMESTAMP())")
-	mock.ExpectQuery(query).WillReturnError(errors.New("failed get daily order"))
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders o WHERE o.status = 'completed'")
+	mock.ExpectQuery(query).WillReturnError(errors.New("failed get total orders"))
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetDailyOrderCount(ctx)
+	res, err := OrderRepository.GetTotalOrderCount(ctx)
 	assert.NotNil(t, err)
 	assert.Equal(t, 0, res)
 }
 
-func Test_GetDailyOrderCount_Success(t *testing.T) {
+func Test_GetTotalOrderCount_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -123,16 +132,16 @@ func Test_GetDailyOrderCount_Success(t *testing.T) {
 
 	var order = sqlmock.NewRows([]string{""}).AddRow(0)
 	ctx := context.TODO()
-	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders WHERE DAY(created_At) = DAY(CURRENT_TIMESTAMP())")
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders o WHERE o.status = 'completed'")
 	mock.ExpectQuery(query).WillReturnRows(order)
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetDailyOrderCount(ctx)
+	res, err := OrderRepository.GetTotalOrderCount(ctx)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, res)
 }
 
-func Test_GetTotalOrderCount_Failed(t *testing.T) {
+func Test_GetOrderItemsByID_Failed(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -140,77 +149,77 @@ func Test_GetTotalOrderCount_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders")
-	mock.ExpectQuery(query).WillReturnError(errors.New("failed get total orders"))
+	orderID := int64(1)
+	query := regexp.QuoteMeta(`
+		SELECT p.id as id, oi.order_id, p.id as product_id, p.code, p.name, p.price, oi.quantity, oi.subtotal, oi.created_at
+				FROM order_items AS oi
+				LEFT JOIN products AS p ON oi.product_id = p.id
+				WHERE oi.order_id = ?`)
+	mock.ExpectQuery(query).
+		WithArgs(orderID).
+		WillReturnError(errors.New("failed get orders"))
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetTotalOrderCount(ctx)
+	orders, err := OrderRepository.GetOrderItemsByID(ctx, orderID)
 	assert.NotNil(t, err)
-	assert.Equal(t, 0, res)
-}
-
-func Test_GetTotalOrderCount_Success(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-	}
-	defer db.Close()
-
-	var order = sqlmock.NewRows([]string{""}).AddRow(0)
-	ctx := context.TODO()
-	query := regexp.QuoteMeta("SELECT COUNT(*) FROM orders")
-	mock.ExpectQuery(query).WillReturnRows(order)
-
-	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetTotalOrderCount(ctx)
-	assert.Nil(t, err)
-	assert.Equal(t, 0, res)
+	assert.Nil(t, orders)
 }
 
-func Test_GetLastDayIncome_Failed(t *testing.T) {
+func Test_GetOrderItemsByID_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
 
+	var eOrderItems = sqlmock.
+		NewRows([]string{"ID", "OrderID", "ProductID", "ProductCode", "ProductName", "ProductPrice", "Quantity", "Subtotal", "CreatedAt"}).
+		AddRow(1, 1, 1, "prod-1", "Prod 1", 10000, 2, 10000, time.Now()).
+		AddRow(2, 1, 2, "prod-2", "Prod 2", 15000, 2, 30000, time.Now())
 	ctx := context.TODO()
+	orderID := int64(1)
 	query := regexp.QuoteMeta(`
-		SELECT SUM(total)
-			FROM orders
-			WHERE DAY(created_At) = DAY(CURRENT_TIMESTAMP())
-			GROUP BY DAY(created_At)`)
-	mock.ExpectQuery(query).WillReturnError(errors.New("failed get last daily income orders"))
+		SELECT p.id as id, oi.order_id, p.id as product_id, p.code, p.name, p.price, oi.quantity, oi.subtotal, oi.created_at
+				FROM order_items AS oi
+				LEFT JOIN products AS p ON oi.product_id = p.id
+				WHERE oi.order_id = ?`)
+	mock.ExpectQuery(query).
+		WithArgs(orderID).
+		WillReturnRows(eOrderItems)
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetLastDayIncome(ctx)
-	assert.NotNil(t, err)
-	assert.Equal(t, 0, res)
+	aOrderItems, err := OrderRepository.GetOrderItemsByID(ctx, orderID)
+	assert.Nil(t, err)
+	assert.ObjectsAreEqualValues(eOrderItems, aOrderItems)
 }
 
-func Test_GetLastDayIncome_Success(t *testing.T) {
+func Test_GetOrdersByCustomerID_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
 
-	var order = sqlmock.NewRows([]string{""}).AddRow(0)
+	var eOrders = sqlmock.
+		NewRows([]string{"ID", "InvoiceNumber", "CustomerID", "Status", "Total", "Discount", "CreatedAt"}).
+		AddRow(1, "INV/KSR/20260101/0001", 3, "completed", 20000, 0, time.Now()).
+		AddRow(2, "", 3, "voided", 15000, 5000, time.Now())
 	ctx := context.TODO()
 	query := regexp.QuoteMeta(`
-		SELECT SUM(total)
+		SELECT id, COALESCE(invoice_number, ''), customer_id, status, total, discount, created_at
 			FROM orders
-			WHERE DAY(created_At) = DAY(CURRENT_TIMESTAMP())
-			GROUP BY DAY(created_At)`)
-	mock.ExpectQuery(query).WillReturnRows(order)
+			WHERE customer_id = ?
+			ORDER BY created_at DESC`)
+	mock.ExpectQuery(query).WithArgs(3).WillReturnRows(eOrders)
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetLastDayIncome(ctx)
+	aOrders, err := OrderRepository.GetOrdersByCustomerID(ctx, 3)
 	assert.Nil(t, err)
-	assert.Equal(t, 0, res)
+	assert.Len(t, aOrders, 2)
+	assert.Equal(t, int64(3), *aOrders[0].CustomerID)
 }
 
-func Test_GetLastMonthIncome_Failed(t *testing.T) {
+func Test_GetOrderHeatmap_Failed(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -218,42 +227,46 @@ func Test_GetLastMonthIncome_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	query := regexp.QuoteMeta(`
-		SELECT SUM(total)
-			FROM orders
-			WHERE MONTH(created_At) = MONTH(CURRENT_TIMESTAMP())
-			GROUP BY MONTH(created_At)`)
-	mock.ExpectQuery(query).WillReturnError(errors.New("failed get last month income orders"))
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 28)
+	query := regexp.QuoteMeta("SELECT WEEKDAY(created_at) AS weekday, HOUR(created_at) AS hour")
+	mock.ExpectQuery(query).
+		WithArgs(start, end).
+		WillReturnError(errors.New("failed get heatmap"))
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetLastMonthIncome(ctx)
+	cells, err := OrderRepository.GetOrderHeatmap(ctx, start, end)
 	assert.NotNil(t, err)
-	assert.Equal(t, 0, res)
+	assert.Nil(t, cells)
 }
 
-func Test_GetLastMonthIncome_Success(t *testing.T) {
+func Test_GetOrderHeatmap_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
 
-	var order = sqlmock.NewRows([]string{""}).AddRow(0)
 	ctx := context.TODO()
-	query := regexp.QuoteMeta(`
-		SELECT SUM(total)
-			FROM orders
-			WHERE MONTH(created_At) = MONTH(CURRENT_TIMESTAMP())
-			GROUP BY MONTH(created_At)`)
-	mock.ExpectQuery(query).WillReturnRows(order)
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 28)
+	query := regexp.QuoteMeta(`FROM orders
+			WHERE created_at >= ? AND created_at < ? AND status != 'voided'
+			GROUP BY weekday, hour`)
+	rows := sqlmock.
+		NewRows([]string{"weekday", "hour", "orders", "revenue"}).
+		AddRow(0, 9, 4, 120000).
+		AddRow(5, 18, 7, 300000)
+	mock.ExpectQuery(query).WithArgs(start, end).WillReturnRows(rows)
 
 	OrderRepository := NewOrderRepository(db)
-	res, err := OrderRepository.GetLastMonthIncome(ctx)
+	cells, err := OrderRepository.GetOrderHeatmap(ctx, start, end)
 	assert.Nil(t, err)
-	assert.Equal(t, 0, res)
+	assert.Len(t, cells, 2)
+	assert.Equal(t, &entity.OrderHeatmapCell{Weekday: 5, Hour: 18, Orders: 7, Revenue: 300000}, cells[1])
 }
 
-func Test_GetOrderItemsByID_Failed(t *testing.T) {
+func Test_GetCustomerLifetimeValue_Success_WithoutOrders(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
@@ -261,48 +274,38 @@ func Test_GetOrderItemsByID_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	orderID := int64(1)
-	query := regexp.QuoteMeta(`
-		SELECT p.id as id, oi.order_id, p.id as product_id, p.code, p.name, p.price, oi.quantity, oi.subtotal, oi.created_at
-				FROM order_items AS oi
-				LEFT JOIN products AS p ON oi.product_id = p.id
-				WHERE oi.order_id = ?`)
+	query := regexp.QuoteMeta("SELECT COUNT(*), COALESCE(SUM(total), 0), MAX(created_at)")
 	mock.ExpectQuery(query).
-		WithArgs(orderID).
-		WillReturnError(errors.New("failed get orders"))
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"", "", ""}).AddRow(0, 0, nil))
 
 	OrderRepository := NewOrderRepository(db)
-	orders, err := OrderRepository.GetOrderItemsByID(ctx, orderID)
-	assert.NotNil(t, err)
-	assert.Nil(t, orders)
+	lifetimeValue, err := OrderRepository.GetCustomerLifetimeValue(ctx, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, lifetimeValue.OrderCount)
+	assert.Nil(t, lifetimeValue.LastOrderAt)
 }
 
-func Test_GetOrderItemsByID_Success(t *testing.T) {
+func Test_GetCustomerLifetimeValue_Success(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 	defer db.Close()
 
-	var eOrderItems = sqlmock.
-		NewRows([]string{"ID", "OrderID", "ProductID", "ProductCode", "ProductName", "ProductPrice", "Quantity", "Subtotal", "CreatedAt"}).
-		AddRow(1, 1, 1, "prod-1", "Prod 1", 10000, 2, 10000, time.Now()).
-		AddRow(2, 1, 2, "prod-2", "Prod 2", 15000, 2, 30000, time.Now())
+	lastOrderAt := time.Now()
 	ctx := context.TODO()
-	orderID := int64(1)
-	query := regexp.QuoteMeta(`
-		SELECT p.id as id, oi.order_id, p.id as product_id, p.code, p.name, p.price, oi.quantity, oi.subtotal, oi.created_at
-				FROM order_items AS oi
-				LEFT JOIN products AS p ON oi.product_id = p.id
-				WHERE oi.order_id = ?`)
+	query := regexp.QuoteMeta("SELECT COUNT(*), COALESCE(SUM(total), 0), MAX(created_at)")
 	mock.ExpectQuery(query).
-		WithArgs(orderID).
-		WillReturnRows(eOrderItems)
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"", "", ""}).AddRow(2, 35000, lastOrderAt))
 
 	OrderRepository := NewOrderRepository(db)
-	aOrderItems, err := OrderRepository.GetOrderItemsByID(ctx, orderID)
+	lifetimeValue, err := OrderRepository.GetCustomerLifetimeValue(ctx, 3)
 	assert.Nil(t, err)
-	assert.ObjectsAreEqualValues(eOrderItems, aOrderItems)
+	assert.Equal(t, 2, lifetimeValue.OrderCount)
+	assert.Equal(t, 35000, lifetimeValue.TotalSpent)
+	assert.True(t, lastOrderAt.Equal(*lifetimeValue.LastOrderAt))
 }
 
 func Test_CreateOrder_Failed(t *testing.T) {
@@ -330,9 +333,9 @@ func Test_CreateOrder_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	queryCreate := regexp.QuoteMeta("INSERT INTO orders(total) VALUES(?)")
+	queryCreate := regexp.QuoteMeta("INSERT INTO orders(invoice_number, customer_id, cashier_id, total, discount, idempotency_key, request_hash) VALUES(?, ?, ?, ?, ?, ?, ?)")
 	mock.ExpectExec(queryCreate).
-		WithArgs(param.Total).
+		WithArgs(param.InvoiceNumber, param.CustomerID, param.CashierID, param.Total, param.Discount, nil, param.RequestHash).
 		WillReturnError(errors.New("failed create order"))
 
 	OrderRepository := NewOrderRepository(db)
@@ -370,9 +373,9 @@ func Test_CreateOrder_Success(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	queryCreate := regexp.QuoteMeta("INSERT INTO orders(total) VALUES(?)")
+	queryCreate := regexp.QuoteMeta("INSERT INTO orders(invoice_number, customer_id, cashier_id, total, discount, idempotency_key, request_hash) VALUES(?, ?, ?, ?, ?, ?, ?)")
 	mock.ExpectExec(queryCreate).
-		WithArgs(param.Total).
+		WithArgs(param.InvoiceNumber, param.CustomerID, param.CashierID, param.Total, param.Discount, nil, param.RequestHash).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	OrderRepository := NewOrderRepository(db)
@@ -381,6 +384,59 @@ func Test_CreateOrder_Success(t *testing.T) {
 	assert.ObjectsAreEqualValues(eOrder, aOrder)
 }
 
+func Test_CreateOrder_Failed_WhenUniqueKeyIsDuplicated(t *testing.T) {
+	param := entity.CreateOrderParam{
+		InvoiceNumber:  "INV/20260101/0001",
+		Total:          10000,
+		IdempotencyKey: "5f1c2a4e-retry",
+		RequestHash:    "request-hash",
+	}
+
+	tests := []struct {
+		name        string
+		message     string
+		isDuplicate bool
+	}{
+		{
+			name:        "idempotency key",
+			message:     "Duplicate entry '5f1c2a4e-retry' for key 'idempotency_key'",
+			isDuplicate: true,
+		},
+		{
+			name:        "idempotency key named with its table",
+			message:     "Duplicate entry '5f1c2a4e-retry' for key 'orders.idempotency_key'",
+			isDuplicate: true,
+		},
+		{
+			name:    "invoice number",
+			message: "Duplicate entry 'INV/20260101/0001' for key 'orders.invoice_number'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			ctx := context.TODO()
+			queryCreate := regexp.QuoteMeta("INSERT INTO orders(invoice_number, customer_id, cashier_id, total, discount, idempotency_key, request_hash) VALUES(?, ?, ?, ?, ?, ?, ?)")
+			mock.ExpectExec(queryCreate).
+				WithArgs(param.InvoiceNumber, param.CustomerID, param.CashierID, param.Total, param.Discount, param.IdempotencyKey, param.RequestHash).
+				WillReturnError(&mysqldriver.MySQLError{Number: 1062, Message: test.message})
+
+			OrderRepository := NewOrderRepository(db)
+			aOrder, err := OrderRepository.Create(ctx, param)
+			assert.NotNil(t, err)
+			_, isDuplicate := err.(entity.ErrItemAlreadyExists)
+			assert.Equal(t, test.isDuplicate, isDuplicate)
+			assert.Nil(t, aOrder)
+		})
+	}
+}
+
 func Test_CreateOrderItems_Failed(t *testing.T) {
 	orderID := int64(1)
 	param := []*entity.CreateOrderItemParam{
@@ -457,3 +513,98 @@ func Test_CreateOrderItems_Success(t *testing.T) {
 	}
 	assert.Nil(t, err)
 }
+
+func Test_NextInvoiceSequence_Failed_WhenNotInTransaction(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	orderRepository := NewOrderRepository(db)
+	seq, err := orderRepository.NextInvoiceSequence(context.TODO(), "20260101")
+	assert.NotNil(t, err)
+	assert.Zero(t, seq)
+}
+
+func Test_NextInvoiceSequence_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO invoice_sequences(period, last_number) VALUES(?, 1) ON DUPLICATE KEY UPDATE last_number = last_number + 1")).
+		WithArgs("20260101").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT last_number FROM invoice_sequences WHERE period = ?")).
+		WithArgs("20260101").
+		WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(42))
+
+	uow := NewMySQLUnitOfWork(db)
+	txContext, err := uow.Begin(context.TODO())
+	assert.Nil(t, err)
+
+	orderRepository := NewOrderRepository(db)
+	seq, err := orderRepository.NextInvoiceSequence(txContext, "20260101")
+	assert.Nil(t, err)
+	assert.Equal(t, 42, seq)
+}
+
+func Test_GetOrderByInvoiceNumber_Failed_WhenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("SELECT id, invoice_number, customer_id, status, total, discount, created_at FROM orders WHERE invoice_number = ?")
+	mock.ExpectQuery(query).
+		WithArgs("INV/KSR/20260101/0001").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "invoice_number", "customer_id", "status", "total", "discount", "created_at"}))
+
+	orderRepository := NewOrderRepository(db)
+	order, err := orderRepository.GetOrderByInvoiceNumber(context.TODO(), "INV/KSR/20260101/0001")
+	assert.Nil(t, order)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+}
+
+func Test_UpdateOrderStatus_Success(t *testing.T) {
+	tests := []struct {
+		status string
+		query  string
+	}{
+		{
+			status: entity.OrderStatusVoided,
+			query:  "UPDATE orders SET status = ?, voided_at = CURRENT_TIMESTAMP WHERE id = ?",
+		},
+		{
+			status: entity.OrderStatusRefunded,
+			query:  "UPDATE orders SET status = ?, refunded_at = CURRENT_TIMESTAMP WHERE id = ?",
+		},
+		{
+			status: entity.OrderStatusCompleted,
+			query:  "UPDATE orders SET status = ? WHERE id = ?",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(regexp.QuoteMeta(test.query)).
+				WithArgs(test.status, int64(1)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			orderRepository := NewOrderRepository(db)
+			err = orderRepository.UpdateStatusByID(context.TODO(), 1, test.status)
+			assert.Nil(t, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
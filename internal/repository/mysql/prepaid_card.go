@@ -0,0 +1,328 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type PrepaidCardRepository struct {
+	DB *sql.DB
+}
+
+func NewPrepaidCardRepository(DB *sql.DB) *PrepaidCardRepository {
+	return &PrepaidCardRepository{DB: DB}
+}
+
+func (repo PrepaidCardRepository) GetAllCards(ctx context.Context) ([]*entity.PrepaidCard, error) {
+	query := `
+		SELECT id, number, type, customer_id, order_id, value, balance, created_at, updated_at
+			FROM prepaid_cards
+			ORDER BY created_at DESC`
+	return repo.queryCards(ctx, query)
+}
+
+func (repo PrepaidCardRepository) GetCardsByOrderID(ctx context.Context, orderID int64) ([]*entity.PrepaidCard, error) {
+	query := `
+		SELECT id, number, type, customer_id, order_id, value, balance, created_at, updated_at
+			FROM prepaid_cards
+			WHERE order_id = ?
+			ORDER BY id ASC`
+	return repo.queryCards(ctx, query, orderID)
+}
+
+func (repo PrepaidCardRepository) queryCards(ctx context.Context, query string, args ...interface{}) ([]*entity.PrepaidCard, error) {
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards := []*entity.PrepaidCard{}
+	for rows.Next() {
+		var card entity.PrepaidCard
+		var err = rows.Scan(
+			&card.ID,
+			&card.Number,
+			&card.Type,
+			&card.CustomerID,
+			&card.OrderID,
+			&card.Value,
+			&card.Balance,
+			&card.CreatedAt,
+			&card.UpdatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		cards = append(cards, &card)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return cards, nil
+}
+
+func (repo PrepaidCardRepository) GetCardByID(ctx context.Context, ID int64) (*entity.PrepaidCard, error) {
+	var row *sql.Row
+	query := `
+		SELECT id, number, type, customer_id, order_id, value, balance, created_at, updated_at
+			FROM prepaid_cards
+			WHERE id = ?`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	return repo.scanCard(row)
+}
+
+func (repo PrepaidCardRepository) GetCardByNumber(ctx context.Context, number string) (*entity.PrepaidCard, error) {
+	var row *sql.Row
+	query := `
+		SELECT id, number, type, customer_id, order_id, value, balance, created_at, updated_at
+			FROM prepaid_cards
+			WHERE number = ?`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, number)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, number)
+	}
+
+	return repo.scanCard(row)
+}
+
+// GetCardByNumberForUpdate locks the card row until the transaction ends so
+// concurrent orders cannot spend the same balance twice.
+func (repo PrepaidCardRepository) GetCardByNumberForUpdate(ctx context.Context, number string) (*entity.PrepaidCard, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return nil, errors.New("failed get transcation context")
+	}
+
+	query := `
+		SELECT id, number, type, customer_id, order_id, value, balance, created_at, updated_at
+			FROM prepaid_cards
+			WHERE number = ? FOR UPDATE`
+	row := tx.QueryRowContext(ctx, query, number)
+	return repo.scanCard(row)
+}
+
+func (repo PrepaidCardRepository) scanCard(row *sql.Row) (*entity.PrepaidCard, error) {
+	var card entity.PrepaidCard
+	var err = row.Scan(
+		&card.ID,
+		&card.Number,
+		&card.Type,
+		&card.CustomerID,
+		&card.OrderID,
+		&card.Value,
+		&card.Balance,
+		&card.CreatedAt,
+		&card.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Prepaid card not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+func (repo PrepaidCardRepository) GetTransactionsByCardID(ctx context.Context, cardID int64) ([]*entity.PrepaidTransaction, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT id, prepaid_card_id, order_id, type, amount, created_at
+			FROM prepaid_transactions
+			WHERE prepaid_card_id = ?
+			ORDER BY created_at DESC, id DESC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, cardID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, cardID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []*entity.PrepaidTransaction{}
+	for rows.Next() {
+		var transaction entity.PrepaidTransaction
+		var err = rows.Scan(
+			&transaction.ID,
+			&transaction.PrepaidCardID,
+			&transaction.OrderID,
+			&transaction.Type,
+			&transaction.Amount,
+			&transaction.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		transactions = append(transactions, &transaction)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// GetOrderRedemptions returns the amount still owed back to each card that
+// was used to pay the order, keyed by card ID.
+func (repo PrepaidCardRepository) GetOrderRedemptions(ctx context.Context, orderID int64) (map[int64]int, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT prepaid_card_id, -SUM(amount)
+			FROM prepaid_transactions
+			WHERE order_id = ? AND type IN ('redeem', 'refund')
+			GROUP BY prepaid_card_id
+			HAVING SUM(amount) < 0`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, orderID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, orderID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	redemptions := map[int64]int{}
+	for rows.Next() {
+		var cardID int64
+		var amount int
+		if err := rows.Scan(&cardID, &amount); err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		redemptions[cardID] = amount
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return redemptions, nil
+}
+
+func (repo PrepaidCardRepository) Create(ctx context.Context, param entity.CreatePrepaidCardParam) (*entity.PrepaidCard, error) {
+	query := "INSERT INTO prepaid_cards(number, type, customer_id, order_id, value, balance) VALUES(?, ?, ?, ?, ?, ?)"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.Number, param.Type, param.CustomerID, param.OrderID, param.Value, param.Value)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.Number, param.Type, param.CustomerID, param.OrderID, param.Value, param.Value)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetCardByID(ctx, ID)
+}
+
+func (repo PrepaidCardRepository) CreateTransaction(ctx context.Context, param entity.CreatePrepaidTransactionParam) error {
+	query := "INSERT INTO prepaid_transactions(prepaid_card_id, order_id, type, amount) VALUES(?, ?, ?, ?)"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.PrepaidCardID, param.OrderID, param.Type, param.Amount)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.PrepaidCardID, param.OrderID, param.Type, param.Amount)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo PrepaidCardRepository) IncrementBalanceByID(ctx context.Context, ID int64, amount int) error {
+	query := "UPDATE prepaid_cards SET balance = balance + ?, updated_at = CURRENT_TIMESTAMP() WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, amount, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, amount, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DecrementBalanceByID spends the amount from the card unless the balance is
+// not enough, in which case it reports false.
+func (repo PrepaidCardRepository) DecrementBalanceByID(ctx context.Context, ID int64, amount int) (bool, error) {
+	query := `
+		UPDATE prepaid_cards SET balance = balance - ?, updated_at = CURRENT_TIMESTAMP()
+			WHERE id = ? AND balance >= ?`
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, amount, ID, amount)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, amount, ID, amount)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,124 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var prepaidCardColumnNames = []string{"id", "number", "type", "customer_id", "order_id", "value", "balance", "created_at", "updated_at"}
+
+func Test_GetCardByNumber_Failed_WhenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("FROM prepaid_cards")
+	mock.ExpectQuery(query).
+		WithArgs("GC-1").
+		WillReturnRows(sqlmock.NewRows(prepaidCardColumnNames))
+
+	prepaidCardRepository := NewPrepaidCardRepository(db)
+	card, err := prepaidCardRepository.GetCardByNumber(context.TODO(), "GC-1")
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, card)
+}
+
+func Test_GetCardByNumberForUpdate_Failed_WithoutTransaction(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	prepaidCardRepository := NewPrepaidCardRepository(db)
+	card, err := prepaidCardRepository.GetCardByNumberForUpdate(context.TODO(), "GC-1")
+	assert.NotNil(t, err)
+	assert.Nil(t, card)
+}
+
+func Test_GetCardByNumberForUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE number = ? FOR UPDATE")).
+		WithArgs("GC-1").
+		WillReturnRows(sqlmock.NewRows(prepaidCardColumnNames).
+			AddRow(1, "GC-1", entity.PrepaidCardTypeGiftCard, nil, 3, 50000, 20000, time.Now(), time.Now()))
+
+	uow := NewMySQLUnitOfWork(db)
+	txContext, err := uow.Begin(context.TODO())
+	assert.Nil(t, err)
+
+	prepaidCardRepository := NewPrepaidCardRepository(db)
+	card, err := prepaidCardRepository.GetCardByNumberForUpdate(txContext, "GC-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 20000, card.Balance)
+	assert.Equal(t, int64(3), *card.OrderID)
+	assert.True(t, card.IsUsed())
+}
+
+func Test_GetOrderRedemptions_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("FROM prepaid_transactions")
+	mock.ExpectQuery(query).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"prepaid_card_id", "amount"}).AddRow(1, 15000).AddRow(2, 3000))
+
+	prepaidCardRepository := NewPrepaidCardRepository(db)
+	redemptions, err := prepaidCardRepository.GetOrderRedemptions(context.TODO(), 5)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int64]int{1: 15000, 2: 3000}, redemptions)
+}
+
+func Test_DecrementBalanceByID_Failed_WhenBalanceIsNotEnough(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE prepaid_cards SET balance = balance - ?")
+	mock.ExpectExec(query).
+		WithArgs(5000, int64(1), 5000).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prepaidCardRepository := NewPrepaidCardRepository(db)
+	ok, err := prepaidCardRepository.DecrementBalanceByID(context.TODO(), 1, 5000)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func Test_DecrementBalanceByID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE prepaid_cards SET balance = balance - ?")
+	mock.ExpectExec(query).
+		WithArgs(5000, int64(1), 5000).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	prepaidCardRepository := NewPrepaidCardRepository(db)
+	ok, err := prepaidCardRepository.DecrementBalanceByID(context.TODO(), 1, 5000)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
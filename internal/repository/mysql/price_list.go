@@ -0,0 +1,275 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type PriceListRepository struct {
+	DB *sql.DB
+}
+
+func NewPriceListRepository(DB *sql.DB) *PriceListRepository {
+	return &PriceListRepository{DB: DB}
+}
+
+func (repo PriceListRepository) GetAllPriceLists(ctx context.Context) ([]*entity.PriceList, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT pl.id, pl.customer_group_id, cg.name, pl.name, pl.starts_on, pl.ends_on, pl.created_at
+			FROM price_lists pl
+			JOIN customer_groups cg ON cg.id = pl.customer_group_id
+			ORDER BY cg.name ASC, pl.name ASC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	priceLists := []*entity.PriceList{}
+	for rows.Next() {
+		var priceList entity.PriceList
+		var err = rows.Scan(
+			&priceList.ID,
+			&priceList.CustomerGroupID,
+			&priceList.CustomerGroupName,
+			&priceList.Name,
+			&priceList.StartsOn,
+			&priceList.EndsOn,
+			&priceList.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		priceLists = append(priceLists, &priceList)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return priceLists, nil
+}
+
+func (repo PriceListRepository) GetPriceListByID(ctx context.Context, ID int64) (*entity.PriceList, error) {
+	var row *sql.Row
+	query := `
+		SELECT pl.id, pl.customer_group_id, cg.name, pl.name, pl.starts_on, pl.ends_on, pl.created_at
+			FROM price_lists pl
+			JOIN customer_groups cg ON cg.id = pl.customer_group_id
+			WHERE pl.id = ?`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	var priceList entity.PriceList
+	var err = row.Scan(
+		&priceList.ID,
+		&priceList.CustomerGroupID,
+		&priceList.CustomerGroupName,
+		&priceList.Name,
+		&priceList.StartsOn,
+		&priceList.EndsOn,
+		&priceList.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Price list not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &priceList, nil
+}
+
+func (repo PriceListRepository) Create(ctx context.Context, param entity.CreatePriceListParam) (*entity.PriceList, error) {
+	query := "INSERT INTO price_lists(customer_group_id, name, starts_on, ends_on) VALUES(?, ?, ?, ?)"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.CustomerGroupID, param.Name, param.StartsOn, param.EndsOn)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.CustomerGroupID, param.Name, param.StartsOn, param.EndsOn)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetPriceListByID(ctx, ID)
+}
+
+func (repo PriceListRepository) DeleteByID(ctx context.Context, ID int64) (bool, error) {
+	query := "DELETE FROM price_lists WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo PriceListRepository) GetItemsByPriceListID(ctx context.Context, priceListID int64) ([]*entity.PriceListItem, error) {
+	query := `
+		SELECT pli.id, pli.price_list_id, pli.product_id, p.code, p.name, pli.min_quantity, pli.price, pli.created_at
+			FROM price_list_items pli
+			JOIN products p ON p.id = pli.product_id
+			WHERE pli.price_list_id = ?
+			ORDER BY p.name ASC, pli.min_quantity ASC`
+	return repo.queryItems(ctx, query, priceListID)
+}
+
+// GetActiveItemsByGroupID returns the items of the price lists of a customer
+// group that are valid on the given date. Only the items of the given products
+// are returned, or the items of every product when none is given.
+func (repo PriceListRepository) GetActiveItemsByGroupID(ctx context.Context, customerGroupID int64, date time.Time, productIDs ...int64) ([]*entity.PriceListItem, error) {
+	day := date.Format("2006-01-02")
+	args := []interface{}{customerGroupID, day, day}
+	productFilter := ""
+	if len(productIDs) > 0 {
+		placeholders := []string{}
+		for _, productID := range productIDs {
+			placeholders = append(placeholders, "?")
+			args = append(args, productID)
+		}
+		productFilter = fmt.Sprintf("AND pli.product_id IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT pli.id, pli.price_list_id, pli.product_id, p.code, p.name, pli.min_quantity, pli.price, pli.created_at
+			FROM price_list_items pli
+			JOIN price_lists pl ON pl.id = pli.price_list_id
+			JOIN products p ON p.id = pli.product_id
+			WHERE pl.customer_group_id = ?
+				AND (pl.starts_on IS NULL OR pl.starts_on <= ?)
+				AND (pl.ends_on IS NULL OR pl.ends_on >= ?)
+				%s
+			ORDER BY pli.product_id ASC, pli.min_quantity ASC`, productFilter)
+	return repo.queryItems(ctx, query, args...)
+}
+
+func (repo PriceListRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*entity.PriceListItem, error) {
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*entity.PriceListItem{}
+	for rows.Next() {
+		var item entity.PriceListItem
+		var err = rows.Scan(
+			&item.ID,
+			&item.PriceListID,
+			&item.ProductID,
+			&item.ProductCode,
+			&item.ProductName,
+			&item.MinQuantity,
+			&item.Price,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		items = append(items, &item)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// SaveItems creates the items of a price list, replacing the price of an
+// existing item with the same product and minimum quantity.
+func (repo PriceListRepository) SaveItems(ctx context.Context, priceListID int64, params []*entity.SavePriceListItemParam) error {
+	saveItemParams := []string{}
+	saveItemVals := []interface{}{}
+	for _, param := range params {
+		saveItemParams = append(saveItemParams, "(?, ?, ?, ?)")
+		saveItemVals = append(saveItemVals, priceListID, param.ProductID, param.MinQuantity, param.Price)
+	}
+	saveItemParamQuery := strings.Join(saveItemParams, ", ")
+
+	query := fmt.Sprintf(`
+		INSERT INTO price_list_items(price_list_id, product_id, min_quantity, price) VALUES %s
+			ON DUPLICATE KEY UPDATE price = VALUES(price)`, saveItemParamQuery)
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, saveItemVals...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, saveItemVals...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo PriceListRepository) DeleteItemByID(ctx context.Context, priceListID int64, ID int64) (bool, error) {
+	query := "DELETE FROM price_list_items WHERE id = ? AND price_list_id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, ID, priceListID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, ID, priceListID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var priceListItemColumnNames = []string{"id", "price_list_id", "product_id", "code", "name", "min_quantity", "price", "created_at"}
+
+func Test_GetPriceListByID_Failed_WhenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE pl.id = ?")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "customer_group_id", "name", "name", "starts_on", "ends_on", "created_at"}))
+
+	priceListRepository := NewPriceListRepository(db)
+	priceList, err := priceListRepository.GetPriceListByID(context.TODO(), 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, priceList)
+}
+
+func Test_GetActiveItemsByGroupID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	date := time.Date(2021, 6, 15, 10, 0, 0, 0, time.Local)
+	query := regexp.QuoteMeta("AND pli.product_id IN (?, ?)")
+	mock.ExpectQuery(query).
+		WithArgs(int64(2), "2021-06-15", "2021-06-15", int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows(priceListItemColumnNames).
+			AddRow(1, 1, 1, "prod-1", "Prod 1", 1, 4500, time.Now()).
+			AddRow(2, 1, 2, "prod-2", "Prod 2", 10, 8000, time.Now()))
+
+	priceListRepository := NewPriceListRepository(db)
+	items, err := priceListRepository.GetActiveItemsByGroupID(context.TODO(), 2, date, 1, 2)
+	assert.Nil(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, 10, items[1].MinQuantity)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_SaveItems_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	params := []*entity.SavePriceListItemParam{
+		{ProductID: 1, MinQuantity: 1, Price: 4500},
+		{ProductID: 2, MinQuantity: 10, Price: 8000},
+	}
+	query := regexp.QuoteMeta("INSERT INTO price_list_items(price_list_id, product_id, min_quantity, price) VALUES (?, ?, ?, ?), (?, ?, ?, ?)")
+	mock.ExpectExec(query).
+		WithArgs(int64(3), int64(1), 1, 4500, int64(3), int64(2), 10, 8000).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	priceListRepository := NewPriceListRepository(db)
+	err = priceListRepository.SaveItems(context.TODO(), 3, params)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -8,6 +8,8 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
 )
@@ -47,6 +49,10 @@ func (repo ProductRepository) GetAllProducts(ctx context.Context) ([]*entity.Pro
 			&product.Stock,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.Category,
+			&product.IsGiftCard,
+			&product.StockPolicy,
+			&product.Cost,
 		)
 		if err != nil {
 			log.Println(err.Error())
@@ -63,6 +69,180 @@ func (repo ProductRepository) GetAllProducts(ctx context.Context) ([]*entity.Pro
 	return products, nil
 }
 
+// productSortColumns maps the sortable ProductQuery fields to their columns.
+var productSortColumns = map[string]string{
+	"code":  "code",
+	"name":  "name",
+	"price": "price",
+	"stock": "stock",
+}
+
+// productQueryConditions builds the WHERE clause shared by the product search
+// and its count. Keywords match whole-word prefixes of the name through the
+// full-text index, or a prefix of the product code.
+func productQueryConditions(query entity.ProductQuery) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	if keyword := strings.TrimSpace(query.Keyword); keyword != "" {
+		words := strings.FieldsFunc(keyword, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		terms := []string{}
+		for _, word := range words {
+			terms = append(terms, "+"+word+"*")
+		}
+
+		if len(terms) > 0 {
+			conditions = append(conditions, "(MATCH(name) AGAINST(? IN BOOLEAN MODE) OR code LIKE ?)")
+			args = append(args, strings.Join(terms, " "), likeEscaper.Replace(keyword)+"%")
+		} else {
+			conditions = append(conditions, "code LIKE ?")
+			args = append(args, likeEscaper.Replace(keyword)+"%")
+		}
+	}
+
+	if query.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, query.Category)
+	}
+
+	if query.InStock {
+		conditions = append(conditions, "stock > 0")
+	}
+
+	if len(query.IDs) > 0 {
+		params := []string{}
+		for _, ID := range query.IDs {
+			params = append(params, "?")
+			args = append(args, ID)
+		}
+		conditions = append(conditions, fmt.Sprintf("id IN (%s)", strings.Join(params, ", ")))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (repo ProductRepository) SearchProducts(ctx context.Context, query entity.ProductQuery) ([]*entity.Product, error) {
+	direction := "ASC"
+	sort := query.Sort
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = strings.TrimPrefix(sort, "-")
+	}
+
+	column, ok := productSortColumns[sort]
+	if !ok {
+		column = "name"
+	}
+
+	where, args := productQueryConditions(query)
+	statement := fmt.Sprintf("SELECT * FROM products %s ORDER BY %s %s, id ASC LIMIT ? OFFSET ?", where, column, direction)
+	args = append(args, query.Limit, query.Offset)
+
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(statement, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, statement, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*entity.Product{}
+	for rows.Next() {
+		var product entity.Product
+		var err = rows.Scan(
+			&product.ID,
+			&product.Code,
+			&product.Name,
+			&product.Price,
+			&product.Stock,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Category,
+			&product.IsGiftCard,
+			&product.StockPolicy,
+			&product.Cost,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		products = append(products, &product)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (repo ProductRepository) CountProducts(ctx context.Context, query entity.ProductQuery) (int, error) {
+	where, args := productQueryConditions(query)
+	statement := fmt.Sprintf("SELECT COUNT(*) FROM products %s", where)
+	var row *sql.Row
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(statement, args...)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, statement, args...)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo ProductRepository) GetProductCategories(ctx context.Context) ([]string, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT DISTINCT category FROM products WHERE category != '' ORDER BY category ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []string{}
+	for rows.Next() {
+		var category string
+		if err := rows.Scan(&category); err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// GetBestSellerProducts ranks the products by the quantity sold in completed
+// orders.
 func (repo ProductRepository) GetBestSellerProducts(ctx context.Context) ([]*entity.ProductSale, error) {
 	var rows *sql.Rows
 	var err error
@@ -70,6 +250,8 @@ func (repo ProductRepository) GetBestSellerProducts(ctx context.Context) ([]*ent
 		SELECT p.ID, p.Code, p.Name, SUM(oi.quantity) as total_sales
 			FROM products AS p  JOIN order_items AS oi
 			ON p.id = oi.product_id
+			JOIN orders AS o ON o.id = oi.order_id
+			WHERE o.status = 'completed'
 			GROUP BY oi.product_id
 			ORDER BY total_sales DESC
 			LIMIT 5`
@@ -108,6 +290,163 @@ func (repo ProductRepository) GetBestSellerProducts(ctx context.Context) ([]*ent
 	return productSales, nil
 }
 
+// GetProductPerformance sums the completed sales of every product in
+// [start, end), products that did not sell are given with nothing sold.
+func (repo ProductRepository) GetProductPerformance(ctx context.Context, start time.Time, end time.Time) ([]*entity.ProductPerformance, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT p.id, p.code, p.name, p.category, p.stock, p.price, p.cost,
+				COALESCE(s.quantity, 0), COALESCE(s.revenue, 0)
+			FROM products p
+			LEFT JOIN (
+				SELECT oi.product_id, SUM(oi.quantity) AS quantity, SUM(oi.subtotal) AS revenue
+					FROM order_items oi
+					JOIN orders o ON o.id = oi.order_id
+					WHERE o.created_at >= ? AND o.created_at < ? AND o.status = 'completed'
+					GROUP BY oi.product_id
+			) s ON s.product_id = p.id
+			WHERE p.is_gift_card = 0`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, start, end)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, start, end)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	performances := []*entity.ProductPerformance{}
+	for rows.Next() {
+		var performance entity.ProductPerformance
+		var err = rows.Scan(
+			&performance.ProductID,
+			&performance.Code,
+			&performance.Name,
+			&performance.Category,
+			&performance.Stock,
+			&performance.Price,
+			&performance.Cost,
+			&performance.Quantity,
+			&performance.Revenue)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		performances = append(performances, &performance)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return performances, nil
+}
+
+// GetDailySales sums the completed sales of every product per day in
+// [start, end), days without a sale are left out.
+func (repo ProductRepository) GetDailySales(ctx context.Context, start time.Time, end time.Time) ([]*entity.DailyProductSales, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT oi.product_id, DATE(o.created_at) AS day, SUM(oi.quantity)
+			FROM order_items oi
+			JOIN orders o ON o.id = oi.order_id
+			WHERE o.created_at >= ? AND o.created_at < ? AND o.status = 'completed'
+			GROUP BY oi.product_id, day`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, start, end)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, start, end)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := []*entity.DailyProductSales{}
+	for rows.Next() {
+		var sale entity.DailyProductSales
+		var err = rows.Scan(&sale.ProductID, &sale.Date, &sale.Quantity)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		sales = append(sales, &sale)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+// GetDeadStock lists the products with stock left and no completed sale
+// since the given time, the ones that sold longest ago first.
+func (repo ProductRepository) GetDeadStock(ctx context.Context, since time.Time) ([]*entity.DeadStockProduct, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT p.id, p.code, p.name, p.category, p.stock, p.price, p.cost, MAX(o.created_at) AS last_sold_at
+			FROM products p
+			LEFT JOIN order_items oi ON oi.product_id = p.id
+			LEFT JOIN orders o ON o.id = oi.order_id AND o.status = 'completed'
+			WHERE p.is_gift_card = 0 AND p.stock > 0
+			GROUP BY p.id
+			HAVING last_sold_at IS NULL OR last_sold_at < ?
+			ORDER BY last_sold_at ASC, p.id ASC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, since)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, since)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []*entity.DeadStockProduct{}
+	for rows.Next() {
+		var product entity.DeadStockProduct
+		var lastSoldAt sql.NullTime
+		var err = rows.Scan(
+			&product.ProductID,
+			&product.Code,
+			&product.Name,
+			&product.Category,
+			&product.Stock,
+			&product.Price,
+			&product.Cost,
+			&lastSoldAt)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		if lastSoldAt.Valid {
+			product.LastSoldAt = &lastSoldAt.Time
+		}
+
+		products = append(products, &product)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return products, nil
+}
+
 func (repo ProductRepository) GetProductByCode(ctx context.Context, code string) (*entity.Product, error) {
 	var row *sql.Row
 	query := "SELECT * FROM products WHERE code = ?"
@@ -126,6 +465,10 @@ func (repo ProductRepository) GetProductByCode(ctx context.Context, code string)
 		&product.Stock,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Category,
+		&product.IsGiftCard,
+		&product.StockPolicy,
+		&product.Cost,
 	)
 	if err == sql.ErrNoRows {
 		log.Println(err.Error())
@@ -162,6 +505,10 @@ func (repo ProductRepository) GetProductByID(ctx context.Context, ID int64) (*en
 		&product.Stock,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Category,
+		&product.IsGiftCard,
+		&product.StockPolicy,
+		&product.Cost,
 	)
 
 	if err == sql.ErrNoRows {
@@ -181,6 +528,46 @@ func (repo ProductRepository) GetProductByID(ctx context.Context, ID int64) (*en
 	return &product, nil
 }
 
+// GetProductByIDForUpdate locks the product row until the surrounding
+// transaction ends, so the product logged before a change is the one changed.
+func (repo ProductRepository) GetProductByIDForUpdate(ctx context.Context, ID int64) (*entity.Product, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return nil, errors.New("failed get transcation context")
+	}
+
+	row := tx.QueryRowContext(ctx, "SELECT * FROM products WHERE id = ? FOR UPDATE", ID)
+	var product entity.Product
+	var err = row.Scan(
+		&product.ID,
+		&product.Code,
+		&product.Name,
+		&product.Price,
+		&product.Stock,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.Category,
+		&product.IsGiftCard,
+		&product.StockPolicy,
+		&product.Cost,
+	)
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Product not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &product, nil
+}
+
 func (repo ProductRepository) GetProductsByIDs(ctx context.Context, IDs ...int64) ([]*entity.Product, error) {
 	if len(IDs) < 1 {
 		err := errors.New("ID is required for getting product")
@@ -220,6 +607,10 @@ func (repo ProductRepository) GetProductsByIDs(ctx context.Context, IDs ...int64
 			&product.Stock,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.Category,
+			&product.IsGiftCard,
+			&product.StockPolicy,
+			&product.Cost,
 		)
 		if err != nil {
 			log.Println(err.Error())
@@ -237,13 +628,13 @@ func (repo ProductRepository) GetProductsByIDs(ctx context.Context, IDs ...int64
 }
 
 func (repo ProductRepository) Create(ctx context.Context, param entity.CreateProductParam) (*entity.Product, error) {
-	query := "INSERT INTO products(code, name, stock, price) VALUES(?, ?, ?, ?)"
+	query := "INSERT INTO products(code, name, stock, price, category, is_gift_card, stock_policy, cost) VALUES(?, ?, ?, ?, ?, ?, ?, ?)"
 	var res sql.Result
 	var err error
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		res, err = tx.Exec(query, param.Code, param.Name, param.Stock, param.Price)
+		res, err = tx.Exec(query, param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost)
 	} else {
-		res, err = repo.DB.ExecContext(ctx, query, param.Code, param.Name, param.Stock, param.Price)
+		res, err = repo.DB.ExecContext(ctx, query, param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost)
 	}
 
 	if err != nil {
@@ -257,7 +648,13 @@ func (repo ProductRepository) Create(ctx context.Context, param entity.CreatePro
 		return nil, err
 	}
 
-	row := repo.DB.QueryRowContext(ctx, "SELECT * FROM products WHERE id = ?", ID)
+	// the new product is read back through the transaction it is created in
+	var row *sql.Row
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow("SELECT * FROM products WHERE id = ?", ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, "SELECT * FROM products WHERE id = ?", ID)
+	}
 	err = row.Err()
 	if err != nil {
 		log.Println(err.Error())
@@ -274,6 +671,10 @@ func (repo ProductRepository) Create(ctx context.Context, param entity.CreatePro
 		&product.Stock,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Category,
+		&product.IsGiftCard,
+		&product.StockPolicy,
+		&product.Cost,
 	)
 	if err != nil {
 		return nil, err
@@ -283,12 +684,12 @@ func (repo ProductRepository) Create(ctx context.Context, param entity.CreatePro
 }
 
 func (repo ProductRepository) UpdateByID(ctx context.Context, ID int64, param entity.UpdateProductParam) (bool, error) {
-	query := "UPDATE products SET code = ?, name = ?, stock = ?, price = ? WHERE id = ?"
+	query := "UPDATE products SET code = ?, name = ?, stock = ?, price = ?, category = ?, is_gift_card = ?, stock_policy = ?, cost = ? WHERE id = ?"
 	var err error
 	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
-		_, err = tx.Exec(query, param.Code, param.Name, param.Stock, param.Price, ID)
+		_, err = tx.Exec(query, param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost, ID)
 	} else {
-		_, err = repo.DB.ExecContext(ctx, query, param.Code, param.Name, param.Stock, param.Price, ID)
+		_, err = repo.DB.ExecContext(ctx, query, param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost, ID)
 	}
 
 	if err != nil {
@@ -326,6 +727,79 @@ func (repo ProductRepository) DecrementProductByIDs(ctx context.Context, IDDecre
 	return nil
 }
 
+// DecrementStockByID takes the quantity out of the product stock unless the
+// stock is not enough, in which case it reports false. The updated row stays
+// locked until the transaction ends, so concurrent orders cannot oversell.
+func (repo ProductRepository) DecrementStockByID(ctx context.Context, ID int64, quantity int) (bool, error) {
+	query := "UPDATE products SET stock = stock - ? WHERE id = ? AND stock >= ?"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, quantity, ID, quantity)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, quantity, ID, quantity)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// SubtractStockByID takes the quantity out of the product stock even when it
+// drives the stock below zero.
+func (repo ProductRepository) SubtractStockByID(ctx context.Context, ID int64, quantity int) error {
+	query := "UPDATE products SET stock = stock - ? WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, quantity, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, quantity, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo ProductRepository) IncrementProductByIDs(ctx context.Context, IDIncrementMap map[int64]int) error {
+	incrementStockParams := []string{}
+	incrementProductIDs := []string{}
+	for id, quantity := range IDIncrementMap {
+		incrementProductIDs = append(incrementProductIDs, strconv.FormatInt(id, 10))
+		param := fmt.Sprintf("stock = IF(id=%d, stock+%d, stock)", id, quantity)
+		incrementStockParams = append(incrementStockParams, param)
+	}
+
+	incrementStockParamQuery := strings.Join(incrementStockParams, ", ")
+	incrementProductIDsQuery := strings.Join(incrementProductIDs, ", ")
+	query := fmt.Sprintf("UPDATE products SET %s WHERE id IN (%s)", incrementStockParamQuery, incrementProductIDsQuery)
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
 func (repo ProductRepository) DeleteByID(ctx context.Context, ID int64) (bool, error) {
 	query := "DELETE FROM products WHERE id = ?"
 	var err error
@@ -341,3 +815,103 @@ func (repo ProductRepository) DeleteByID(ctx context.Context, ID int64) (bool, e
 
 	return true, nil
 }
+
+func (repo ProductRepository) GetNegativeStockSales(ctx context.Context) ([]*entity.NegativeStockSale, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT nss.id, nss.order_id, nss.product_id, p.code, p.name, nss.quantity,
+			nss.shortfall, nss.stock_after, nss.reconciled_at, nss.created_at
+			FROM negative_stock_sales AS nss JOIN products AS p
+			ON p.id = nss.product_id
+			ORDER BY nss.created_at DESC, nss.id DESC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := []*entity.NegativeStockSale{}
+	for rows.Next() {
+		var sale entity.NegativeStockSale
+		var err = rows.Scan(
+			&sale.ID,
+			&sale.OrderID,
+			&sale.ProductID,
+			&sale.ProductCode,
+			&sale.ProductName,
+			&sale.Quantity,
+			&sale.Shortfall,
+			&sale.StockAfter,
+			&sale.ReconciledAt,
+			&sale.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		sales = append(sales, &sale)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+func (repo ProductRepository) CreateNegativeStockSales(ctx context.Context, orderID int64, params []*entity.CreateNegativeStockSaleParam) error {
+	createSaleParams := []string{}
+	createSaleVals := []interface{}{}
+	for _, param := range params {
+		createSaleParams = append(createSaleParams, "(?, ?, ?, ?, ?)")
+		createSaleVals = append(createSaleVals, orderID, param.ProductID, param.Quantity, param.Shortfall, param.StockAfter)
+	}
+	createSaleParamQuery := strings.Join(createSaleParams, ", ")
+
+	query := fmt.Sprintf("INSERT INTO negative_stock_sales(order_id, product_id, quantity, shortfall, stock_after) VALUES %s", createSaleParamQuery)
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, createSaleVals...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, createSaleVals...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo ProductRepository) ReconcileNegativeStockSaleByID(ctx context.Context, ID int64, date time.Time) (bool, error) {
+	query := "UPDATE negative_stock_sales SET reconciled_at = ? WHERE id = ? AND reconciled_at IS NULL"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, date, ID)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, date, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -38,8 +38,8 @@ func Test_GetAllProducts_Success(t *testing.T) {
 	defer db.Close()
 
 	var eProducts = sqlmock.
-		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt"}).
-		AddRow(1, "prod-1", "Prod 1", 10000, 100, time.Now(), time.Now())
+		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt", "Category", "IsGiftCard", "StockPolicy", "Cost"}).
+		AddRow(1, "prod-1", "Prod 1", 10000, 100, time.Now(), time.Now(), "", false, "", 0)
 	ctx := context.TODO()
 	query := regexp.QuoteMeta("SELECT * FROM products")
 	mock.ExpectQuery(query).WillReturnRows(eProducts)
@@ -50,6 +50,111 @@ func Test_GetAllProducts_Success(t *testing.T) {
 	assert.ObjectsAreEqualValues(eProducts, aProducts)
 }
 
+func Test_SearchProducts_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT * FROM products ORDER BY name ASC, id ASC LIMIT ? OFFSET ?")
+	mock.ExpectQuery(query).WithArgs(20, 0).WillReturnError(errors.New("failed search products"))
+
+	productRepository := NewProductRepository(db)
+	products, err := productRepository.SearchProducts(ctx, entity.ProductQuery{Limit: 20})
+	assert.NotNil(t, err)
+	assert.Nil(t, products)
+}
+
+func Test_SearchProducts_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	var eProducts = sqlmock.
+		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt", "Category", "IsGiftCard", "StockPolicy", "Cost"}).
+		AddRow(1, "IND-01", "Indomie Goreng", 3000, 90, time.Now(), time.Now(), "Food", false, "", 2400)
+	productQuery := entity.ProductQuery{
+		Keyword:  "indomie gor+",
+		Category: "Food",
+		InStock:  true,
+		Sort:     "-price",
+		Offset:   20,
+		Limit:    20,
+	}
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT * FROM products WHERE (MATCH(name) AGAINST(? IN BOOLEAN MODE) OR code LIKE ?) AND category = ? AND stock > 0 ORDER BY price DESC, id ASC LIMIT ? OFFSET ?")
+	mock.ExpectQuery(query).
+		WithArgs("+indomie* +gor*", "indomie gor+%", "Food", 20, 20).
+		WillReturnRows(eProducts)
+
+	productRepository := NewProductRepository(db)
+	aProducts, err := productRepository.SearchProducts(ctx, productQuery)
+	assert.Nil(t, err)
+	assert.Len(t, aProducts, 1)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_SearchProducts_Success_ByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT * FROM products WHERE id IN (?, ?) ORDER BY name ASC, id ASC LIMIT ? OFFSET ?")
+	mock.ExpectQuery(query).
+		WithArgs(int64(1), int64(2), 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt", "Category", "IsGiftCard", "StockPolicy", "Cost"}))
+
+	productRepository := NewProductRepository(db)
+	aProducts, err := productRepository.SearchProducts(ctx, entity.ProductQuery{IDs: []int64{1, 2}, Limit: 2})
+	assert.Nil(t, err)
+	assert.Empty(t, aProducts)
+}
+
+func Test_CountProducts_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM products WHERE code LIKE ?")
+	mock.ExpectQuery(query).
+		WithArgs(`\_%`).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(4))
+
+	productRepository := NewProductRepository(db)
+	count, err := productRepository.CountProducts(ctx, entity.ProductQuery{Keyword: "_"})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, count)
+}
+
+func Test_GetProductCategories_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT DISTINCT category FROM products WHERE category != '' ORDER BY category ASC")
+	mock.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows([]string{"category"}).AddRow("Drink").AddRow("Food"))
+
+	productRepository := NewProductRepository(db)
+	categories, err := productRepository.GetProductCategories(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Drink", "Food"}, categories)
+}
+
 func Test_GetBestSellerProducts_Failed_WhenSelectData(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -62,6 +167,8 @@ func Test_GetBestSellerProducts_Failed_WhenSelectData(t *testing.T) {
 		SELECT p.ID, p.Code, p.Name, SUM(oi.quantity) as total_sales
 			FROM products AS p  JOIN order_items AS oi
 			ON p.id = oi.product_id
+			JOIN orders AS o ON o.id = oi.order_id
+			WHERE o.status = 'completed'
 			GROUP BY oi.product_id
 			ORDER BY total_sales DESC
 			LIMIT 5`)
@@ -88,6 +195,8 @@ func Test_GetBestSellerProducts_Success(t *testing.T) {
 		SELECT p.ID, p.Code, p.Name, SUM(oi.quantity) as total_sales
 			FROM products AS p  JOIN order_items AS oi
 			ON p.id = oi.product_id
+			JOIN orders AS o ON o.id = oi.order_id
+			WHERE o.status = 'completed'
 			GROUP BY oi.product_id
 			ORDER BY total_sales DESC
 			LIMIT 5`)
@@ -99,6 +208,103 @@ func Test_GetBestSellerProducts_Success(t *testing.T) {
 	assert.ObjectsAreEqualValues(eSales, aSales)
 }
 
+func Test_GetProductPerformance_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 30)
+	query := regexp.QuoteMeta("COALESCE(s.quantity, 0), COALESCE(s.revenue, 0) FROM products p")
+	mock.ExpectQuery(query).WithArgs(start, end).WillReturnError(errors.New("failed get performance"))
+
+	productRepository := NewProductRepository(db)
+	performances, err := productRepository.GetProductPerformance(ctx, start, end)
+	assert.NotNil(t, err)
+	assert.Nil(t, performances)
+}
+
+func Test_GetProductPerformance_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 30)
+	query := regexp.QuoteMeta("WHERE o.created_at >= ? AND o.created_at < ? AND o.status = 'completed'")
+	rows := sqlmock.
+		NewRows([]string{"id", "code", "name", "category", "stock", "price", "cost", "quantity", "revenue"}).
+		AddRow(1, "IND-01", "Indomie Goreng", "Food", 90, 3000, 2400, 40, 120000).
+		AddRow(2, "TEH-01", "Teh Botol", "Drink", 12, 4000, 3000, 0, 0)
+	mock.ExpectQuery(query).WithArgs(start, end).WillReturnRows(rows)
+
+	productRepository := NewProductRepository(db)
+	performances, err := productRepository.GetProductPerformance(ctx, start, end)
+	assert.Nil(t, err)
+	assert.Len(t, performances, 2)
+	assert.Equal(t, 2400, performances[0].Cost)
+	assert.Equal(t, 120000, performances[0].Revenue)
+	assert.Equal(t, 0, performances[1].Quantity)
+}
+
+func Test_GetDeadStock_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	lastSoldAt := since.AddDate(0, -2, 0)
+	query := regexp.QuoteMeta("HAVING last_sold_at IS NULL OR last_sold_at < ?")
+	rows := sqlmock.
+		NewRows([]string{"id", "code", "name", "category", "stock", "price", "cost", "last_sold_at"}).
+		AddRow(3, "SAB-01", "Sabun", "Home", 7, 5000, 3500, nil).
+		AddRow(4, "SIK-01", "Sikat", "Home", 2, 8000, 6000, lastSoldAt)
+	mock.ExpectQuery(query).WithArgs(since).WillReturnRows(rows)
+
+	productRepository := NewProductRepository(db)
+	products, err := productRepository.GetDeadStock(ctx, since)
+	assert.Nil(t, err)
+	assert.Len(t, products, 2)
+	assert.Nil(t, products[0].LastSoldAt)
+	assert.True(t, lastSoldAt.Equal(*products[1].LastSoldAt))
+}
+
+func Test_GetDailySales_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 28)
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta("GROUP BY oi.product_id, day")
+	rows := sqlmock.
+		NewRows([]string{"product_id", "day", "quantity"}).
+		AddRow(1, day, 12).
+		AddRow(2, day, 3)
+	mock.ExpectQuery(query).WithArgs(start, end).WillReturnRows(rows)
+
+	productRepository := NewProductRepository(db)
+	sales, err := productRepository.GetDailySales(ctx, start, end)
+	assert.Nil(t, err)
+	assert.Len(t, sales, 2)
+	assert.Equal(t, int64(1), sales[0].ProductID)
+	assert.True(t, day.Equal(sales[0].Date))
+	assert.Equal(t, 12, sales[0].Quantity)
+}
+
 func Test_GetProductByID_Failed_WhenSelectData(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -126,8 +332,8 @@ func Test_GetProductByID_Success(t *testing.T) {
 	defer db.Close()
 
 	var eProducts = sqlmock.
-		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt"}).
-		AddRow(1, "prod-1", "Prod 1", 10000, 100, time.Now(), time.Now())
+		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt", "Category", "IsGiftCard", "StockPolicy", "Cost"}).
+		AddRow(1, "prod-1", "Prod 1", 10000, 100, time.Now(), time.Now(), "", false, "", 0)
 	ctx := context.TODO()
 	query := regexp.QuoteMeta("SELECT * FROM products WHERE id = ?")
 	mock.ExpectQuery(query).
@@ -168,8 +374,8 @@ func Test_GetProductByCode_Success(t *testing.T) {
 	defer db.Close()
 
 	var eProducts = sqlmock.
-		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt"}).
-		AddRow(1, "prod-1", "Prod 1", 10000, 100, time.Now(), time.Now())
+		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt", "Category", "IsGiftCard", "StockPolicy", "Cost"}).
+		AddRow(1, "prod-1", "Prod 1", 10000, 100, time.Now(), time.Now(), "", false, "", 0)
 	ctx := context.TODO()
 	query := regexp.QuoteMeta("SELECT * FROM products WHERE code = ?")
 	mock.ExpectQuery(query).
@@ -204,9 +410,9 @@ func Test_CreateProduct_Failed_WhenInsertingProduct(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	queryCreate := regexp.QuoteMeta("INSERT INTO products(code, name, stock, price) VALUES(?, ?, ?, ?)")
+	queryCreate := regexp.QuoteMeta("INSERT INTO products(code, name, stock, price, category, is_gift_card, stock_policy, cost) VALUES(?, ?, ?, ?, ?, ?, ?, ?)")
 	mock.ExpectExec(queryCreate).
-		WithArgs(param.Code, param.Name, param.Stock, param.Price).
+		WithArgs(param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost).
 		WillReturnError(errors.New("failed create product"))
 
 	productRepository := NewProductRepository(db)
@@ -237,10 +443,10 @@ func Test_CreateProduct_Failed_WhenFetchingTheProduct(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	queryCreate := regexp.QuoteMeta("INSERT INTO products(code, name, stock, price) VALUES(?, ?, ?, ?)")
+	queryCreate := regexp.QuoteMeta("INSERT INTO products(code, name, stock, price, category, is_gift_card, stock_policy, cost) VALUES(?, ?, ?, ?, ?, ?, ?, ?)")
 	queryGet := regexp.QuoteMeta("SELECT * FROM products WHERE id = ?")
 	mock.ExpectExec(queryCreate).
-		WithArgs(param.Code, param.Name, param.Stock, param.Price).
+		WithArgs(param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost).
 		WillReturnError(errors.New("failed create product"))
 	mock.ExpectQuery(queryGet).
 		WithArgs(eProduct.ID).
@@ -277,12 +483,12 @@ func Test_CreateProduct_Success(t *testing.T) {
 
 	ctx := context.TODO()
 	var resProduct = sqlmock.
-		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt"}).
-		AddRow(eProduct.ID, eProduct.Code, eProduct.Name, eProduct.Price, eProduct.Stock, eProduct.CreatedAt, eProduct.UpdatedAt)
-	queryCreate := regexp.QuoteMeta("INSERT INTO products(code, name, stock, price) VALUES(?, ?, ?, ?)")
+		NewRows([]string{"ID", "Code", "Name", "Price", "Stock", "CreatedAt", "UpdatedAt", "Category", "IsGiftCard", "StockPolicy", "Cost"}).
+		AddRow(eProduct.ID, eProduct.Code, eProduct.Name, eProduct.Price, eProduct.Stock, eProduct.CreatedAt, eProduct.UpdatedAt, eProduct.Category, eProduct.IsGiftCard, eProduct.StockPolicy, eProduct.Cost)
+	queryCreate := regexp.QuoteMeta("INSERT INTO products(code, name, stock, price, category, is_gift_card, stock_policy, cost) VALUES(?, ?, ?, ?, ?, ?, ?, ?)")
 	queryGet := regexp.QuoteMeta("SELECT * FROM products WHERE id = ?")
 	mock.ExpectExec(queryCreate).
-		WithArgs(param.Code, param.Name, param.Stock, param.Price).
+		WithArgs(param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost).
 		WillReturnResult(sqlmock.NewResult(eProduct.ID, 1))
 	mock.ExpectQuery(queryGet).
 		WithArgs(eProduct.ID).
@@ -316,9 +522,9 @@ func Test_UpdateProductByID_Failed(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	queryUpdate := regexp.QuoteMeta("UPDATE products SET code = ?, name = ?, stock = ?, price = ? WHERE id = ?")
+	queryUpdate := regexp.QuoteMeta("UPDATE products SET code = ?, name = ?, stock = ?, price = ?, category = ?, is_gift_card = ?, stock_policy = ?, cost = ? WHERE id = ?")
 	mock.ExpectExec(queryUpdate).
-		WithArgs(param.Code, param.Name, param.Stock, param.Price).
+		WithArgs(param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost).
 		WillReturnError(errors.New("failed create product"))
 
 	productRepository := NewProductRepository(db)
@@ -349,9 +555,9 @@ func Test_UpdateProductByID_Success(t *testing.T) {
 	defer db.Close()
 
 	ctx := context.TODO()
-	queryUpdate := regexp.QuoteMeta("UPDATE products SET code = ?, name = ?, stock = ?, price = ? WHERE id = ?")
+	queryUpdate := regexp.QuoteMeta("UPDATE products SET code = ?, name = ?, stock = ?, price = ?, category = ?, is_gift_card = ?, stock_policy = ?, cost = ? WHERE id = ?")
 	mock.ExpectExec(queryUpdate).
-		WithArgs(param.Code, param.Name, param.Stock, param.Price, eProduct.ID).
+		WithArgs(param.Code, param.Name, param.Stock, param.Price, param.Category, param.IsGiftCard, param.StockPolicy, param.Cost, eProduct.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	productRepository := NewProductRepository(db)
@@ -402,6 +608,44 @@ func Test_DecrementProductByIDs_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_DecrementStockByID_Failed_WhenStockIsInsufficient(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	queryUpdate := regexp.QuoteMeta("UPDATE products SET stock = stock - ? WHERE id = ? AND stock >= ?")
+	mock.ExpectExec(queryUpdate).
+		WithArgs(5, 1, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	productRepository := NewProductRepository(db)
+	isDecremented, err := productRepository.DecrementStockByID(ctx, 1, 5)
+	assert.Nil(t, err)
+	assert.False(t, isDecremented)
+}
+
+func Test_DecrementStockByID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	queryUpdate := regexp.QuoteMeta("UPDATE products SET stock = stock - ? WHERE id = ? AND stock >= ?")
+	mock.ExpectExec(queryUpdate).
+		WithArgs(2, 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	productRepository := NewProductRepository(db)
+	isDecremented, err := productRepository.DecrementStockByID(ctx, 1, 2)
+	assert.Nil(t, err)
+	assert.True(t, isDecremented)
+}
+
 func Test_DeleteProductByID_Failed(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -441,3 +685,88 @@ func Test_DeleteProductByID_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, isUpdated)
 }
+
+func Test_SubtractStockByID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	queryUpdate := regexp.QuoteMeta("UPDATE products SET stock = stock - ? WHERE id = ?")
+	mock.ExpectExec(queryUpdate).
+		WithArgs(5, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	productRepository := NewProductRepository(db)
+	err = productRepository.SubtractStockByID(ctx, 1, 5)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateNegativeStockSales_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	params := []*entity.CreateNegativeStockSaleParam{
+		{ProductID: 1, Quantity: 5, Shortfall: 3, StockAfter: -3},
+		{ProductID: 2, Quantity: 1, Shortfall: 1, StockAfter: -4},
+	}
+	queryInsert := regexp.QuoteMeta("INSERT INTO negative_stock_sales(order_id, product_id, quantity, shortfall, stock_after) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)")
+	mock.ExpectExec(queryInsert).
+		WithArgs(10, 1, 5, 3, -3, 10, 2, 1, 1, -4).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	productRepository := NewProductRepository(db)
+	err = productRepository.CreateNegativeStockSales(ctx, 10, params)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetNegativeStockSales_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.
+		NewRows([]string{"id", "order_id", "product_id", "code", "name", "quantity", "shortfall", "stock_after", "reconciled_at", "created_at"}).
+		AddRow(1, 10, 1, "prod-1", "Prod 1", 5, 3, -3, nil, now).
+		AddRow(2, 9, 2, "prod-2", "Prod 2", 1, 1, 0, now, now)
+	mock.ExpectQuery("SELECT (.+) FROM negative_stock_sales").WillReturnRows(rows)
+
+	productRepository := NewProductRepository(db)
+	sales, err := productRepository.GetNegativeStockSales(context.TODO())
+	assert.Nil(t, err)
+	assert.Len(t, sales, 2)
+	assert.Equal(t, "Prod 1", sales[0].ProductName)
+	assert.Equal(t, -3, sales[0].StockAfter)
+	assert.Nil(t, sales[0].ReconciledAt)
+	assert.NotNil(t, sales[1].ReconciledAt)
+}
+
+func Test_ReconcileNegativeStockSaleByID_Failed_WhenAlreadyReconciled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	queryUpdate := regexp.QuoteMeta("UPDATE negative_stock_sales SET reconciled_at = ? WHERE id = ? AND reconciled_at IS NULL")
+	mock.ExpectExec(queryUpdate).
+		WithArgs(now, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	productRepository := NewProductRepository(db)
+	isReconciled, err := productRepository.ReconcileNegativeStockSaleByID(context.TODO(), 1, now)
+	assert.Nil(t, err)
+	assert.False(t, isReconciled)
+}
@@ -0,0 +1,268 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type PurchaseOrderRepository struct {
+	DB *sql.DB
+}
+
+func NewPurchaseOrderRepository(DB *sql.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{DB: DB}
+}
+
+const purchaseOrderColumns = `po.id, po.supplier, po.status, po.notes,
+	COALESCE((SELECT SUM(poi.quantity * poi.cost) FROM purchase_order_items poi WHERE poi.purchase_order_id = po.id), 0),
+	po.created_at, po.updated_at`
+
+func scanPurchaseOrder(scanner interface{ Scan(...interface{}) error }) (*entity.PurchaseOrder, error) {
+	var purchaseOrder entity.PurchaseOrder
+	err := scanner.Scan(
+		&purchaseOrder.ID,
+		&purchaseOrder.Supplier,
+		&purchaseOrder.Status,
+		&purchaseOrder.Notes,
+		&purchaseOrder.Total,
+		&purchaseOrder.CreatedAt,
+		&purchaseOrder.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &purchaseOrder, nil
+}
+
+// GetPurchaseOrders returns the purchase orders, the latest first.
+func (repo PurchaseOrderRepository) GetPurchaseOrders(ctx context.Context) ([]*entity.PurchaseOrder, error) {
+	var rows *sql.Rows
+	var err error
+	query := fmt.Sprintf("SELECT %s FROM purchase_orders po ORDER BY po.id DESC", purchaseOrderColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	purchaseOrders := []*entity.PurchaseOrder{}
+	for rows.Next() {
+		purchaseOrder, err := scanPurchaseOrder(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return purchaseOrders, nil
+}
+
+func (repo PurchaseOrderRepository) GetPurchaseOrderByID(ctx context.Context, ID int64) (*entity.PurchaseOrder, error) {
+	var row *sql.Row
+	query := fmt.Sprintf("SELECT %s FROM purchase_orders po WHERE po.id = ?", purchaseOrderColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	purchaseOrder, err := scanPurchaseOrder(row)
+	if err == sql.ErrNoRows {
+		err = entity.ErrNotFound{
+			Message: "Purchase order not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return purchaseOrder, nil
+}
+
+func (repo PurchaseOrderRepository) GetItemsByPurchaseOrderID(ctx context.Context, purchaseOrderID int64) ([]*entity.PurchaseOrderItem, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT poi.id, poi.purchase_order_id, poi.product_id, p.code, p.name, poi.quantity, poi.cost
+			FROM purchase_order_items poi
+			JOIN products p ON p.id = poi.product_id
+			WHERE poi.purchase_order_id = ?
+			ORDER BY p.code ASC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, purchaseOrderID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, purchaseOrderID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*entity.PurchaseOrderItem{}
+	for rows.Next() {
+		var item entity.PurchaseOrderItem
+		var err = rows.Scan(
+			&item.ID,
+			&item.PurchaseOrderID,
+			&item.ProductID,
+			&item.ProductCode,
+			&item.ProductName,
+			&item.Quantity,
+			&item.Cost)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		item.Subtotal = item.Quantity * item.Cost
+		items = append(items, &item)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetOnOrderQuantities sums the quantities of the open purchase orders per
+// product, drafts included so a drafted reorder is not suggested again.
+func (repo PurchaseOrderRepository) GetOnOrderQuantities(ctx context.Context) (map[int64]int, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT poi.product_id, SUM(poi.quantity)
+			FROM purchase_order_items poi
+			JOIN purchase_orders po ON po.id = poi.purchase_order_id
+			WHERE po.status IN ('draft', 'ordered')
+			GROUP BY poi.product_id`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	quantities := map[int64]int{}
+	for rows.Next() {
+		var productID int64
+		var quantity int
+		var err = rows.Scan(&productID, &quantity)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		quantities[productID] = quantity
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return quantities, nil
+}
+
+// Create drafts the purchase order with its items, it is meant to run in a
+// transaction.
+func (repo PurchaseOrderRepository) Create(ctx context.Context, param entity.CreatePurchaseOrderParam) (*entity.PurchaseOrder, error) {
+	query := "INSERT INTO purchase_orders(supplier, status, notes) VALUES(?, ?, ?)"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.Supplier, entity.PurchaseOrderStatusDraft, param.Notes)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.Supplier, entity.PurchaseOrderStatusDraft, param.Notes)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if len(param.Items) > 0 {
+		itemParams := []string{}
+		itemVals := []interface{}{}
+		for _, item := range param.Items {
+			itemParams = append(itemParams, "(?, ?, ?, ?)")
+			itemVals = append(itemVals, ID, item.ProductID, item.Quantity, item.Cost)
+		}
+
+		query = fmt.Sprintf(`
+			INSERT INTO purchase_order_items(purchase_order_id, product_id, quantity, cost)
+				VALUES %s`, strings.Join(itemParams, ", "))
+		if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+			_, err = tx.Exec(query, itemVals...)
+		} else {
+			_, err = repo.DB.ExecContext(ctx, query, itemVals...)
+		}
+
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+	}
+
+	return repo.GetPurchaseOrderByID(ctx, ID)
+}
+
+// UpdateStatusByID moves the purchase order to a status only while it still
+// has the expected one, so two clerks cannot receive it twice.
+func (repo PurchaseOrderRepository) UpdateStatusByID(ctx context.Context, ID int64, from string, to string) (bool, error) {
+	query := "UPDATE purchase_orders SET status = ?, updated_at = CURRENT_TIMESTAMP() WHERE id = ? AND status = ?"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, to, ID, from)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, to, ID, from)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var purchaseOrderRowColumns = []string{"id", "supplier", "status", "notes", "total", "created_at", "updated_at"}
+
+func Test_GetPurchaseOrderByID_Failed_WhenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("FROM purchase_orders po WHERE po.id = ?")
+	mock.ExpectQuery(query).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+
+	purchaseOrderRepository := NewPurchaseOrderRepository(db)
+	purchaseOrder, err := purchaseOrderRepository.GetPurchaseOrderByID(context.TODO(), 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, purchaseOrder)
+}
+
+func Test_GetOnOrderQuantities_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("WHERE po.status IN ('draft', 'ordered')")
+	mock.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "quantity"}).
+			AddRow(1, 20).
+			AddRow(3, 6))
+
+	purchaseOrderRepository := NewPurchaseOrderRepository(db)
+	quantities, err := purchaseOrderRepository.GetOnOrderQuantities(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, map[int64]int{1: 20, 3: 6}, quantities)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreatePurchaseOrder_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	param := entity.CreatePurchaseOrderParam{
+		Supplier: "PT Sumber",
+		Items: []*entity.CreatePurchaseOrderItemParam{
+			{ProductID: 1, Quantity: 15, Cost: 1000},
+			{ProductID: 2, Quantity: 4, Cost: 2500},
+		},
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO purchase_orders(supplier, status, notes)")).
+		WithArgs("PT Sumber", entity.PurchaseOrderStatusDraft, "").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO purchase_order_items(purchase_order_id, product_id, quantity, cost)")).
+		WithArgs(int64(7), int64(1), 15, 1000, int64(7), int64(2), 4, 2500).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM purchase_orders po WHERE po.id = ?")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(purchaseOrderRowColumns).
+			AddRow(7, "PT Sumber", entity.PurchaseOrderStatusDraft, "", 25000, now, now))
+
+	purchaseOrderRepository := NewPurchaseOrderRepository(db)
+	purchaseOrder, err := purchaseOrderRepository.Create(context.TODO(), param)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), purchaseOrder.ID)
+	assert.Equal(t, 25000, purchaseOrder.Total)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdatePurchaseOrderStatusByID_Failed_WhenStatusChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE purchase_orders SET status = ?, updated_at = CURRENT_TIMESTAMP() WHERE id = ? AND status = ?")
+	mock.ExpectExec(query).
+		WithArgs(entity.PurchaseOrderStatusReceived, int64(1), entity.PurchaseOrderStatusOrdered).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	purchaseOrderRepository := NewPurchaseOrderRepository(db)
+	isUpdated, err := purchaseOrderRepository.UpdateStatusByID(context.TODO(), 1, entity.PurchaseOrderStatusOrdered, entity.PurchaseOrderStatusReceived)
+	assert.Nil(t, err)
+	assert.False(t, isUpdated)
+}
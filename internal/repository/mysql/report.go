@@ -0,0 +1,178 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type ReportRepository struct {
+	DB *sql.DB
+}
+
+func NewReportRepository(DB *sql.DB) *ReportRepository {
+	return &ReportRepository{DB: DB}
+}
+
+// GetSalesByPeriods sums the orders of every period in one query. The period
+// bounds are computed by the caller in the store timezone, so days, weeks and
+// months are cut at local midnight whatever the server timezone is. Refunds
+// count in the period they were made, not the one the order was placed in.
+func (repo ReportRepository) GetSalesByPeriods(ctx context.Context, periods []*entity.SalesPeriod) ([]*entity.SalesPeriod, error) {
+	if len(periods) < 1 {
+		return periods, nil
+	}
+
+	bounds := []string{}
+	args := []interface{}{}
+	for index, period := range periods {
+		bounds = append(bounds, fmt.Sprintf("SELECT %d AS period, CAST(? AS DATETIME) AS period_start, CAST(? AS DATETIME) AS period_end", index))
+		args = append(args, period.Start, period.End)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT p.period,
+				COUNT(o.id),
+				COALESCE(SUM(o.total + o.discount), 0),
+				COALESCE(SUM(o.discount), 0),
+				COALESCE((
+					SELECT SUM(r.total)
+						FROM orders r
+						WHERE r.status = 'refunded' AND r.refunded_at >= p.period_start AND r.refunded_at < p.period_end
+				), 0)
+			FROM (%s) p
+			LEFT JOIN orders o ON o.created_at >= p.period_start AND o.created_at < p.period_end AND o.status != 'voided'
+			GROUP BY p.period, p.period_start, p.period_end
+			ORDER BY p.period ASC`, strings.Join(bounds, " UNION ALL "))
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var index int
+		var sales entity.SalesPeriod
+		var err = rows.Scan(&index, &sales.Orders, &sales.GrossSales, &sales.Discounts, &sales.Refunds)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		if index < 0 || index >= len(periods) {
+			continue
+		}
+
+		period := periods[index]
+		period.Orders = sales.Orders
+		period.GrossSales = sales.GrossSales
+		period.Discounts = sales.Discounts
+		period.Refunds = sales.Refunds
+		period.NetSales = sales.GrossSales - sales.Discounts - sales.Refunds
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+// GetStockValues values the stock of the products after the cursor in ID
+// order, up to the limit of the query.
+func (repo ReportRepository) GetStockValues(ctx context.Context, query entity.StockValuationQuery) ([]*entity.StockValue, error) {
+	conditions, args := stockValuationConditions(query.Category)
+	conditions = append(conditions, "id > ?")
+	args = append(args, query.Cursor, query.Limit)
+	sqlQuery := fmt.Sprintf(`
+		SELECT id, code, name, category, stock, price, GREATEST(stock, 0) * price
+			FROM products
+			WHERE %s
+			ORDER BY id ASC
+			LIMIT ?`, strings.Join(conditions, " AND "))
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(sqlQuery, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, sqlQuery, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []*entity.StockValue{}
+	for rows.Next() {
+		var value entity.StockValue
+		var err = rows.Scan(
+			&value.ProductID,
+			&value.Code,
+			&value.Name,
+			&value.Category,
+			&value.Stock,
+			&value.Price,
+			&value.Value)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		values = append(values, &value)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (repo ReportRepository) GetStockValuationSummary(ctx context.Context, category string) (*entity.StockValuationSummary, error) {
+	conditions, args := stockValuationConditions(category)
+	query := fmt.Sprintf(`
+		SELECT COUNT(id), COALESCE(SUM(GREATEST(stock, 0)), 0), COALESCE(SUM(GREATEST(stock, 0) * price), 0)
+			FROM products
+			WHERE %s`, strings.Join(conditions, " AND "))
+	var row *sql.Row
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, args...)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, args...)
+	}
+
+	var summary entity.StockValuationSummary
+	if err := row.Scan(&summary.Products, &summary.Units, &summary.Value); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// stockValuationConditions leaves out gift cards, which are not stock, and
+// narrows the valuation down to a category when one is given.
+func stockValuationConditions(category string) ([]string, []interface{}) {
+	conditions := []string{"is_gift_card = 0"}
+	args := []interface{}{}
+	if category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, category)
+	}
+
+	return conditions, args
+}
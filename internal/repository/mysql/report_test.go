@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetSalesByPeriods_Success_WhenNoPeriods(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	reportRepository := NewReportRepository(db)
+	periods, err := reportRepository.GetSalesByPeriods(context.TODO(), []*entity.SalesPeriod{})
+	assert.Nil(t, err)
+	assert.Empty(t, periods)
+}
+
+func Test_GetSalesByPeriods_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	periods := []*entity.SalesPeriod{{Start: start, End: start.AddDate(0, 0, 1)}}
+	query := regexp.QuoteMeta("FROM (SELECT 0 AS period, CAST(? AS DATETIME) AS period_start, CAST(? AS DATETIME) AS period_end) p")
+	mock.ExpectQuery(query).WillReturnError(errors.New("failed get sales"))
+
+	reportRepository := NewReportRepository(db)
+	aPeriods, err := reportRepository.GetSalesByPeriods(context.TODO(), periods)
+	assert.NotNil(t, err)
+	assert.Nil(t, aPeriods)
+}
+
+func Test_GetSalesByPeriods_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	periods := []*entity.SalesPeriod{
+		{Start: start, End: start.AddDate(0, 0, 1)},
+		{Start: start.AddDate(0, 0, 1), End: start.AddDate(0, 0, 2)},
+	}
+	query := regexp.QuoteMeta(`WHERE r.status = 'refunded' AND r.refunded_at >= p.period_start AND r.refunded_at < p.period_end
+				), 0)
+			FROM (SELECT 0 AS period, CAST(? AS DATETIME) AS period_start, CAST(? AS DATETIME) AS period_end UNION ALL SELECT 1 AS period, CAST(? AS DATETIME) AS period_start, CAST(? AS DATETIME) AS period_end) p
+			LEFT JOIN orders o ON o.created_at >= p.period_start AND o.created_at < p.period_end AND o.status != 'voided'
+			GROUP BY p.period, p.period_start, p.period_end`)
+	rows := sqlmock.
+		NewRows([]string{"period", "orders", "gross_sales", "discounts", "refunds"}).
+		AddRow(0, 3, 60000, 5000, 10000).
+		AddRow(1, 0, 0, 0, 0)
+	mock.ExpectQuery(query).
+		WithArgs(periods[0].Start, periods[0].End, periods[1].Start, periods[1].End).
+		WillReturnRows(rows)
+
+	reportRepository := NewReportRepository(db)
+	aPeriods, err := reportRepository.GetSalesByPeriods(context.TODO(), periods)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, aPeriods[0].Orders)
+	assert.Equal(t, 45000, aPeriods[0].NetSales)
+	assert.Equal(t, 0, aPeriods[1].NetSales)
+}
+
+func Test_GetStockValues_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("FROM products WHERE is_gift_card = 0 AND id > ? ORDER BY id ASC LIMIT ?")
+	mock.ExpectQuery(query).WithArgs(0, 51).WillReturnError(errors.New("failed get stock values"))
+
+	reportRepository := NewReportRepository(db)
+	values, err := reportRepository.GetStockValues(context.TODO(), entity.StockValuationQuery{Limit: 51})
+	assert.NotNil(t, err)
+	assert.Nil(t, values)
+}
+
+func Test_GetStockValues_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`SELECT id, code, name, category, stock, price, GREATEST(stock, 0) * price
+			FROM products
+			WHERE is_gift_card = 0 AND category = ? AND id > ?`)
+	rows := sqlmock.
+		NewRows([]string{"id", "code", "name", "category", "stock", "price", "value"}).
+		AddRow(3, "P003", "Tea", "Drinks", 10, 5000, 50000).
+		AddRow(4, "P004", "Coffee", "Drinks", -2, 8000, 0)
+	mock.ExpectQuery(query).WithArgs("Drinks", 2, 51).WillReturnRows(rows)
+
+	reportRepository := NewReportRepository(db)
+	stockQuery := entity.StockValuationQuery{Category: "Drinks", Cursor: 2, Limit: 51}
+	values, err := reportRepository.GetStockValues(context.TODO(), stockQuery)
+	assert.Nil(t, err)
+	assert.Len(t, values, 2)
+	assert.Equal(t, 50000, values[0].Value)
+	assert.Equal(t, -2, values[1].Stock)
+}
+
+func Test_GetStockValuationSummary_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("COALESCE(SUM(GREATEST(stock, 0) * price), 0) FROM products WHERE is_gift_card = 0")
+	rows := sqlmock.NewRows([]string{"products", "units", "value"}).AddRow(2, 10, 50000)
+	mock.ExpectQuery(query).WithArgs().WillReturnRows(rows)
+
+	reportRepository := NewReportRepository(db)
+	summary, err := reportRepository.GetStockValuationSummary(context.TODO(), "")
+	assert.Nil(t, err)
+	assert.Equal(t, &entity.StockValuationSummary{Products: 2, Units: 10, Value: 50000}, summary)
+}
@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type StoreSettingRepository struct {
+	DB *sql.DB
+}
+
+func NewStoreSettingRepository(DB *sql.DB) *StoreSettingRepository {
+	return &StoreSettingRepository{DB: DB}
+}
+
+func (repo StoreSettingRepository) GetSetting(ctx context.Context) (*entity.StoreSetting, error) {
+	var row *sql.Row
+	query := "SELECT store_name, store_code, invoice_pattern, invoice_reset, stock_policy, low_stock_threshold, updated_at FROM store_settings WHERE id = 1"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query)
+	}
+
+	var setting entity.StoreSetting
+	err := row.Scan(
+		&setting.StoreName,
+		&setting.StoreCode,
+		&setting.InvoicePattern,
+		&setting.InvoiceReset,
+		&setting.StockPolicy,
+		&setting.LowStockThreshold,
+		&setting.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Store setting not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+func (repo StoreSettingRepository) UpdateSetting(ctx context.Context, param entity.UpdateStoreSettingParam) error {
+	query := `
+		UPDATE store_settings
+			SET store_name = ?, store_code = ?, invoice_pattern = ?, invoice_reset = ?, stock_policy = ?, low_stock_threshold = ?, updated_at = CURRENT_TIMESTAMP()
+			WHERE id = 1`
+	args := []interface{}{param.StoreName, param.StoreCode, param.InvoicePattern, param.InvoiceReset, param.StockPolicy, param.LowStockThreshold}
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, args...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
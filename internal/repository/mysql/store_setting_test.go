@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetStoreSetting_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.
+		NewRows([]string{"store_name", "store_code", "invoice_pattern", "invoice_reset", "stock_policy", "low_stock_threshold", "updated_at"}).
+		AddRow("Kaseer", "KSR", "INV/{store}/{YYYYMMDD}/{seq}", entity.InvoiceResetDaily, entity.StockPolicyWarn, 5, time.Now())
+	query := regexp.QuoteMeta("SELECT store_name, store_code, invoice_pattern, invoice_reset, stock_policy, low_stock_threshold, updated_at FROM store_settings WHERE id = 1")
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	storeSettingRepository := NewStoreSettingRepository(db)
+	setting, err := storeSettingRepository.GetSetting(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, "KSR", setting.StoreCode)
+	assert.Equal(t, entity.StockPolicyWarn, setting.StockPolicy)
+	assert.Equal(t, 5, setting.LowStockThreshold)
+}
+
+func Test_GetStoreSetting_Failed_WhenNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"store_name", "store_code", "invoice_pattern", "invoice_reset", "stock_policy", "low_stock_threshold", "updated_at"})
+	query := regexp.QuoteMeta("SELECT store_name, store_code, invoice_pattern, invoice_reset, stock_policy, low_stock_threshold, updated_at FROM store_settings WHERE id = 1")
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	storeSettingRepository := NewStoreSettingRepository(db)
+	setting, err := storeSettingRepository.GetSetting(context.TODO())
+	assert.Nil(t, setting)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+}
+
+func Test_UpdateStoreSetting_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	param := entity.UpdateStoreSettingParam{
+		StoreName:         "Kaseer",
+		StoreCode:         "KSR",
+		InvoicePattern:    "INV/{store}/{YYYY}/{seq}",
+		InvoiceReset:      entity.InvoiceResetYearly,
+		StockPolicy:       entity.StockPolicyAllow,
+		LowStockThreshold: 5,
+	}
+	mock.ExpectExec("UPDATE store_settings").
+		WithArgs(param.StoreName, param.StoreCode, param.InvoicePattern, param.InvoiceReset, param.StockPolicy, param.LowStockThreshold).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	storeSettingRepository := NewStoreSettingRepository(db)
+	err = storeSettingRepository.UpdateSetting(context.TODO(), param)
+	assert.Nil(t, err)
+}
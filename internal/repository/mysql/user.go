@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
@@ -16,6 +17,50 @@ func NewUserRepository(DB *sql.DB) *UserRepository {
 	return &UserRepository{DB: DB}
 }
 
+func (repo UserRepository) GetAllUsers(ctx context.Context) ([]*entity.User, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT * FROM users ORDER BY name ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		var user entity.User
+		var err = rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.PhotoUrl,
+			&user.Password,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.ServiceAccount,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		users = append(users, &user)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (repo UserRepository) GetUserByID(ctx context.Context, ID int64) (*entity.User, error) {
 	var row *sql.Row
 	query := "SELECT * FROM users WHERE id = ?"
@@ -34,6 +79,42 @@ func (repo UserRepository) GetUserByID(ctx context.Context, ID int64) (*entity.U
 		&user.Password,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.ServiceAccount,
+	)
+	if err == sql.ErrNoRows {
+		err := entity.ErrNotFound{
+			Message: "User not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetUserByIDForUpdate locks the user row until the surrounding transaction
+// ends, so the profile logged before a change is the one changed.
+func (repo UserRepository) GetUserByIDForUpdate(ctx context.Context, ID int64) (*entity.User, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return nil, errors.New("failed get transcation context")
+	}
+
+	row := tx.QueryRowContext(ctx, "SELECT * FROM users WHERE id = ? FOR UPDATE", ID)
+	var user entity.User
+	var err = row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.PhotoUrl,
+		&user.Password,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.ServiceAccount,
 	)
 	if err == sql.ErrNoRows {
 		err := entity.ErrNotFound{
@@ -68,6 +149,7 @@ func (repo UserRepository) GetUserByEmail(ctx context.Context, email string) (*e
 		&user.Password,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.ServiceAccount,
 	)
 
 	if err == sql.ErrNoRows {
@@ -85,6 +167,78 @@ func (repo UserRepository) GetUserByEmail(ctx context.Context, email string) (*e
 	return &user, nil
 }
 
+// GetServiceAccounts returns the users integrations authenticate as by their
+// API tokens.
+func (repo UserRepository) GetServiceAccounts(ctx context.Context) ([]*entity.User, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT * FROM users WHERE service_account = 1 ORDER BY name ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		var user entity.User
+		var err = rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.PhotoUrl,
+			&user.Password,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.ServiceAccount,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		users = append(users, &user)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// CreateServiceAccount creates a user without a password, it can only
+// authenticate by its API tokens.
+func (repo UserRepository) CreateServiceAccount(ctx context.Context, name string, email string) (*entity.User, error) {
+	query := "INSERT INTO users(name, email, password, service_account) VALUES(?, ?, '', 1)"
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, name, email)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, name, email)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetUserByID(ctx, ID)
+}
+
 func (repo UserRepository) UpdateByID(ctx context.Context, ID int64, param entity.UpdateUserParam) (bool, error) {
 	query := "UPDATE users SET name = ?, email = ?, photo_url = ? WHERE id = ?"
 	var err error
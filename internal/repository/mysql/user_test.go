@@ -13,6 +13,26 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func Test_GetAllUsers_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	eUsers := sqlmock.
+		NewRows([]string{"id", "name", "email", "photo_url", "password", "created_at", "updated_at", "service_account"}).
+		AddRow(1, "Arda", "arda@kaseer.com", nil, "hashed", time.Now(), time.Now(), false)
+	query := regexp.QuoteMeta("SELECT * FROM users ORDER BY name ASC")
+	mock.ExpectQuery(query).WillReturnRows(eUsers)
+
+	userRepository := NewUserRepository(db)
+	users, err := userRepository.GetAllUsers(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, users, 1)
+}
+
 func Test_GetUserByID_Failed_WhenNoRows(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -73,8 +93,8 @@ func Test_GetUserByID_Success(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 	query := regexp.QuoteMeta("SELECT * FROM users WHERE id = ?")
-	rows := sqlmock.NewRows([]string{"id", "name", "email", "photo_url", "passsword", "created_at", "updated_at"})
-	rows.AddRow(eUser.ID, eUser.Name, eUser.Email, eUser.PhotoUrl, eUser.Password, eUser.CreatedAt, eUser.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "photo_url", "passsword", "created_at", "updated_at", "service_account"})
+	rows.AddRow(eUser.ID, eUser.Name, eUser.Email, eUser.PhotoUrl, eUser.Password, eUser.CreatedAt, eUser.UpdatedAt, eUser.ServiceAccount)
 	mock.ExpectQuery(query).
 		WithArgs(eUser.ID).
 		WillReturnRows(rows)
@@ -144,8 +164,8 @@ func Test_GetUserByEmail_Success(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 	query := regexp.QuoteMeta("SELECT * FROM users WHERE email = ?")
-	rows := sqlmock.NewRows([]string{"id", "name", "email", "photo_url", "passsword", "created_at", "updated_at"})
-	rows.AddRow(eUser.ID, eUser.Name, eUser.Email, eUser.PhotoUrl, eUser.Password, eUser.CreatedAt, eUser.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "photo_url", "passsword", "created_at", "updated_at", "service_account"})
+	rows.AddRow(eUser.ID, eUser.Name, eUser.Email, eUser.PhotoUrl, eUser.Password, eUser.CreatedAt, eUser.UpdatedAt, eUser.ServiceAccount)
 	mock.ExpectQuery(query).
 		WithArgs(eUser.Email).
 		WillReturnRows(rows)
@@ -246,3 +266,28 @@ func Test_UpdatePasswordByID_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, updated)
 }
+
+func Test_CreateServiceAccount_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("INSERT INTO users(name, email, password, service_account) VALUES(?, ?, '', 1)")
+	mock.ExpectExec(query).
+		WithArgs("Accounting", "service-a1b2c3@kaseer.local").
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	rows := sqlmock.NewRows([]string{"id", "name", "email", "photo_url", "password", "created_at", "updated_at", "service_account"})
+	rows.AddRow(3, "Accounting", "service-a1b2c3@kaseer.local", nil, "", time.Now(), time.Now(), true)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM users WHERE id = ?")).
+		WithArgs(int64(3)).
+		WillReturnRows(rows)
+
+	userRepository := NewUserRepository(db)
+	serviceAccount, err := userRepository.CreateServiceAccount(ctx, "Accounting", "service-a1b2c3@kaseer.local")
+	assert.Nil(t, err)
+	assert.True(t, serviceAccount.ServiceAccount)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,325 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type VoucherRepository struct {
+	DB *sql.DB
+}
+
+func NewVoucherRepository(DB *sql.DB) *VoucherRepository {
+	return &VoucherRepository{DB: DB}
+}
+
+const voucherColumns = `id, code, value_type, value, min_spend, starts_at, expires_at,
+	usage_limit, usage_limit_per_customer, used_count, created_at, updated_at`
+
+func scanVoucher(scanner interface{ Scan(...interface{}) error }) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	err := scanner.Scan(
+		&voucher.ID,
+		&voucher.Code,
+		&voucher.ValueType,
+		&voucher.Value,
+		&voucher.MinSpend,
+		&voucher.StartsAt,
+		&voucher.ExpiresAt,
+		&voucher.UsageLimit,
+		&voucher.UsageLimitPerCustomer,
+		&voucher.UsedCount,
+		&voucher.CreatedAt,
+		&voucher.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &voucher, nil
+}
+
+func (repo VoucherRepository) GetAllVouchers(ctx context.Context) ([]*entity.Voucher, error) {
+	var rows *sql.Rows
+	var err error
+	query := fmt.Sprintf("SELECT %s FROM vouchers ORDER BY created_at DESC", voucherColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	vouchers := []*entity.Voucher{}
+	for rows.Next() {
+		voucher, err := scanVoucher(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		vouchers = append(vouchers, voucher)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return vouchers, nil
+}
+
+func (repo VoucherRepository) GetVoucherByID(ctx context.Context, ID int64) (*entity.Voucher, error) {
+	var row *sql.Row
+	query := fmt.Sprintf("SELECT %s FROM vouchers WHERE id = ?", voucherColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	voucher, err := scanVoucher(row)
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Voucher not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return voucher, nil
+}
+
+func (repo VoucherRepository) GetVouchersByCodes(ctx context.Context, codes ...string) ([]*entity.Voucher, error) {
+	if len(codes) < 1 {
+		err := errors.New("code is required for getting voucher")
+		return nil, err
+	}
+
+	placeholders := make([]string, 0, len(codes))
+	args := make([]interface{}, 0, len(codes))
+	for _, code := range codes {
+		placeholders = append(placeholders, "?")
+		args = append(args, code)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM vouchers WHERE code IN (%s)", voucherColumns, strings.Join(placeholders, ", "))
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	vouchers := []*entity.Voucher{}
+	for rows.Next() {
+		voucher, err := scanVoucher(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		vouchers = append(vouchers, voucher)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return vouchers, nil
+}
+
+// GetVoucherByCodeForUpdate locks the voucher row until the surrounding
+// transaction ends, so concurrent redemptions of the same code are serialized.
+func (repo VoucherRepository) GetVoucherByCodeForUpdate(ctx context.Context, code string) (*entity.Voucher, error) {
+	tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx)
+	if !ok {
+		return nil, errors.New("failed get transcation context")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM vouchers WHERE code = ? FOR UPDATE", voucherColumns)
+	voucher, err := scanVoucher(tx.QueryRowContext(ctx, query, code))
+	if err == sql.ErrNoRows {
+		log.Println(err.Error())
+		err = entity.ErrNotFound{
+			Message: "Voucher not found",
+			Err:     err,
+		}
+		return nil, err
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return voucher, nil
+}
+
+func (repo VoucherRepository) GetRedemptionsByVoucherID(ctx context.Context, voucherID int64) ([]*entity.VoucherRedemption, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT id, voucher_id, order_id, customer_id, discount, created_at
+			FROM voucher_redemptions
+			WHERE voucher_id = ?
+			ORDER BY created_at DESC`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, voucherID)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, voucherID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	redemptions := []*entity.VoucherRedemption{}
+	for rows.Next() {
+		var redemption entity.VoucherRedemption
+		err := rows.Scan(
+			&redemption.ID,
+			&redemption.VoucherID,
+			&redemption.OrderID,
+			&redemption.CustomerID,
+			&redemption.Discount,
+			&redemption.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		redemptions = append(redemptions, &redemption)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return redemptions, nil
+}
+
+func (repo VoucherRepository) CountRedemptionsByCustomerID(ctx context.Context, voucherID int64, customerID int64) (int, error) {
+	var row *sql.Row
+	query := "SELECT COUNT(*) FROM voucher_redemptions WHERE voucher_id = ? AND customer_id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, voucherID, customerID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, voucherID, customerID)
+	}
+
+	var val int
+	if err := row.Scan(&val); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return val, nil
+}
+
+func (repo VoucherRepository) CreateVouchers(ctx context.Context, params []*entity.CreateVoucherParam) error {
+	createVoucherParams := []string{}
+	createVoucherVals := []interface{}{}
+	for _, param := range params {
+		createVoucherParams = append(createVoucherParams, "(?, ?, ?, ?, ?, ?, ?, ?)")
+		createVoucherVals = append(createVoucherVals,
+			param.Code,
+			param.ValueType,
+			param.Value,
+			param.MinSpend,
+			param.StartsAt,
+			param.ExpiresAt,
+			param.UsageLimit,
+			param.UsageLimitPerCustomer,
+		)
+	}
+	createVoucherParamQuery := strings.Join(createVoucherParams, ", ")
+
+	query := fmt.Sprintf(`
+		INSERT INTO vouchers(code, value_type, value, min_spend, starts_at, expires_at, usage_limit, usage_limit_per_customer)
+			VALUES %s`, createVoucherParamQuery)
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, createVoucherVals...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, createVoucherVals...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (repo VoucherRepository) CreateRedemption(ctx context.Context, param entity.CreateVoucherRedemptionParam) error {
+	query := "INSERT INTO voucher_redemptions(voucher_id, order_id, customer_id, discount) VALUES(?, ?, ?, ?)"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.VoucherID, param.OrderID, param.CustomerID, param.Discount)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.VoucherID, param.OrderID, param.CustomerID, param.Discount)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// IncrementUsageByID bumps the usage counter unless the global usage limit
+// has already been reached, in which case it reports false.
+func (repo VoucherRepository) IncrementUsageByID(ctx context.Context, ID int64) (bool, error) {
+	query := `
+		UPDATE vouchers SET used_count = used_count + 1
+			WHERE id = ? AND (usage_limit = 0 OR used_count < usage_limit)`
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, ID)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,208 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var voucherColumnNames = []string{
+	"ID", "Code", "ValueType", "Value", "MinSpend", "StartsAt", "ExpiresAt",
+	"UsageLimit", "UsageLimitPerCustomer", "UsedCount", "CreatedAt", "UpdatedAt",
+}
+
+func Test_GetAllVouchers_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta(fmt.Sprintf("SELECT %s FROM vouchers ORDER BY created_at DESC", voucherColumns))
+	mock.ExpectQuery(query).WillReturnError(errors.New("failed get vouchers"))
+
+	voucherRepository := NewVoucherRepository(db)
+	vouchers, err := voucherRepository.GetAllVouchers(ctx)
+	assert.NotNil(t, err)
+	assert.Nil(t, vouchers)
+}
+
+func Test_GetAllVouchers_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows(voucherColumnNames).
+		AddRow(1, "PROMOABCD1234", "fixed", 5000, 20000, time.Now(), time.Now(), 1, 0, 0, time.Now(), time.Now())
+	ctx := context.TODO()
+	query := regexp.QuoteMeta(fmt.Sprintf("SELECT %s FROM vouchers ORDER BY created_at DESC", voucherColumns))
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	voucherRepository := NewVoucherRepository(db)
+	vouchers, err := voucherRepository.GetAllVouchers(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, vouchers, 1)
+	assert.Equal(t, "PROMOABCD1234", vouchers[0].Code)
+}
+
+func Test_GetVoucherByID_Failed_WhenNoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta(fmt.Sprintf("SELECT %s FROM vouchers WHERE id = ?", voucherColumns))
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(voucherColumnNames))
+
+	voucherRepository := NewVoucherRepository(db)
+	voucher, err := voucherRepository.GetVoucherByID(ctx, 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, voucher)
+}
+
+func Test_GetVoucherByCodeForUpdate_Failed_WithoutTransaction(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	voucherRepository := NewVoucherRepository(db)
+	voucher, err := voucherRepository.GetVoucherByCodeForUpdate(context.TODO(), "PROMO")
+	assert.NotNil(t, err)
+	assert.Nil(t, voucher)
+}
+
+func Test_GetVoucherByCodeForUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows(voucherColumnNames).
+		AddRow(1, "PROMO", "percent", 10, 0, time.Now(), time.Now(), 0, 0, 3, time.Now(), time.Now())
+	query := regexp.QuoteMeta(fmt.Sprintf("SELECT %s FROM vouchers WHERE code = ? FOR UPDATE", voucherColumns))
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs("PROMO").WillReturnRows(rows)
+
+	uow := NewMySQLUnitOfWork(db)
+	txContext, err := uow.Begin(context.TODO())
+	assert.Nil(t, err)
+
+	voucherRepository := NewVoucherRepository(db)
+	voucher, err := voucherRepository.GetVoucherByCodeForUpdate(txContext, "PROMO")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, voucher.UsedCount)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_CountRedemptionsByCustomerID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM voucher_redemptions WHERE voucher_id = ? AND customer_id = ?")
+	mock.ExpectQuery(query).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{""}).AddRow(2))
+
+	voucherRepository := NewVoucherRepository(db)
+	count, err := voucherRepository.CountRedemptionsByCustomerID(ctx, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func Test_CreateVouchers_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	params := []*entity.CreateVoucherParam{
+		{Code: "A", ValueType: "fixed", Value: 1000, StartsAt: now, ExpiresAt: now, UsageLimit: 1},
+		{Code: "B", ValueType: "fixed", Value: 1000, StartsAt: now, ExpiresAt: now, UsageLimit: 1},
+	}
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("VALUES (?, ?, ?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?, ?, ?)")
+	mock.ExpectExec(query).
+		WithArgs(
+			"A", "fixed", 1000, 0, now, now, 1, 0,
+			"B", "fixed", 1000, 0, now, now, 1, 0,
+		).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+
+	voucherRepository := NewVoucherRepository(db)
+	err = voucherRepository.CreateVouchers(ctx, params)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_IncrementUsageByID_Failed_WhenLimitReached(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("UPDATE vouchers SET used_count = used_count + 1")
+	mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	voucherRepository := NewVoucherRepository(db)
+	isIncremented, err := voucherRepository.IncrementUsageByID(ctx, 1)
+	assert.Nil(t, err)
+	assert.False(t, isIncremented)
+}
+
+func Test_IncrementUsageByID_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("UPDATE vouchers SET used_count = used_count + 1")
+	mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	voucherRepository := NewVoucherRepository(db)
+	isIncremented, err := voucherRepository.IncrementUsageByID(ctx, 1)
+	assert.Nil(t, err)
+	assert.True(t, isIncremented)
+}
+
+func Test_CreateRedemption_Failed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	param := entity.CreateVoucherRedemptionParam{VoucherID: 1, OrderID: 2, Discount: 5000}
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("INSERT INTO voucher_redemptions(voucher_id, order_id, customer_id, discount) VALUES(?, ?, ?, ?)")
+	mock.ExpectExec(query).
+		WithArgs(param.VoucherID, param.OrderID, nil, param.Discount).
+		WillReturnError(errors.New("failed create redemption"))
+
+	voucherRepository := NewVoucherRepository(db)
+	err = voucherRepository.CreateRedemption(ctx, param)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,416 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type WebhookRepository struct {
+	DB *sql.DB
+}
+
+func NewWebhookRepository(DB *sql.DB) *WebhookRepository {
+	return &WebhookRepository{DB: DB}
+}
+
+const webhookColumns = "id, url, secret, events, active, created_at, updated_at"
+
+const webhookDeliveryColumns = `wd.id, wd.webhook_id, w.url, w.secret, wd.event_id, wd.event_type, wd.body,
+	wd.status, wd.attempts, wd.next_attempt_at, wd.last_attempt_at, wd.response_status,
+	wd.response_body, wd.error, wd.created_at`
+
+func scanWebhook(scanner interface{ Scan(...interface{}) error }) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	var events string
+	err := scanner.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&webhook.Secret,
+		&events,
+		&webhook.Active,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Events = []string{}
+	if events != "" {
+		webhook.Events = strings.Split(events, ",")
+	}
+
+	return &webhook, nil
+}
+
+func scanWebhookDelivery(scanner interface{ Scan(...interface{}) error }) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	err := scanner.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.WebhookURL,
+		&delivery.WebhookSecret,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Body,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.ResponseBody,
+		&delivery.Error,
+		&delivery.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (repo WebhookRepository) GetWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	var rows *sql.Rows
+	var err error
+	query := "SELECT " + webhookColumns + " FROM webhooks ORDER BY id ASC"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []*entity.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (repo WebhookRepository) GetWebhookByID(ctx context.Context, ID int64) (*entity.Webhook, error) {
+	var row *sql.Row
+	query := "SELECT " + webhookColumns + " FROM webhooks WHERE id = ?"
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	webhook, err := scanWebhook(row)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrNotFound{Message: "Webhook not found", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (repo WebhookRepository) Create(ctx context.Context, param entity.CreateWebhookParam) (*entity.Webhook, error) {
+	query := "INSERT INTO webhooks(url, secret, events, active) VALUES(?, ?, ?, ?)"
+	events := strings.Join(param.Events, ",")
+	var res sql.Result
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		res, err = tx.Exec(query, param.URL, param.Secret, events, param.Active)
+	} else {
+		res, err = repo.DB.ExecContext(ctx, query, param.URL, param.Secret, events, param.Active)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ID, err := res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return repo.GetWebhookByID(ctx, ID)
+}
+
+func (repo WebhookRepository) UpdateByID(ctx context.Context, ID int64, param entity.SaveWebhookParam) error {
+	query := "UPDATE webhooks SET url = ?, events = ?, active = ?, updated_at = CURRENT_TIMESTAMP() WHERE id = ?"
+	events := strings.Join(param.Events, ",")
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.URL, events, param.Active, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.URL, events, param.Active, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+// DeleteByID deletes a webhook along with its deliveries.
+func (repo WebhookRepository) DeleteByID(ctx context.Context, ID int64) error {
+	query := "DELETE FROM webhooks WHERE id = ?"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, ID)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, ID)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+// CreateEvent records an event in the outbox, in the transaction of the
+// context when there is one.
+func (repo WebhookRepository) CreateEvent(ctx context.Context, param entity.CreateWebhookEventParam) error {
+	query := "INSERT INTO webhook_events(type, payload) VALUES(?, ?)"
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, param.Type, param.Payload)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, param.Type, param.Payload)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+// GetUndispatchedEventsForUpdate returns the oldest events not dispatched
+// yet, locking them until the transaction ends.
+func (repo WebhookRepository) GetUndispatchedEventsForUpdate(ctx context.Context, limit int) ([]*entity.WebhookEvent, error) {
+	var rows *sql.Rows
+	var err error
+	query := `
+		SELECT id, type, payload, created_at, dispatched_at FROM webhook_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id ASC
+			LIMIT ?
+			FOR UPDATE`
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, limit)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, limit)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*entity.WebhookEvent{}
+	for rows.Next() {
+		var event entity.WebhookEvent
+		err := rows.Scan(&event.ID, &event.Type, &event.Payload, &event.CreatedAt, &event.DispatchedAt)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (repo WebhookRepository) MarkEventsDispatched(ctx context.Context, IDs []int64, at time.Time) error {
+	if len(IDs) < 1 {
+		return nil
+	}
+
+	params := make([]string, len(IDs))
+	args := []interface{}{at}
+	for i, ID := range IDs {
+		params[i] = "?"
+		args = append(args, ID)
+	}
+
+	query := fmt.Sprintf("UPDATE webhook_events SET dispatched_at = ? WHERE id IN (%s)", strings.Join(params, ", "))
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, args...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+// CreateDeliveries queues the deliveries to be attempted right away.
+func (repo WebhookRepository) CreateDeliveries(ctx context.Context, params []*entity.CreateWebhookDeliveryParam) error {
+	if len(params) < 1 {
+		return nil
+	}
+
+	placeholders := []string{}
+	args := []interface{}{}
+	for _, param := range params {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, '')")
+		args = append(args, param.WebhookID, param.EventID, param.EventType, param.Body, entity.WebhookDeliveryPending)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO webhook_deliveries(webhook_id, event_id, event_type, body, status, response_body)
+			VALUES %s`, strings.Join(placeholders, ", "))
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, args...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+func (repo WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entity.WebhookDelivery, error) {
+	var rows *sql.Rows
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		rows, err = tx.Query(query, args...)
+	} else {
+		rows, err = repo.DB.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*entity.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// GetDueDeliveries returns the pending deliveries of the active webhooks
+// whose next attempt is due, the oldest first.
+func (repo WebhookRepository) GetDueDeliveries(ctx context.Context, at time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM webhook_deliveries wd JOIN webhooks w ON w.id = wd.webhook_id
+			WHERE wd.status = ? AND wd.next_attempt_at <= ? AND w.active = 1
+			ORDER BY wd.next_attempt_at ASC, wd.id ASC
+			LIMIT ?`, webhookDeliveryColumns)
+	return repo.queryDeliveries(ctx, query, entity.WebhookDeliveryPending, at, limit)
+}
+
+// GetDeliveriesByWebhookID returns the latest deliveries of a webhook.
+func (repo WebhookRepository) GetDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM webhook_deliveries wd JOIN webhooks w ON w.id = wd.webhook_id
+			WHERE wd.webhook_id = ?
+			ORDER BY wd.id DESC
+			LIMIT ?`, webhookDeliveryColumns)
+	return repo.queryDeliveries(ctx, query, webhookID, limit)
+}
+
+func (repo WebhookRepository) GetDeliveryByID(ctx context.Context, ID int64) (*entity.WebhookDelivery, error) {
+	var row *sql.Row
+	query := fmt.Sprintf(`
+		SELECT %s FROM webhook_deliveries wd JOIN webhooks w ON w.id = wd.webhook_id
+			WHERE wd.id = ?`, webhookDeliveryColumns)
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		row = tx.QueryRow(query, ID)
+	} else {
+		row = repo.DB.QueryRowContext(ctx, query, ID)
+	}
+
+	delivery, err := scanWebhookDelivery(row)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrNotFound{Message: "Webhook delivery not found", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (repo WebhookRepository) UpdateDeliveryByID(ctx context.Context, ID int64, param entity.UpdateWebhookDeliveryParam) error {
+	query := `
+		UPDATE webhook_deliveries
+			SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?,
+				response_status = ?, response_body = ?, error = ?
+			WHERE id = ?`
+	args := []interface{}{
+		param.Status,
+		param.Attempts,
+		param.NextAttemptAt,
+		param.LastAttemptAt,
+		param.ResponseStatus,
+		param.ResponseBody,
+		param.Error,
+		ID,
+	}
+	var err error
+	if tx, ok := ctx.Value(MySQLTransactionKey("tx")).(*sql.Tx); ok {
+		_, err = tx.Exec(query, args...)
+	} else {
+		_, err = repo.DB.ExecContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,131 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+var webhookDeliveryRowColumns = []string{
+	"id", "webhook_id", "url", "secret", "event_id", "event_type", "body", "status", "attempts",
+	"next_attempt_at", "last_attempt_at", "response_status", "response_body", "error", "created_at",
+}
+
+func Test_GetWebhookByID_Failed_WhenNoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhooks WHERE id = ?")).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	webhookRepository := NewWebhookRepository(db)
+	webhook, err := webhookRepository.GetWebhookByID(context.TODO(), 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, webhook)
+}
+
+func Test_CreateWebhook_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	param := entity.CreateWebhookParam{
+		SaveWebhookParam: entity.SaveWebhookParam{
+			URL:    "https://example.com/hooks",
+			Events: []string{entity.WebhookEventOrderCreated, entity.WebhookEventOrderRefunded},
+			Active: true,
+		},
+		Secret: "whsec_secret",
+	}
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhooks(url, secret, events, active) VALUES(?, ?, ?, ?)")).
+		WithArgs(param.URL, param.Secret, "order.created,order.refunded", true).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM webhooks WHERE id = ?")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "events", "active", "created_at", "updated_at"}).
+			AddRow(1, param.URL, param.Secret, "order.created,order.refunded", true, now, now))
+
+	webhookRepository := NewWebhookRepository(db)
+	webhook, err := webhookRepository.Create(context.TODO(), param)
+	assert.Nil(t, err)
+	assert.Equal(t, param.Events, webhook.Events)
+	assert.Equal(t, param.Secret, webhook.Secret)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateDeliveries_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	params := []*entity.CreateWebhookDeliveryParam{
+		{WebhookID: 1, EventID: 7, EventType: entity.WebhookEventOrderCreated, Body: `{"id":7}`},
+		{WebhookID: 2, EventID: 7, EventType: entity.WebhookEventOrderCreated, Body: `{"id":7}`},
+	}
+	mock.ExpectExec(regexp.QuoteMeta("VALUES (?, ?, ?, ?, ?, ''), (?, ?, ?, ?, ?, '')")).
+		WithArgs(
+			int64(1), int64(7), entity.WebhookEventOrderCreated, `{"id":7}`, entity.WebhookDeliveryPending,
+			int64(2), int64(7), entity.WebhookEventOrderCreated, `{"id":7}`, entity.WebhookDeliveryPending).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	webhookRepository := NewWebhookRepository(db)
+	err = webhookRepository.CreateDeliveries(context.TODO(), params)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_MarkEventsDispatched_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook_events SET dispatched_at = ? WHERE id IN (?, ?)")).
+		WithArgs(now, int64(7), int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	webhookRepository := NewWebhookRepository(db)
+	err = webhookRepository.MarkEventsDispatched(context.TODO(), []int64{7, 8}, now)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetDueDeliveries_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE wd.status = ? AND wd.next_attempt_at <= ? AND w.active = 1")).
+		WithArgs(entity.WebhookDeliveryPending, now, 50).
+		WillReturnRows(sqlmock.NewRows(webhookDeliveryRowColumns).
+			AddRow(4, 1, "https://example.com/hooks", "whsec_secret", 7, entity.WebhookEventOrderCreated, `{"id":7}`,
+				entity.WebhookDeliveryPending, 1, now, now, 500, "oops", "Receiver answered with status 500", now))
+
+	webhookRepository := NewWebhookRepository(db)
+	deliveries, err := webhookRepository.GetDueDeliveries(context.TODO(), now, 50)
+	assert.Nil(t, err)
+	if assert.Len(t, deliveries, 1) {
+		assert.Equal(t, "whsec_secret", deliveries[0].WebhookSecret)
+		assert.Equal(t, 500, *deliveries[0].ResponseStatus)
+	}
+}
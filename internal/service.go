@@ -1,7 +1,44 @@
 package internal
 
-import "mime/multipart"
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
 
 type Storage interface {
 	Save(file *multipart.FileHeader, dir string, filename string) (string, error)
 }
+
+// Tokenizer signs the access tokens of the API and reads their subject back.
+type Tokenizer interface {
+	Sign(subject int64, expiresAt time.Time) (string, error)
+	Verify(token string) (int64, error)
+}
+
+// WebhookSender posts a delivery to a webhook and returns the answer of the
+// receiver whatever its status.
+type WebhookSender interface {
+	Send(ctx context.Context, request entity.WebhookRequest) (*entity.WebhookResponse, error)
+}
+
+// EventHandler handles a domain event. The change behind the event is
+// already committed, so a failing handler is only logged.
+type EventHandler func(ctx context.Context, event entity.Event) error
+
+// EventPublisher is how the usecases publish their domain events.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event)
+}
+
+// EventBus delivers the published events to their subscribers. A synchronous
+// subscriber runs before Publish returns, an asynchronous one runs in the
+// background in the order the events were published.
+type EventBus interface {
+	EventPublisher
+	Subscribe(name string, handler EventHandler)
+	SubscribeAsync(name string, handler EventHandler)
+	Close()
+}
@@ -2,12 +2,14 @@ package internal
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
 )
 
 type UserUsecase interface {
+	GetAllUsers(ctx context.Context) ([]*entity.User, error)
 	GetUserByID(ctx context.Context, ID int64) (*entity.User, error)
 	GetUserByCredential(ctx context.Context, credential entity.UserCredential) (*entity.User, error)
 	SaveUserPhoto(ctx context.Context, user *entity.User, file *multipart.FileHeader) (string, error)
@@ -15,23 +17,146 @@ type UserUsecase interface {
 	UpdateUserPassword(ctx context.Context, ID int64, password string) (bool, error)
 }
 
+type AccessTokenUsecase interface {
+	IssueAccessToken(ctx context.Context, credential entity.UserCredential) (*entity.AccessToken, error)
+	Authenticate(ctx context.Context, token string) (*entity.User, error)
+}
+
+type APITokenUsecase interface {
+	GetAPITokens(ctx context.Context, userID int64) ([]*entity.APIToken, error)
+	CreateAPIToken(ctx context.Context, userID int64, param entity.CreateAPITokenParam) (*entity.IssuedAPIToken, error)
+	RevokeAPIToken(ctx context.Context, userID int64, ID int64) error
+	AuthenticateAPIToken(ctx context.Context, token string) (*entity.User, *entity.APIToken, error)
+	GetServiceAccounts(ctx context.Context) ([]*entity.User, error)
+	GetServiceAccount(ctx context.Context, ID int64) (*entity.User, error)
+	CreateServiceAccount(ctx context.Context, param entity.CreateServiceAccountParam) (*entity.User, error)
+}
+
 type ProductUsecase interface {
 	GetAllProducts(ctx context.Context) ([]*entity.Product, error)
+	SearchProducts(ctx context.Context, query entity.ProductQuery) (*entity.ProductPage, error)
+	GetProductCategories(ctx context.Context) ([]string, error)
 	GetProductByID(ctx context.Context, ID int64) (*entity.Product, error)
 	GetProductByCode(ctx context.Context, code string) (*entity.Product, error)
 	GetBestSellerProducts(ctx context.Context) ([]*entity.ProductSale, error)
 	CreateProduct(ctx context.Context, param entity.CreateProductParam) (*entity.Product, error)
 	UpdateProduct(ctx context.Context, ID int64, param entity.UpdateProductParam) (bool, error)
 	DeleteProduct(ctx context.Context, ID int64) (bool, error)
+	GetNegativeStockSales(ctx context.Context) ([]*entity.NegativeStockSale, error)
+	ReconcileNegativeStockSale(ctx context.Context, ID int64) (bool, error)
 }
 
 type OrderUsecase interface {
-	GetAllOrders(ctx context.Context) ([]*entity.Order, error)
+	GetOrders(ctx context.Context, query entity.OrderQuery) (*entity.OrderPage, error)
+	GetOrderByID(ctx context.Context, ID int64) (*entity.Order, error)
+	GetOrderByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entity.Order, error)
 	GetOrderItems(ctx context.Context, orderID int64) ([]*entity.OrderItem, error)
-	GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIncome, error)
-	GetDailyOrderCount(ctx context.Context) (int, error)
+	GetOrderPayments(ctx context.Context, orderID int64) ([]*entity.OrderPayment, error)
 	GetTotalOrderCount(ctx context.Context) (int, error)
-	GetLastDayIncome(ctx context.Context) (int, error)
-	GetLastMonthIncome(ctx context.Context) (int, error)
 	Create(ctx context.Context, param entity.CreateOrderParam) (*entity.Order, error)
+	VoidOrder(ctx context.Context, ID int64) error
+	RefundOrder(ctx context.Context, ID int64, param entity.RefundOrderParam) (*entity.PrepaidCard, error)
+}
+
+type VoucherUsecase interface {
+	GetAllVouchers(ctx context.Context) ([]*entity.Voucher, error)
+	GetVoucherByID(ctx context.Context, ID int64) (*entity.Voucher, error)
+	GetVoucherRedemptions(ctx context.Context, voucherID int64) ([]*entity.VoucherRedemption, error)
+	GenerateVouchers(ctx context.Context, param entity.GenerateVoucherParam) ([]string, error)
+}
+
+type CustomerUsecase interface {
+	GetAllCustomers(ctx context.Context) ([]*entity.Customer, error)
+	SearchCustomers(ctx context.Context, keyword string) ([]*entity.Customer, error)
+	GetCustomerByID(ctx context.Context, ID int64) (*entity.Customer, error)
+	GetCustomerOrders(ctx context.Context, customerID int64) ([]*entity.Order, error)
+	GetCustomerLifetimeValue(ctx context.Context, customerID int64) (*entity.CustomerLifetimeValue, error)
+	CreateCustomer(ctx context.Context, param entity.CreateCustomerParam) (*entity.Customer, error)
+	UpdateCustomerGroup(ctx context.Context, ID int64, param entity.UpdateCustomerGroupParam) (bool, error)
+	GetAllGroups(ctx context.Context) ([]*entity.CustomerGroup, error)
+	CreateGroup(ctx context.Context, param entity.CreateCustomerGroupParam) (*entity.CustomerGroup, error)
+}
+
+type LoyaltyUsecase interface {
+	GetLoyaltySetting(ctx context.Context) (*entity.LoyaltySetting, error)
+	UpdateLoyaltySetting(ctx context.Context, param entity.UpdateLoyaltySettingParam) error
+	GetAllMultipliers(ctx context.Context) ([]*entity.LoyaltyMultiplier, error)
+	SaveMultiplier(ctx context.Context, param entity.SaveLoyaltyMultiplierParam) error
+	DeleteMultiplier(ctx context.Context, ID int64) (bool, error)
+	GetCustomerBalance(ctx context.Context, customerID int64) (int, error)
+	GetCustomerEntries(ctx context.Context, customerID int64) ([]*entity.LoyaltyEntry, error)
+}
+
+type PrepaidCardUsecase interface {
+	GetAllCards(ctx context.Context) ([]*entity.PrepaidCard, error)
+	GetCardByID(ctx context.Context, ID int64) (*entity.PrepaidCard, error)
+	GetCardByNumber(ctx context.Context, number string) (*entity.PrepaidCard, error)
+	GetCardTransactions(ctx context.Context, cardID int64) ([]*entity.PrepaidTransaction, error)
+}
+
+type PriceListUsecase interface {
+	GetAllPriceLists(ctx context.Context) ([]*entity.PriceList, error)
+	GetPriceListByID(ctx context.Context, ID int64) (*entity.PriceList, error)
+	GetPriceListItems(ctx context.Context, priceListID int64) ([]*entity.PriceListItem, error)
+	GetCustomerPrices(ctx context.Context, customerID int64) ([]*entity.PriceListItem, error)
+	CreatePriceList(ctx context.Context, param entity.SavePriceListParam) (*entity.PriceList, error)
+	DeletePriceList(ctx context.Context, ID int64) (bool, error)
+	SavePriceListItem(ctx context.Context, priceListID int64, param entity.SavePriceListItemParam) error
+	DeletePriceListItem(ctx context.Context, priceListID int64, ID int64) (bool, error)
+	ImportPriceListItems(ctx context.Context, priceListID int64, file io.Reader) (int, error)
+}
+
+type HeldCartUsecase interface {
+	GetHeldCarts(ctx context.Context, register string) ([]*entity.HeldCart, error)
+	HoldCart(ctx context.Context, param entity.HoldCartParam) (*entity.HeldCart, error)
+	ResumeHeldCart(ctx context.Context, ID int64) (*entity.HeldCart, error)
+	DiscardHeldCart(ctx context.Context, ID int64) (bool, error)
+}
+
+type StoreSettingUsecase interface {
+	GetStoreSetting(ctx context.Context) (*entity.StoreSetting, error)
+	UpdateStoreSetting(ctx context.Context, param entity.UpdateStoreSettingParam) error
+}
+
+type ReportUsecase interface {
+	GetSalesReport(ctx context.Context, param entity.SalesReportParam) (*entity.SalesReport, error)
+	GetSalesHeatmap(ctx context.Context, param entity.SalesHeatmapParam) (*entity.SalesHeatmap, error)
+	GetStockValuation(ctx context.Context, query entity.StockValuationQuery) (*entity.StockValuation, error)
+}
+
+type ProductAnalyticsUsecase interface {
+	GetProductAnalytics(ctx context.Context, param entity.ProductAnalyticsParam) (*entity.ProductAnalytics, error)
+}
+
+type MarketBasketUsecase interface {
+	RefreshMarketBasket(ctx context.Context, param entity.MarketBasketParam) (*entity.MarketBasketRun, error)
+	GetMarketBasket(ctx context.Context) (*entity.MarketBasket, error)
+	GetSuggestions(ctx context.Context, productIDs []int64) ([]*entity.ProductSuggestion, error)
+}
+
+type ForecastUsecase interface {
+	GetReorderPlan(ctx context.Context, param entity.ReorderParam) (*entity.ReorderPlan, error)
+}
+
+type PurchaseOrderUsecase interface {
+	GetPurchaseOrders(ctx context.Context) ([]*entity.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, ID int64) (*entity.PurchaseOrder, error)
+	CreatePurchaseOrder(ctx context.Context, param entity.SavePurchaseOrderParam) (*entity.PurchaseOrder, error)
+	UpdatePurchaseOrderStatus(ctx context.Context, ID int64, param entity.UpdatePurchaseOrderStatusParam) error
+}
+
+type WebhookUsecase interface {
+	GetWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	GetWebhook(ctx context.Context, ID int64) (*entity.Webhook, error)
+	CreateWebhook(ctx context.Context, param entity.SaveWebhookParam) (*entity.Webhook, error)
+	UpdateWebhook(ctx context.Context, ID int64, param entity.SaveWebhookParam) error
+	DeleteWebhook(ctx context.Context, ID int64) error
+	GetWebhookDeliveries(ctx context.Context, webhookID int64) ([]*entity.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, webhookID int64, deliveryID int64) error
+	DispatchWebhookEvents(ctx context.Context) (int, error)
+	DeliverWebhooks(ctx context.Context) (int, error)
+}
+
+type AuditLogUsecase interface {
+	GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) (*entity.AuditLogPage, error)
 }
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type AccessTokenUsecase struct {
+	userRepository     internal.UserRepository
+	auditLogRepository internal.AuditLogRepository
+	tokenizer          internal.Tokenizer
+	eventPublisher     internal.EventPublisher
+	ttl                time.Duration
+}
+
+func NewAccessTokenUsecase(
+	userRepository internal.UserRepository,
+	auditLogRepository internal.AuditLogRepository,
+	tokenizer internal.Tokenizer,
+	eventPublisher internal.EventPublisher,
+	ttl time.Duration) *AccessTokenUsecase {
+	return &AccessTokenUsecase{userRepository, auditLogRepository, tokenizer, eventPublisher, ttl}
+}
+
+// IssueAccessToken signs a token for the user of the credential, valid for
+// the configured time to live.
+func (atu AccessTokenUsecase) IssueAccessToken(ctx context.Context, credential entity.UserCredential) (*entity.AccessToken, error) {
+	user, err := atu.userRepository.GetUserByEmail(ctx, credential.Email)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, entity.ErrInvalidCredential{Message: "Invalid email or password"}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if user.ServiceAccount || stringsHash(credential.Password) != user.Password {
+		return nil, entity.ErrInvalidCredential{Message: "Invalid email or password"}
+	}
+
+	expiresAt := time.Now().Add(atu.ttl)
+	token, err := atu.tokenizer.Sign(user.ID, expiresAt)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	accessToken := &entity.AccessToken{
+		Token:     token,
+		TokenType: entity.AccessTokenTypeBearer,
+		ExpiresAt: expiresAt,
+		User:      user,
+	}
+	if err := recordLogin(ctx, atu.auditLogRepository, user); err != nil {
+		return nil, err
+	}
+
+	atu.eventPublisher.Publish(ctx, entity.Event{
+		Name: entity.EventUserLoggedIn,
+		Data: entity.UserLoggedInEvent{User: user, Channel: entity.LoginChannelAPI},
+	})
+	return accessToken, nil
+}
+
+// Authenticate returns the user an access token was issued to, as the user
+// is now rather than when the token was signed.
+func (atu AccessTokenUsecase) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+	userID, err := atu.tokenizer.Verify(token)
+	if err != nil {
+		return nil, entity.ErrInvalidCredential{Message: "Invalid or expired access token", Err: err}
+	}
+
+	user, err := atu.userRepository.GetUserByID(ctx, userID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, entity.ErrInvalidCredential{Message: "Invalid or expired access token", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_IssueAccessToken_Failed_WhenPasswordIsWrong(t *testing.T) {
+	ctx := context.TODO()
+	tokenUser := user
+	tokenUser.Password = stringsHash("secret")
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByEmail", ctx, tokenUser.Email).Return(&tokenUser, nil)
+	mockTokenizer := new(mocks.Tokenizer)
+	credential := entity.UserCredential{Email: tokenUser.Email, Password: "guess"}
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	accessTokenUsecase := NewAccessTokenUsecase(mockUserRepository, mockAuditLogRepo, mockTokenizer, mockEventPublisher, time.Hour)
+	accessToken, err := accessTokenUsecase.IssueAccessToken(ctx, credential)
+	assert.IsType(t, entity.ErrInvalidCredential{}, err)
+	assert.Nil(t, accessToken)
+	mockTokenizer.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything)
+}
+
+func Test_IssueAccessToken_Failed_WhenUserIsNotFound(t *testing.T) {
+	ctx := context.TODO()
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByEmail", ctx, "nobody@mail.com").Return(nil, entity.ErrNotFound{Message: "User not found"})
+	mockTokenizer := new(mocks.Tokenizer)
+	credential := entity.UserCredential{Email: "nobody@mail.com", Password: "secret"}
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	accessTokenUsecase := NewAccessTokenUsecase(mockUserRepository, mockAuditLogRepo, mockTokenizer, mockEventPublisher, time.Hour)
+	accessToken, err := accessTokenUsecase.IssueAccessToken(ctx, credential)
+	assert.IsType(t, entity.ErrInvalidCredential{}, err)
+	assert.Nil(t, accessToken)
+}
+
+func Test_IssueAccessToken_Success(t *testing.T) {
+	ctx := context.TODO()
+	tokenUser := user
+	tokenUser.Password = stringsHash("secret")
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByEmail", ctx, tokenUser.Email).Return(&tokenUser, nil)
+	mockTokenizer := new(mocks.Tokenizer)
+	mockTokenizer.On("Sign", tokenUser.ID, mock.Anything).Return("signed-token", nil)
+	credential := entity.UserCredential{Email: tokenUser.Email, Password: "secret"}
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	accessTokenUsecase := NewAccessTokenUsecase(mockUserRepository, mockAuditLogRepo, mockTokenizer, mockEventPublisher, time.Hour)
+	before := time.Now()
+	accessToken, err := accessTokenUsecase.IssueAccessToken(ctx, credential)
+	assert.Nil(t, err)
+	assert.Equal(t, "signed-token", accessToken.Token)
+	assert.Equal(t, entity.AccessTokenTypeBearer, accessToken.TokenType)
+	assert.WithinDuration(t, before.Add(time.Hour), accessToken.ExpiresAt, time.Second)
+	assert.Equal(t, tokenUser.ID, accessToken.User.ID)
+}
+
+func Test_Authenticate_Failed_WhenTokenIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockUserRepository := new(mocks.UserRepository)
+	mockTokenizer := new(mocks.Tokenizer)
+	mockTokenizer.On("Verify", "expired-token").Return(int64(0), errors.New("token has expired"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	accessTokenUsecase := NewAccessTokenUsecase(mockUserRepository, mockAuditLogRepo, mockTokenizer, mockEventPublisher, time.Hour)
+	authenticated, err := accessTokenUsecase.Authenticate(ctx, "expired-token")
+	assert.IsType(t, entity.ErrInvalidCredential{}, err)
+	assert.Nil(t, authenticated)
+	mockUserRepository.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func Test_Authenticate_Failed_WhenUserIsDeleted(t *testing.T) {
+	ctx := context.TODO()
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByID", ctx, int64(9)).Return(nil, entity.ErrNotFound{Message: "User not found"})
+	mockTokenizer := new(mocks.Tokenizer)
+	mockTokenizer.On("Verify", "signed-token").Return(int64(9), nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	accessTokenUsecase := NewAccessTokenUsecase(mockUserRepository, mockAuditLogRepo, mockTokenizer, mockEventPublisher, time.Hour)
+	authenticated, err := accessTokenUsecase.Authenticate(ctx, "signed-token")
+	assert.IsType(t, entity.ErrInvalidCredential{}, err)
+	assert.Nil(t, authenticated)
+}
+
+func Test_Authenticate_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&user, nil)
+	mockTokenizer := new(mocks.Tokenizer)
+	mockTokenizer.On("Verify", "signed-token").Return(user.ID, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	accessTokenUsecase := NewAccessTokenUsecase(mockUserRepository, mockAuditLogRepo, mockTokenizer, mockEventPublisher, time.Hour)
+	authenticated, err := accessTokenUsecase.Authenticate(ctx, "signed-token")
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID, authenticated.ID)
+}
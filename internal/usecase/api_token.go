@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// apiTokenPrefixLength is how much of a token is kept in the clear, enough
+// for its owner to recognize it in the list of tokens.
+const apiTokenPrefixLength = len(entity.APITokenPrefix) + 8
+
+// apiTokenTouchInterval spaces the writes of the last use of a token out.
+const apiTokenTouchInterval = time.Minute
+
+var randomHex = func(bytes int) (string, error) {
+	b := make([]byte, bytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type APITokenUsecase struct {
+	apiTokenRepository internal.APITokenRepository
+	userRepository     internal.UserRepository
+}
+
+func NewAPITokenUsecase(
+	apiTokenRepository internal.APITokenRepository,
+	userRepository internal.UserRepository) *APITokenUsecase {
+	return &APITokenUsecase{apiTokenRepository, userRepository}
+}
+
+func (atu APITokenUsecase) GetAPITokens(ctx context.Context, userID int64) ([]*entity.APIToken, error) {
+	apiTokens, err := atu.apiTokenRepository.GetAPITokensByUserID(ctx, userID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return apiTokens, err
+}
+
+// CreateAPIToken issues a token to a user with the scopes of the param, the
+// token itself is only returned here.
+func (atu APITokenUsecase) CreateAPIToken(ctx context.Context, userID int64, param entity.CreateAPITokenParam) (*entity.IssuedAPIToken, error) {
+	requested := map[string]bool{}
+	for _, scope := range param.Scopes {
+		requested[scope] = true
+	}
+
+	scopes := []string{}
+	for _, scope := range entity.APIScopes {
+		if requested[scope] {
+			scopes = append(scopes, scope)
+			delete(requested, scope)
+		}
+	}
+
+	if len(scopes) < 1 || len(requested) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid API token",
+			Errors:  map[string]string{"Scopes": "Scopes must be some of the listed scopes"},
+		}
+	}
+
+	secret, err := randomHex(20)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	token := entity.APITokenPrefix + secret
+	saveParam := entity.SaveAPITokenParam{
+		UserID:    userID,
+		Name:      param.Name,
+		Prefix:    token[:apiTokenPrefixLength],
+		TokenHash: hashAPIToken(token),
+		Scopes:    scopes,
+	}
+	if param.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, param.ExpiresInDays)
+		saveParam.ExpiresAt = &expiresAt
+	}
+
+	apiToken, err := atu.apiTokenRepository.Create(ctx, saveParam)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &entity.IssuedAPIToken{Token: token, APIToken: apiToken}, nil
+}
+
+func (atu APITokenUsecase) RevokeAPIToken(ctx context.Context, userID int64, ID int64) error {
+	revoked, err := atu.apiTokenRepository.RevokeByID(ctx, userID, ID)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	if !revoked {
+		return entity.ErrNotFound{Message: "API token not found"}
+	}
+
+	return nil
+}
+
+// AuthenticateAPIToken returns the owner of an active API token along with
+// the token, whose scopes limit what the owner can do.
+func (atu APITokenUsecase) AuthenticateAPIToken(ctx context.Context, token string) (*entity.User, *entity.APIToken, error) {
+	apiToken, err := atu.apiTokenRepository.GetAPITokenByHash(ctx, hashAPIToken(token))
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, nil, entity.ErrInvalidCredential{Message: "Invalid or revoked API token", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if !apiToken.IsActive(now) {
+		return nil, nil, entity.ErrInvalidCredential{Message: "Invalid or revoked API token"}
+	}
+
+	user, err := atu.userRepository.GetUserByID(ctx, apiToken.UserID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, nil, entity.ErrInvalidCredential{Message: "Invalid or revoked API token", Err: err}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, nil, err
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) >= apiTokenTouchInterval {
+		// failing to track the use must not fail the request
+		if err := atu.apiTokenRepository.UpdateLastUsedAtByID(ctx, apiToken.ID, now); err == nil {
+			apiToken.LastUsedAt = &now
+		}
+	}
+
+	return user, apiToken, nil
+}
+
+func (atu APITokenUsecase) GetServiceAccounts(ctx context.Context) ([]*entity.User, error) {
+	serviceAccounts, err := atu.userRepository.GetServiceAccounts(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return serviceAccounts, err
+}
+
+func (atu APITokenUsecase) GetServiceAccount(ctx context.Context, ID int64) (*entity.User, error) {
+	user, err := atu.userRepository.GetUserByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.ServiceAccount {
+		return nil, entity.ErrNotFound{Message: "Service account not found"}
+	}
+
+	return user, nil
+}
+
+// CreateServiceAccount creates a user for an integration, addressed by a
+// generated email since it never logs in.
+func (atu APITokenUsecase) CreateServiceAccount(ctx context.Context, param entity.CreateServiceAccountParam) (*entity.User, error) {
+	suffix, err := randomHex(6)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	email := fmt.Sprintf("service-%s@kaseer.local", suffix)
+	user, err := atu.userRepository.CreateServiceAccount(ctx, param.Name, email)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return user, nil
+}
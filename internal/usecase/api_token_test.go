@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CreateAPIToken_Failed_WhenScopeIsUnknown(t *testing.T) {
+	ctx := context.TODO()
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockUserRepository := new(mocks.UserRepository)
+	param := entity.CreateAPITokenParam{
+		Name:   "Accounting",
+		Scopes: []string{entity.APIScopeOrdersRead, "admin"},
+	}
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	issued, err := apiTokenUsecase.CreateAPIToken(ctx, user.ID, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, issued)
+	mockAPITokenRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func Test_CreateAPIToken_Success(t *testing.T) {
+	ctx := context.TODO()
+	var saved entity.SaveAPITokenParam
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockAPITokenRepository.On("Create", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(entity.SaveAPITokenParam) }).
+		Return(&entity.APIToken{ID: 1, UserID: user.ID}, nil)
+	mockUserRepository := new(mocks.UserRepository)
+	param := entity.CreateAPITokenParam{
+		Name:          "Accounting",
+		Scopes:        []string{entity.APIScopeReportsRead, entity.APIScopeOrdersRead, entity.APIScopeOrdersRead},
+		ExpiresInDays: 30,
+	}
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	issued, err := apiTokenUsecase.CreateAPIToken(ctx, user.ID, param)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), issued.APIToken.ID)
+	assert.True(t, strings.HasPrefix(issued.Token, entity.APITokenPrefix))
+	assert.Len(t, issued.Token, len(entity.APITokenPrefix)+40)
+
+	// only the hash and the prefix of the token are kept
+	assert.Equal(t, user.ID, saved.UserID)
+	assert.Equal(t, hashAPIToken(issued.Token), saved.TokenHash)
+	assert.Equal(t, issued.Token[:apiTokenPrefixLength], saved.Prefix)
+	assert.Equal(t, []string{entity.APIScopeOrdersRead, entity.APIScopeReportsRead}, saved.Scopes)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *saved.ExpiresAt, time.Second)
+}
+
+func Test_RevokeAPIToken_Failed_WhenTokenIsNotOwned(t *testing.T) {
+	ctx := context.TODO()
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockAPITokenRepository.On("RevokeByID", ctx, user.ID, int64(9)).Return(false, nil)
+	mockUserRepository := new(mocks.UserRepository)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	err := apiTokenUsecase.RevokeAPIToken(ctx, user.ID, 9)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+}
+
+func Test_AuthenticateAPIToken_Failed_WhenTokenIsUnknown(t *testing.T) {
+	ctx := context.TODO()
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockAPITokenRepository.On("GetAPITokenByHash", ctx, hashAPIToken("kst_unknown")).Return(nil, entity.ErrNotFound{Message: "API token not found"})
+	mockUserRepository := new(mocks.UserRepository)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	tokenUser, apiToken, err := apiTokenUsecase.AuthenticateAPIToken(ctx, "kst_unknown")
+	assert.IsType(t, entity.ErrInvalidCredential{}, err)
+	assert.Nil(t, tokenUser)
+	assert.Nil(t, apiToken)
+}
+
+func Test_AuthenticateAPIToken_Failed_WhenTokenIsRevokedOrExpired(t *testing.T) {
+	ctx := context.TODO()
+	past := time.Now().Add(-time.Hour)
+	for _, stored := range []*entity.APIToken{
+		{ID: 1, UserID: user.ID, RevokedAt: &past},
+		{ID: 2, UserID: user.ID, ExpiresAt: &past},
+	} {
+		mockAPITokenRepository := new(mocks.APITokenRepository)
+		mockAPITokenRepository.On("GetAPITokenByHash", ctx, mock.Anything).Return(stored, nil)
+		mockUserRepository := new(mocks.UserRepository)
+
+		apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+		tokenUser, _, err := apiTokenUsecase.AuthenticateAPIToken(ctx, "kst_token")
+		assert.IsType(t, entity.ErrInvalidCredential{}, err)
+		assert.Nil(t, tokenUser)
+		mockUserRepository.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	}
+}
+
+func Test_AuthenticateAPIToken_Success_TracksLastUse(t *testing.T) {
+	ctx := context.TODO()
+	stored := &entity.APIToken{ID: 1, UserID: user.ID, Scopes: []string{entity.APIScopeOrdersRead}}
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockAPITokenRepository.On("GetAPITokenByHash", ctx, hashAPIToken("kst_token")).Return(stored, nil)
+	mockAPITokenRepository.On("UpdateLastUsedAtByID", ctx, int64(1), mock.Anything).Return(nil)
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&user, nil)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	tokenUser, apiToken, err := apiTokenUsecase.AuthenticateAPIToken(ctx, "kst_token")
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID, tokenUser.ID)
+	assert.True(t, apiToken.HasScope(entity.APIScopeOrdersRead))
+	assert.NotNil(t, apiToken.LastUsedAt)
+}
+
+func Test_AuthenticateAPIToken_Success_WhenTrackingLastUseFails(t *testing.T) {
+	ctx := context.TODO()
+	stored := &entity.APIToken{ID: 1, UserID: user.ID}
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockAPITokenRepository.On("GetAPITokenByHash", ctx, mock.Anything).Return(stored, nil)
+	mockAPITokenRepository.On("UpdateLastUsedAtByID", ctx, int64(1), mock.Anything).Return(errors.New("failed update"))
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&user, nil)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	tokenUser, _, err := apiTokenUsecase.AuthenticateAPIToken(ctx, "kst_token")
+	assert.Nil(t, err)
+	assert.NotNil(t, tokenUser)
+}
+
+func Test_AuthenticateAPIToken_Success_SkipsRecentlyTrackedUse(t *testing.T) {
+	ctx := context.TODO()
+	recently := time.Now().Add(-10 * time.Second)
+	stored := &entity.APIToken{ID: 1, UserID: user.ID, LastUsedAt: &recently}
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockAPITokenRepository.On("GetAPITokenByHash", ctx, mock.Anything).Return(stored, nil)
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&user, nil)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	_, _, err := apiTokenUsecase.AuthenticateAPIToken(ctx, "kst_token")
+	assert.Nil(t, err)
+	mockAPITokenRepository.AssertNotCalled(t, "UpdateLastUsedAtByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_GetServiceAccount_Failed_WhenUserIsNotAServiceAccount(t *testing.T) {
+	ctx := context.TODO()
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&user, nil)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	serviceAccount, err := apiTokenUsecase.GetServiceAccount(ctx, user.ID)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, serviceAccount)
+}
+
+func Test_CreateServiceAccount_Success(t *testing.T) {
+	oriRandomHex := randomHex
+	randomHex = func(bytes int) (string, error) {
+		return "a1b2c3", nil
+	}
+
+	ctx := context.TODO()
+	serviceAccount := &entity.User{ID: 3, Name: "Accounting", ServiceAccount: true}
+	mockAPITokenRepository := new(mocks.APITokenRepository)
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("CreateServiceAccount", ctx, "Accounting", "service-a1b2c3@kaseer.local").Return(serviceAccount, nil)
+
+	apiTokenUsecase := NewAPITokenUsecase(mockAPITokenRepository, mockUserRepository)
+	created, err := apiTokenUsecase.CreateServiceAccount(ctx, entity.CreateServiceAccountParam{Name: "Accounting"})
+	assert.Nil(t, err)
+	assert.Equal(t, serviceAccount, created)
+	randomHex = oriRandomHex
+}
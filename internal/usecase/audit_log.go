@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+const (
+	defaultAuditLogPageLimit = 20
+	maxAuditLogPageLimit     = 100
+
+	// auditUserAgentLength is as long as the user agent column
+	auditUserAgentLength = 255
+)
+
+// auditOmittedFields are left out of the changes, they either name the
+// entity or change with every update.
+var auditOmittedFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+type AuditLogUsecase struct {
+	auditLogRepository internal.AuditLogRepository
+}
+
+func NewAuditLogUsecase(auditLogRepository internal.AuditLogRepository) *AuditLogUsecase {
+	return &AuditLogUsecase{auditLogRepository: auditLogRepository}
+}
+
+func (alu AuditLogUsecase) GetAuditLogs(ctx context.Context, query entity.AuditLogQuery) (*entity.AuditLogPage, error) {
+	errs := map[string]string{}
+	if query.StartDate != nil && query.EndDate != nil && !query.EndDate.After(*query.StartDate) {
+		errs["EndDate"] = "End date must be after the start date"
+	}
+
+	if query.Action != "" && !isAuditAction(query.Action) {
+		errs["Action"] = "Unknown action"
+	}
+
+	switch query.EntityType {
+	case "", entity.AuditEntityProduct, entity.AuditEntityUser, entity.AuditEntityOrder:
+	default:
+		errs["EntityType"] = "Unknown entity type"
+	}
+
+	if query.Offset < 0 {
+		errs["Offset"] = "Offset must not be negative"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid audit log filter",
+			Errors:  errs,
+		}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultAuditLogPageLimit
+	}
+
+	if query.Limit > maxAuditLogPageLimit {
+		query.Limit = maxAuditLogPageLimit
+	}
+
+	// one extra row tells whether there is a next page without counting
+	fetchQuery := query
+	fetchQuery.Limit = query.Limit + 1
+	auditLogs, err := alu.auditLogRepository.GetAuditLogs(ctx, fetchQuery)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	page := &entity.AuditLogPage{Offset: query.Offset, Limit: query.Limit}
+	if len(auditLogs) > query.Limit {
+		auditLogs = auditLogs[:query.Limit]
+		page.HasMore = true
+		page.NextCursor = auditLogs[len(auditLogs)-1].ID
+	}
+	page.Logs = auditLogs
+
+	if query.Cursor == 0 {
+		total, err := alu.auditLogRepository.CountAuditLogs(ctx, query)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+func isAuditAction(action string) bool {
+	for _, auditAction := range entity.AuditActions {
+		if auditAction == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newAuditLog fills the actor of the request in, to be replaced by the user
+// logging in for the logins.
+func newAuditLog(ctx context.Context, action string, entityType string, entityID int64, changes map[string]entity.AuditChange) entity.CreateAuditLogParam {
+	actor := entity.AuditActorFromContext(ctx)
+	userAgent := actor.UserAgent
+	if utf8.RuneCountInString(userAgent) > auditUserAgentLength {
+		userAgent = string([]rune(userAgent)[:auditUserAgentLength])
+	}
+
+	return entity.CreateAuditLogParam{
+		ActorID:    actor.UserID,
+		ActorName:  actor.Name,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		IPAddress:  actor.IPAddress,
+		UserAgent:  userAgent,
+	}
+}
+
+// auditChanges compares before and after field by field as they marshal to
+// JSON, a nil side stands for an entity which does not exist.
+func auditChanges(before interface{}, after interface{}) map[string]entity.AuditChange {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+	changes := map[string]entity.AuditChange{}
+	for field, value := range beforeFields {
+		if afterValue, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, afterValue) {
+			changes[field] = entity.AuditChange{Before: value, After: afterFields[field]}
+		}
+	}
+
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = entity.AuditChange{Before: nil, After: value}
+		}
+	}
+
+	return changes
+}
+
+func auditFields(value interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	payload, err := json.Marshal(value)
+	if err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(payload))
+		decoder.UseNumber()
+		err = decoder.Decode(&fields)
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	for field := range auditOmittedFields {
+		delete(fields, field)
+	}
+
+	return fields
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetAuditLogs_Failed_WhenActionIsUnknown(t *testing.T) {
+	ctx := context.TODO()
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+
+	auditLogUsecase := NewAuditLogUsecase(mockAuditLogRepo)
+	page, err := auditLogUsecase.GetAuditLogs(ctx, entity.AuditLogQuery{Action: "product.renamed"})
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, page)
+	mockAuditLogRepo.AssertNotCalled(t, "GetAuditLogs", mock.Anything, mock.Anything)
+}
+
+func Test_GetAuditLogs_Success(t *testing.T) {
+	ctx := context.TODO()
+	query := entity.AuditLogQuery{Action: entity.AuditActionProductUpdated, Limit: 2}
+	fetchQuery := query
+	fetchQuery.Limit = 3
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("GetAuditLogs", ctx, fetchQuery).Return([]*entity.AuditLog{{ID: 9}, {ID: 8}, {ID: 7}}, nil)
+	mockAuditLogRepo.On("CountAuditLogs", ctx, query).Return(3, nil)
+
+	auditLogUsecase := NewAuditLogUsecase(mockAuditLogRepo)
+	page, err := auditLogUsecase.GetAuditLogs(ctx, query)
+	assert.Nil(t, err)
+	assert.Len(t, page.Logs, 2)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, int64(8), page.NextCursor)
+	assert.Equal(t, 3, *page.Total)
+}
+
+func Test_NewAuditLog_TakesTheActorOfTheContext(t *testing.T) {
+	var actorID int64 = 3
+	ctx := entity.ContextWithAuditActor(context.TODO(), entity.AuditActor{
+		UserID:    &actorID,
+		Name:      "Jane",
+		IPAddress: "10.0.0.7",
+		UserAgent: strings.Repeat("a", 300),
+	})
+
+	param := newAuditLog(ctx, entity.AuditActionProductDeleted, entity.AuditEntityProduct, 1, nil)
+	assert.Equal(t, &actorID, param.ActorID)
+	assert.Equal(t, "Jane", param.ActorName)
+	assert.Equal(t, "10.0.0.7", param.IPAddress)
+	assert.Len(t, param.UserAgent, auditUserAgentLength)
+}
+
+func Test_AuditChanges(t *testing.T) {
+	before := &entity.Product{ID: 1, Code: "P1", Name: "Tea", Price: 1500000, Stock: 3}
+	after := &entity.Product{ID: 1, Code: "P1", Name: "Green Tea", Price: 1500000, Stock: 3}
+
+	changes := auditChanges(before, after)
+	assert.Equal(t, map[string]entity.AuditChange{"name": {Before: "Tea", After: "Green Tea"}}, changes)
+
+	changes = auditChanges(nil, after)
+	assert.Equal(t, entity.AuditChange{Before: nil, After: json.Number("1500000")}, changes["price"])
+	assert.NotContains(t, changes, "id")
+	assert.NotContains(t, changes, "created_at")
+}
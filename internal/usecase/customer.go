@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+const customerSearchLimit = 10
+
+type CustomerUsecase struct {
+	customerRepository internal.CustomerRepository
+	orderRepository    internal.OrderRepository
+}
+
+func NewCustomerUsecase(
+	customerRepository internal.CustomerRepository,
+	orderRepository internal.OrderRepository) *CustomerUsecase {
+	return &CustomerUsecase{customerRepository, orderRepository}
+}
+
+func (cu CustomerUsecase) GetAllCustomers(ctx context.Context) ([]*entity.Customer, error) {
+	customers, err := cu.customerRepository.GetAllCustomers(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return customers, err
+}
+
+func (cu CustomerUsecase) SearchCustomers(ctx context.Context, keyword string) ([]*entity.Customer, error) {
+	customers, err := cu.customerRepository.SearchCustomers(ctx, keyword, customerSearchLimit)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return customers, err
+}
+
+func (cu CustomerUsecase) GetCustomerByID(ctx context.Context, ID int64) (*entity.Customer, error) {
+	customer, err := cu.customerRepository.GetCustomerByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return customer, err
+}
+
+func (cu CustomerUsecase) GetCustomerOrders(ctx context.Context, customerID int64) ([]*entity.Order, error) {
+	orders, err := cu.orderRepository.GetOrdersByCustomerID(ctx, customerID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return orders, err
+}
+
+func (cu CustomerUsecase) GetCustomerLifetimeValue(ctx context.Context, customerID int64) (*entity.CustomerLifetimeValue, error) {
+	lifetimeValue, err := cu.orderRepository.GetCustomerLifetimeValue(ctx, customerID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return lifetimeValue, err
+}
+
+func (cu CustomerUsecase) CreateCustomer(ctx context.Context, param entity.CreateCustomerParam) (*entity.Customer, error) {
+	if err := cu.checkGroup(ctx, param.CustomerGroupID); err != nil {
+		return nil, err
+	}
+
+	if param.Phone != "" {
+		exCustomer, _ := cu.customerRepository.GetCustomerByPhone(ctx, param.Phone)
+		if exCustomer != nil {
+			return nil, entity.ErrItemAlreadyExists{
+				Message: "Customer with this phone number already exists",
+				Err:     nil,
+			}
+		}
+	}
+
+	customer, err := cu.customerRepository.Create(ctx, param)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+func (cu CustomerUsecase) UpdateCustomerGroup(ctx context.Context, ID int64, param entity.UpdateCustomerGroupParam) (bool, error) {
+	if err := cu.checkGroup(ctx, param.CustomerGroupID); err != nil {
+		return false, err
+	}
+
+	isUpdated, err := cu.customerRepository.UpdateGroupByID(ctx, ID, param.CustomerGroupID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return isUpdated, err
+}
+
+func (cu CustomerUsecase) checkGroup(ctx context.Context, customerGroupID *int64) error {
+	if customerGroupID == nil {
+		return nil
+	}
+
+	_, err := cu.customerRepository.GetGroupByID(ctx, *customerGroupID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return entity.ErrValidation{
+			Message: "Invalid customer group",
+			Errors:  map[string]string{"CustomerGroupID": "Customer group not found"},
+		}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (cu CustomerUsecase) GetAllGroups(ctx context.Context) ([]*entity.CustomerGroup, error) {
+	groups, err := cu.customerRepository.GetAllGroups(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return groups, err
+}
+
+func (cu CustomerUsecase) CreateGroup(ctx context.Context, param entity.CreateCustomerGroupParam) (*entity.CustomerGroup, error) {
+	group, err := cu.customerRepository.CreateGroup(ctx, param)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return group, err
+}
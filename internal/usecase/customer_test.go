@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SearchCustomers_Success(t *testing.T) {
+	ctx := context.TODO()
+	eCustomers := []*entity.Customer{{ID: 1, Name: "Budi"}}
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("SearchCustomers", ctx, "bud", customerSearchLimit).Return(eCustomers, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+
+	customerUsecase := NewCustomerUsecase(mockCustomerRepo, mockOrderRepo)
+	aCustomers, err := customerUsecase.SearchCustomers(ctx, "bud")
+	assert.Nil(t, err)
+	assert.Equal(t, eCustomers, aCustomers)
+}
+
+func Test_GetCustomerLifetimeValue_Failed(t *testing.T) {
+	ctx := context.TODO()
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetCustomerLifetimeValue", ctx, int64(1)).Return(nil, errors.New("failed get lifetime value"))
+
+	customerUsecase := NewCustomerUsecase(mockCustomerRepo, mockOrderRepo)
+	lifetimeValue, err := customerUsecase.GetCustomerLifetimeValue(ctx, 1)
+	assert.NotNil(t, err)
+	assert.Nil(t, lifetimeValue)
+}
+
+func Test_CreateCustomer_Failed_WhenPhoneExists(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.CreateCustomerParam{Name: "Budi", Phone: "0812"}
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetCustomerByPhone", ctx, param.Phone).Return(&entity.Customer{ID: 1}, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+
+	customerUsecase := NewCustomerUsecase(mockCustomerRepo, mockOrderRepo)
+	customer, err := customerUsecase.CreateCustomer(ctx, param)
+	assert.IsType(t, entity.ErrItemAlreadyExists{}, err)
+	assert.Nil(t, customer)
+	mockCustomerRepo.AssertNotCalled(t, "Create", ctx, param)
+}
+
+func Test_CreateCustomer_Success(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.CreateCustomerParam{Name: "Budi"}
+	eCustomer := &entity.Customer{ID: 1, Name: "Budi"}
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("Create", ctx, param).Return(eCustomer, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+
+	customerUsecase := NewCustomerUsecase(mockCustomerRepo, mockOrderRepo)
+	aCustomer, err := customerUsecase.CreateCustomer(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, eCustomer, aCustomer)
+	mockCustomerRepo.AssertNotCalled(t, "GetCustomerByPhone", ctx, param.Phone)
+}
+
+func Test_UpdateCustomerGroup_Failed_WhenGroupNotFound(t *testing.T) {
+	ctx := context.TODO()
+	var customerGroupID int64 = 3
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetGroupByID", ctx, customerGroupID).Return(nil, entity.ErrNotFound{Message: "Customer group not found"})
+	mockOrderRepo := new(mocks.OrderRepository)
+
+	customerUsecase := NewCustomerUsecase(mockCustomerRepo, mockOrderRepo)
+	isUpdated, err := customerUsecase.UpdateCustomerGroup(ctx, 1, entity.UpdateCustomerGroupParam{CustomerGroupID: &customerGroupID})
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.False(t, isUpdated)
+	mockCustomerRepo.AssertNotCalled(t, "UpdateGroupByID", ctx, int64(1), &customerGroupID)
+}
+
+func Test_UpdateCustomerGroup_Success_WhenRemovingGroup(t *testing.T) {
+	ctx := context.TODO()
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("UpdateGroupByID", ctx, int64(1), (*int64)(nil)).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+
+	customerUsecase := NewCustomerUsecase(mockCustomerRepo, mockOrderRepo)
+	isUpdated, err := customerUsecase.UpdateCustomerGroup(ctx, 1, entity.UpdateCustomerGroupParam{})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
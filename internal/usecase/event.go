@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// publishStockChanges publishes a stock changed event for every product of
+// changes, by product ID, in the order of the product IDs.
+func publishStockChanges(
+	ctx context.Context,
+	publisher internal.EventPublisher,
+	changes map[int64]int,
+	reason string,
+	referenceID *int64,
+) {
+	productIDs := make([]int64, 0, len(changes))
+	for productID, change := range changes {
+		if change != 0 {
+			productIDs = append(productIDs, productID)
+		}
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	for _, productID := range productIDs {
+		publisher.Publish(ctx, entity.Event{
+			Name: entity.EventStockChanged,
+			Data: entity.StockChangedEvent{
+				ProductID:   productID,
+				Change:      changes[productID],
+				Reason:      reason,
+				ReferenceID: referenceID,
+			},
+		})
+	}
+}
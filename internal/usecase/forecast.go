@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+const (
+	defaultForecastHistoryDays  = 56
+	defaultForecastLeadTimeDays = 7
+	defaultForecastCoverDays    = 14
+	movingAverageDays           = 28
+	smoothingFactor             = 0.2
+)
+
+type ForecastUsecase struct {
+	productRepository       internal.ProductRepository
+	purchaseOrderRepository internal.PurchaseOrderRepository
+	location                *time.Location
+}
+
+func NewForecastUsecase(
+	productRepository internal.ProductRepository,
+	purchaseOrderRepository internal.PurchaseOrderRepository,
+	location *time.Location) *ForecastUsecase {
+	return &ForecastUsecase{productRepository, purchaseOrderRepository, location}
+}
+
+// GetReorderPlan forecasts the demand of every product from the complete
+// days of its history and lists the products the stock and the open
+// purchase orders do not cover, the largest quantities first.
+func (fu ForecastUsecase) GetReorderPlan(ctx context.Context, param entity.ReorderParam) (*entity.ReorderPlan, error) {
+	if param.Method == "" {
+		param.Method = entity.ForecastMethodExponentialSmoothing
+	}
+
+	if param.HistoryDays == 0 {
+		param.HistoryDays = defaultForecastHistoryDays
+	}
+
+	if param.LeadTimeDays == 0 {
+		param.LeadTimeDays = defaultForecastLeadTimeDays
+	}
+
+	if param.CoverDays == 0 {
+		param.CoverDays = defaultForecastCoverDays
+	}
+
+	errs := map[string]string{}
+	switch param.Method {
+	case entity.ForecastMethodMovingAverage, entity.ForecastMethodExponentialSmoothing:
+	default:
+		errs["Method"] = "Demand can be forecast by moving average or exponential smoothing"
+	}
+
+	if param.HistoryDays < 14 || param.HistoryDays > 365 {
+		errs["HistoryDays"] = "History must be between 14 and 365 days"
+	}
+
+	if param.LeadTimeDays < 1 || param.LeadTimeDays > 90 {
+		errs["LeadTimeDays"] = "Lead time must be between 1 and 90 days"
+	}
+
+	if param.CoverDays < 1 || param.CoverDays > 180 {
+		errs["CoverDays"] = "Cover must be between 1 and 180 days"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid reorder filter",
+			Errors:  errs,
+		}
+	}
+
+	// today has not finished selling, the history ends yesterday
+	today := time.Now().In(fu.location)
+	year, month, day := today.Date()
+	end := time.Date(year, month, day, 0, 0, 0, 0, fu.location)
+	start := time.Date(year, month, day-param.HistoryDays, 0, 0, 0, 0, fu.location)
+
+	products, err := fu.productRepository.GetAllProducts(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	dailySales, err := fu.productRepository.GetDailySales(ctx, start, end)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	onOrder, err := fu.purchaseOrderRepository.GetOnOrderQuantities(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	histories := map[int64][]float64{}
+	for _, sale := range dailySales {
+		date := time.Date(sale.Date.Year(), sale.Date.Month(), sale.Date.Day(), 0, 0, 0, 0, fu.location)
+		index := int(math.Round(date.Sub(start).Hours() / 24))
+		if index < 0 || index >= param.HistoryDays {
+			continue
+		}
+
+		if _, ok := histories[sale.ProductID]; !ok {
+			histories[sale.ProductID] = make([]float64, param.HistoryDays)
+		}
+		histories[sale.ProductID][index] += float64(sale.Quantity)
+	}
+
+	plan := &entity.ReorderPlan{
+		Method:       param.Method,
+		HistoryDays:  param.HistoryDays,
+		LeadTimeDays: param.LeadTimeDays,
+		CoverDays:    param.CoverDays,
+		Category:     param.Category,
+		Timezone:     fu.location.String(),
+		Products:     []*entity.ProductForecast{},
+	}
+	for _, product := range products {
+		if product.IsGiftCard || (param.Category != "" && product.Category != param.Category) {
+			continue
+		}
+
+		history, ok := histories[product.ID]
+		if !ok {
+			continue
+		}
+
+		days := param.LeadTimeDays + param.CoverDays
+		forecast := forecastSales(history, start.Weekday(), param.Method, today.Weekday(), days)
+		forecastProduct := &entity.ProductForecast{
+			ProductID: product.ID,
+			Code:      product.Code,
+			Name:      product.Name,
+			Category:  product.Category,
+			Stock:     product.Stock,
+			OnOrder:   onOrder[product.ID],
+			Cost:      product.Cost,
+		}
+		for i, quantity := range forecast {
+			if i < param.LeadTimeDays {
+				forecastProduct.LeadTimeDemand += quantity
+			}
+			forecastProduct.Demand += quantity
+		}
+
+		for _, quantity := range history {
+			forecastProduct.AverageDailySales += quantity
+		}
+		forecastProduct.AverageDailySales /= float64(len(history))
+		forecastProduct.ForecastDailySales = forecastProduct.Demand / float64(days)
+
+		available := forecastProduct.Stock
+		if available < 0 {
+			available = 0
+		}
+		forecastProduct.RunsOutBeforeDelivery = float64(available) < forecastProduct.LeadTimeDemand
+		available += forecastProduct.OnOrder
+
+		// a fraction of a unit still has to be bought whole
+		forecastProduct.SuggestedQuantity = int(math.Ceil(forecastProduct.Demand - float64(available) - 1e-9))
+		if forecastProduct.SuggestedQuantity <= 0 {
+			continue
+		}
+
+		plan.Cost += forecastProduct.SuggestedQuantity * forecastProduct.Cost
+		plan.Products = append(plan.Products, forecastProduct)
+	}
+
+	sort.SliceStable(plan.Products, func(i, j int) bool {
+		a, b := plan.Products[i], plan.Products[j]
+		if a.SuggestedQuantity != b.SuggestedQuantity {
+			return a.SuggestedQuantity > b.SuggestedQuantity
+		}
+
+		return a.Code < b.Code
+	})
+
+	return plan, nil
+}
+
+// forecastSales projects the daily sales of the given days from the first
+// weekday on. The history, which starts on firstWeekday, is taken out of
+// its weekday pattern, smoothed into the level of a plain day and the
+// pattern put back on the days ahead.
+func forecastSales(history []float64, firstWeekday time.Weekday, method string, fromWeekday time.Weekday, days int) []float64 {
+	seasonality := weekdaySeasonality(history, firstWeekday)
+
+	// a weekday that never sells, say when the store is closed, tells
+	// nothing about the level
+	deseasonalized := []float64{}
+	for i, quantity := range history {
+		index := seasonality[(int(firstWeekday)+i)%7]
+		if index > 0 {
+			deseasonalized = append(deseasonalized, quantity/index)
+		}
+	}
+
+	level := 0.0
+	if len(deseasonalized) > 0 {
+		switch method {
+		case entity.ForecastMethodMovingAverage:
+			window := deseasonalized
+			if len(window) > movingAverageDays {
+				window = window[len(window)-movingAverageDays:]
+			}
+
+			for _, quantity := range window {
+				level += quantity
+			}
+			level /= float64(len(window))
+		default:
+			// the first week starts the level so the first day does not
+			// weigh on it for the rest of the history
+			first := deseasonalized
+			if len(first) > 7 {
+				first = first[:7]
+			}
+
+			for _, quantity := range first {
+				level += quantity
+			}
+			level /= float64(len(first))
+
+			for _, quantity := range deseasonalized[len(first):] {
+				level = smoothingFactor*quantity + (1-smoothingFactor)*level
+			}
+		}
+	}
+
+	forecast := make([]float64, days)
+	for i := range forecast {
+		forecast[i] = level * seasonality[(int(fromWeekday)+i)%7]
+	}
+
+	return forecast
+}
+
+// weekdaySeasonality gives how each weekday sells against the average day
+// of the history, 1 for every weekday when nothing sold.
+func weekdaySeasonality(history []float64, firstWeekday time.Weekday) [7]float64 {
+	var sums, counts [7]float64
+	total := 0.0
+	for i, quantity := range history {
+		weekday := (int(firstWeekday) + i) % 7
+		sums[weekday] += quantity
+		counts[weekday]++
+		total += quantity
+	}
+
+	seasonality := [7]float64{1, 1, 1, 1, 1, 1, 1}
+	if total <= 0 {
+		return seasonality
+	}
+
+	mean := total / float64(len(history))
+	for weekday := range seasonality {
+		if counts[weekday] > 0 {
+			seasonality[weekday] = sums[weekday] / counts[weekday] / mean
+		}
+	}
+
+	return seasonality
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetReorderPlan_Failed_WhenParamIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	param := entity.ReorderParam{
+		Method:       "naive",
+		HistoryDays:  7,
+		LeadTimeDays: 120,
+	}
+
+	forecastUsecase := NewForecastUsecase(mockProductRepo, mockPurchaseOrderRepo, jakarta)
+	plan, err := forecastUsecase.GetReorderPlan(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Len(t, err.(entity.ErrValidation).Errors, 3)
+	assert.Nil(t, plan)
+	mockProductRepo.AssertNotCalled(t, "GetDailySales", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_GetReorderPlan_Failed_WhenGettingDailySales(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo.On("GetAllProducts", ctx).Return([]*entity.Product{}, nil)
+	mockProductRepo.On("GetDailySales", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("failed get daily sales"))
+
+	forecastUsecase := NewForecastUsecase(mockProductRepo, mockPurchaseOrderRepo, jakarta)
+	plan, err := forecastUsecase.GetReorderPlan(ctx, entity.ReorderParam{})
+	assert.NotNil(t, err)
+	assert.Nil(t, plan)
+	mockPurchaseOrderRepo.AssertNotCalled(t, "GetOnOrderQuantities", mock.Anything)
+}
+
+func Test_GetReorderPlan_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	products := []*entity.Product{
+		{ID: 1, Code: "P1", Category: "Food", Stock: 10, Cost: 1000},
+		{ID: 2, Code: "P2", Category: "Food", Stock: 100, Cost: 2000},
+		{ID: 3, Code: "P3", Category: "Food", Stock: 0, Cost: 500},
+		{ID: 4, Code: "GC", Stock: 0, IsGiftCard: true},
+	}
+
+	// P1 sells 2 and P2 sells 1 every day of the last 4 weeks
+	now := time.Now().In(jakarta)
+	year, month, day := now.Date()
+	dailySales := []*entity.DailyProductSales{}
+	for i := 1; i <= 28; i++ {
+		date := time.Date(year, month, day-i, 0, 0, 0, 0, time.UTC)
+		dailySales = append(dailySales,
+			&entity.DailyProductSales{ProductID: 1, Date: date, Quantity: 2},
+			&entity.DailyProductSales{ProductID: 2, Date: date, Quantity: 1},
+			&entity.DailyProductSales{ProductID: 4, Date: date, Quantity: 1})
+	}
+	mockProductRepo.On("GetAllProducts", ctx).Return(products, nil)
+	mockProductRepo.On("GetDailySales", ctx, mock.Anything, mock.Anything).Return(dailySales, nil)
+	mockPurchaseOrderRepo.On("GetOnOrderQuantities", ctx).Return(map[int64]int{1: 5}, nil)
+	param := entity.ReorderParam{
+		Method:       entity.ForecastMethodMovingAverage,
+		HistoryDays:  28,
+		LeadTimeDays: 7,
+		CoverDays:    14,
+	}
+
+	forecastUsecase := NewForecastUsecase(mockProductRepo, mockPurchaseOrderRepo, jakarta)
+	plan, err := forecastUsecase.GetReorderPlan(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, jakarta.String(), plan.Timezone)
+
+	// P2 has stock for the 21 days and P3 never sold
+	assert.Len(t, plan.Products, 1)
+
+	// 2 a day over 21 days less the 10 in stock and 5 on order
+	forecast := plan.Products[0]
+	assert.Equal(t, "P1", forecast.Code)
+	assert.InDelta(t, 2.0, forecast.AverageDailySales, 1e-9)
+	assert.InDelta(t, 2.0, forecast.ForecastDailySales, 1e-9)
+	assert.InDelta(t, 14.0, forecast.LeadTimeDemand, 1e-9)
+	assert.InDelta(t, 42.0, forecast.Demand, 1e-9)
+	assert.Equal(t, 5, forecast.OnOrder)
+	assert.Equal(t, 27, forecast.SuggestedQuantity)
+	assert.True(t, forecast.RunsOutBeforeDelivery)
+	assert.Equal(t, 27000, plan.Cost)
+}
+
+func Test_forecastSales_FollowsWeekdaySeasonality(t *testing.T) {
+	// four weeks starting on a Monday, Saturdays sell three times a weekday
+	// and the store is closed on Sundays
+	history := []float64{}
+	for week := 0; week < 4; week++ {
+		history = append(history, 2, 2, 2, 2, 2, 6, 0)
+	}
+
+	for _, method := range []string{entity.ForecastMethodMovingAverage, entity.ForecastMethodExponentialSmoothing} {
+		forecast := forecastSales(history, time.Monday, method, time.Friday, 3)
+		assert.InDelta(t, 2.0, forecast[0], 1e-9, method)
+		assert.InDelta(t, 6.0, forecast[1], 1e-9, method)
+		assert.InDelta(t, 0.0, forecast[2], 1e-9, method)
+	}
+}
+
+func Test_forecastSales_ExponentialSmoothingFollowsTheTrend(t *testing.T) {
+	history := make([]float64, 28)
+	for i := range history {
+		if i < 21 {
+			history[i] = 1
+		} else {
+			history[i] = 5
+		}
+	}
+
+	average := forecastSales(history, time.Monday, entity.ForecastMethodMovingAverage, time.Monday, 1)
+	smoothed := forecastSales(history, time.Monday, entity.ForecastMethodExponentialSmoothing, time.Monday, 1)
+	assert.InDelta(t, 2.0, average[0], 1e-9)
+	assert.Greater(t, smoothed[0], average[0])
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type HeldCartUsecase struct {
+	heldCartRepository internal.HeldCartRepository
+	expiry             time.Duration
+}
+
+func NewHeldCartUsecase(heldCartRepository internal.HeldCartRepository, expiry time.Duration) *HeldCartUsecase {
+	return &HeldCartUsecase{heldCartRepository, expiry}
+}
+
+func (hcu HeldCartUsecase) GetHeldCarts(ctx context.Context, register string) ([]*entity.HeldCart, error) {
+	heldCarts, err := hcu.heldCartRepository.GetHeldCartsByRegister(ctx, register, time.Now())
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return heldCarts, err
+}
+
+// HoldCart parks the cart until the configured expiry. Stock is only checked
+// and taken when the resumed cart is ordered.
+func (hcu HeldCartUsecase) HoldCart(ctx context.Context, param entity.HoldCartParam) (*entity.HeldCart, error) {
+	if len(param.Cart.Items) < 1 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid held cart",
+			Errors:  map[string]string{"order_items": "Cart has no items to hold"},
+		}
+	}
+
+	now := time.Now()
+	if err := hcu.heldCartRepository.DeleteExpired(ctx, now); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	createParam := entity.CreateHeldCartParam{
+		Register:  param.Register,
+		Label:     param.Label,
+		Cart:      param.Cart,
+		ExpiresAt: now.Add(hcu.expiry),
+	}
+	heldCart, err := hcu.heldCartRepository.Create(ctx, createParam)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return heldCart, err
+}
+
+// ResumeHeldCart takes the cart off hold and returns it, so a cart can only
+// be resumed by one register.
+func (hcu HeldCartUsecase) ResumeHeldCart(ctx context.Context, ID int64) (*entity.HeldCart, error) {
+	heldCart, err := hcu.heldCartRepository.GetHeldCartByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	isDeleted, err := hcu.heldCartRepository.DeleteByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if !isDeleted {
+		return nil, entity.ErrNotFound{Message: "Held cart has already been resumed"}
+	}
+
+	if heldCart.IsExpired(time.Now()) {
+		return nil, entity.ErrNotFound{Message: "Held cart has expired"}
+	}
+
+	return heldCart, nil
+}
+
+func (hcu HeldCartUsecase) DiscardHeldCart(ctx context.Context, ID int64) (bool, error) {
+	isDeleted, err := hcu.heldCartRepository.DeleteByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return isDeleted, err
+}
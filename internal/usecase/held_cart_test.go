@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var heldCartParam = entity.HoldCartParam{
+	Register: "Register 1",
+	Label:    "Blue shirt",
+	Cart: entity.CreateOrderParam{
+		Total: 10000,
+		Items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 2, Subtotal: 10000}},
+	},
+}
+
+func Test_HoldCart_Failed_WhenCartIsEmpty(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.HoldCartParam{Register: "Register 1", Label: "Empty"}
+	mockHeldCartRepo := new(mocks.HeldCartRepository)
+
+	heldCartUsecase := NewHeldCartUsecase(mockHeldCartRepo, time.Hour)
+	heldCart, err := heldCartUsecase.HoldCart(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, heldCart)
+	mockHeldCartRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func Test_HoldCart_Success(t *testing.T) {
+	ctx := context.TODO()
+	eHeldCart := &entity.HeldCart{ID: 1, Register: heldCartParam.Register, Label: heldCartParam.Label}
+	mockHeldCartRepo := new(mocks.HeldCartRepository)
+	mockHeldCartRepo.On("DeleteExpired", ctx, mock.AnythingOfType("time.Time")).Return(nil)
+	mockHeldCartRepo.On("Create", ctx, mock.MatchedBy(func(param entity.CreateHeldCartParam) bool {
+		expiresIn := time.Until(param.ExpiresAt)
+		return param.Label == heldCartParam.Label &&
+			len(param.Cart.Items) == 1 &&
+			expiresIn > 29*time.Minute && expiresIn <= 30*time.Minute
+	})).Return(eHeldCart, nil)
+
+	heldCartUsecase := NewHeldCartUsecase(mockHeldCartRepo, 30*time.Minute)
+	aHeldCart, err := heldCartUsecase.HoldCart(ctx, heldCartParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eHeldCart, aHeldCart)
+}
+
+func Test_ResumeHeldCart_Failed_WhenExpired(t *testing.T) {
+	ctx := context.TODO()
+	heldCart := &entity.HeldCart{ID: 1, ExpiresAt: time.Now().Add(-time.Minute)}
+	mockHeldCartRepo := new(mocks.HeldCartRepository)
+	mockHeldCartRepo.On("GetHeldCartByID", ctx, int64(1)).Return(heldCart, nil)
+	mockHeldCartRepo.On("DeleteByID", ctx, int64(1)).Return(true, nil)
+
+	heldCartUsecase := NewHeldCartUsecase(mockHeldCartRepo, time.Hour)
+	aHeldCart, err := heldCartUsecase.ResumeHeldCart(ctx, 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, aHeldCart)
+}
+
+func Test_ResumeHeldCart_Failed_WhenAlreadyResumed(t *testing.T) {
+	ctx := context.TODO()
+	heldCart := &entity.HeldCart{ID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	mockHeldCartRepo := new(mocks.HeldCartRepository)
+	mockHeldCartRepo.On("GetHeldCartByID", ctx, int64(1)).Return(heldCart, nil)
+	mockHeldCartRepo.On("DeleteByID", ctx, int64(1)).Return(false, nil)
+
+	heldCartUsecase := NewHeldCartUsecase(mockHeldCartRepo, time.Hour)
+	aHeldCart, err := heldCartUsecase.ResumeHeldCart(ctx, 1)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, aHeldCart)
+}
+
+func Test_ResumeHeldCart_Success(t *testing.T) {
+	ctx := context.TODO()
+	heldCart := &entity.HeldCart{ID: 1, Cart: heldCartParam.Cart, ExpiresAt: time.Now().Add(time.Hour)}
+	mockHeldCartRepo := new(mocks.HeldCartRepository)
+	mockHeldCartRepo.On("GetHeldCartByID", ctx, int64(1)).Return(heldCart, nil)
+	mockHeldCartRepo.On("DeleteByID", ctx, int64(1)).Return(true, nil)
+
+	heldCartUsecase := NewHeldCartUsecase(mockHeldCartRepo, time.Hour)
+	aHeldCart, err := heldCartUsecase.ResumeHeldCart(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, heldCart, aHeldCart)
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type LoyaltyUsecase struct {
+	loyaltyRepository internal.LoyaltyRepository
+}
+
+func NewLoyaltyUsecase(loyaltyRepository internal.LoyaltyRepository) *LoyaltyUsecase {
+	return &LoyaltyUsecase{loyaltyRepository}
+}
+
+func (lu LoyaltyUsecase) GetLoyaltySetting(ctx context.Context) (*entity.LoyaltySetting, error) {
+	setting, err := lu.loyaltyRepository.GetSetting(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return setting, err
+}
+
+func (lu LoyaltyUsecase) UpdateLoyaltySetting(ctx context.Context, param entity.UpdateLoyaltySettingParam) error {
+	err := lu.loyaltyRepository.UpdateSetting(ctx, param)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+func (lu LoyaltyUsecase) GetAllMultipliers(ctx context.Context) ([]*entity.LoyaltyMultiplier, error) {
+	multipliers, err := lu.loyaltyRepository.GetAllMultipliers(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return multipliers, err
+}
+
+func (lu LoyaltyUsecase) SaveMultiplier(ctx context.Context, param entity.SaveLoyaltyMultiplierParam) error {
+	err := lu.loyaltyRepository.SaveMultiplier(ctx, param)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+func (lu LoyaltyUsecase) DeleteMultiplier(ctx context.Context, ID int64) (bool, error) {
+	isDeleted, err := lu.loyaltyRepository.DeleteMultiplierByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return isDeleted, err
+}
+
+func (lu LoyaltyUsecase) GetCustomerBalance(ctx context.Context, customerID int64) (int, error) {
+	balance, err := lu.loyaltyRepository.GetBalanceByCustomerID(ctx, customerID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return balance, err
+}
+
+func (lu LoyaltyUsecase) GetCustomerEntries(ctx context.Context, customerID int64) ([]*entity.LoyaltyEntry, error) {
+	entries, err := lu.loyaltyRepository.GetEntriesByCustomerID(ctx, customerID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return entries, err
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetLoyaltySetting_Success(t *testing.T) {
+	ctx := context.TODO()
+	eSetting := &entity.LoyaltySetting{SpendPerPoint: 10000, PointValue: 100}
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("GetSetting", ctx).Return(eSetting, nil)
+
+	loyaltyUsecase := NewLoyaltyUsecase(mockLoyaltyRepo)
+	aSetting, err := loyaltyUsecase.GetLoyaltySetting(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, eSetting, aSetting)
+}
+
+func Test_SaveMultiplier_Failed(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.SaveLoyaltyMultiplierParam{Category: "drink", Multiplier: 2}
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("SaveMultiplier", ctx, param).Return(errors.New("failed save multiplier"))
+
+	loyaltyUsecase := NewLoyaltyUsecase(mockLoyaltyRepo)
+	err := loyaltyUsecase.SaveMultiplier(ctx, param)
+	assert.NotNil(t, err)
+}
+
+func Test_GetCustomerBalance_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("GetBalanceByCustomerID", ctx, int64(7)).Return(120, nil)
+
+	loyaltyUsecase := NewLoyaltyUsecase(mockLoyaltyRepo)
+	balance, err := loyaltyUsecase.GetCustomerBalance(ctx, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, 120, balance)
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+const (
+	defaultMarketBasketDays      = 90
+	defaultMarketBasketMinOrders = 2
+	marketBasketAssociationLimit = 100
+	maxSuggestionCartProducts    = 100
+	maxSuggestions               = 5
+)
+
+type MarketBasketUsecase struct {
+	marketBasketRepository internal.MarketBasketRepository
+	UnitOfWork             internal.UnitOfWork
+	location               *time.Location
+}
+
+func NewMarketBasketUsecase(
+	marketBasketRepository internal.MarketBasketRepository,
+	UnitOfWork internal.UnitOfWork,
+	location *time.Location) *MarketBasketUsecase {
+	return &MarketBasketUsecase{marketBasketRepository, UnitOfWork, location}
+}
+
+// RefreshMarketBasket counts which products are bought together over the
+// period and replaces the rules of the previous run with the new ones.
+func (mbu MarketBasketUsecase) RefreshMarketBasket(ctx context.Context, param entity.MarketBasketParam) (*entity.MarketBasketRun, error) {
+	if param.StartDate == "" && param.EndDate == "" {
+		today := time.Now().In(mbu.location)
+		param.StartDate = today.AddDate(0, 0, 1-defaultMarketBasketDays).Format("2006-01-02")
+		param.EndDate = today.Format("2006-01-02")
+	}
+
+	errs := map[string]string{}
+	start, end := reportRange(param.StartDate, param.EndDate, mbu.location, errs)
+	if param.MinOrders == 0 {
+		param.MinOrders = defaultMarketBasketMinOrders
+	}
+
+	if param.MinOrders < 1 {
+		errs["MinOrders"] = "Minimum orders must be at least 1"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid market basket period",
+			Errors:  errs,
+		}
+	}
+
+	orders, err := mbu.marketBasketRepository.CountOrders(ctx, start, end)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	productOrders, err := mbu.marketBasketRepository.GetProductOrderCounts(ctx, start, end)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	pairs, err := mbu.marketBasketRepository.GetPairOrderCounts(ctx, start, end, param.MinOrders)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	associations := associateProducts(pairs, productOrders, orders)
+	txContext, err := mbu.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if err := mbu.marketBasketRepository.ReplaceAssociations(txContext, associations); err != nil {
+		mbu.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	createRunParam := entity.CreateMarketBasketRunParam{
+		StartDate:    param.StartDate,
+		EndDate:      param.EndDate,
+		MinOrders:    param.MinOrders,
+		Orders:       orders,
+		Associations: len(associations),
+	}
+	run, err := mbu.marketBasketRepository.CreateRun(txContext, createRunParam)
+	if err != nil {
+		mbu.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if err := mbu.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (mbu MarketBasketUsecase) GetMarketBasket(ctx context.Context) (*entity.MarketBasket, error) {
+	run, err := mbu.marketBasketRepository.GetLatestRun(ctx)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return &entity.MarketBasket{Associations: []*entity.ProductAssociation{}}, nil
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	associations, err := mbu.marketBasketRepository.GetAssociations(ctx, marketBasketAssociationLimit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &entity.MarketBasket{Run: run, Associations: associations}, nil
+}
+
+// GetSuggestions offers the products most often bought with the products of
+// the cart, each product once, for the cart product it goes best with.
+func (mbu MarketBasketUsecase) GetSuggestions(ctx context.Context, productIDs []int64) ([]*entity.ProductSuggestion, error) {
+	if len(productIDs) > maxSuggestionCartProducts {
+		return nil, entity.ErrValidation{
+			Message: "Invalid cart",
+			Errors:  map[string]string{"ProductIDs": "Cart has too many products to suggest for"},
+		}
+	}
+
+	candidates, err := mbu.marketBasketRepository.GetSuggestionsByProductIDs(ctx, productIDs)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	suggested := map[int64]bool{}
+	suggestions := []*entity.ProductSuggestion{}
+	for _, candidate := range candidates {
+		if suggested[candidate.ID] {
+			continue
+		}
+
+		suggested[candidate.ID] = true
+		suggestions = append(suggestions, candidate)
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+	}
+
+	return suggestions, nil
+}
+
+// associateProducts turns each pair into a rule both ways, support is the
+// same both ways but confidence is counted against the orders of the first
+// product and lift against the share of orders of the second.
+func associateProducts(pairs []*entity.ProductPairCount, productOrders map[int64]int, orders int) []*entity.ProductAssociation {
+	associations := []*entity.ProductAssociation{}
+	if orders < 1 {
+		return associations
+	}
+
+	associate := func(productID int64, associatedProductID int64, pairOrders int) {
+		if productOrders[productID] < 1 || productOrders[associatedProductID] < 1 {
+			return
+		}
+
+		confidence := float64(pairOrders) / float64(productOrders[productID])
+		associations = append(associations, &entity.ProductAssociation{
+			ProductID:           productID,
+			AssociatedProductID: associatedProductID,
+			Orders:              pairOrders,
+			Support:             float64(pairOrders) / float64(orders),
+			Confidence:          confidence,
+			Lift:                confidence / (float64(productOrders[associatedProductID]) / float64(orders)),
+		})
+	}
+
+	for _, pair := range pairs {
+		associate(pair.ProductID, pair.AssociatedProductID, pair.Orders)
+		associate(pair.AssociatedProductID, pair.ProductID, pair.Orders)
+	}
+
+	return associations
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_RefreshMarketBasket_Failed_WhenParamIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockMarketBasketRepo := new(mocks.MarketBasketRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	param := entity.MarketBasketParam{
+		StartDate: "2026-03-10",
+		EndDate:   "2026-03-01",
+		MinOrders: -1,
+	}
+
+	marketBasketUsecase := NewMarketBasketUsecase(mockMarketBasketRepo, mockUnitOfWork, jakarta)
+	run, err := marketBasketUsecase.RefreshMarketBasket(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Len(t, err.(entity.ErrValidation).Errors, 2)
+	assert.Nil(t, run)
+	mockMarketBasketRepo.AssertNotCalled(t, "CountOrders", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_RefreshMarketBasket_Failed_WhenReplacingAssociations(t *testing.T) {
+	ctx := context.TODO()
+	mockMarketBasketRepo := new(mocks.MarketBasketRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockMarketBasketRepo.On("CountOrders", ctx, mock.Anything, mock.Anything).Return(0, nil)
+	mockMarketBasketRepo.On("GetProductOrderCounts", ctx, mock.Anything, mock.Anything).Return(map[int64]int{}, nil)
+	mockMarketBasketRepo.On("GetPairOrderCounts", ctx, mock.Anything, mock.Anything, 2).Return([]*entity.ProductPairCount{}, nil)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockMarketBasketRepo.On("ReplaceAssociations", ctx, []*entity.ProductAssociation{}).Return(errors.New("failed replace associations"))
+
+	marketBasketUsecase := NewMarketBasketUsecase(mockMarketBasketRepo, mockUnitOfWork, jakarta)
+	run, err := marketBasketUsecase.RefreshMarketBasket(ctx, entity.MarketBasketParam{})
+	assert.NotNil(t, err)
+	assert.Nil(t, run)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", ctx)
+	mockMarketBasketRepo.AssertNotCalled(t, "CreateRun", mock.Anything, mock.Anything)
+}
+
+func Test_RefreshMarketBasket_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockMarketBasketRepo := new(mocks.MarketBasketRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, jakarta)
+	end := time.Date(2026, 3, 11, 0, 0, 0, 0, jakarta)
+	productOrders := map[int64]int{1: 40, 2: 20, 3: 50}
+	pairs := []*entity.ProductPairCount{{ProductID: 1, AssociatedProductID: 2, Orders: 10}}
+	mockMarketBasketRepo.On("CountOrders", ctx, start, end).Return(100, nil)
+	mockMarketBasketRepo.On("GetProductOrderCounts", ctx, start, end).Return(productOrders, nil)
+	mockMarketBasketRepo.On("GetPairOrderCounts", ctx, start, end, 5).Return(pairs, nil)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockMarketBasketRepo.On("ReplaceAssociations", ctx, mock.Anything).Return(nil)
+	createRunParam := entity.CreateMarketBasketRunParam{
+		StartDate:    "2026-03-01",
+		EndDate:      "2026-03-10",
+		MinOrders:    5,
+		Orders:       100,
+		Associations: 2,
+	}
+	mockMarketBasketRepo.On("CreateRun", ctx, createRunParam).Return(&entity.MarketBasketRun{ID: 1, Orders: 100, Associations: 2}, nil)
+	param := entity.MarketBasketParam{
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-10",
+		MinOrders: 5,
+	}
+
+	marketBasketUsecase := NewMarketBasketUsecase(mockMarketBasketRepo, mockUnitOfWork, jakarta)
+	run, err := marketBasketUsecase.RefreshMarketBasket(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), run.ID)
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
+
+	associations := mockMarketBasketRepo.Calls[3].Arguments.Get(1).([]*entity.ProductAssociation)
+	assert.Len(t, associations, 2)
+
+	// 1 with 2: in 10 of the 40 orders of 1, and 2 is in 20% of all orders
+	assert.Equal(t, int64(1), associations[0].ProductID)
+	assert.Equal(t, int64(2), associations[0].AssociatedProductID)
+	assert.InDelta(t, 0.1, associations[0].Support, 1e-9)
+	assert.InDelta(t, 0.25, associations[0].Confidence, 1e-9)
+	assert.InDelta(t, 1.25, associations[0].Lift, 1e-9)
+
+	// 2 with 1: in 10 of the 20 orders of 2, and 1 is in 40% of all orders
+	assert.Equal(t, int64(2), associations[1].ProductID)
+	assert.InDelta(t, 0.1, associations[1].Support, 1e-9)
+	assert.InDelta(t, 0.5, associations[1].Confidence, 1e-9)
+	assert.InDelta(t, 1.25, associations[1].Lift, 1e-9)
+}
+
+func Test_GetMarketBasket_Success_WhenNotRunYet(t *testing.T) {
+	ctx := context.TODO()
+	mockMarketBasketRepo := new(mocks.MarketBasketRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockMarketBasketRepo.On("GetLatestRun", ctx).Return(nil, entity.ErrNotFound{Message: "Market basket analysis has not run yet"})
+
+	marketBasketUsecase := NewMarketBasketUsecase(mockMarketBasketRepo, mockUnitOfWork, jakarta)
+	basket, err := marketBasketUsecase.GetMarketBasket(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, basket.Run)
+	assert.Empty(t, basket.Associations)
+	mockMarketBasketRepo.AssertNotCalled(t, "GetAssociations", mock.Anything, mock.Anything)
+}
+
+func Test_GetSuggestions_Failed_WhenCartIsTooBig(t *testing.T) {
+	ctx := context.TODO()
+	mockMarketBasketRepo := new(mocks.MarketBasketRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	productIDs := make([]int64, 101)
+
+	marketBasketUsecase := NewMarketBasketUsecase(mockMarketBasketRepo, mockUnitOfWork, jakarta)
+	suggestions, err := marketBasketUsecase.GetSuggestions(ctx, productIDs)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, suggestions)
+}
+
+func Test_GetSuggestions_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockMarketBasketRepo := new(mocks.MarketBasketRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	candidates := []*entity.ProductSuggestion{
+		{ID: 3, BecauseOfProductID: 1, Lift: 4},
+		{ID: 4, BecauseOfProductID: 1, Lift: 3},
+		{ID: 3, BecauseOfProductID: 2, Lift: 2.5},
+		{ID: 5, BecauseOfProductID: 2, Lift: 2},
+		{ID: 6, BecauseOfProductID: 2, Lift: 1.8},
+		{ID: 7, BecauseOfProductID: 1, Lift: 1.5},
+		{ID: 8, BecauseOfProductID: 1, Lift: 1.2},
+	}
+	mockMarketBasketRepo.On("GetSuggestionsByProductIDs", ctx, []int64{1, 2}).Return(candidates, nil)
+
+	marketBasketUsecase := NewMarketBasketUsecase(mockMarketBasketRepo, mockUnitOfWork, jakarta)
+	suggestions, err := marketBasketUsecase.GetSuggestions(ctx, []int64{1, 2})
+	assert.Nil(t, err)
+	assert.Len(t, suggestions, 5)
+	assert.Equal(t, int64(3), suggestions[0].ID)
+	assert.Equal(t, int64(1), suggestions[0].BecauseOfProductID)
+	assert.Equal(t, int64(7), suggestions[4].ID)
+}
@@ -2,82 +2,176 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal"
 	"github.com/ardafirdausr/kaseer/internal/entity"
 )
 
+const prepaidCardNumberLength = 16
+
+const (
+	defaultOrderPageLimit = 20
+	maxOrderPageLimit     = 100
+)
+
 type OrderUsecase struct {
-	orderRepository   internal.OrderRepository
-	productRepository internal.ProductRepository
-	UnitOfWork        internal.UnitOfWork
+	orderRepository        internal.OrderRepository
+	productRepository      internal.ProductRepository
+	voucherRepository      internal.VoucherRepository
+	customerRepository     internal.CustomerRepository
+	loyaltyRepository      internal.LoyaltyRepository
+	prepaidCardRepository  internal.PrepaidCardRepository
+	priceListRepository    internal.PriceListRepository
+	storeSettingRepository internal.StoreSettingRepository
+	webhookRepository      internal.WebhookRepository
+	auditLogRepository     internal.AuditLogRepository
+	eventPublisher         internal.EventPublisher
+	UnitOfWork             internal.UnitOfWork
+	location               *time.Location
 }
 
 func NewOrderUsecase(
 	orderRepository internal.OrderRepository,
 	productRepository internal.ProductRepository,
-	UnitOfWork internal.UnitOfWork) *OrderUsecase {
-	return &OrderUsecase{orderRepository, productRepository, UnitOfWork}
+	voucherRepository internal.VoucherRepository,
+	customerRepository internal.CustomerRepository,
+	loyaltyRepository internal.LoyaltyRepository,
+	prepaidCardRepository internal.PrepaidCardRepository,
+	priceListRepository internal.PriceListRepository,
+	storeSettingRepository internal.StoreSettingRepository,
+	webhookRepository internal.WebhookRepository,
+	auditLogRepository internal.AuditLogRepository,
+	eventPublisher internal.EventPublisher,
+	UnitOfWork internal.UnitOfWork,
+	location *time.Location) *OrderUsecase {
+	return &OrderUsecase{
+		orderRepository,
+		productRepository,
+		voucherRepository,
+		customerRepository,
+		loyaltyRepository,
+		prepaidCardRepository,
+		priceListRepository,
+		storeSettingRepository,
+		webhookRepository,
+		auditLogRepository,
+		eventPublisher,
+		UnitOfWork,
+		location,
+	}
 }
 
-func (ou OrderUsecase) GetAllOrders(ctx context.Context) ([]*entity.Order, error) {
-	orders, err := ou.orderRepository.GetAllOrders(ctx)
-	if err != nil {
-		log.Println(err.Error())
+func (ou OrderUsecase) GetOrders(ctx context.Context, query entity.OrderQuery) (*entity.OrderPage, error) {
+	errs := map[string]string{}
+	if query.StartDate != nil && query.EndDate != nil && !query.EndDate.After(*query.StartDate) {
+		errs["EndDate"] = "End date must be after the start date"
 	}
 
-	return orders, err
-}
+	if query.MinTotal != nil && query.MaxTotal != nil && *query.MaxTotal < *query.MinTotal {
+		errs["MaxTotal"] = "Maximum total must not be less than the minimum total"
+	}
 
-func (ou OrderUsecase) GetOrderItems(ctx context.Context, orderID int64) ([]*entity.OrderItem, error) {
-	orderItems, err := ou.orderRepository.GetOrderItemsByID(ctx, orderID)
+	switch query.Status {
+	case "", entity.OrderStatusCompleted, entity.OrderStatusVoided, entity.OrderStatusRefunded:
+	default:
+		errs["Status"] = "Unknown order status"
+	}
+
+	if query.Offset < 0 {
+		errs["Offset"] = "Offset must not be negative"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid order filter",
+			Errors:  errs,
+		}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultOrderPageLimit
+	}
+
+	if query.Limit > maxOrderPageLimit {
+		query.Limit = maxOrderPageLimit
+	}
+
+	// one extra row tells whether there is a next page without counting
+	fetchQuery := query
+	fetchQuery.Limit = query.Limit + 1
+	orders, err := ou.orderRepository.GetOrders(ctx, fetchQuery)
 	if err != nil {
 		log.Println(err.Error())
+		return nil, err
 	}
 
-	return orderItems, err
+	page := &entity.OrderPage{Offset: query.Offset, Limit: query.Limit}
+	if len(orders) > query.Limit {
+		orders = orders[:query.Limit]
+		page.HasMore = true
+		page.NextCursor = orders[len(orders)-1].ID
+	}
+	page.Orders = orders
+
+	if query.Cursor == 0 {
+		total, err := ou.orderRepository.CountOrders(ctx, query)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		page.Total = &total
+	}
+
+	return page, nil
 }
 
-func (ou OrderUsecase) GetAnnualIncome(ctx context.Context) ([]*entity.AnnualIncome, error) {
-	annualIncomes, err := ou.orderRepository.GetAnnualIncome(ctx)
+func (ou OrderUsecase) GetOrderByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	order, err := ou.orderRepository.GetOrderByID(ctx, ID)
 	if err != nil {
 		log.Println(err.Error())
 	}
 
-	return annualIncomes, err
+	return order, err
 }
 
-func (ou OrderUsecase) GetDailyOrderCount(ctx context.Context) (int, error) {
-	res, err := ou.orderRepository.GetDailyOrderCount(ctx)
+func (ou OrderUsecase) GetOrderByInvoiceNumber(ctx context.Context, invoiceNumber string) (*entity.Order, error) {
+	order, err := ou.orderRepository.GetOrderByInvoiceNumber(ctx, invoiceNumber)
 	if err != nil {
 		log.Println(err.Error())
 	}
 
-	return res, err
+	return order, err
 }
 
-func (ou OrderUsecase) GetTotalOrderCount(ctx context.Context) (int, error) {
-	res, err := ou.orderRepository.GetTotalOrderCount(ctx)
+func (ou OrderUsecase) GetOrderPayments(ctx context.Context, orderID int64) ([]*entity.OrderPayment, error) {
+	payments, err := ou.orderRepository.GetPaymentsByOrderID(ctx, orderID)
 	if err != nil {
 		log.Println(err.Error())
 	}
 
-	return res, err
+	return payments, err
 }
 
-func (ou OrderUsecase) GetLastDayIncome(ctx context.Context) (int, error) {
-	res, err := ou.orderRepository.GetLastDayIncome(ctx)
+func (ou OrderUsecase) GetOrderItems(ctx context.Context, orderID int64) ([]*entity.OrderItem, error) {
+	orderItems, err := ou.orderRepository.GetOrderItemsByID(ctx, orderID)
 	if err != nil {
 		log.Println(err.Error())
 	}
 
-	return res, err
+	return orderItems, err
 }
 
-func (ou OrderUsecase) GetLastMonthIncome(ctx context.Context) (int, error) {
-	res, err := ou.orderRepository.GetLastMonthIncome(ctx)
+func (ou OrderUsecase) GetTotalOrderCount(ctx context.Context) (int, error) {
+	res, err := ou.orderRepository.GetTotalOrderCount(ctx)
 	if err != nil {
 		log.Println(err.Error())
 	}
@@ -86,15 +180,24 @@ func (ou OrderUsecase) GetLastMonthIncome(ctx context.Context) (int, error) {
 }
 
 func (ou OrderUsecase) Create(ctx context.Context, param entity.CreateOrderParam) (*entity.Order, error) {
-	// check available quantity
-	orderQuantity := make(map[int64]int)
+	if param.IdempotencyKey != "" {
+		param.RequestHash = orderRequestHash(param)
+		order, err := ou.replayOrder(ctx, param)
+		if order != nil || err != nil {
+			return order, err
+		}
+	}
+
+	// check available quantity early, the stock is only taken under a row
+	// lock inside the transaction
 	productSale := make(map[int64]int)
 	productIDs := make([]int64, 0)
 
 	for _, item := range param.Items {
-		orderQuantity[item.ProductID] = item.Quantity
-		productSale[item.ProductID] = item.Quantity
-		productIDs = append(productIDs, item.ProductID)
+		if _, ok := productSale[item.ProductID]; !ok {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		productSale[item.ProductID] += item.Quantity
 	}
 
 	products, err := ou.productRepository.GetProductsByIDs(ctx, productIDs...)
@@ -102,32 +205,173 @@ func (ou OrderUsecase) Create(ctx context.Context, param entity.CreateOrderParam
 		return nil, err
 	}
 
+	param, err = priceItems(products, param)
+	if err != nil {
+		return nil, err
+	}
+
 	ev := entity.ErrValidation{
 		Message: "Insufficient product quantity",
 		Errors:  map[string]string{},
 	}
+	isBlocked := false
 	for _, product := range products {
-		if product.Stock-orderQuantity[product.ID] < 0 {
-			ev.Errors[product.Name] = fmt.Sprintf("%s remaining quantity: %d", product.Name, product.Stock)
+		if product.Stock-productSale[product.ID] >= 0 {
+			continue
 		}
+
+		policy, err := ou.stockPolicy(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+
+		if canSellBeyondStock(policy, param) {
+			continue
+		}
+
+		isBlocked = isBlocked || policy != entity.StockPolicyWarn
+		ev.Errors[product.Name] = fmt.Sprintf("%s remaining quantity: %d", product.Name, product.Stock)
 	}
 
 	if len(ev.Errors) > 0 {
+		// the cashier may only confirm the sale when every product short on
+		// stock is under the warn policy
+		if !isBlocked {
+			ev.Errors["allow_negative_stock"] = "Confirm selling beyond the remaining quantity"
+		}
 		return nil, ev
 	}
 
+	if param.CustomerID != nil {
+		customer, err := ou.customerRepository.GetCustomerByID(ctx, *param.CustomerID)
+		if _, ok := err.(entity.ErrNotFound); ok {
+			return nil, entity.ErrValidation{
+				Message: "Invalid customer",
+				Errors:  map[string]string{"customer_id": "Customer not found"},
+			}
+		}
+
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		if customer.CustomerGroupID != nil {
+			param, err = ou.applyPriceLists(ctx, *customer.CustomerGroupID, productIDs, param)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if param.RedeemPoints > 0 && param.CustomerID == nil {
+		return nil, entity.ErrValidation{
+			Message: "Invalid points redemption",
+			Errors:  map[string]string{"redeem_points": "Points can only be redeemed by a registered customer"},
+		}
+	}
+
 	txContext, err := ou.UnitOfWork.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
 		return nil, err
 	}
 
+	negativeStockSales, err := ou.takeStock(txContext, productSale, products, param)
+	if err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	var voucher *entity.Voucher
+	if param.VoucherCode != "" {
+		voucher, err = ou.lockVoucher(txContext, param)
+		if err != nil {
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+
+		param.Discount = voucher.Discount(param.Total)
+		param.Total -= param.Discount
+	}
+
+	var loyaltySetting *entity.LoyaltySetting
+	pointsAmount := 0
+	if param.CustomerID != nil {
+		loyaltySetting, err = ou.loyaltyRepository.GetSetting(txContext)
+		if err != nil {
+			log.Println(err.Error())
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+
+		if param.RedeemPoints > 0 {
+			pointsAmount, err = ou.lockPoints(txContext, loyaltySetting, param)
+			if err != nil {
+				ou.UnitOfWork.Rollback(txContext)
+				return nil, err
+			}
+		}
+	}
+
+	prepaidTenders, err := ou.lockPrepaidCards(txContext, param, param.Total-pointsAmount)
+	if err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	prepaidAmount := 0
+	for _, tender := range prepaidTenders {
+		prepaidAmount += tender.amount
+	}
+
+	storeSetting, err := ou.storeSettingRepository.GetSetting(txContext)
+	if err != nil {
+		log.Println(err.Error())
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	param.InvoiceNumber, err = ou.allocateInvoiceNumber(txContext, storeSetting)
+	if err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
 	order, err := ou.orderRepository.Create(txContext, param)
+	if eae, ok := err.(entity.ErrItemAlreadyExists); ok {
+		// a concurrent request with the same idempotency key won the race
+		ou.UnitOfWork.Rollback(txContext)
+		if order, err := ou.replayOrder(ctx, param); order != nil || err != nil {
+			return order, err
+		}
+
+		return nil, eae
+	}
+
 	if err != nil {
 		log.Println(err.Error())
 		ou.UnitOfWork.Rollback(txContext)
 		return nil, err
 	}
+	order.CashierName = param.CashierName
+
+	if voucher != nil {
+		if err := ou.redeemVoucher(txContext, voucher, order, param.CustomerID); err != nil {
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+	}
+
+	if err := ou.redeemPrepaidCards(txContext, order, prepaidTenders); err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if err := ou.createPayments(txContext, order, pointsAmount, prepaidTenders); err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
 
 	if err := ou.orderRepository.CreateOrderItems(txContext, order.ID, param.Items); err != nil {
 		log.Println(err.Error())
@@ -135,8 +379,37 @@ func (ou OrderUsecase) Create(ctx context.Context, param entity.CreateOrderParam
 		return nil, err
 	}
 
-	if err := ou.productRepository.DecrementProductByIDs(txContext, productSale); err != nil {
-		log.Println(err.Error())
+	if len(negativeStockSales) > 0 {
+		if err := ou.productRepository.CreateNegativeStockSales(txContext, order.ID, negativeStockSales); err != nil {
+			log.Println(err.Error())
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+	}
+
+	order.PrepaidCards, err = ou.issueGiftCards(txContext, order, products, param)
+	if err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if param.CustomerID != nil {
+		err := ou.recordPoints(txContext, order, loyaltySetting, products, param, order.Total-pointsAmount-prepaidAmount)
+		if err != nil {
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+	}
+
+	createdOrder := *order
+	createdOrder.Items = orderEventItems(order, products, param.Items)
+	eventData := entity.OrderEventData{Order: &createdOrder}
+	if err := ou.recordWebhookEvent(txContext, entity.WebhookEventOrderCreated, eventData); err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if err := ou.recordStockLowEvents(txContext, storeSetting.LowStockThreshold, productSale, products); err != nil {
 		ou.UnitOfWork.Rollback(txContext)
 		return nil, err
 	}
@@ -146,5 +419,1068 @@ func (ou OrderUsecase) Create(ctx context.Context, param entity.CreateOrderParam
 		return nil, err
 	}
 
+	ou.eventPublisher.Publish(ctx, entity.Event{
+		Name: entity.EventOrderCreated,
+		Data: entity.OrderCreatedEvent{Order: &createdOrder},
+	})
+	stockChanges := make(map[int64]int, len(productSale))
+	for productID, quantity := range productSale {
+		stockChanges[productID] = -quantity
+	}
+	publishStockChanges(ctx, ou.eventPublisher, stockChanges, entity.StockChangeSale, &order.ID)
 	return order, nil
 }
+
+// recordWebhookEvent records an event in the outbox of the webhooks, inside
+// the transaction of the change so the event is only sent when it commits.
+func (ou OrderUsecase) recordWebhookEvent(txContext context.Context, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	param := entity.CreateWebhookEventParam{Type: eventType, Payload: payload}
+	if err := ou.webhookRepository.CreateEvent(txContext, param); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// recordStockLowEvents tells about the products whose stock fell to the
+// threshold or below with this sale, a product already low does not tell
+// again until it is restocked above the threshold.
+func (ou OrderUsecase) recordStockLowEvents(
+	txContext context.Context,
+	threshold int,
+	productSale map[int64]int,
+	products []*entity.Product,
+) error {
+	if threshold < 1 {
+		return nil
+	}
+
+	for _, product := range products {
+		sold := productSale[product.ID]
+		if product.Stock-sold > threshold {
+			continue
+		}
+
+		// the stock read before the transaction may be stale, the product
+		// row is locked by now so this is the stock left by this sale
+		current, err := ou.productRepository.GetProductByID(txContext, product.ID)
+		if err != nil {
+			log.Println(err.Error())
+			return err
+		}
+
+		if current.Stock > threshold || current.Stock+sold <= threshold {
+			continue
+		}
+
+		eventData := entity.StockLowEventData{Product: current, Threshold: threshold}
+		if err := ou.recordWebhookEvent(txContext, entity.WebhookEventProductStockLow, eventData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orderEventItems describes the items of a new order the way they are read
+// back from the order.
+func orderEventItems(order *entity.Order, products []*entity.Product, params []*entity.CreateOrderItemParam) []*entity.OrderItem {
+	productMap := make(map[int64]*entity.Product, len(products))
+	for _, product := range products {
+		productMap[product.ID] = product
+	}
+
+	items := []*entity.OrderItem{}
+	for _, param := range params {
+		item := &entity.OrderItem{
+			OrderID:   order.ID,
+			ProductID: param.ProductID,
+			Quantity:  param.Quantity,
+			Subtotal:  param.Subtotal,
+			CreatedAt: order.CreatedAt,
+		}
+		if product, ok := productMap[param.ProductID]; ok {
+			item.ProductCode = product.Code
+			item.ProductName = product.Name
+			item.ProductPrice = product.Price
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// priceItems prices the items at the prices of their products and totals the
+// order, the price lists of the customer may lower them afterwards.
+func priceItems(products []*entity.Product, param entity.CreateOrderParam) (entity.CreateOrderParam, error) {
+	productMap := make(map[int64]*entity.Product, len(products))
+	for _, product := range products {
+		productMap[product.ID] = product
+	}
+
+	errs := map[string]string{}
+	items := make([]*entity.CreateOrderItemParam, 0, len(param.Items))
+	total := 0
+	for i, item := range param.Items {
+		product, ok := productMap[item.ProductID]
+		if !ok {
+			errs[fmt.Sprintf("order_items[%d].product_id", i)] = "Product not found"
+			continue
+		}
+
+		if item.Quantity < 1 {
+			errs[fmt.Sprintf("order_items[%d].quantity", i)] = "Quantity must be at least 1"
+			continue
+		}
+
+		pricedItem := *item
+		pricedItem.Subtotal = product.Price * item.Quantity
+		total += pricedItem.Subtotal
+		items = append(items, &pricedItem)
+	}
+
+	if len(items) < 1 && len(errs) < 1 {
+		errs["order_items"] = "Order must have at least one item"
+	}
+
+	if len(errs) > 0 {
+		return param, entity.ErrValidation{
+			Message: "Invalid order items",
+			Errors:  errs,
+		}
+	}
+
+	param.Items = items
+	param.Total = total
+	return param, nil
+}
+
+// applyPriceLists reprices the items having a price in the price lists of the
+// customer group, taking the lowest price among the quantity breaks reached
+// by the item quantity, and recalculates the order total.
+func (ou OrderUsecase) applyPriceLists(
+	ctx context.Context,
+	customerGroupID int64,
+	productIDs []int64,
+	param entity.CreateOrderParam) (entity.CreateOrderParam, error) {
+	priceListItems, err := ou.priceListRepository.GetActiveItemsByGroupID(ctx, customerGroupID, time.Now().In(ou.location), productIDs...)
+	if err != nil {
+		log.Println(err.Error())
+		return param, err
+	}
+
+	if len(priceListItems) < 1 {
+		return param, nil
+	}
+
+	items := make([]*entity.CreateOrderItemParam, 0, len(param.Items))
+	total := 0
+	for _, item := range param.Items {
+		pricedItem := *item
+		listPrice := 0
+		for _, priceListItem := range priceListItems {
+			if priceListItem.ProductID != item.ProductID || priceListItem.MinQuantity > item.Quantity {
+				continue
+			}
+
+			if listPrice == 0 || priceListItem.Price < listPrice {
+				listPrice = priceListItem.Price
+			}
+		}
+
+		if listPrice > 0 {
+			pricedItem.Subtotal = listPrice * item.Quantity
+		}
+
+		total += pricedItem.Subtotal
+		items = append(items, &pricedItem)
+	}
+
+	param.Items = items
+	param.Total = total
+	return param, nil
+}
+
+// lockVoucher fetches the voucher with a row lock held for the rest of the
+// order transaction and checks whether it can be applied to the order.
+func (ou OrderUsecase) lockVoucher(txContext context.Context, param entity.CreateOrderParam) (*entity.Voucher, error) {
+	invalidVoucher := func(message string) error {
+		return entity.ErrValidation{
+			Message: "Invalid voucher",
+			Errors:  map[string]string{"voucher_code": message},
+		}
+	}
+
+	voucher, err := ou.voucherRepository.GetVoucherByCodeForUpdate(txContext, param.VoucherCode)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, invalidVoucher(fmt.Sprintf("Voucher %s does not exist", param.VoucherCode))
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.Before(voucher.StartsAt) || !now.Before(voucher.ExpiresAt) {
+		return nil, invalidVoucher(fmt.Sprintf("Voucher %s is not valid at this time", voucher.Code))
+	}
+
+	if param.Total < voucher.MinSpend {
+		return nil, invalidVoucher(fmt.Sprintf("Voucher %s requires a minimum spend of %d", voucher.Code, voucher.MinSpend))
+	}
+
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return nil, invalidVoucher(fmt.Sprintf("Voucher %s has been fully redeemed", voucher.Code))
+	}
+
+	if voucher.UsageLimitPerCustomer > 0 {
+		if param.CustomerID == nil {
+			return nil, invalidVoucher(fmt.Sprintf("Voucher %s can only be redeemed by a registered customer", voucher.Code))
+		}
+
+		redemptionCount, err := ou.voucherRepository.CountRedemptionsByCustomerID(txContext, voucher.ID, *param.CustomerID)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		if redemptionCount >= voucher.UsageLimitPerCustomer {
+			return nil, invalidVoucher(fmt.Sprintf("Voucher %s has reached its limit for this customer", voucher.Code))
+		}
+	}
+
+	return voucher, nil
+}
+
+func (ou OrderUsecase) redeemVoucher(txContext context.Context, voucher *entity.Voucher, order *entity.Order, customerID *int64) error {
+	isIncremented, err := ou.voucherRepository.IncrementUsageByID(txContext, voucher.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	if !isIncremented {
+		return entity.ErrValidation{
+			Message: "Invalid voucher",
+			Errors:  map[string]string{"voucher_code": fmt.Sprintf("Voucher %s has been fully redeemed", voucher.Code)},
+		}
+	}
+
+	redemptionParam := entity.CreateVoucherRedemptionParam{
+		VoucherID:  voucher.ID,
+		OrderID:    order.ID,
+		CustomerID: customerID,
+		Discount:   order.Discount,
+	}
+	if err := ou.voucherRepository.CreateRedemption(txContext, redemptionParam); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// lockPoints checks whether the customer can pay with the requested points,
+// holding a lock on the customer until the order transaction ends, and returns
+// the amount the points are worth.
+func (ou OrderUsecase) lockPoints(txContext context.Context, setting *entity.LoyaltySetting, param entity.CreateOrderParam) (int, error) {
+	invalidPoints := func(message string) error {
+		return entity.ErrValidation{
+			Message: "Invalid points redemption",
+			Errors:  map[string]string{"redeem_points": message},
+		}
+	}
+
+	if setting.PointValue < 1 {
+		return 0, invalidPoints("Points redemption is disabled")
+	}
+
+	balance, err := ou.loyaltyRepository.GetBalanceByCustomerIDForUpdate(txContext, *param.CustomerID)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	if balance < param.RedeemPoints {
+		return 0, invalidPoints(fmt.Sprintf("Customer only has %d points", balance))
+	}
+
+	amount := param.RedeemPoints * setting.PointValue
+	if amount > param.Total {
+		return 0, invalidPoints(fmt.Sprintf("%d points are worth more than the order total", param.RedeemPoints))
+	}
+
+	return amount, nil
+}
+
+type prepaidTender struct {
+	card   *entity.PrepaidCard
+	amount int
+}
+
+// takeStock decrements the stock of the sold products in ID order, so that
+// concurrent orders lock the product rows in the same order, and reports every
+// product that has run out of stock since it was checked. Products whose
+// policy lets them be sold beyond their stock are taken below zero instead,
+// and returned to be recorded for reconciliation.
+func (ou OrderUsecase) takeStock(
+	ctx context.Context,
+	productSale map[int64]int,
+	products []*entity.Product,
+	param entity.CreateOrderParam,
+) ([]*entity.CreateNegativeStockSaleParam, error) {
+	productMap := make(map[int64]*entity.Product, len(products))
+	for _, product := range products {
+		productMap[product.ID] = product
+	}
+
+	productIDs := make([]int64, 0, len(productSale))
+	for productID := range productSale {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	ev := entity.ErrValidation{
+		Message: "Insufficient product quantity",
+		Errors:  map[string]string{},
+	}
+	negativeStockSales := []*entity.CreateNegativeStockSaleParam{}
+	for _, productID := range productIDs {
+		quantity := productSale[productID]
+		isDecremented, err := ou.productRepository.DecrementStockByID(ctx, productID, quantity)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		if isDecremented {
+			continue
+		}
+
+		canSell := false
+		if product, ok := productMap[productID]; ok {
+			policy, err := ou.stockPolicy(ctx, product)
+			if err != nil {
+				return nil, err
+			}
+
+			canSell = canSellBeyondStock(policy, param)
+		}
+
+		if canSell {
+			if err := ou.productRepository.SubtractStockByID(ctx, productID, quantity); err != nil {
+				log.Println(err.Error())
+				return nil, err
+			}
+		}
+
+		// the product row is locked by now when it was taken, so the stock
+		// read here is the one left by this order
+		product, err := ou.productRepository.GetProductByID(ctx, productID)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		if !canSell {
+			ev.Errors[product.Name] = fmt.Sprintf("%s remaining quantity: %d", product.Name, product.Stock)
+			continue
+		}
+
+		shortfall := -product.Stock
+		if shortfall > quantity {
+			shortfall = quantity
+		}
+		negativeStockSales = append(negativeStockSales, &entity.CreateNegativeStockSaleParam{
+			ProductID:  productID,
+			Quantity:   quantity,
+			Shortfall:  shortfall,
+			StockAfter: product.Stock,
+		})
+	}
+
+	if len(ev.Errors) > 0 {
+		return nil, ev
+	}
+
+	return negativeStockSales, nil
+}
+
+// stockPolicy resolves the negative-stock policy of the product, the store
+// policy applies unless the product overrides it.
+func (ou OrderUsecase) stockPolicy(ctx context.Context, product *entity.Product) (string, error) {
+	if product.StockPolicy != "" {
+		return product.StockPolicy, nil
+	}
+
+	setting, err := ou.storeSettingRepository.GetSetting(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return "", err
+	}
+
+	return setting.StockPolicy, nil
+}
+
+func canSellBeyondStock(policy string, param entity.CreateOrderParam) bool {
+	switch policy {
+	case entity.StockPolicyAllow:
+		return true
+	case entity.StockPolicyWarn:
+		return param.AllowNegativeStock
+	default:
+		return false
+	}
+}
+
+// allocateInvoiceNumber takes the next number of the invoice sequence inside
+// the order transaction, so the numbers of rolled back orders are reused. The
+// period of the number starts at midnight of the store.
+func (ou OrderUsecase) allocateInvoiceNumber(txContext context.Context, setting *entity.StoreSetting) (string, error) {
+	now := time.Now().In(ou.location)
+	seq, err := ou.orderRepository.NextInvoiceSequence(txContext, setting.InvoicePeriod(now))
+	if err != nil {
+		log.Println(err.Error())
+		return "", err
+	}
+
+	return setting.InvoiceNumber(now, seq), nil
+}
+
+// orderRequestHash fingerprints the order request as the client sent it.
+func orderRequestHash(param entity.CreateOrderParam) string {
+	payload, _ := json.Marshal(param)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// replayOrder returns the order already created with the idempotency key of
+// the param, or nothing when the key has not been used yet. Reusing the key
+// for a different request is rejected.
+func (ou OrderUsecase) replayOrder(ctx context.Context, param entity.CreateOrderParam) (*entity.Order, error) {
+	order, err := ou.orderRepository.GetOrderByIdempotencyKey(ctx, param.IdempotencyKey)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, nil
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if order.RequestHash != param.RequestHash {
+		return nil, entity.ErrItemAlreadyExists{
+			Message: "Idempotency key has already been used for a different order",
+		}
+	}
+
+	cards, err := ou.prepaidCardRepository.GetCardsByOrderID(ctx, order.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	for _, card := range cards {
+		if card.Type == entity.PrepaidCardTypeGiftCard {
+			order.PrepaidCards = append(order.PrepaidCards, card)
+		}
+	}
+
+	return order, nil
+}
+
+// lockPrepaidCards fetches the prepaid cards paying the order with a row lock
+// held for the rest of the order transaction and works out how much is spent
+// from each of them, at most the amount still due.
+func (ou OrderUsecase) lockPrepaidCards(txContext context.Context, param entity.CreateOrderParam, due int) ([]*prepaidTender, error) {
+	invalidPrepaid := func(message string) error {
+		return entity.ErrValidation{
+			Message: "Invalid prepaid card payment",
+			Errors:  map[string]string{"prepaid_payments": message},
+		}
+	}
+
+	tenders := []*prepaidTender{}
+	usedNumbers := make(map[string]bool)
+	for _, payment := range param.PrepaidPayments {
+		if usedNumbers[payment.Number] {
+			return nil, invalidPrepaid(fmt.Sprintf("Card %s is used more than once", payment.Number))
+		}
+		usedNumbers[payment.Number] = true
+
+		card, err := ou.prepaidCardRepository.GetCardByNumberForUpdate(txContext, payment.Number)
+		if _, ok := err.(entity.ErrNotFound); ok {
+			return nil, invalidPrepaid(fmt.Sprintf("Card %s does not exist", payment.Number))
+		}
+
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		amount := payment.Amount
+		if amount == 0 {
+			amount = card.Balance
+			if amount > due {
+				amount = due
+			}
+		}
+
+		if amount < 1 {
+			return nil, invalidPrepaid(fmt.Sprintf("Card %s has nothing left to pay", card.Number))
+		}
+
+		if amount > card.Balance {
+			return nil, invalidPrepaid(fmt.Sprintf("Card %s only has a balance of %d", card.Number, card.Balance))
+		}
+
+		if amount > due {
+			return nil, invalidPrepaid(fmt.Sprintf("Card %s pays more than the order total", card.Number))
+		}
+
+		due -= amount
+		tenders = append(tenders, &prepaidTender{card: card, amount: amount})
+	}
+
+	return tenders, nil
+}
+
+func (ou OrderUsecase) redeemPrepaidCards(txContext context.Context, order *entity.Order, tenders []*prepaidTender) error {
+	for _, tender := range tenders {
+		isDecremented, err := ou.prepaidCardRepository.DecrementBalanceByID(txContext, tender.card.ID, tender.amount)
+		if err != nil {
+			log.Println(err.Error())
+			return err
+		}
+
+		if !isDecremented {
+			return entity.ErrValidation{
+				Message: "Invalid prepaid card payment",
+				Errors:  map[string]string{"prepaid_payments": fmt.Sprintf("Card %s has insufficient balance", tender.card.Number)},
+			}
+		}
+
+		transactionParam := entity.CreatePrepaidTransactionParam{
+			PrepaidCardID: tender.card.ID,
+			OrderID:       &order.ID,
+			Type:          entity.PrepaidTransactionTypeRedeem,
+			Amount:        -tender.amount,
+		}
+		if err := ou.prepaidCardRepository.CreateTransaction(txContext, transactionParam); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// issueGiftCards issues one gift card for every unit of a gift card product
+// sold on the order, worth the product price.
+func (ou OrderUsecase) issueGiftCards(
+	txContext context.Context,
+	order *entity.Order,
+	products []*entity.Product,
+	param entity.CreateOrderParam) ([]*entity.PrepaidCard, error) {
+	giftCardProducts := make(map[int64]*entity.Product)
+	for _, product := range products {
+		if product.IsGiftCard {
+			giftCardProducts[product.ID] = product
+		}
+	}
+
+	// a card is worth what its line was priced at, which a price list may
+	// have lowered
+	cards := []*entity.PrepaidCard{}
+	for _, item := range param.Items {
+		if _, ok := giftCardProducts[item.ProductID]; !ok {
+			continue
+		}
+
+		for i := 0; i < item.Quantity; i++ {
+			card, err := ou.issuePrepaidCard(txContext, entity.CreatePrepaidCardParam{
+				Type:       entity.PrepaidCardTypeGiftCard,
+				CustomerID: param.CustomerID,
+				OrderID:    &order.ID,
+				Value:      item.Subtotal / item.Quantity,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			cards = append(cards, card)
+		}
+	}
+
+	return cards, nil
+}
+
+// issuePrepaidCard creates a card under a fresh unique number and records its
+// issued value as the first transaction.
+func (ou OrderUsecase) issuePrepaidCard(txContext context.Context, param entity.CreatePrepaidCardParam) (*entity.PrepaidCard, error) {
+	number, err := ou.generatePrepaidCardNumber(txContext)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	param.Number = number
+	card, err := ou.prepaidCardRepository.Create(txContext, param)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	transactionParam := entity.CreatePrepaidTransactionParam{
+		PrepaidCardID: card.ID,
+		OrderID:       param.OrderID,
+		Type:          entity.PrepaidTransactionTypeIssue,
+		Amount:        card.Value,
+	}
+	if err := ou.prepaidCardRepository.CreateTransaction(txContext, transactionParam); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (ou OrderUsecase) generatePrepaidCardNumber(ctx context.Context) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		number, err := stringsRandomCode(prepaidCardNumberLength)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = ou.prepaidCardRepository.GetCardByNumber(ctx, number)
+		if _, ok := err.(entity.ErrNotFound); ok {
+			return number, nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", errors.New("failed generating a unique prepaid card number")
+}
+
+// createPayments records the tenders settling the order, the redeemed points
+// first, then the prepaid cards and cash for the remainder.
+func (ou OrderUsecase) createPayments(txContext context.Context, order *entity.Order, pointsAmount int, prepaidTenders []*prepaidTender) error {
+	payments := []*entity.CreateOrderPaymentParam{}
+	if pointsAmount > 0 {
+		payments = append(payments, &entity.CreateOrderPaymentParam{
+			Method: entity.PaymentMethodPoints,
+			Amount: pointsAmount,
+		})
+	}
+
+	cashAmount := order.Total - pointsAmount
+	for _, tender := range prepaidTenders {
+		method := entity.PaymentMethodGiftCard
+		if tender.card.Type == entity.PrepaidCardTypeStoreCredit {
+			method = entity.PaymentMethodStoreCredit
+		}
+
+		payments = append(payments, &entity.CreateOrderPaymentParam{
+			Method:    method,
+			Amount:    tender.amount,
+			Reference: tender.card.Number,
+		})
+		cashAmount -= tender.amount
+	}
+
+	if cashAmount > 0 {
+		payments = append(payments, &entity.CreateOrderPaymentParam{
+			Method: entity.PaymentMethodCash,
+			Amount: cashAmount,
+		})
+	}
+
+	if len(payments) < 1 {
+		return nil
+	}
+
+	if err := ou.orderRepository.CreatePayments(txContext, order.ID, payments); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// recordPoints writes the ledger entries of a customer order: the points
+// redeemed as a tender and the points earned on the amount paid with money.
+func (ou OrderUsecase) recordPoints(
+	txContext context.Context,
+	order *entity.Order,
+	setting *entity.LoyaltySetting,
+	products []*entity.Product,
+	param entity.CreateOrderParam,
+	paid int) error {
+	if param.RedeemPoints > 0 {
+		entryParam := entity.CreateLoyaltyEntryParam{
+			CustomerID: *param.CustomerID,
+			OrderID:    &order.ID,
+			Type:       entity.LoyaltyEntryTypeRedeem,
+			Points:     -param.RedeemPoints,
+		}
+		if err := ou.loyaltyRepository.CreateEntry(txContext, entryParam); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+	}
+
+	if setting.SpendPerPoint < 1 {
+		return nil
+	}
+
+	multipliers, err := ou.loyaltyRepository.GetAllMultipliers(txContext)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	points := earnedPoints(setting, multipliers, products, param.Items, paid)
+	if points < 1 {
+		return nil
+	}
+
+	entryParam := entity.CreateLoyaltyEntryParam{
+		CustomerID: *param.CustomerID,
+		OrderID:    &order.ID,
+		Type:       entity.LoyaltyEntryTypeEarn,
+		Points:     points,
+	}
+	if err := ou.loyaltyRepository.CreateEntry(txContext, entryParam); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// earnedPoints converts the amount paid with money into points. Every line is
+// weighted by the multiplier of its product category, then the weighted amount
+// is scaled down to what was actually paid so discounts, redeemed points and
+// prepaid cards do not earn points themselves.
+func earnedPoints(
+	setting *entity.LoyaltySetting,
+	multipliers []*entity.LoyaltyMultiplier,
+	products []*entity.Product,
+	items []*entity.CreateOrderItemParam,
+	paid int) int {
+	categoryMultipliers := make(map[string]float64)
+	for _, multiplier := range multipliers {
+		categoryMultipliers[multiplier.Category] = multiplier.Multiplier
+	}
+
+	productCategories := make(map[int64]string)
+	for _, product := range products {
+		productCategories[product.ID] = product.Category
+	}
+
+	gross := 0
+	weighted := 0.0
+	for _, item := range items {
+		amount := item.Subtotal
+		multiplier, ok := categoryMultipliers[productCategories[item.ProductID]]
+		if !ok {
+			multiplier = 1
+		}
+
+		gross += amount
+		weighted += float64(amount) * multiplier
+	}
+
+	if gross < 1 || paid < 1 {
+		return 0
+	}
+
+	return int(weighted * float64(paid) / float64(gross) / float64(setting.SpendPerPoint))
+}
+
+// VoidOrder cancels an order made today, e.g. one that was rung up by mistake.
+func (ou OrderUsecase) VoidOrder(ctx context.Context, ID int64) error {
+	_, err := ou.reverseOrder(ctx, ID, entity.OrderStatusVoided, false)
+	return err
+}
+
+// RefundOrder reverses an order whose goods are returned by the customer. When
+// settled as store credit, the cash paid for the order is issued as a store
+// credit card which is returned, otherwise the returned card is nil.
+func (ou OrderUsecase) RefundOrder(ctx context.Context, ID int64, param entity.RefundOrderParam) (*entity.PrepaidCard, error) {
+	return ou.reverseOrder(ctx, ID, entity.OrderStatusRefunded, param.StoreCredit)
+}
+
+// reverseOrder marks the order with the given status, puts the sold products
+// back in stock and reverses the loyalty points and prepaid cards of the order.
+func (ou OrderUsecase) reverseOrder(ctx context.Context, ID int64, status string, storeCredit bool) (*entity.PrepaidCard, error) {
+	txContext, err := ou.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	order, err := ou.orderRepository.GetOrderByIDForUpdate(txContext, ID)
+	if err != nil {
+		log.Println(err.Error())
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if !order.IsCompleted() {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, entity.ErrValidation{
+			Message: "Invalid order",
+			Errors:  map[string]string{"status": fmt.Sprintf("Order is already %s", order.Status)},
+		}
+	}
+
+	today := time.Now().In(ou.location).Format("2006-01-02")
+	if status == entity.OrderStatusVoided && order.CreatedAt.In(ou.location).Format("2006-01-02") != today {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, entity.ErrValidation{
+			Message: "Invalid order",
+			Errors:  map[string]string{"status": "Only today's orders can be voided, refund the order instead"},
+		}
+	}
+
+	orderItems, err := ou.orderRepository.GetOrderItemsByID(txContext, order.ID)
+	if err != nil {
+		log.Println(err.Error())
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	productRestock := make(map[int64]int)
+	for _, orderItem := range orderItems {
+		if orderItem.ProductID > 0 {
+			productRestock[orderItem.ProductID] += orderItem.Quantity
+		}
+	}
+
+	if len(productRestock) > 0 {
+		if err := ou.productRepository.IncrementProductByIDs(txContext, productRestock); err != nil {
+			log.Println(err.Error())
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+	}
+
+	if err := ou.orderRepository.UpdateStatusByID(txContext, order.ID, status); err != nil {
+		log.Println(err.Error())
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if order.CustomerID != nil {
+		if err := ou.reversePoints(txContext, order); err != nil {
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+	}
+
+	if err := ou.reversePrepaidCards(txContext, order); err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	var storeCreditCard *entity.PrepaidCard
+	if storeCredit {
+		storeCreditCard, err = ou.issueStoreCredit(txContext, order)
+		if err != nil {
+			ou.UnitOfWork.Rollback(txContext)
+			return nil, err
+		}
+	}
+
+	eventType := entity.WebhookEventOrderVoided
+	if status == entity.OrderStatusRefunded {
+		eventType = entity.WebhookEventOrderRefunded
+	}
+	reversedOrder := *order
+	reversedOrder.Status = status
+	reversedOrder.Items = orderItems
+	eventData := entity.OrderEventData{Order: &reversedOrder, StoreCredit: storeCreditCard}
+	if err := ou.recordWebhookEvent(txContext, eventType, eventData); err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	// the log is kept along with the reversal or not at all
+	auditAction := entity.AuditActionOrderVoided
+	if status == entity.OrderStatusRefunded {
+		auditAction = entity.AuditActionOrderRefunded
+	}
+	changes := map[string]entity.AuditChange{"status": {Before: order.Status, After: status}}
+	if storeCreditCard != nil {
+		changes["store_credit"] = entity.AuditChange{Before: nil, After: storeCreditCard.Value}
+	}
+	auditLog := newAuditLog(ctx, auditAction, entity.AuditEntityOrder, order.ID, changes)
+	if err := ou.auditLogRepository.Create(txContext, auditLog); err != nil {
+		ou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if err := ou.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	eventName := entity.EventOrderVoided
+	stockChangeReason := entity.StockChangeVoid
+	if status == entity.OrderStatusRefunded {
+		eventName = entity.EventOrderRefunded
+		stockChangeReason = entity.StockChangeRefund
+	}
+	ou.eventPublisher.Publish(ctx, entity.Event{
+		Name: eventName,
+		Data: entity.OrderReversedEvent{Order: &reversedOrder, StoreCredit: storeCreditCard},
+	})
+	publishStockChanges(ctx, ou.eventPublisher, productRestock, stockChangeReason, &order.ID)
+	return storeCreditCard, nil
+}
+
+// reversePoints claws back the points earned by the order and gives back the
+// points redeemed on it. The clawback may leave a negative balance when the
+// earned points have already been spent.
+func (ou OrderUsecase) reversePoints(txContext context.Context, order *entity.Order) error {
+	orderPoints, err := ou.loyaltyRepository.GetOrderPoints(txContext, order.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	entryParams := []entity.CreateLoyaltyEntryParam{}
+	if orderPoints.Earned > 0 {
+		entryParams = append(entryParams, entity.CreateLoyaltyEntryParam{
+			CustomerID: *order.CustomerID,
+			OrderID:    &order.ID,
+			Type:       entity.LoyaltyEntryTypeClawback,
+			Points:     -orderPoints.Earned,
+		})
+	}
+
+	if orderPoints.Redeemed > 0 {
+		entryParams = append(entryParams, entity.CreateLoyaltyEntryParam{
+			CustomerID: *order.CustomerID,
+			OrderID:    &order.ID,
+			Type:       entity.LoyaltyEntryTypeRestore,
+			Points:     orderPoints.Redeemed,
+		})
+	}
+
+	for _, entryParam := range entryParams {
+		if err := ou.loyaltyRepository.CreateEntry(txContext, entryParam); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reversePrepaidCards cancels the gift cards sold on the order and gives back
+// the balance spent from prepaid cards paying the order. Gift cards that have
+// already been spent from cannot be taken back, so the order cannot be
+// reversed either.
+func (ou OrderUsecase) reversePrepaidCards(txContext context.Context, order *entity.Order) error {
+	issuedCards, err := ou.prepaidCardRepository.GetCardsByOrderID(txContext, order.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	for _, card := range issuedCards {
+		if card.IsUsed() {
+			return entity.ErrValidation{
+				Message: "Invalid order",
+				Errors:  map[string]string{"prepaid_cards": fmt.Sprintf("Gift card %s sold on this order has already been used", card.Number)},
+			}
+		}
+
+		if card.Balance < 1 {
+			continue
+		}
+
+		if _, err := ou.prepaidCardRepository.DecrementBalanceByID(txContext, card.ID, card.Balance); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+
+		transactionParam := entity.CreatePrepaidTransactionParam{
+			PrepaidCardID: card.ID,
+			OrderID:       &order.ID,
+			Type:          entity.PrepaidTransactionTypeCancel,
+			Amount:        -card.Balance,
+		}
+		if err := ou.prepaidCardRepository.CreateTransaction(txContext, transactionParam); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+	}
+
+	redemptions, err := ou.prepaidCardRepository.GetOrderRedemptions(txContext, order.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	for cardID, amount := range redemptions {
+		if err := ou.prepaidCardRepository.IncrementBalanceByID(txContext, cardID, amount); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+
+		transactionParam := entity.CreatePrepaidTransactionParam{
+			PrepaidCardID: cardID,
+			OrderID:       &order.ID,
+			Type:          entity.PrepaidTransactionTypeRefund,
+			Amount:        amount,
+		}
+		if err := ou.prepaidCardRepository.CreateTransaction(txContext, transactionParam); err != nil {
+			log.Println(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// issueStoreCredit issues the cash paid for the order as store credit instead
+// of handing the cash back.
+func (ou OrderUsecase) issueStoreCredit(txContext context.Context, order *entity.Order) (*entity.PrepaidCard, error) {
+	payments, err := ou.orderRepository.GetPaymentsByOrderID(txContext, order.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	cashAmount := 0
+	for _, payment := range payments {
+		if payment.Method == entity.PaymentMethodCash {
+			cashAmount += payment.Amount
+		}
+	}
+
+	if cashAmount < 1 {
+		return nil, nil
+	}
+
+	return ou.issuePrepaidCard(txContext, entity.CreatePrepaidCardParam{
+		Type:       entity.PrepaidCardTypeStoreCredit,
+		CustomerID: order.CustomerID,
+		OrderID:    &order.ID,
+		Value:      cashAmount,
+	})
+}
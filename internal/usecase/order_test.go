@@ -2,130 +2,198 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal/entity"
 	"github.com/ardafirdausr/kaseer/internal/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
-func Test_GetAllOrders_Failed(t *testing.T) {
-	ctx := context.TODO()
-	mockUnitOfWork := new(mocks.UnitOfWork)
-	mockProductRepo := new(mocks.ProductRepository)
-	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetAllOrders", ctx).Return(nil, errors.New("failed get orders"))
-
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aOrders, err := orderUsecase.GetAllOrders(ctx)
-	assert.NotNil(t, err)
-	assert.Nil(t, aOrders)
+var storeSetting = &entity.StoreSetting{
+	StoreCode:      "KSR",
+	InvoicePattern: "INV/{store}/{YYYY}/{seq}",
+	InvoiceReset:   entity.InvoiceResetYearly,
+	StockPolicy:    entity.StockPolicyBlock,
 }
 
-func Test_GetAllOrders_Success(t *testing.T) {
-	eOrders := []*entity.Order{
-		{
-			ID:    1,
-			Total: 40000,
-		}, {
-			ID:    1,
-			Total: 40000,
-		},
-	}
-	ctx := context.TODO()
-	mockUnitOfWork := new(mocks.UnitOfWork)
-	mockProductRepo := new(mocks.ProductRepository)
-	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetAllOrders", ctx).Return(eOrders, nil)
+// invoiced is the order param as it is created with the first invoice number
+// of the storeSetting sequence.
+func invoiced(param entity.CreateOrderParam) entity.CreateOrderParam {
+	param.InvoiceNumber = storeSetting.InvoiceNumber(time.Now(), 1)
+	return param
+}
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aOrders, err := orderUsecase.GetAllOrders(ctx)
-	assert.Nil(t, err)
-	assert.ObjectsAreEqualValues(eOrders, aOrders)
+func cashPayments(amount int) []*entity.CreateOrderPaymentParam {
+	return []*entity.CreateOrderPaymentParam{{Method: entity.PaymentMethodCash, Amount: amount}}
 }
 
-func Test_GetOrderItems_Failed(t *testing.T) {
+func Test_GetOrders_Failed(t *testing.T) {
 	ctx := context.TODO()
-	var orderID int64 = 1
 	mockUnitOfWork := new(mocks.UnitOfWork)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetOrderItemsByID", ctx, orderID).Return(nil, errors.New("failed get order items"))
+	mockOrderRepo.On("GetOrders", ctx, mock.AnythingOfType("entity.OrderQuery")).Return(nil, errors.New("failed get orders"))
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aOrders, err := orderUsecase.GetOrderItems(ctx, orderID)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aPage, err := orderUsecase.GetOrders(ctx, entity.OrderQuery{})
 	assert.NotNil(t, err)
-	assert.Nil(t, aOrders)
+	assert.Nil(t, aPage)
 }
 
-func Test_GetOrderItems_Success(t *testing.T) {
+func Test_GetOrders_Failed_WhenFilterIsInvalid(t *testing.T) {
 	ctx := context.TODO()
-	var orderID int64 = 1
-	eOrderItems := make([]*entity.OrderItem, 0)
 	mockUnitOfWork := new(mocks.UnitOfWork)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetOrderItemsByID", ctx, orderID).Return(eOrderItems, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aOrderItems, err := orderUsecase.GetOrderItems(ctx, orderID)
-	assert.Nil(t, err)
-	assert.ObjectsAreEqualValues(eOrderItems, aOrderItems)
+	startDate := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	endDate := startDate.AddDate(0, 0, -1)
+	minTotal, maxTotal := 50000, 10000
+	query := entity.OrderQuery{
+		StartDate: &startDate,
+		EndDate:   &endDate,
+		MinTotal:  &minTotal,
+		MaxTotal:  &maxTotal,
+		Status:    "pending",
+	}
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aPage, err := orderUsecase.GetOrders(ctx, query)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Len(t, err.(entity.ErrValidation).Errors, 3)
+	assert.Nil(t, aPage)
+	mockOrderRepo.AssertNotCalled(t, "GetOrders", mock.Anything, mock.Anything)
 }
 
-func Test_GetAnnualIncome_Failed(t *testing.T) {
+func Test_GetOrders_Success_WithOffset(t *testing.T) {
+	eOrders := []*entity.Order{
+		{ID: 30, Total: 40000},
+		{ID: 29, Total: 20000},
+		{ID: 28, Total: 10000},
+	}
+	query := entity.OrderQuery{Offset: 4, Limit: 2}
 	ctx := context.TODO()
 	mockUnitOfWork := new(mocks.UnitOfWork)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetAnnualIncome", ctx).Return(nil, errors.New("failed get anual income"))
+	mockOrderRepo.On("GetOrders", ctx, entity.OrderQuery{Offset: 4, Limit: 3}).Return(eOrders, nil)
+	mockOrderRepo.On("CountOrders", ctx, query).Return(7, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetAnnualIncome(ctx)
-	assert.NotNil(t, err)
-	assert.Nil(t, aRes)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aPage, err := orderUsecase.GetOrders(ctx, query)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrders[:2], aPage.Orders)
+	assert.True(t, aPage.HasMore)
+	assert.Equal(t, int64(29), aPage.NextCursor)
+	assert.Equal(t, 7, *aPage.Total)
 }
 
-func Test_GetAnnualIncome_Success(t *testing.T) {
+func Test_GetOrders_Success_WithCursor(t *testing.T) {
+	eOrders := []*entity.Order{
+		{ID: 9, Total: 40000},
+	}
 	ctx := context.TODO()
-	eRes := make([]*entity.AnnualIncome, 0)
 	mockUnitOfWork := new(mocks.UnitOfWork)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetAnnualIncome", ctx).Return(eRes, nil)
+	mockOrderRepo.On("GetOrders", ctx, entity.OrderQuery{Cursor: 10, Limit: 101}).Return(eOrders, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetAnnualIncome(ctx)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aPage, err := orderUsecase.GetOrders(ctx, entity.OrderQuery{Cursor: 10, Limit: 500})
 	assert.Nil(t, err)
-	assert.ObjectsAreEqualValues(eRes, aRes)
+	assert.Equal(t, eOrders, aPage.Orders)
+	assert.False(t, aPage.HasMore)
+	assert.Nil(t, aPage.Total)
+	mockOrderRepo.AssertNotCalled(t, "CountOrders", mock.Anything, mock.Anything)
 }
 
-func Test_GetDailyOrderCount_Failed(t *testing.T) {
+func Test_GetOrderItems_Failed(t *testing.T) {
 	ctx := context.TODO()
+	var orderID int64 = 1
 	mockUnitOfWork := new(mocks.UnitOfWork)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetDailyOrderCount", ctx).Return(0, errors.New("failed get daily order count"))
+	mockOrderRepo.On("GetOrderItemsByID", ctx, orderID).Return(nil, errors.New("failed get order items"))
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetDailyOrderCount(ctx)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrders, err := orderUsecase.GetOrderItems(ctx, orderID)
 	assert.NotNil(t, err)
-	assert.Equal(t, 0, aRes)
+	assert.Nil(t, aOrders)
 }
 
-func Test_GetDailyOrderCount_Success(t *testing.T) {
+func Test_GetOrderItems_Success(t *testing.T) {
 	ctx := context.TODO()
-	eRes := 10
+	var orderID int64 = 1
+	eOrderItems := make([]*entity.OrderItem, 0)
 	mockUnitOfWork := new(mocks.UnitOfWork)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetDailyOrderCount", ctx).Return(eRes, nil)
+	mockOrderRepo.On("GetOrderItemsByID", ctx, orderID).Return(eOrderItems, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetDailyOrderCount(ctx)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrderItems, err := orderUsecase.GetOrderItems(ctx, orderID)
 	assert.Nil(t, err)
-	assert.Equal(t, eRes, aRes)
+	assert.ObjectsAreEqualValues(eOrderItems, aOrderItems)
 }
 
 func Test_GetTotalOrderCount_Failed(t *testing.T) {
@@ -134,8 +202,17 @@ func Test_GetTotalOrderCount_Failed(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
 	mockOrderRepo.On("GetTotalOrderCount", ctx).Return(0, errors.New("failed get total order count"))
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aRes, err := orderUsecase.GetTotalOrderCount(ctx)
 	assert.NotNil(t, err)
 	assert.Equal(t, 0, aRes)
@@ -148,67 +225,22 @@ func Test_GetTotalOrderCount_Success(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockOrderRepo := new(mocks.OrderRepository)
 	mockOrderRepo.On("GetTotalOrderCount", ctx).Return(eRes, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aRes, err := orderUsecase.GetTotalOrderCount(ctx)
 	assert.Nil(t, err)
 	assert.Equal(t, eRes, aRes)
 }
 
-func Test_GetLastDayIncome_Failed(t *testing.T) {
-	ctx := context.TODO()
-	mockUnitOfWork := new(mocks.UnitOfWork)
-	mockProductRepo := new(mocks.ProductRepository)
-	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetLastDayIncome", ctx).Return(0, errors.New("failed last daily income"))
-
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetLastDayIncome(ctx)
-	assert.NotNil(t, err)
-	assert.Equal(t, 0, aRes)
-}
-
-func Test_GetLastDayIncome_Success(t *testing.T) {
-	ctx := context.TODO()
-	eRes := 10
-	mockUnitOfWork := new(mocks.UnitOfWork)
-	mockProductRepo := new(mocks.ProductRepository)
-	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetLastDayIncome", ctx).Return(eRes, nil)
-
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetLastDayIncome(ctx)
-	assert.Nil(t, err)
-	assert.Equal(t, eRes, aRes)
-}
-
-func Test_GetLastMonthIncome_Failed(t *testing.T) {
-	ctx := context.TODO()
-	mockUnitOfWork := new(mocks.UnitOfWork)
-	mockProductRepo := new(mocks.ProductRepository)
-	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetLastMonthIncome", ctx).Return(0, errors.New("failed last month income"))
-
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetLastMonthIncome(ctx)
-	assert.NotNil(t, err)
-	assert.Equal(t, 0, aRes)
-}
-
-func Test_GetLastMonthIncome_Success(t *testing.T) {
-	ctx := context.TODO()
-	eRes := 10
-	mockUnitOfWork := new(mocks.UnitOfWork)
-	mockProductRepo := new(mocks.ProductRepository)
-	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("GetLastMonthIncome", ctx).Return(eRes, nil)
-
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
-	aRes, err := orderUsecase.GetLastMonthIncome(ctx)
-	assert.Nil(t, err)
-	assert.Equal(t, eRes, aRes)
-}
-
 func Test_Create_Failed_WhenCannotGetProductsByID(t *testing.T) {
 	ctx := context.TODO()
 	var createOrderParam = entity.CreateOrderParam{
@@ -232,8 +264,19 @@ func Test_Create_Failed_WhenCannotGetProductsByID(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(nil, errors.New("failed get order items"))
 	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.Nil(t, aOrders)
@@ -262,11 +305,24 @@ func Test_Create_Failed_WhenQuantityInsufficient(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
 	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&entity.StoreSetting{StockPolicy: entity.StockPolicyBlock}, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.IsType(t, err, entity.ErrValidation{})
+	assert.NotContains(t, err.(entity.ErrValidation).Errors, "allow_negative_stock")
 	assert.Nil(t, aOrders)
 }
 
@@ -294,8 +350,19 @@ func Test_Create_Failed_WhenBeginTransaction(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
 	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.Nil(t, aOrders)
@@ -325,10 +392,25 @@ func Test_Create_Failed_WhenCreatingOrder(t *testing.T) {
 	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 3).Return(true, nil)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("Create", ctx, createOrderParam).Return(nil, errors.New("failed creating order"))
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(nil, errors.New("failed creating order"))
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.Nil(t, aOrders)
@@ -362,11 +444,27 @@ func Test_Create_Failed_WhenCreatingOrderItems(t *testing.T) {
 	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 3).Return(true, nil)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("Create", ctx, createOrderParam).Return(eOrder, nil)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
 	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(errors.New("failed create order items"))
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.Nil(t, aOrders)
@@ -390,7 +488,6 @@ func Test_Create_Failed_WhenDecreasingProductQuantity(t *testing.T) {
 			},
 		},
 	}
-	var productSale = map[int64]int{1: 2, 2: 3}
 	var eOrder = &entity.Order{
 		ID:    1,
 		Total: createOrderParam.Total,
@@ -401,12 +498,26 @@ func Test_Create_Failed_WhenDecreasingProductQuantity(t *testing.T) {
 	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
-	mockProductRepo.On("DecrementProductByIDs", ctx, productSale).Return(errors.New("failed to decrease product quantity"))
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(false, errors.New("failed to decrease product quantity"))
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("Create", ctx, createOrderParam).Return(eOrder, nil)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
 	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.Nil(t, aOrders)
@@ -430,7 +541,6 @@ func Test_Create_Failed_WhenCommitingTransaction(t *testing.T) {
 			},
 		},
 	}
-	var productSale = map[int64]int{1: 2, 2: 3}
 	var eOrder = &entity.Order{
 		ID:    1,
 		Total: createOrderParam.Total,
@@ -441,21 +551,38 @@ func Test_Create_Failed_WhenCommitingTransaction(t *testing.T) {
 	mockUnitOfWork.On("Commit", ctx).Return(errors.New("failed to commit transcation"))
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
-	mockProductRepo.On("DecrementProductByIDs", ctx, productSale).Return(nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 3).Return(true, nil)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("Create", ctx, createOrderParam).Return(eOrder, nil)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
 	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrders, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.NotNil(t, err)
 	assert.Nil(t, aOrders)
+	mockEventPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
 }
 
 func Test_Create_Success(t *testing.T) {
 	ctx := context.TODO()
 	var createOrderParam = entity.CreateOrderParam{
-		Total: 40000,
+		Total:       40000,
+		CashierName: "Jane",
 		Items: []*entity.CreateOrderItemParam{
 			{
 				ProductID: 1,
@@ -470,7 +597,6 @@ func Test_Create_Success(t *testing.T) {
 			},
 		},
 	}
-	var productSale = map[int64]int{1: 2, 2: 3}
 	var eOrder = &entity.Order{
 		ID:    1,
 		Total: createOrderParam.Total,
@@ -481,13 +607,1557 @@ func Test_Create_Success(t *testing.T) {
 	mockUnitOfWork.On("Commit", ctx).Return(nil)
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID, createOrderParam.Items[1].ProductID).Return(products, nil)
-	mockProductRepo.On("DecrementProductByIDs", ctx, productSale).Return(nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 3).Return(true, nil)
 	mockOrderRepo := new(mocks.OrderRepository)
-	mockOrderRepo.On("Create", ctx, createOrderParam).Return(eOrder, nil)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
 	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	events := []entity.Event{}
+	mockEventPublisher.On("Publish", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { events = append(events, args.Get(1).(entity.Event)) }).
+		Return()
 
-	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockUnitOfWork)
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
 	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqual(eOrder, aOrder)
+	if assert.Len(t, events, 3) {
+		assert.Equal(t, entity.EventOrderCreated, events[0].Name)
+		assert.Equal(t, eOrder.ID, events[0].Data.(entity.OrderCreatedEvent).Order.ID)
+		assert.Equal(t, "Jane", events[0].Data.(entity.OrderCreatedEvent).Order.CashierName)
+		assert.Equal(t, entity.StockChangedEvent{ProductID: 1, Change: -2, Reason: entity.StockChangeSale, ReferenceID: &eOrder.ID}, events[1].Data)
+		assert.Equal(t, entity.StockChangedEvent{ProductID: 2, Change: -3, Reason: entity.StockChangeSale, ReferenceID: &eOrder.ID}, events[2].Data)
+	}
+}
+
+func Test_Create_Success_PricesItemsFromProducts(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 1,
+		Items: []*entity.CreateOrderItemParam{
+			{ProductID: 1, Quantity: 2, Subtotal: 1},
+			{ProductID: 2, Quantity: 1, Subtotal: 0},
+		},
+	}
+	var pricedParam = entity.CreateOrderParam{
+		Total: 20000,
+		Items: []*entity.CreateOrderItemParam{
+			{ProductID: 1, Quantity: 2, Subtotal: 10000},
+			{ProductID: 2, Quantity: 1, Subtotal: 10000},
+		},
+	}
+	var eOrder = &entity.Order{ID: 1, Total: pricedParam.Total}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1), int64(2)).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 1).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(pricedParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, pricedParam.Items).Return(nil)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", ctx, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, new(mocks.VoucherRepository), new(mocks.CustomerRepository), new(mocks.LoyaltyRepository), new(mocks.PrepaidCardRepository), new(mocks.PriceListRepository), mockStoreSettingRepo, mockWebhookRepo, new(mocks.AuditLogRepository), mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder, aOrder)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_Create_Failed_WhenItemIsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []*entity.CreateOrderItemParam
+		field string
+	}{
+		{
+			name:  "unknown product",
+			items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 1}, {ProductID: 9, Quantity: 1}},
+			field: "order_items[1].product_id",
+		},
+		{
+			name:  "negative quantity",
+			items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: -2}},
+			field: "order_items[0].quantity",
+		},
+		{
+			name:  "no items",
+			items: []*entity.CreateOrderItemParam{},
+			field: "order_items",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.TODO()
+			mockUnitOfWork := new(mocks.UnitOfWork)
+			mockProductRepo := new(mocks.ProductRepository)
+			mockProductRepo.On("GetProductsByIDs", ctx, mock.Anything, mock.Anything).Return(products, nil)
+			mockProductRepo.On("GetProductsByIDs", ctx, mock.Anything).Return(products, nil)
+			mockProductRepo.On("GetProductsByIDs", ctx).Return(products, nil)
+
+			orderUsecase := NewOrderUsecase(new(mocks.OrderRepository), mockProductRepo, new(mocks.VoucherRepository), new(mocks.CustomerRepository), new(mocks.LoyaltyRepository), new(mocks.PrepaidCardRepository), new(mocks.PriceListRepository), new(mocks.StoreSettingRepository), new(mocks.WebhookRepository), new(mocks.AuditLogRepository), new(mocks.EventPublisher), mockUnitOfWork, jakarta)
+			aOrder, err := orderUsecase.Create(ctx, entity.CreateOrderParam{Items: test.items})
+			assert.Nil(t, aOrder)
+			if ev, ok := err.(entity.ErrValidation); assert.True(t, ok) {
+				assert.Contains(t, ev.Errors, test.field)
+			}
+			mockUnitOfWork.AssertNotCalled(t, "Begin", ctx)
+		})
+	}
+}
+
+func Test_Create_Success_RecordsWebhookEvents(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 20000,
+		Items: []*entity.CreateOrderItemParam{
+			{ProductID: 1, Quantity: 2, Subtotal: 10000},
+			{ProductID: 2, Quantity: 1, Subtotal: 10000},
+		},
+	}
+	var eOrder = &entity.Order{ID: 1, Total: createOrderParam.Total}
+	// the first product falls to the threshold, the second one was already
+	// below it before the sale
+	lowProducts := []*entity.Product{
+		{ID: 1, Code: "prod-1", Name: "prod 1", Price: 5000, Stock: 6},
+		{ID: 2, Code: "prod-2", Name: "prod 2", Price: 10000, Stock: 3},
+	}
+	setting := *storeSetting
+	setting.LowStockThreshold = 5
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1), int64(2)).Return(lowProducts, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 1).Return(true, nil)
+	mockProductRepo.On("GetProductByID", ctx, int64(1)).Return(&entity.Product{ID: 1, Code: "prod-1", Stock: 4}, nil)
+	mockProductRepo.On("GetProductByID", ctx, int64(2)).Return(&entity.Product{ID: 2, Code: "prod-2", Stock: 2}, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&setting, nil)
+	events := []entity.CreateWebhookEventParam{}
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { events = append(events, args.Get(1).(entity.CreateWebhookEventParam)) }).
+		Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	_, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 2) {
+		var created entity.OrderEventData
+		assert.Equal(t, entity.WebhookEventOrderCreated, events[0].Type)
+		assert.Nil(t, json.Unmarshal(events[0].Payload, &created))
+		assert.Equal(t, eOrder.ID, created.Order.ID)
+		assert.Len(t, created.Order.Items, 2)
+		assert.Equal(t, "prod-1", created.Order.Items[0].ProductCode)
+
+		var stockLow entity.StockLowEventData
+		assert.Equal(t, entity.WebhookEventProductStockLow, events[1].Type)
+		assert.Nil(t, json.Unmarshal(events[1].Payload, &stockLow))
+		assert.Equal(t, int64(1), stockLow.Product.ID)
+		assert.Equal(t, 4, stockLow.Product.Stock)
+		assert.Equal(t, 5, stockLow.Threshold)
+	}
+}
+
+func Test_Create_Failed_WhenVoucherExpired(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total:       40000,
+		VoucherCode: "PROMO",
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			},
+		},
+	}
+	var voucher = &entity.Voucher{
+		ID:        1,
+		Code:      "PROMO",
+		ValueType: entity.VoucherValueTypeFixed,
+		Value:     5000,
+		StartsAt:  time.Now().Add(-48 * time.Hour),
+		ExpiresAt: time.Now().Add(-24 * time.Hour),
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetVoucherByCodeForUpdate", ctx, "PROMO").Return(voucher, nil)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aOrder)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+}
+
+func Test_Create_Failed_WhenVoucherCustomerLimitReached(t *testing.T) {
+	ctx := context.TODO()
+	var customerID int64 = 7
+	var createOrderParam = entity.CreateOrderParam{
+		Total:       40000,
+		VoucherCode: "PROMO",
+		CustomerID:  &customerID,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			},
+		},
+	}
+	var voucher = &entity.Voucher{
+		ID:                    1,
+		Code:                  "PROMO",
+		ValueType:             entity.VoucherValueTypeFixed,
+		Value:                 5000,
+		StartsAt:              time.Now().Add(-time.Hour),
+		ExpiresAt:             time.Now().Add(time.Hour),
+		UsageLimitPerCustomer: 1,
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetVoucherByCodeForUpdate", ctx, "PROMO").Return(voucher, nil)
+	mockVoucherRepo.On("CountRedemptionsByCustomerID", ctx, voucher.ID, customerID).Return(1, nil)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetCustomerByID", ctx, customerID).Return(&entity.Customer{ID: customerID, Name: "Budi"}, nil)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aOrder)
+}
+
+func Test_Create_Success_WithVoucher(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total:       40000,
+		VoucherCode: "PROMO",
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  8,
+				Subtotal:  40000,
+			},
+		},
+	}
+	var voucher = &entity.Voucher{
+		ID:        1,
+		Code:      "PROMO",
+		ValueType: entity.VoucherValueTypePercent,
+		Value:     10,
+		MinSpend:  20000,
+		StartsAt:  time.Now().Add(-time.Hour),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	var discountedParam = createOrderParam
+	discountedParam.Discount = 4000
+	discountedParam.Total = 36000
+	var eOrder = &entity.Order{
+		ID:       1,
+		Total:    36000,
+		Discount: 4000,
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 8).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(discountedParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetVoucherByCodeForUpdate", ctx, "PROMO").Return(voucher, nil)
+	mockVoucherRepo.On("IncrementUsageByID", ctx, voucher.ID).Return(true, nil)
+	mockVoucherRepo.On("CreateRedemption", ctx, entity.CreateVoucherRedemptionParam{
+		VoucherID: voucher.ID,
+		OrderID:   eOrder.ID,
+		Discount:  4000,
+	}).Return(nil)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder, aOrder)
+	mockVoucherRepo.AssertExpectations(t)
+}
+
+func Test_Create_Failed_WhenCustomerNotFound(t *testing.T) {
+	ctx := context.TODO()
+	var customerID int64 = 7
+	var createOrderParam = entity.CreateOrderParam{
+		Total:      20000,
+		CustomerID: &customerID,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			},
+		},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetCustomerByID", ctx, customerID).Return(nil, entity.ErrNotFound{Message: "Customer not found"})
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aOrder)
+	mockUnitOfWork.AssertNotCalled(t, "Begin", ctx)
+}
+
+func Test_Create_Failed_WhenRedeemingPointsWithoutCustomer(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total:        20000,
+		RedeemPoints: 10,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			},
+		},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aOrder)
+	mockUnitOfWork.AssertNotCalled(t, "Begin", ctx)
+}
+
+func Test_Create_Failed_WhenPointsBalanceInsufficient(t *testing.T) {
+	ctx := context.TODO()
+	var customerID int64 = 7
+	var createOrderParam = entity.CreateOrderParam{
+		Total:        20000,
+		CustomerID:   &customerID,
+		RedeemPoints: 50,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			},
+		},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetCustomerByID", ctx, customerID).Return(&entity.Customer{ID: customerID}, nil)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("GetSetting", ctx).Return(&entity.LoyaltySetting{SpendPerPoint: 10000, PointValue: 100}, nil)
+	mockLoyaltyRepo.On("GetBalanceByCustomerIDForUpdate", ctx, customerID).Return(20, nil)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aOrder)
+	mockOrderRepo.AssertNotCalled(t, "Create", ctx, createOrderParam)
+}
+
+func Test_Create_Success_WithPointsRedemption(t *testing.T) {
+	ctx := context.TODO()
+	var customerID int64 = 7
+	var createOrderParam = entity.CreateOrderParam{
+		Total:        40000,
+		CustomerID:   &customerID,
+		RedeemPoints: 100,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			}, {
+				ProductID: 2,
+				Quantity:  3,
+				Subtotal:  30000,
+			},
+		},
+	}
+	var categorizedProducts = []*entity.Product{
+		{ID: 1, Price: 5000, Stock: 100, Category: "food"},
+		{ID: 2, Price: 10000, Stock: 200, Category: "drink"},
+	}
+	var eOrder = &entity.Order{
+		ID:         1,
+		CustomerID: &customerID,
+		Total:      createOrderParam.Total,
+	}
+	var payments = []*entity.CreateOrderPaymentParam{
+		{Method: entity.PaymentMethodPoints, Amount: 10000},
+		{Method: entity.PaymentMethodCash, Amount: 30000},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1), int64(2)).Return(categorizedProducts, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 3).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, payments).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetCustomerByID", ctx, customerID).Return(&entity.Customer{ID: customerID}, nil)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("GetSetting", ctx).Return(&entity.LoyaltySetting{SpendPerPoint: 10000, PointValue: 100}, nil)
+	mockLoyaltyRepo.On("GetBalanceByCustomerIDForUpdate", ctx, customerID).Return(150, nil)
+	mockLoyaltyRepo.On("GetAllMultipliers", ctx).Return([]*entity.LoyaltyMultiplier{{Category: "drink", Multiplier: 2}}, nil)
+	mockLoyaltyRepo.On("CreateEntry", ctx, entity.CreateLoyaltyEntryParam{
+		CustomerID: customerID,
+		OrderID:    &eOrder.ID,
+		Type:       entity.LoyaltyEntryTypeRedeem,
+		Points:     -100,
+	}).Return(nil)
+	// (10000 + 2 * 30000) weighted and scaled to the 30000 paid in cash is 52500, worth 5 points.
+	mockLoyaltyRepo.On("CreateEntry", ctx, entity.CreateLoyaltyEntryParam{
+		CustomerID: customerID,
+		OrderID:    &eOrder.ID,
+		Type:       entity.LoyaltyEntryTypeEarn,
+		Points:     5,
+	}).Return(nil)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder, aOrder)
+	mockOrderRepo.AssertExpectations(t)
+	mockLoyaltyRepo.AssertExpectations(t)
+}
+
+func Test_Create_Failed_WhenPrepaidBalanceInsufficient(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 20000,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			},
+		},
+		PrepaidPayments: []*entity.CreateOrderPrepaidPaymentParam{
+			{Number: "GIFT", Amount: 15000},
+		},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, createOrderParam.Items[0].ProductID).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardByNumberForUpdate", ctx, "GIFT").Return(&entity.PrepaidCard{
+		ID:      3,
+		Number:  "GIFT",
+		Type:    entity.PrepaidCardTypeGiftCard,
+		Value:   50000,
+		Balance: 10000,
+	}, nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aOrder)
+	mockOrderRepo.AssertNotCalled(t, "Create", ctx, createOrderParam)
+}
+
+func Test_Create_Success_WithPrepaidCardPayments(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 40000,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			}, {
+				ProductID: 2,
+				Quantity:  3,
+				Subtotal:  30000,
+			},
+		},
+		PrepaidPayments: []*entity.CreateOrderPrepaidPaymentParam{
+			{Number: "CREDIT", Amount: 5000},
+			{Number: "GIFT"},
+		},
+	}
+	var storeCredit = &entity.PrepaidCard{ID: 3, Number: "CREDIT", Type: entity.PrepaidCardTypeStoreCredit, Value: 8000, Balance: 8000}
+	var giftCard = &entity.PrepaidCard{ID: 4, Number: "GIFT", Type: entity.PrepaidCardTypeGiftCard, Value: 100000, Balance: 60000}
+	var eOrder = &entity.Order{
+		ID:    1,
+		Total: createOrderParam.Total,
+	}
+	var payments = []*entity.CreateOrderPaymentParam{
+		{Method: entity.PaymentMethodStoreCredit, Amount: 5000, Reference: "CREDIT"},
+		{Method: entity.PaymentMethodGiftCard, Amount: 35000, Reference: "GIFT"},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1), int64(2)).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 3).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, payments).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardByNumberForUpdate", ctx, "CREDIT").Return(storeCredit, nil)
+	mockPrepaidCardRepo.On("GetCardByNumberForUpdate", ctx, "GIFT").Return(giftCard, nil)
+	mockPrepaidCardRepo.On("DecrementBalanceByID", ctx, storeCredit.ID, 5000).Return(true, nil)
+	mockPrepaidCardRepo.On("DecrementBalanceByID", ctx, giftCard.ID, 35000).Return(true, nil)
+	mockPrepaidCardRepo.On("CreateTransaction", ctx, entity.CreatePrepaidTransactionParam{
+		PrepaidCardID: storeCredit.ID,
+		OrderID:       &eOrder.ID,
+		Type:          entity.PrepaidTransactionTypeRedeem,
+		Amount:        -5000,
+	}).Return(nil)
+	mockPrepaidCardRepo.On("CreateTransaction", ctx, entity.CreatePrepaidTransactionParam{
+		PrepaidCardID: giftCard.ID,
+		OrderID:       &eOrder.ID,
+		Type:          entity.PrepaidTransactionTypeRedeem,
+		Amount:        -35000,
+	}).Return(nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder, aOrder)
+	mockOrderRepo.AssertExpectations(t)
+	mockPrepaidCardRepo.AssertExpectations(t)
+}
+
+func Test_Create_Success_WithGiftCardSale(t *testing.T) {
+	defer stubRandomCodes("GIFTCARD00000001")()
+
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 50000,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 9,
+				Quantity:  1,
+				Subtotal:  50000,
+			},
+		},
+	}
+	var giftCardProducts = []*entity.Product{
+		{ID: 9, Price: 50000, Stock: 10, IsGiftCard: true},
+	}
+	var eOrder = &entity.Order{
+		ID:    1,
+		Total: createOrderParam.Total,
+	}
+	var eCard = &entity.PrepaidCard{
+		ID:      5,
+		Number:  "GIFTCARD00000001",
+		Type:    entity.PrepaidCardTypeGiftCard,
+		OrderID: &eOrder.ID,
+		Value:   50000,
+		Balance: 50000,
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(9)).Return(giftCardProducts, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(9), 1).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(createOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardByNumber", ctx, eCard.Number).Return(nil, entity.ErrNotFound{})
+	mockPrepaidCardRepo.On("Create", ctx, entity.CreatePrepaidCardParam{
+		Number:  eCard.Number,
+		Type:    entity.PrepaidCardTypeGiftCard,
+		OrderID: &eOrder.ID,
+		Value:   50000,
+	}).Return(eCard, nil)
+	mockPrepaidCardRepo.On("CreateTransaction", ctx, entity.CreatePrepaidTransactionParam{
+		PrepaidCardID: eCard.ID,
+		OrderID:       &eOrder.ID,
+		Type:          entity.PrepaidTransactionTypeIssue,
+		Amount:        50000,
+	}).Return(nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, []*entity.PrepaidCard{eCard}, aOrder.PrepaidCards)
+	mockPrepaidCardRepo.AssertExpectations(t)
+}
+
+func Test_Create_Success_WithPriceList(t *testing.T) {
+	ctx := context.TODO()
+	var customerID int64 = 7
+	var customerGroupID int64 = 2
+	var createOrderParam = entity.CreateOrderParam{
+		Total:      60000,
+		CustomerID: &customerID,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			}, {
+				ProductID: 2,
+				Quantity:  5,
+				Subtotal:  50000,
+			},
+		},
+	}
+	var priceListItems = []*entity.PriceListItem{
+		{ProductID: 1, MinQuantity: 5, Price: 4000},
+		{ProductID: 2, MinQuantity: 1, Price: 9000},
+		{ProductID: 2, MinQuantity: 5, Price: 8000},
+		{ProductID: 2, MinQuantity: 10, Price: 7000},
+	}
+	var pricedOrderParam = entity.CreateOrderParam{
+		Total:      50000,
+		CustomerID: &customerID,
+		Items: []*entity.CreateOrderItemParam{
+			{
+				ProductID: 1,
+				Quantity:  2,
+				Subtotal:  10000,
+			}, {
+				ProductID: 2,
+				Quantity:  5,
+				Subtotal:  40000,
+			},
+		},
+	}
+	var eOrder = &entity.Order{
+		ID:         1,
+		CustomerID: &customerID,
+		Total:      pricedOrderParam.Total,
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1), int64(2)).Return(products, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(2), 5).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, invoiced(pricedOrderParam)).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, pricedOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetCustomerByID", ctx, customerID).Return(&entity.Customer{ID: customerID, CustomerGroupID: &customerGroupID}, nil)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("GetSetting", ctx).Return(&entity.LoyaltySetting{}, nil)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockPriceListRepo.On("GetActiveItemsByGroupID", ctx, customerGroupID, mock.AnythingOfType("time.Time"), int64(1), int64(2)).
+		Return(priceListItems, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder, aOrder)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_VoidOrder_Failed_WhenOrderIsNotFromToday(t *testing.T) {
+	ctx := context.TODO()
+	var eOrder = &entity.Order{
+		ID:        1,
+		Status:    entity.OrderStatusCompleted,
+		Total:     40000,
+		CreatedAt: time.Now().AddDate(0, 0, -2),
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIDForUpdate", ctx, eOrder.ID).Return(eOrder, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	err := orderUsecase.VoidOrder(ctx, eOrder.ID)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateStatusByID", ctx, eOrder.ID, entity.OrderStatusVoided)
+}
+
+func Test_RefundOrder_Failed_WhenAlreadyRefunded(t *testing.T) {
+	ctx := context.TODO()
+	var eOrder = &entity.Order{
+		ID:        1,
+		Status:    entity.OrderStatusRefunded,
+		Total:     40000,
+		CreatedAt: time.Now(),
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIDForUpdate", ctx, eOrder.ID).Return(eOrder, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	card, err := orderUsecase.RefundOrder(ctx, eOrder.ID, entity.RefundOrderParam{})
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, card)
+}
+
+func Test_RefundOrder_Success(t *testing.T) {
+	ctx := context.TODO()
+	var customerID int64 = 7
+	var eOrder = &entity.Order{
+		ID:         1,
+		CustomerID: &customerID,
+		Status:     entity.OrderStatusCompleted,
+		Total:      40000,
+		CreatedAt:  time.Now().AddDate(0, -1, 0),
+	}
+	var orderItems = []*entity.OrderItem{
+		{ID: 1, OrderID: 1, ProductID: 1, Quantity: 2},
+		{ID: 2, OrderID: 1, ProductID: 2, Quantity: 3},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("IncrementProductByIDs", ctx, map[int64]int{1: 2, 2: 3}).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIDForUpdate", ctx, eOrder.ID).Return(eOrder, nil)
+	mockOrderRepo.On("GetOrderItemsByID", ctx, eOrder.ID).Return(orderItems, nil)
+	mockOrderRepo.On("UpdateStatusByID", ctx, eOrder.ID, entity.OrderStatusRefunded).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockLoyaltyRepo.On("GetOrderPoints", ctx, eOrder.ID).Return(&entity.LoyaltyOrderPoints{Earned: 5, Redeemed: 100}, nil)
+	mockLoyaltyRepo.On("CreateEntry", ctx, entity.CreateLoyaltyEntryParam{
+		CustomerID: customerID,
+		OrderID:    &eOrder.ID,
+		Type:       entity.LoyaltyEntryTypeClawback,
+		Points:     -5,
+	}).Return(nil)
+	mockLoyaltyRepo.On("CreateEntry", ctx, entity.CreateLoyaltyEntryParam{
+		CustomerID: customerID,
+		OrderID:    &eOrder.ID,
+		Type:       entity.LoyaltyEntryTypeRestore,
+		Points:     100,
+	}).Return(nil)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardsByOrderID", ctx, eOrder.ID).Return([]*entity.PrepaidCard{}, nil)
+	mockPrepaidCardRepo.On("GetOrderRedemptions", ctx, eOrder.ID).Return(map[int64]int{}, nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	card, err := orderUsecase.RefundOrder(ctx, eOrder.ID, entity.RefundOrderParam{})
+	assert.Nil(t, err)
+	assert.Nil(t, card)
+	mockProductRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+	mockLoyaltyRepo.AssertExpectations(t)
+	mockWebhookRepo.AssertCalled(t, "CreateEvent", ctx, mock.MatchedBy(func(param entity.CreateWebhookEventParam) bool {
+		return param.Type == entity.WebhookEventOrderRefunded
+	}))
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		return param.Action == entity.AuditActionOrderRefunded &&
+			param.EntityType == entity.AuditEntityOrder &&
+			param.EntityID == eOrder.ID &&
+			param.Changes["status"] == entity.AuditChange{Before: entity.OrderStatusCompleted, After: entity.OrderStatusRefunded}
+	}))
+	mockEventPublisher.AssertCalled(t, "Publish", ctx, mock.MatchedBy(func(event entity.Event) bool {
+		return event.Name == entity.EventOrderRefunded &&
+			event.Data.(entity.OrderReversedEvent).Order.Status == entity.OrderStatusRefunded
+	}))
+}
+
+func Test_VoidOrder_Failed_WhenWritingAuditLog(t *testing.T) {
+	ctx := context.TODO()
+	var eOrder = &entity.Order{
+		ID:        1,
+		Status:    entity.OrderStatusCompleted,
+		Total:     40000,
+		CreatedAt: time.Now(),
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("IncrementProductByIDs", ctx, map[int64]int{1: 2}).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIDForUpdate", ctx, eOrder.ID).Return(eOrder, nil)
+	mockOrderRepo.On("GetOrderItemsByID", ctx, eOrder.ID).Return([]*entity.OrderItem{{ID: 1, OrderID: 1, ProductID: 1, Quantity: 2}}, nil)
+	mockOrderRepo.On("UpdateStatusByID", ctx, eOrder.ID, entity.OrderStatusVoided).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardsByOrderID", ctx, eOrder.ID).Return([]*entity.PrepaidCard{}, nil)
+	mockPrepaidCardRepo.On("GetOrderRedemptions", ctx, eOrder.ID).Return(map[int64]int{}, nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", ctx, mock.Anything).Return(errors.New("failed to write the audit log"))
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	err := orderUsecase.VoidOrder(ctx, eOrder.ID)
+	assert.NotNil(t, err)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+	mockEventPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func Test_RefundOrder_Failed_WhenSoldGiftCardIsUsed(t *testing.T) {
+	ctx := context.TODO()
+	var eOrder = &entity.Order{
+		ID:        1,
+		Status:    entity.OrderStatusCompleted,
+		Total:     50000,
+		CreatedAt: time.Now(),
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("IncrementProductByIDs", ctx, map[int64]int{9: 1}).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIDForUpdate", ctx, eOrder.ID).Return(eOrder, nil)
+	mockOrderRepo.On("GetOrderItemsByID", ctx, eOrder.ID).Return([]*entity.OrderItem{{ID: 1, OrderID: 1, ProductID: 9, Quantity: 1}}, nil)
+	mockOrderRepo.On("UpdateStatusByID", ctx, eOrder.ID, entity.OrderStatusRefunded).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardsByOrderID", ctx, eOrder.ID).Return([]*entity.PrepaidCard{
+		{ID: 5, Number: "GIFT", Type: entity.PrepaidCardTypeGiftCard, Value: 50000, Balance: 20000},
+	}, nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	card, err := orderUsecase.RefundOrder(ctx, eOrder.ID, entity.RefundOrderParam{})
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, card)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", ctx)
+}
+
+func Test_RefundOrder_Success_AsStoreCredit(t *testing.T) {
+	defer stubRandomCodes("CREDIT0000000001")()
+
+	ctx := context.TODO()
+	var eOrder = &entity.Order{
+		ID:        1,
+		Status:    entity.OrderStatusCompleted,
+		Total:     40000,
+		CreatedAt: time.Now(),
+	}
+	var payments = []*entity.OrderPayment{
+		{ID: 1, OrderID: 1, Method: entity.PaymentMethodGiftCard, Amount: 15000, Reference: "GIFT"},
+		{ID: 2, OrderID: 1, Method: entity.PaymentMethodCash, Amount: 25000},
+	}
+	var eCard = &entity.PrepaidCard{
+		ID:      6,
+		Number:  "CREDIT0000000001",
+		Type:    entity.PrepaidCardTypeStoreCredit,
+		OrderID: &eOrder.ID,
+		Value:   25000,
+		Balance: 25000,
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("IncrementProductByIDs", ctx, map[int64]int{1: 2}).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIDForUpdate", ctx, eOrder.ID).Return(eOrder, nil)
+	mockOrderRepo.On("GetOrderItemsByID", ctx, eOrder.ID).Return([]*entity.OrderItem{{ID: 1, OrderID: 1, ProductID: 1, Quantity: 2}}, nil)
+	mockOrderRepo.On("UpdateStatusByID", ctx, eOrder.ID, entity.OrderStatusRefunded).Return(nil)
+	mockOrderRepo.On("GetPaymentsByOrderID", ctx, eOrder.ID).Return(payments, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardsByOrderID", ctx, eOrder.ID).Return([]*entity.PrepaidCard{}, nil)
+	mockPrepaidCardRepo.On("GetOrderRedemptions", ctx, eOrder.ID).Return(map[int64]int{4: 15000}, nil)
+	mockPrepaidCardRepo.On("IncrementBalanceByID", ctx, int64(4), 15000).Return(nil)
+	mockPrepaidCardRepo.On("CreateTransaction", ctx, entity.CreatePrepaidTransactionParam{
+		PrepaidCardID: 4,
+		OrderID:       &eOrder.ID,
+		Type:          entity.PrepaidTransactionTypeRefund,
+		Amount:        15000,
+	}).Return(nil)
+	mockPrepaidCardRepo.On("GetCardByNumber", ctx, eCard.Number).Return(nil, entity.ErrNotFound{})
+	mockPrepaidCardRepo.On("Create", ctx, entity.CreatePrepaidCardParam{
+		Number:  eCard.Number,
+		Type:    entity.PrepaidCardTypeStoreCredit,
+		OrderID: &eOrder.ID,
+		Value:   25000,
+	}).Return(eCard, nil)
+	mockPrepaidCardRepo.On("CreateTransaction", ctx, entity.CreatePrepaidTransactionParam{
+		PrepaidCardID: eCard.ID,
+		OrderID:       &eOrder.ID,
+		Type:          entity.PrepaidTransactionTypeIssue,
+		Amount:        25000,
+	}).Return(nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	card, err := orderUsecase.RefundOrder(ctx, eOrder.ID, entity.RefundOrderParam{StoreCredit: true})
+	assert.Nil(t, err)
+	assert.Equal(t, eCard, card)
+	mockPrepaidCardRepo.AssertExpectations(t)
+}
+
+func Test_Create_Success_WhenReplayingIdempotencyKey(t *testing.T) {
+	ctx := context.TODO()
+	createOrderParam := entity.CreateOrderParam{
+		Total:          10000,
+		IdempotencyKey: "5f1c2a4e-retry",
+		Items:          []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 2, Subtotal: 10000}},
+	}
+	eOrder := &entity.Order{
+		ID:          7,
+		Status:      entity.OrderStatusCompleted,
+		Total:       10000,
+		RequestHash: orderRequestHash(createOrderParam),
+	}
+	giftCard := &entity.PrepaidCard{ID: 1, Type: entity.PrepaidCardTypeGiftCard, OrderID: &eOrder.ID}
+	storeCredit := &entity.PrepaidCard{ID: 2, Type: entity.PrepaidCardTypeStoreCredit, OrderID: &eOrder.ID}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIdempotencyKey", ctx, createOrderParam.IdempotencyKey).Return(eOrder, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardsByOrderID", ctx, eOrder.ID).Return([]*entity.PrepaidCard{giftCard, storeCredit}, nil)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder.ID, aOrder.ID)
+	assert.Equal(t, []*entity.PrepaidCard{giftCard}, aOrder.PrepaidCards)
+	mockUnitOfWork.AssertNotCalled(t, "Begin", ctx)
+	mockProductRepo.AssertNotCalled(t, "GetProductsByIDs", mock.Anything, mock.Anything)
+}
+
+func Test_Create_Failed_WhenIdempotencyKeyIsReusedForDifferentOrder(t *testing.T) {
+	ctx := context.TODO()
+	createOrderParam := entity.CreateOrderParam{
+		Total:          10000,
+		IdempotencyKey: "5f1c2a4e-retry",
+		Items:          []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 2, Subtotal: 10000}},
+	}
+	eOrder := &entity.Order{ID: 7, Total: 15000, RequestHash: "hash-of-another-cart"}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderByIdempotencyKey", ctx, createOrderParam.IdempotencyKey).Return(eOrder, nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.IsType(t, entity.ErrItemAlreadyExists{}, err)
+	assert.Nil(t, aOrder)
+	mockUnitOfWork.AssertNotCalled(t, "Begin", ctx)
+}
+
+// stockProductRepository keeps the product stock in memory and only takes
+// stock that is available, like the conditional UPDATE of the MySQL
+// repository, so parallel orders can race on it.
+type stockProductRepository struct {
+	*mocks.ProductRepository
+	mu    sync.Mutex
+	stock map[int64]int
+}
+
+func (repo *stockProductRepository) GetProductsByIDs(ctx context.Context, IDs ...int64) ([]*entity.Product, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	products := []*entity.Product{}
+	for _, ID := range IDs {
+		products = append(products, &entity.Product{ID: ID, Name: fmt.Sprintf("prod %d", ID), Price: 5000, Stock: repo.stock[ID]})
+	}
+
+	return products, nil
+}
+
+func (repo *stockProductRepository) GetProductByID(ctx context.Context, ID int64) (*entity.Product, error) {
+	products, err := repo.GetProductsByIDs(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return products[0], nil
+}
+
+func (repo *stockProductRepository) DecrementStockByID(ctx context.Context, ID int64, quantity int) (bool, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.stock[ID] < quantity {
+		return false, nil
+	}
+
+	repo.stock[ID] -= quantity
+	return true, nil
+}
+
+func (repo *stockProductRepository) SubtractStockByID(ctx context.Context, ID int64, quantity int) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.stock[ID] -= quantity
+	return nil
+}
+
+func Test_Create_Failed_WhenParallelOrdersExceedStock(t *testing.T) {
+	ctx := context.TODO()
+	productRepo := &stockProductRepository{
+		ProductRepository: new(mocks.ProductRepository),
+		stock:             map[int64]int{1: 10},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, mock.Anything).Return(func(ctx context.Context, param entity.CreateOrderParam) *entity.Order {
+		return &entity.Order{ID: 1, Total: param.Total}
+	}, nil)
+	mockOrderRepo.On("CreatePayments", ctx, int64(1), cashPayments(5000)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, int64(1), mock.Anything).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&entity.StoreSetting{StockPolicy: entity.StockPolicyBlock}, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, productRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	start := make(chan struct{})
+	created, rejected := 0, 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			param := entity.CreateOrderParam{
+				Total: 5000,
+				Items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 1, Subtotal: 5000}},
+			}
+			_, err := orderUsecase.Create(ctx, param)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := err.(entity.ErrValidation); ok {
+				rejected++
+				return
+			}
+
+			assert.Nil(t, err)
+			created++
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 10, created)
+	assert.Equal(t, 40, rejected)
+	assert.Equal(t, 0, productRepo.stock[1])
+}
+
+func Test_Create_Failed_WhenStockPolicyWarnIsNotConfirmed(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 510000,
+		Items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 102, Subtotal: 510000}},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1)).Return(products[:1], nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&entity.StoreSetting{StockPolicy: entity.StockPolicyWarn}, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, aOrder)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Contains(t, err.(entity.ErrValidation).Errors, "allow_negative_stock")
+	assert.Contains(t, err.(entity.ErrValidation).Errors, "prod 1")
+	mockUnitOfWork.AssertNotCalled(t, "Begin", ctx)
+}
+
+func Test_Create_Success_WhenStockPolicyWarnIsConfirmed(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total:              510000,
+		AllowNegativeStock: true,
+		Items:              []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 102, Subtotal: 510000}},
+	}
+	var eOrder = &entity.Order{
+		ID:    1,
+		Total: createOrderParam.Total,
+	}
+	negativeStockSales := []*entity.CreateNegativeStockSaleParam{
+		{ProductID: 1, Quantity: 102, Shortfall: 2, StockAfter: -2},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1)).Return(products[:1], nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 102).Return(false, nil)
+	mockProductRepo.On("SubtractStockByID", ctx, int64(1), 102).Return(nil)
+	mockProductRepo.On("GetProductByID", ctx, int64(1)).Return(&entity.Product{ID: 1, Name: "prod 1", Stock: -2}, nil)
+	mockProductRepo.On("CreateNegativeStockSales", ctx, eOrder.ID, negativeStockSales).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, createOrderParam).Return(eOrder, nil)
+	mockOrderRepo.On("CreatePayments", ctx, eOrder.ID, cashPayments(eOrder.Total)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, eOrder.ID, createOrderParam.Items).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&entity.StoreSetting{StockPolicy: entity.StockPolicyWarn}, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, err)
+	assert.Equal(t, eOrder, aOrder)
+	mockProductRepo.AssertCalled(t, "CreateNegativeStockSales", ctx, eOrder.ID, negativeStockSales)
+}
+
+func Test_Create_Failed_WhenProductStockPolicyOverridesStore(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 510000,
+		Items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 102, Subtotal: 510000}},
+	}
+	product := *products[0]
+	product.StockPolicy = entity.StockPolicyBlock
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1)).Return([]*entity.Product{&product}, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&entity.StoreSetting{StockPolicy: entity.StockPolicyAllow}, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.Nil(t, aOrder)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.NotContains(t, err.(entity.ErrValidation).Errors, "allow_negative_stock")
+	mockStoreSettingRepo.AssertNotCalled(t, "GetSetting", ctx)
+}
+
+func Test_Create_Success_WhenParallelOrdersSellBeyondStock(t *testing.T) {
+	ctx := context.TODO()
+	productRepo := &stockProductRepository{
+		ProductRepository: new(mocks.ProductRepository),
+		stock:             map[int64]int{1: 10},
+	}
+	productRepo.On("CreateNegativeStockSales", ctx, int64(1), mock.Anything).Return(nil)
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(1, nil)
+	mockOrderRepo.On("Create", ctx, mock.Anything).Return(func(ctx context.Context, param entity.CreateOrderParam) *entity.Order {
+		return &entity.Order{ID: 1, Total: param.Total}
+	}, nil)
+	mockOrderRepo.On("CreatePayments", ctx, int64(1), cashPayments(5000)).Return(nil)
+	mockOrderRepo.On("CreateOrderItems", ctx, int64(1), mock.Anything).Return(nil)
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(&entity.StoreSetting{StockPolicy: entity.StockPolicyAllow}, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, productRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			param := entity.CreateOrderParam{
+				Total: 5000,
+				Items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 1, Subtotal: 5000}},
+			}
+			_, err := orderUsecase.Create(ctx, param)
+			assert.Nil(t, err)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	shortfall := 0
+	for _, call := range productRepo.Calls {
+		if call.Method != "CreateNegativeStockSales" {
+			continue
+		}
+
+		for _, sale := range call.Arguments.Get(2).([]*entity.CreateNegativeStockSaleParam) {
+			shortfall += sale.Shortfall
+		}
+	}
+
+	assert.Equal(t, -40, productRepo.stock[1])
+	assert.Equal(t, 40, shortfall)
+}
+
+func Test_Create_Failed_WhenAllocatingInvoiceNumber(t *testing.T) {
+	ctx := context.TODO()
+	var createOrderParam = entity.CreateOrderParam{
+		Total: 10000,
+		Items: []*entity.CreateOrderItemParam{{ProductID: 1, Quantity: 2, Subtotal: 10000}},
+	}
+
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1)).Return(products[:1], nil)
+	mockProductRepo.On("DecrementStockByID", ctx, int64(1), 2).Return(true, nil)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("NextInvoiceSequence", ctx, mock.AnythingOfType("string")).Return(0, errors.New("lock wait timeout"))
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLoyaltyRepo := new(mocks.LoyaltyRepository)
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(storeSetting, nil)
+	mockWebhookRepo := new(mocks.WebhookRepository)
+	mockWebhookRepo.On("CreateEvent", ctx, mock.Anything).Return(nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	orderUsecase := NewOrderUsecase(mockOrderRepo, mockProductRepo, mockVoucherRepo, mockCustomerRepo, mockLoyaltyRepo, mockPrepaidCardRepo, mockPriceListRepo, mockStoreSettingRepo, mockWebhookRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork, jakarta)
+	aOrder, err := orderUsecase.Create(ctx, createOrderParam)
+	assert.NotNil(t, err)
+	assert.Nil(t, aOrder)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+	mockOrderRepo.AssertNotCalled(t, "Create", ctx, mock.Anything)
 }
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type PrepaidCardUsecase struct {
+	prepaidCardRepository internal.PrepaidCardRepository
+}
+
+func NewPrepaidCardUsecase(prepaidCardRepository internal.PrepaidCardRepository) *PrepaidCardUsecase {
+	return &PrepaidCardUsecase{prepaidCardRepository}
+}
+
+func (pcu PrepaidCardUsecase) GetAllCards(ctx context.Context) ([]*entity.PrepaidCard, error) {
+	cards, err := pcu.prepaidCardRepository.GetAllCards(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return cards, err
+}
+
+func (pcu PrepaidCardUsecase) GetCardByID(ctx context.Context, ID int64) (*entity.PrepaidCard, error) {
+	card, err := pcu.prepaidCardRepository.GetCardByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return card, err
+}
+
+func (pcu PrepaidCardUsecase) GetCardByNumber(ctx context.Context, number string) (*entity.PrepaidCard, error) {
+	card, err := pcu.prepaidCardRepository.GetCardByNumber(ctx, number)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return card, err
+}
+
+func (pcu PrepaidCardUsecase) GetCardTransactions(ctx context.Context, cardID int64) ([]*entity.PrepaidTransaction, error) {
+	transactions, err := pcu.prepaidCardRepository.GetTransactionsByCardID(ctx, cardID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return transactions, err
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetCardByNumber_Failed_WhenNotFound(t *testing.T) {
+	ctx := context.TODO()
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetCardByNumber", ctx, "GIFT").Return(nil, entity.ErrNotFound{Message: "Prepaid card not found"})
+
+	prepaidCardUsecase := NewPrepaidCardUsecase(mockPrepaidCardRepo)
+	aCard, err := prepaidCardUsecase.GetCardByNumber(ctx, "GIFT")
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	assert.Nil(t, aCard)
+}
+
+func Test_GetCardTransactions_Success(t *testing.T) {
+	ctx := context.TODO()
+	eTransactions := []*entity.PrepaidTransaction{
+		{ID: 2, PrepaidCardID: 4, Type: entity.PrepaidTransactionTypeRedeem, Amount: -15000},
+		{ID: 1, PrepaidCardID: 4, Type: entity.PrepaidTransactionTypeIssue, Amount: 50000},
+	}
+	mockPrepaidCardRepo := new(mocks.PrepaidCardRepository)
+	mockPrepaidCardRepo.On("GetTransactionsByCardID", ctx, int64(4)).Return(eTransactions, nil)
+
+	prepaidCardUsecase := NewPrepaidCardUsecase(mockPrepaidCardRepo)
+	aTransactions, err := prepaidCardUsecase.GetCardTransactions(ctx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, eTransactions, aTransactions)
+}
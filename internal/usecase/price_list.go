@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type PriceListUsecase struct {
+	priceListRepository internal.PriceListRepository
+	productRepository   internal.ProductRepository
+	customerRepository  internal.CustomerRepository
+	location            *time.Location
+}
+
+func NewPriceListUsecase(
+	priceListRepository internal.PriceListRepository,
+	productRepository internal.ProductRepository,
+	customerRepository internal.CustomerRepository,
+	location *time.Location) *PriceListUsecase {
+	return &PriceListUsecase{priceListRepository, productRepository, customerRepository, location}
+}
+
+func (plu PriceListUsecase) GetAllPriceLists(ctx context.Context) ([]*entity.PriceList, error) {
+	priceLists, err := plu.priceListRepository.GetAllPriceLists(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return priceLists, err
+}
+
+func (plu PriceListUsecase) GetPriceListByID(ctx context.Context, ID int64) (*entity.PriceList, error) {
+	priceList, err := plu.priceListRepository.GetPriceListByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return priceList, err
+}
+
+func (plu PriceListUsecase) GetPriceListItems(ctx context.Context, priceListID int64) ([]*entity.PriceListItem, error) {
+	items, err := plu.priceListRepository.GetItemsByPriceListID(ctx, priceListID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return items, err
+}
+
+// GetCustomerPrices returns the price list items that apply to the customer
+// today, or none when the customer is not in a group.
+func (plu PriceListUsecase) GetCustomerPrices(ctx context.Context, customerID int64) ([]*entity.PriceListItem, error) {
+	customer, err := plu.customerRepository.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if customer.CustomerGroupID == nil {
+		return []*entity.PriceListItem{}, nil
+	}
+
+	items, err := plu.priceListRepository.GetActiveItemsByGroupID(ctx, *customer.CustomerGroupID, time.Now().In(plu.location))
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return items, err
+}
+
+func (plu PriceListUsecase) CreatePriceList(ctx context.Context, param entity.SavePriceListParam) (*entity.PriceList, error) {
+	_, err := plu.customerRepository.GetGroupByID(ctx, param.CustomerGroupID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return nil, entity.ErrValidation{
+			Message: "Invalid customer group",
+			Errors:  map[string]string{"CustomerGroupID": "Customer group not found"},
+		}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	createParam := entity.CreatePriceListParam{
+		CustomerGroupID: param.CustomerGroupID,
+		Name:            param.Name,
+	}
+
+	if param.StartDate != "" {
+		startsOn, err := time.ParseInLocation("2006-01-02", param.StartDate, plu.location)
+		if err != nil {
+			return nil, entity.ErrValidation{
+				Message: "Invalid price list validity",
+				Errors:  map[string]string{"StartDate": "Start date is not a valid date"},
+			}
+		}
+
+		createParam.StartsOn = &startsOn
+	}
+
+	if param.EndDate != "" {
+		endsOn, err := time.ParseInLocation("2006-01-02", param.EndDate, plu.location)
+		if err != nil || (createParam.StartsOn != nil && endsOn.Before(*createParam.StartsOn)) {
+			return nil, entity.ErrValidation{
+				Message: "Invalid price list validity",
+				Errors:  map[string]string{"EndDate": "End date must not be before the start date"},
+			}
+		}
+
+		createParam.EndsOn = &endsOn
+	}
+
+	priceList, err := plu.priceListRepository.Create(ctx, createParam)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return priceList, err
+}
+
+func (plu PriceListUsecase) DeletePriceList(ctx context.Context, ID int64) (bool, error) {
+	isDeleted, err := plu.priceListRepository.DeleteByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return isDeleted, err
+}
+
+func (plu PriceListUsecase) SavePriceListItem(ctx context.Context, priceListID int64, param entity.SavePriceListItemParam) error {
+	_, err := plu.productRepository.GetProductByID(ctx, param.ProductID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		return entity.ErrValidation{
+			Message: "Invalid price list item",
+			Errors:  map[string]string{"ProductID": "Product not found"},
+		}
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	err = plu.priceListRepository.SaveItems(ctx, priceListID, []*entity.SavePriceListItemParam{&param})
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+func (plu PriceListUsecase) DeletePriceListItem(ctx context.Context, priceListID int64, ID int64) (bool, error) {
+	isDeleted, err := plu.priceListRepository.DeleteItemByID(ctx, priceListID, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return isDeleted, err
+}
+
+// ImportPriceListItems saves the items of a CSV file with the columns
+// product_code, min_quantity and price, and an optional header row. Nothing
+// is saved when any line is invalid.
+func (plu PriceListUsecase) ImportPriceListItems(ctx context.Context, priceListID int64, file io.Reader) (int, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return 0, entity.ErrValidation{
+			Message: "Invalid price list file",
+			Errors:  map[string]string{"File": "File is not a valid CSV"},
+		}
+	}
+
+	firstLine := 1
+	if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "product_code") {
+		records = records[1:]
+		firstLine = 2
+	}
+
+	if len(records) < 1 {
+		return 0, entity.ErrValidation{
+			Message: "Invalid price list file",
+			Errors:  map[string]string{"File": "File has no price list items"},
+		}
+	}
+
+	products, err := plu.productRepository.GetAllProducts(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	productIDs := make(map[string]int64, len(products))
+	for _, product := range products {
+		productIDs[product.Code] = product.ID
+	}
+
+	ev := entity.ErrValidation{
+		Message: "Invalid price list file",
+		Errors:  map[string]string{},
+	}
+	params := make([]*entity.SavePriceListItemParam, 0, len(records))
+	for i, record := range records {
+		param, message := parsePriceListRecord(record, productIDs)
+		if message != "" {
+			ev.Errors[fmt.Sprintf("Line %d", i+firstLine)] = message
+			continue
+		}
+
+		params = append(params, param)
+	}
+
+	if len(ev.Errors) > 0 {
+		return 0, ev
+	}
+
+	if err := plu.priceListRepository.SaveItems(ctx, priceListID, params); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return len(params), nil
+}
+
+func parsePriceListRecord(record []string, productIDs map[string]int64) (*entity.SavePriceListItemParam, string) {
+	if len(record) != 3 {
+		return nil, "Expected product_code, min_quantity and price"
+	}
+
+	code := strings.TrimSpace(record[0])
+	productID, ok := productIDs[code]
+	if !ok {
+		return nil, fmt.Sprintf("Product %s does not exist", code)
+	}
+
+	minQuantity := 1
+	if value := strings.TrimSpace(record[1]); value != "" {
+		quantity, err := strconv.Atoi(value)
+		if err != nil || quantity < 1 {
+			return nil, "Minimum quantity must be a number greater than 0"
+		}
+
+		minQuantity = quantity
+	}
+
+	price, err := strconv.Atoi(strings.TrimSpace(record[2]))
+	if err != nil || price < 1 {
+		return nil, "Price must be a number greater than 0"
+	}
+
+	return &entity.SavePriceListItemParam{
+		ProductID:   productID,
+		MinQuantity: minQuantity,
+		Price:       price,
+	}, ""
+}
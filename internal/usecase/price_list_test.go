@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreatePriceList_Failed_WhenEndDateIsBeforeStartDate(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.SavePriceListParam{
+		CustomerGroupID: 2,
+		Name:            "Wholesale",
+		StartDate:       "2021-07-01",
+		EndDate:         "2021-06-30",
+	}
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockCustomerRepo.On("GetGroupByID", ctx, int64(2)).Return(&entity.CustomerGroup{ID: 2, Name: "Wholesale"}, nil)
+
+	priceListUsecase := NewPriceListUsecase(mockPriceListRepo, mockProductRepo, mockCustomerRepo, jakarta)
+	priceList, err := priceListUsecase.CreatePriceList(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, priceList)
+}
+
+func Test_ImportPriceListItems_Failed_WhenLineIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	file := strings.NewReader("product_code,min_quantity,price\nprod-1,1,4500\nprod-9,1,4000\nprod-2,0,9000\n")
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetAllProducts", ctx).Return(products, nil)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+
+	priceListUsecase := NewPriceListUsecase(mockPriceListRepo, mockProductRepo, mockCustomerRepo, jakarta)
+	count, err := priceListUsecase.ImportPriceListItems(ctx, 1, file)
+	assert.Equal(t, 0, count)
+	if assert.IsType(t, entity.ErrValidation{}, err) {
+		ev := err.(entity.ErrValidation)
+		assert.Len(t, ev.Errors, 2)
+		assert.Contains(t, ev.Errors, "Line 3")
+		assert.Contains(t, ev.Errors, "Line 4")
+	}
+	mockPriceListRepo.AssertNotCalled(t, "SaveItems")
+}
+
+func Test_ImportPriceListItems_Success(t *testing.T) {
+	ctx := context.TODO()
+	file := strings.NewReader("prod-1,,4500\nprod-2,10,8000\n")
+	params := []*entity.SavePriceListItemParam{
+		{ProductID: 1, MinQuantity: 1, Price: 4500},
+		{ProductID: 2, MinQuantity: 10, Price: 8000},
+	}
+	mockPriceListRepo := new(mocks.PriceListRepository)
+	mockPriceListRepo.On("SaveItems", ctx, int64(1), params).Return(nil)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetAllProducts", ctx).Return(products, nil)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+
+	priceListUsecase := NewPriceListUsecase(mockPriceListRepo, mockProductRepo, mockCustomerRepo, jakarta)
+	count, err := priceListUsecase.ImportPriceListItems(ctx, 1, file)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	mockPriceListRepo.AssertExpectations(t)
+}
@@ -3,17 +3,36 @@ package usecase
 import (
 	"context"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/ardafirdausr/kaseer/internal"
 	"github.com/ardafirdausr/kaseer/internal/entity"
 )
 
+const (
+	defaultProductPageLimit = 20
+	maxProductPageLimit     = 100
+)
+
 type ProductUsecase struct {
-	productRepository internal.ProductRepository
+	productRepository  internal.ProductRepository
+	auditLogRepository internal.AuditLogRepository
+	eventPublisher     internal.EventPublisher
+	UnitOfWork         internal.UnitOfWork
 }
 
-func NewProductUsecase(productRepository internal.ProductRepository) *ProductUsecase {
-	return &ProductUsecase{productRepository: productRepository}
+func NewProductUsecase(
+	productRepository internal.ProductRepository,
+	auditLogRepository internal.AuditLogRepository,
+	eventPublisher internal.EventPublisher,
+	UnitOfWork internal.UnitOfWork) *ProductUsecase {
+	return &ProductUsecase{
+		productRepository:  productRepository,
+		auditLogRepository: auditLogRepository,
+		eventPublisher:     eventPublisher,
+		UnitOfWork:         UnitOfWork,
+	}
 }
 
 func (pu ProductUsecase) GetAllProducts(ctx context.Context) ([]*entity.Product, error) {
@@ -25,6 +44,64 @@ func (pu ProductUsecase) GetAllProducts(ctx context.Context) ([]*entity.Product,
 	return products, err
 }
 
+func (pu ProductUsecase) SearchProducts(ctx context.Context, query entity.ProductQuery) (*entity.ProductPage, error) {
+	errs := map[string]string{}
+	switch strings.TrimPrefix(query.Sort, "-") {
+	case "", "code", "name", "price", "stock":
+	default:
+		errs["Sort"] = "Products can only be sorted by code, name, price or stock"
+	}
+
+	if query.Offset < 0 {
+		errs["Offset"] = "Offset must not be negative"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid product filter",
+			Errors:  errs,
+		}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultProductPageLimit
+	}
+
+	if query.Limit > maxProductPageLimit {
+		query.Limit = maxProductPageLimit
+	}
+
+	products, err := pu.productRepository.SearchProducts(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	total, err := pu.productRepository.CountProducts(ctx, query)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	page := &entity.ProductPage{
+		Products: products,
+		Total:    total,
+		Offset:   query.Offset,
+		Limit:    query.Limit,
+		HasMore:  query.Offset+len(products) < total,
+	}
+	return page, nil
+}
+
+func (pu ProductUsecase) GetProductCategories(ctx context.Context) ([]string, error) {
+	categories, err := pu.productRepository.GetProductCategories(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return categories, err
+}
+
 func (pu ProductUsecase) GetProductByID(ctx context.Context, ID int64) (*entity.Product, error) {
 	product, err := pu.productRepository.GetProductByID(ctx, ID)
 	if err != nil {
@@ -52,6 +129,8 @@ func (pu ProductUsecase) GetBestSellerProducts(ctx context.Context) ([]*entity.P
 	return productSales, err
 }
 
+// CreateProduct, UpdateProduct and DeleteProduct keep the log of the change
+// along with the change or neither of them.
 func (pu ProductUsecase) CreateProduct(ctx context.Context, param entity.CreateProductParam) (*entity.Product, error) {
 	exProduct, _ := pu.productRepository.GetProductByCode(ctx, param.Code)
 	if exProduct != nil {
@@ -61,13 +140,32 @@ func (pu ProductUsecase) CreateProduct(ctx context.Context, param entity.CreateP
 		}
 	}
 
-	product, err := pu.productRepository.Create(ctx, param)
+	txContext, err := pu.UnitOfWork.Begin(ctx)
 	if err != nil {
 		log.Println(err.Error())
 		return nil, err
 	}
 
-	return product, err
+	product, err := pu.productRepository.Create(txContext, param)
+	if err != nil {
+		log.Println(err.Error())
+		pu.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	changes := auditChanges(nil, product)
+	auditLog := newAuditLog(ctx, entity.AuditActionProductCreated, entity.AuditEntityProduct, product.ID, changes)
+	if err := pu.auditLogRepository.Create(txContext, auditLog); err != nil {
+		pu.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if err := pu.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return product, nil
 }
 
 func (pu ProductUsecase) UpdateProduct(ctx context.Context, ID int64, param entity.UpdateProductParam) (bool, error) {
@@ -79,21 +177,120 @@ func (pu ProductUsecase) UpdateProduct(ctx context.Context, ID int64, param enti
 		}
 	}
 
-	isUpdated, err := pu.productRepository.UpdateByID(ctx, ID, param)
+	txContext, err := pu.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	before, err := pu.productRepository.GetProductByIDForUpdate(txContext, ID)
+	if err != nil {
+		log.Println(err.Error())
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	isUpdated, err := pu.productRepository.UpdateByID(txContext, ID, param)
 	if err != nil {
+		log.Println(err.Error())
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if !isUpdated {
+		pu.UnitOfWork.Rollback(txContext)
+		return false, nil
+	}
+
+	after, err := pu.productRepository.GetProductByID(txContext, ID)
+	if err != nil {
+		log.Println(err.Error())
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	changes := auditChanges(before, after)
+	auditLog := newAuditLog(ctx, entity.AuditActionProductUpdated, entity.AuditEntityProduct, ID, changes)
+	if err := pu.auditLogRepository.Create(txContext, auditLog); err != nil {
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if err := pu.UnitOfWork.Commit(txContext); err != nil {
 		log.Println(err.Error())
 		return false, err
 	}
 
-	return isUpdated, err
+	pu.eventPublisher.Publish(ctx, entity.Event{
+		Name: entity.EventProductUpdated,
+		Data: entity.ProductUpdatedEvent{Before: before, After: after},
+	})
+	stockChanges := map[int64]int{ID: after.Stock - before.Stock}
+	publishStockChanges(ctx, pu.eventPublisher, stockChanges, entity.StockChangeAdjustment, nil)
+	return true, nil
 }
 
 func (pu ProductUsecase) DeleteProduct(ctx context.Context, ID int64) (bool, error) {
-	isUpdated, err := pu.productRepository.DeleteByID(ctx, ID)
+	txContext, err := pu.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	before, err := pu.productRepository.GetProductByIDForUpdate(txContext, ID)
+	if _, ok := err.(entity.ErrNotFound); ok {
+		pu.UnitOfWork.Rollback(txContext)
+		return false, nil
+	}
+
+	if err != nil {
+		log.Println(err.Error())
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	isDeleted, err := pu.productRepository.DeleteByID(txContext, ID)
+	if err != nil {
+		log.Println(err.Error())
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if !isDeleted {
+		pu.UnitOfWork.Rollback(txContext)
+		return false, nil
+	}
+
+	changes := auditChanges(before, nil)
+	auditLog := newAuditLog(ctx, entity.AuditActionProductDeleted, entity.AuditEntityProduct, ID, changes)
+	if err := pu.auditLogRepository.Create(txContext, auditLog); err != nil {
+		pu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if err := pu.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (pu ProductUsecase) GetNegativeStockSales(ctx context.Context) ([]*entity.NegativeStockSale, error) {
+	sales, err := pu.productRepository.GetNegativeStockSales(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return sales, err
+}
+
+func (pu ProductUsecase) ReconcileNegativeStockSale(ctx context.Context, ID int64) (bool, error) {
+	isReconciled, err := pu.productRepository.ReconcileNegativeStockSaleByID(ctx, ID, time.Now())
 	if err != nil {
 		log.Println(err.Error())
 		return false, err
 	}
 
-	return isUpdated, err
+	return isReconciled, nil
 }
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+const (
+	defaultAnalyticsDays  = 30
+	defaultDeadStockDays  = 30
+	defaultAnalyticsLimit = 50
+	maxAnalyticsLimit     = 500
+)
+
+// The cumulative revenue shares that close the A and B classes.
+const (
+	abcClassAThreshold = 0.80
+	abcClassBThreshold = 0.95
+)
+
+type ProductAnalyticsUsecase struct {
+	productRepository internal.ProductRepository
+	location          *time.Location
+}
+
+func NewProductAnalyticsUsecase(productRepository internal.ProductRepository, location *time.Location) *ProductAnalyticsUsecase {
+	return &ProductAnalyticsUsecase{productRepository, location}
+}
+
+func (pau ProductAnalyticsUsecase) GetProductAnalytics(ctx context.Context, param entity.ProductAnalyticsParam) (*entity.ProductAnalytics, error) {
+	today := time.Now().In(pau.location)
+	if param.StartDate == "" && param.EndDate == "" {
+		param.StartDate = today.AddDate(0, 0, 1-defaultAnalyticsDays).Format("2006-01-02")
+		param.EndDate = today.Format("2006-01-02")
+	}
+
+	errs := map[string]string{}
+	start, end := reportRange(param.StartDate, param.EndDate, pau.location, errs)
+	if param.RankBy == "" {
+		param.RankBy = entity.ProductRankRevenue
+	}
+
+	switch param.RankBy {
+	case entity.ProductRankQuantity, entity.ProductRankRevenue, entity.ProductRankMargin:
+	default:
+		errs["RankBy"] = "Products can be ranked by quantity, revenue or margin"
+	}
+
+	if param.DeadStockDays == 0 {
+		param.DeadStockDays = defaultDeadStockDays
+	}
+
+	if param.DeadStockDays < 1 || param.DeadStockDays > 365 {
+		errs["DeadStockDays"] = "Dead stock days must be between 1 and 365"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid analytics filter",
+			Errors:  errs,
+		}
+	}
+
+	if param.Limit <= 0 {
+		param.Limit = defaultAnalyticsLimit
+	}
+
+	if param.Limit > maxAnalyticsLimit {
+		param.Limit = maxAnalyticsLimit
+	}
+
+	performances, err := pau.productRepository.GetProductPerformance(ctx, start, end)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	year, month, day := today.Date()
+	since := time.Date(year, month, day-param.DeadStockDays, 0, 0, 0, 0, pau.location)
+	deadStock, err := pau.productRepository.GetDeadStock(ctx, since)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// days rather than hours, a DST change makes one day 23 or 25 hours long
+	days := int(math.Round(end.Sub(start).Hours() / 24))
+	analytics := &entity.ProductAnalytics{
+		StartDate:     param.StartDate,
+		EndDate:       param.EndDate,
+		Timezone:      pau.location.String(),
+		Days:          days,
+		RankBy:        param.RankBy,
+		DeadStockDays: param.DeadStockDays,
+	}
+	for _, performance := range performances {
+		performance.Margin = performance.Revenue - performance.Quantity*performance.Cost
+		performance.AverageDailySales = float64(performance.Quantity) / float64(days)
+		if performance.AverageDailySales > 0 {
+			stock := performance.Stock
+			if stock < 0 {
+				stock = 0
+			}
+
+			daysOfCover := float64(stock) / performance.AverageDailySales
+			performance.DaysOfCover = &daysOfCover
+		}
+
+		analytics.Revenue += performance.Revenue
+		analytics.Margin += performance.Margin
+	}
+
+	analytics.Classes = classifyProducts(performances, analytics.Revenue)
+	rankProducts(performances, param.RankBy)
+	if len(performances) > param.Limit {
+		performances = performances[:param.Limit]
+	}
+	analytics.Products = performances
+
+	if len(deadStock) > param.Limit {
+		deadStock = deadStock[:param.Limit]
+	}
+	analytics.DeadStock = deadStock
+
+	return analytics, nil
+}
+
+// classifyProducts ranks the products by revenue and gives each its ABC
+// class from the share of the revenue of the products ranked above it, so
+// the product that crosses a threshold still belongs to the higher class.
+// Products that brought in nothing are always C.
+func classifyProducts(performances []*entity.ProductPerformance, revenue int) []*entity.ABCClassSummary {
+	rankProducts(performances, entity.ProductRankRevenue)
+	classes := []*entity.ABCClassSummary{
+		{Class: entity.ABCClassA},
+		{Class: entity.ABCClassB},
+		{Class: entity.ABCClassC},
+	}
+
+	cumulative := 0
+	for _, performance := range performances {
+		class := classes[2]
+		if revenue > 0 {
+			share := float64(cumulative) / float64(revenue)
+			performance.RevenueShare = float64(performance.Revenue) / float64(revenue)
+			switch {
+			case performance.Revenue <= 0:
+			case share < abcClassAThreshold:
+				class = classes[0]
+			case share < abcClassBThreshold:
+				class = classes[1]
+			}
+
+			cumulative += performance.Revenue
+			performance.CumulativeShare = float64(cumulative) / float64(revenue)
+		}
+
+		performance.Class = class.Class
+		class.Products++
+		class.Revenue += performance.Revenue
+	}
+
+	for _, class := range classes {
+		if revenue > 0 {
+			class.RevenueShare = float64(class.Revenue) / float64(revenue)
+		}
+	}
+
+	return classes
+}
+
+// rankProducts sorts the products best first by the given figure, ties go
+// to the higher revenue and then to the older product.
+func rankProducts(performances []*entity.ProductPerformance, rankBy string) {
+	figure := func(performance *entity.ProductPerformance) int {
+		switch rankBy {
+		case entity.ProductRankQuantity:
+			return performance.Quantity
+		case entity.ProductRankMargin:
+			return performance.Margin
+		default:
+			return performance.Revenue
+		}
+	}
+
+	sort.SliceStable(performances, func(i, j int) bool {
+		a, b := performances[i], performances[j]
+		if figure(a) != figure(b) {
+			return figure(a) > figure(b)
+		}
+
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+
+		return a.ProductID < b.ProductID
+	})
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetProductAnalytics_Failed_WhenParamIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	param := entity.ProductAnalyticsParam{
+		StartDate:     "2026-03-10",
+		EndDate:       "2026-03-01",
+		RankBy:        "profit",
+		DeadStockDays: 400,
+	}
+
+	productAnalyticsUsecase := NewProductAnalyticsUsecase(mockProductRepo, jakarta)
+	analytics, err := productAnalyticsUsecase.GetProductAnalytics(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Len(t, err.(entity.ErrValidation).Errors, 3)
+	assert.Nil(t, analytics)
+	mockProductRepo.AssertNotCalled(t, "GetProductPerformance", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_GetProductAnalytics_Failed_WhenGettingPerformance(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductPerformance", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("failed get performance"))
+
+	productAnalyticsUsecase := NewProductAnalyticsUsecase(mockProductRepo, jakarta)
+	analytics, err := productAnalyticsUsecase.GetProductAnalytics(ctx, entity.ProductAnalyticsParam{})
+	assert.NotNil(t, err)
+	assert.Nil(t, analytics)
+}
+
+func Test_GetProductAnalytics_Failed_WhenGettingDeadStock(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductPerformance", ctx, mock.Anything, mock.Anything).Return([]*entity.ProductPerformance{}, nil)
+	mockProductRepo.On("GetDeadStock", ctx, mock.Anything).Return(nil, errors.New("failed get dead stock"))
+
+	productAnalyticsUsecase := NewProductAnalyticsUsecase(mockProductRepo, jakarta)
+	analytics, err := productAnalyticsUsecase.GetProductAnalytics(ctx, entity.ProductAnalyticsParam{})
+	assert.NotNil(t, err)
+	assert.Nil(t, analytics)
+}
+
+func Test_GetProductAnalytics_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	performances := []*entity.ProductPerformance{
+		{ProductID: 1, Code: "P1", Stock: 10, Cost: 1000, Quantity: 100, Revenue: 150000},
+		{ProductID: 2, Code: "P2", Stock: 50, Cost: 9000, Quantity: 10, Revenue: 100000},
+		{ProductID: 3, Code: "P3", Stock: 5, Cost: 4000, Quantity: 5, Revenue: 40000},
+		{ProductID: 4, Code: "P4", Stock: 8, Cost: 1000, Quantity: 2, Revenue: 10000},
+		{ProductID: 5, Code: "P5", Stock: 20, Cost: 1000},
+	}
+	deadStock := []*entity.DeadStockProduct{{ProductID: 5, Code: "P5", Stock: 20}}
+	mockProductRepo.On("GetProductPerformance", ctx, mock.Anything, mock.Anything).Return(performances, nil)
+	mockProductRepo.On("GetDeadStock", ctx, mock.Anything).Return(deadStock, nil)
+	param := entity.ProductAnalyticsParam{
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-10",
+		RankBy:    entity.ProductRankQuantity,
+	}
+
+	productAnalyticsUsecase := NewProductAnalyticsUsecase(mockProductRepo, jakarta)
+	analytics, err := productAnalyticsUsecase.GetProductAnalytics(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, analytics.Days)
+	assert.Equal(t, 300000, analytics.Revenue)
+
+	// ranked by quantity
+	assert.Equal(t, "P1", analytics.Products[0].Code)
+	assert.Equal(t, "P2", analytics.Products[1].Code)
+	assert.Equal(t, "P5", analytics.Products[4].Code)
+
+	// P1 and P2 bring in the first 80%, P3 crosses 95% and P4 is past it
+	assert.Equal(t, entity.ABCClassA, analytics.Products[0].Class)
+	assert.Equal(t, entity.ABCClassA, analytics.Products[1].Class)
+	assert.Equal(t, entity.ABCClassB, analytics.Products[2].Class)
+	assert.Equal(t, entity.ABCClassC, analytics.Products[3].Class)
+	assert.Equal(t, entity.ABCClassC, analytics.Products[4].Class)
+	assert.Equal(t, 2, analytics.Classes[0].Products)
+	assert.Equal(t, 2, analytics.Classes[2].Products)
+
+	// 100 sold over 10 days covers the 10 left for one day
+	assert.Equal(t, 50000, analytics.Products[0].Margin)
+	assert.Equal(t, 10.0, analytics.Products[0].AverageDailySales)
+	assert.Equal(t, 1.0, *analytics.Products[0].DaysOfCover)
+	assert.Nil(t, analytics.Products[4].DaysOfCover)
+	assert.Equal(t, deadStock, analytics.DeadStock)
+}
+
+func Test_GetProductAnalytics_Success_RankByMarginWithLimit(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	performances := []*entity.ProductPerformance{
+		{ProductID: 1, Cost: 1400, Quantity: 100, Revenue: 150000},
+		{ProductID: 2, Cost: 2000, Quantity: 10, Revenue: 100000},
+	}
+	mockProductRepo.On("GetProductPerformance", ctx, mock.Anything, mock.Anything).Return(performances, nil)
+	mockProductRepo.On("GetDeadStock", ctx, mock.Anything).Return([]*entity.DeadStockProduct{}, nil)
+	param := entity.ProductAnalyticsParam{RankBy: entity.ProductRankMargin, Limit: 1}
+
+	productAnalyticsUsecase := NewProductAnalyticsUsecase(mockProductRepo, jakarta)
+	analytics, err := productAnalyticsUsecase.GetProductAnalytics(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, analytics.Days)
+	assert.Len(t, analytics.Products, 1)
+	assert.Equal(t, int64(2), analytics.Products[0].ProductID)
+	assert.Equal(t, 80000, analytics.Products[0].Margin)
+}
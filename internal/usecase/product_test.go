@@ -8,6 +8,7 @@ import (
 	"github.com/ardafirdausr/kaseer/internal/entity"
 	"github.com/ardafirdausr/kaseer/internal/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var products = []*entity.Product{
@@ -44,8 +45,11 @@ func Test_GetAllProducts_Failed(t *testing.T) {
 	ctx := context.TODO()
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetAllProducts", ctx).Return(nil, errors.New("failed get products"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetAllProducts(ctx)
 	assert.NotNil(t, err)
 	assert.Nil(t, aProducts)
@@ -55,20 +59,74 @@ func Test_GetAllProducts_Success(t *testing.T) {
 	ctx := context.TODO()
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetAllProducts", ctx).Return(products, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetAllProducts(ctx)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(t, aProducts)
 }
 
+func Test_SearchProducts_Failed_WhenSortIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	aPage, err := productUsecase.SearchProducts(ctx, entity.ProductQuery{Sort: "-created_at"})
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, aPage)
+	mockProductRepo.AssertNotCalled(t, "SearchProducts", mock.Anything, mock.Anything)
+}
+
+func Test_SearchProducts_Failed_WhenCounting(t *testing.T) {
+	ctx := context.TODO()
+	query := entity.ProductQuery{Keyword: "prod", Limit: defaultProductPageLimit}
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("SearchProducts", ctx, query).Return(products, nil)
+	mockProductRepo.On("CountProducts", ctx, query).Return(0, errors.New("failed count products"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	aPage, err := productUsecase.SearchProducts(ctx, entity.ProductQuery{Keyword: "prod"})
+	assert.NotNil(t, err)
+	assert.Nil(t, aPage)
+}
+
+func Test_SearchProducts_Success(t *testing.T) {
+	ctx := context.TODO()
+	query := entity.ProductQuery{Sort: "-stock", Offset: 100, Limit: maxProductPageLimit}
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("SearchProducts", ctx, query).Return(products, nil)
+	mockProductRepo.On("CountProducts", ctx, query).Return(102, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	aPage, err := productUsecase.SearchProducts(ctx, entity.ProductQuery{Sort: "-stock", Offset: 100, Limit: 1000})
+	assert.Nil(t, err)
+	assert.Equal(t, products, aPage.Products)
+	assert.Equal(t, 102, aPage.Total)
+	assert.False(t, aPage.HasMore)
+}
+
 func Test_GetProductByID_Failed(t *testing.T) {
 	ctx := context.TODO()
 	expectedProduct := products[0]
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByID", ctx, expectedProduct.ID).Return(nil, errors.New("failed get product by id"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetProductByID(ctx, expectedProduct.ID)
 	assert.NotNil(t, err)
 	assert.Nil(t, aProducts)
@@ -79,8 +137,11 @@ func Test_GetProductByID_Success(t *testing.T) {
 	expectedProduct := products[0]
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByID", ctx, expectedProduct.ID).Return(expectedProduct, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetProductByID(ctx, expectedProduct.ID)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(expectedProduct, aProducts)
@@ -91,8 +152,11 @@ func Test_GetProductByCode_Failed(t *testing.T) {
 	expectedProduct := products[0]
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, expectedProduct.Code).Return(nil, errors.New("failed get product by code"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetProductByCode(ctx, expectedProduct.Code)
 	assert.NotNil(t, err)
 	assert.Nil(t, aProducts)
@@ -103,8 +167,11 @@ func Test_GetProductByCode_Success(t *testing.T) {
 	expectedProduct := products[0]
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, expectedProduct.Code).Return(expectedProduct, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetProductByCode(ctx, expectedProduct.Code)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(expectedProduct, aProducts)
@@ -114,8 +181,11 @@ func Test_GetBestSellerProducts_Failed(t *testing.T) {
 	ctx := context.TODO()
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetBestSellerProducts", ctx).Return(nil, errors.New("failed get product sales"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetBestSellerProducts(ctx)
 	assert.NotNil(t, err)
 	assert.Nil(t, aProducts)
@@ -125,8 +195,11 @@ func Test_GetBestSellerProducts_Success(t *testing.T) {
 	ctx := context.TODO()
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetBestSellerProducts", ctx).Return(productSales, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.GetBestSellerProducts(ctx)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(productSales, aProducts)
@@ -144,8 +217,11 @@ func Test_CreateProduct_Failed_WhenProductCodeAlreadyExists(t *testing.T) {
 
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, existProduct.Code).Return(existProduct, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.CreateProduct(ctx, createParam)
 	assert.Nil(t, aProducts)
 	assert.NotNil(t, err)
@@ -164,11 +240,44 @@ func Test_CreateProduct_Failed_WhenCreatingProduct(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, createParam.Code).Return(nil, nil)
 	mockProductRepo.On("Create", ctx, createParam).Return(nil, errors.New("failed create product"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProducts, err := productUsecase.CreateProduct(ctx, createParam)
 	assert.Nil(t, aProducts)
 	assert.NotNil(t, err)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+}
+
+func Test_CreateProduct_Failed_WhenWritingAuditLog(t *testing.T) {
+	ctx := context.TODO()
+	createParam := entity.CreateProductParam{
+		Code:  "new-product",
+		Name:  "New Product",
+		Price: 10000,
+		Stock: 50,
+	}
+
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductByCode", ctx, createParam.Code).Return(nil, nil)
+	mockProductRepo.On("Create", ctx, createParam).Return(&entity.Product{ID: 99, Code: createParam.Code}, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", ctx, mock.Anything).Return(errors.New("failed create audit log"))
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	aProduct, err := productUsecase.CreateProduct(ctx, createParam)
+	assert.Nil(t, aProduct)
+	assert.NotNil(t, err)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
 }
 
 func Test_CreateProduct_Success(t *testing.T) {
@@ -190,11 +299,23 @@ func Test_CreateProduct_Success(t *testing.T) {
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, createParam.Code).Return(nil, nil)
 	mockProductRepo.On("Create", ctx, createParam).Return(eProduct, nil)
-
-	productUsecase := NewProductUsecase(mockProductRepo)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	aProduct, err := productUsecase.CreateProduct(ctx, createParam)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(eProduct, aProduct)
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		return param.Action == entity.AuditActionProductCreated &&
+			param.EntityID == eProduct.ID &&
+			param.Changes["code"] == entity.AuditChange{Before: nil, After: eProduct.Code}
+	}))
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
 }
 
 func Test_UpdateProduct_Failed_WhenProductCodeAlreadyExists(t *testing.T) {
@@ -209,8 +330,12 @@ func Test_UpdateProduct_Failed_WhenProductCodeAlreadyExists(t *testing.T) {
 
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, updateParam.Code).Return(products[0], nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := productUsecase.UpdateProduct(ctx, productID, updateParam)
 	assert.False(t, isUpdated)
 	assert.NotNil(t, err)
@@ -229,12 +354,52 @@ func Test_UpdateProduct_Failed_WhenUpdatingProduct(t *testing.T) {
 
 	mockProductRepo := new(mocks.ProductRepository)
 	mockProductRepo.On("GetProductByCode", ctx, updateParam.Code).Return(nil, nil)
+	mockProductRepo.On("GetProductByIDForUpdate", ctx, productID).Return(products[0], nil)
 	mockProductRepo.On("UpdateByID", ctx, productID, updateParam).Return(false, errors.New("failed update product"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	isUpdated, err := productUsecase.UpdateProduct(ctx, productID, updateParam)
+	assert.NotNil(t, err)
+	assert.False(t, isUpdated)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+}
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+func Test_UpdateProduct_Failed_WhenWritingAuditLog(t *testing.T) {
+	ctx := context.TODO()
+	var productID int64 = 1
+	updateParam := entity.UpdateProductParam{
+		Code:  "updated-product",
+		Name:  "Updated Product",
+		Price: 15000,
+		Stock: 89,
+	}
+
+	mockProductRepo := new(mocks.ProductRepository)
+	updatedProduct := &entity.Product{ID: productID, Code: updateParam.Code, Name: updateParam.Name, Price: 15000, Stock: 89}
+	mockProductRepo.On("GetProductByCode", ctx, updateParam.Code).Return(nil, nil)
+	mockProductRepo.On("GetProductByIDForUpdate", ctx, productID).Return(products[0], nil)
+	mockProductRepo.On("UpdateByID", ctx, productID, updateParam).Return(true, nil)
+	mockProductRepo.On("GetProductByID", ctx, productID).Return(updatedProduct, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", ctx, mock.Anything).Return(errors.New("failed create audit log"))
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := productUsecase.UpdateProduct(ctx, productID, updateParam)
 	assert.NotNil(t, err)
 	assert.False(t, isUpdated)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
+	mockEventPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
 }
 
 func Test_UpdateProduct_Success(t *testing.T) {
@@ -248,33 +413,110 @@ func Test_UpdateProduct_Success(t *testing.T) {
 	}
 
 	mockProductRepo := new(mocks.ProductRepository)
+	updatedProduct := &entity.Product{ID: productID, Code: updateParam.Code, Name: updateParam.Name, Price: 15000, Stock: 89}
 	mockProductRepo.On("GetProductByCode", ctx, updateParam.Code).Return(nil, nil)
+	mockProductRepo.On("GetProductByIDForUpdate", ctx, productID).Return(products[0], nil)
 	mockProductRepo.On("UpdateByID", ctx, productID, updateParam).Return(true, nil)
-
-	productUsecase := NewProductUsecase(mockProductRepo)
+	mockProductRepo.On("GetProductByID", ctx, productID).Return(updatedProduct, nil)
+	events := []entity.Event{}
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { events = append(events, args.Get(1).(entity.Event)) }).
+		Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := productUsecase.UpdateProduct(ctx, productID, updateParam)
 	assert.Nil(t, err)
 	assert.True(t, isUpdated)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, entity.EventProductUpdated, events[0].Name)
+		assert.Equal(t, entity.ProductUpdatedEvent{Before: products[0], After: updatedProduct}, events[0].Data)
+		assert.Equal(t, entity.EventStockChanged, events[1].Name)
+		assert.Equal(t, entity.StockChangedEvent{ProductID: productID, Change: -11, Reason: entity.StockChangeAdjustment}, events[1].Data)
+	}
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		return param.Action == entity.AuditActionProductUpdated &&
+			param.Changes["name"] == entity.AuditChange{Before: products[0].Name, After: updatedProduct.Name}
+	}))
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
 }
 
 func Test_DeleteProduct_Failed(t *testing.T) {
 	ctx := context.TODO()
 	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductByIDForUpdate", ctx, products[0].ID).Return(products[0], nil)
 	mockProductRepo.On("DeleteByID", ctx, products[0].ID).Return(false, errors.New("failed to delete product"))
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 
-	productUsecase := NewProductUsecase(mockProductRepo)
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	isDeleted, err := productUsecase.DeleteProduct(ctx, products[0].ID)
 	assert.NotNil(t, err)
 	assert.False(t, isDeleted)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
 }
 
 func Test_DeleteProduct_Success(t *testing.T) {
 	ctx := context.TODO()
 	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductByIDForUpdate", ctx, products[0].ID).Return(products[0], nil)
 	mockProductRepo.On("DeleteByID", ctx, products[0].ID).Return(true, nil)
-
-	productUsecase := NewProductUsecase(mockProductRepo)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
 	isDeleted, err := productUsecase.DeleteProduct(ctx, products[0].ID)
 	assert.Nil(t, err)
 	assert.True(t, isDeleted)
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		return param.Action == entity.AuditActionProductDeleted &&
+			param.EntityID == products[0].ID &&
+			param.Changes["name"].Before == products[0].Name &&
+			param.Changes["name"].After == nil
+	}))
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
+}
+
+func Test_DeleteProduct_Success_WhenNotFound(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("GetProductByIDForUpdate", ctx, products[0].ID).Return(nil, entity.ErrNotFound{Message: "Product not found"})
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	isDeleted, err := productUsecase.DeleteProduct(ctx, products[0].ID)
+	assert.Nil(t, err)
+	assert.False(t, isDeleted)
+	mockProductRepo.AssertNotCalled(t, "DeleteByID", mock.Anything, mock.Anything)
+	mockAuditLogRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func Test_ReconcileNegativeStockSale_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockProductRepo := new(mocks.ProductRepository)
+	mockProductRepo.On("ReconcileNegativeStockSaleByID", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(true, nil)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	productUsecase := NewProductUsecase(mockProductRepo, mockAuditLogRepo, mockEventPublisher, mockUnitOfWork)
+	isReconciled, err := productUsecase.ReconcileNegativeStockSale(ctx, 1)
+	assert.Nil(t, err)
+	assert.True(t, isReconciled)
 }
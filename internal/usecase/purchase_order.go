@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type PurchaseOrderUsecase struct {
+	purchaseOrderRepository internal.PurchaseOrderRepository
+	productRepository       internal.ProductRepository
+	eventPublisher          internal.EventPublisher
+	UnitOfWork              internal.UnitOfWork
+}
+
+func NewPurchaseOrderUsecase(
+	purchaseOrderRepository internal.PurchaseOrderRepository,
+	productRepository internal.ProductRepository,
+	eventPublisher internal.EventPublisher,
+	UnitOfWork internal.UnitOfWork) *PurchaseOrderUsecase {
+	return &PurchaseOrderUsecase{purchaseOrderRepository, productRepository, eventPublisher, UnitOfWork}
+}
+
+func (pou PurchaseOrderUsecase) GetPurchaseOrders(ctx context.Context) ([]*entity.PurchaseOrder, error) {
+	purchaseOrders, err := pou.purchaseOrderRepository.GetPurchaseOrders(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return purchaseOrders, err
+}
+
+func (pou PurchaseOrderUsecase) GetPurchaseOrder(ctx context.Context, ID int64) (*entity.PurchaseOrder, error) {
+	purchaseOrder, err := pou.purchaseOrderRepository.GetPurchaseOrderByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseOrder.Items, err = pou.purchaseOrderRepository.GetItemsByPurchaseOrderID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return purchaseOrder, nil
+}
+
+// CreatePurchaseOrder drafts a purchase order of the lines with a quantity,
+// costed at the current cost of their products.
+func (pou PurchaseOrderUsecase) CreatePurchaseOrder(ctx context.Context, param entity.SavePurchaseOrderParam) (*entity.PurchaseOrder, error) {
+	if len(param.ProductIDs) != len(param.Quantities) {
+		return nil, entity.ErrValidation{
+			Message: "Invalid purchase order",
+			Errors:  map[string]string{"Quantities": "Every product must have a quantity"},
+		}
+	}
+
+	quantities := map[int64]int{}
+	productIDs := []int64{}
+	for i, productID := range param.ProductIDs {
+		quantity := param.Quantities[i]
+		if quantity < 0 {
+			return nil, entity.ErrValidation{
+				Message: "Invalid purchase order",
+				Errors:  map[string]string{"Quantities": "Quantities must not be negative"},
+			}
+		}
+
+		if quantity == 0 {
+			continue
+		}
+
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+		quantities[productID] += quantity
+	}
+
+	if len(productIDs) < 1 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid purchase order",
+			Errors:  map[string]string{"Quantities": "Purchase order has no products to order"},
+		}
+	}
+
+	products, err := pou.productRepository.GetProductsByIDs(ctx, productIDs...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	costs := map[int64]int{}
+	for _, product := range products {
+		if product.IsGiftCard {
+			return nil, entity.ErrValidation{
+				Message: "Invalid purchase order",
+				Errors:  map[string]string{"ProductIDs": fmt.Sprintf("%s is a gift card and is not bought from suppliers", product.Code)},
+			}
+		}
+
+		costs[product.ID] = product.Cost
+	}
+
+	createParam := entity.CreatePurchaseOrderParam{
+		Supplier: param.Supplier,
+		Notes:    param.Notes,
+	}
+	for _, productID := range productIDs {
+		cost, ok := costs[productID]
+		if !ok {
+			return nil, entity.ErrValidation{
+				Message: "Invalid purchase order",
+				Errors:  map[string]string{"ProductIDs": fmt.Sprintf("Product %d not found", productID)},
+			}
+		}
+
+		createParam.Items = append(createParam.Items, &entity.CreatePurchaseOrderItemParam{
+			ProductID: productID,
+			Quantity:  quantities[productID],
+			Cost:      cost,
+		})
+	}
+
+	txContext, err := pou.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	purchaseOrder, err := pou.purchaseOrderRepository.Create(txContext, createParam)
+	if err != nil {
+		pou.UnitOfWork.Rollback(txContext)
+		return nil, err
+	}
+
+	if err := pou.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return purchaseOrder, nil
+}
+
+// UpdatePurchaseOrderStatus moves the purchase order on, receiving it puts
+// its quantities into stock in the same transaction.
+func (pou PurchaseOrderUsecase) UpdatePurchaseOrderStatus(ctx context.Context, ID int64, param entity.UpdatePurchaseOrderStatusParam) error {
+	purchaseOrder, err := pou.purchaseOrderRepository.GetPurchaseOrderByID(ctx, ID)
+	if err != nil {
+		return err
+	}
+
+	if !purchaseOrder.CanBecome(param.Status) {
+		return entity.ErrValidation{
+			Message: "Invalid purchase order status",
+			Errors:  map[string]string{"Status": fmt.Sprintf("A %s purchase order cannot become %s", purchaseOrder.Status, param.Status)},
+		}
+	}
+
+	txContext, err := pou.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	isUpdated, err := pou.purchaseOrderRepository.UpdateStatusByID(txContext, ID, purchaseOrder.Status, param.Status)
+	if err != nil {
+		pou.UnitOfWork.Rollback(txContext)
+		return err
+	}
+
+	if !isUpdated {
+		pou.UnitOfWork.Rollback(txContext)
+		return entity.ErrValidation{
+			Message: "Invalid purchase order status",
+			Errors:  map[string]string{"Status": "Purchase order has just been changed by someone else"},
+		}
+	}
+
+	received := map[int64]int{}
+	if param.Status == entity.PurchaseOrderStatusReceived {
+		items, err := pou.purchaseOrderRepository.GetItemsByPurchaseOrderID(txContext, ID)
+		if err != nil {
+			pou.UnitOfWork.Rollback(txContext)
+			return err
+		}
+
+		for _, item := range items {
+			received[item.ProductID] += item.Quantity
+		}
+
+		if len(received) > 0 {
+			if err := pou.productRepository.IncrementProductByIDs(txContext, received); err != nil {
+				pou.UnitOfWork.Rollback(txContext)
+				return err
+			}
+		}
+	}
+
+	if err := pou.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	publishStockChanges(ctx, pou.eventPublisher, received, entity.StockChangePurchaseOrder, &purchaseOrder.ID)
+	return nil
+}
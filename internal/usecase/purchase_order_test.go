@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CreatePurchaseOrder_Failed_WhenNoQuantity(t *testing.T) {
+	ctx := context.TODO()
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	param := entity.SavePurchaseOrderParam{
+		ProductIDs: []int64{1, 2},
+		Quantities: []int{0, 0},
+	}
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	purchaseOrderUsecase := NewPurchaseOrderUsecase(mockPurchaseOrderRepo, mockProductRepo, mockEventPublisher, mockUnitOfWork)
+	purchaseOrder, err := purchaseOrderUsecase.CreatePurchaseOrder(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, purchaseOrder)
+	mockProductRepo.AssertNotCalled(t, "GetProductsByIDs", mock.Anything, mock.Anything)
+}
+
+func Test_CreatePurchaseOrder_Failed_WhenProductIsGiftCard(t *testing.T) {
+	ctx := context.TODO()
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	products := []*entity.Product{{ID: 1, Code: "GC-100", IsGiftCard: true}}
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1)).Return(products, nil)
+	param := entity.SavePurchaseOrderParam{
+		ProductIDs: []int64{1},
+		Quantities: []int{3},
+	}
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	purchaseOrderUsecase := NewPurchaseOrderUsecase(mockPurchaseOrderRepo, mockProductRepo, mockEventPublisher, mockUnitOfWork)
+	purchaseOrder, err := purchaseOrderUsecase.CreatePurchaseOrder(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, purchaseOrder)
+	mockUnitOfWork.AssertNotCalled(t, "Begin", mock.Anything)
+}
+
+func Test_CreatePurchaseOrder_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	products := []*entity.Product{{ID: 1, Cost: 1000}, {ID: 2, Cost: 2500}}
+	mockProductRepo.On("GetProductsByIDs", ctx, int64(1), int64(2)).Return(products, nil)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	createParam := entity.CreatePurchaseOrderParam{
+		Supplier: "PT Sumber",
+		Items: []*entity.CreatePurchaseOrderItemParam{
+			{ProductID: 1, Quantity: 15, Cost: 1000},
+			{ProductID: 2, Quantity: 4, Cost: 2500},
+		},
+	}
+	ePurchaseOrder := &entity.PurchaseOrder{ID: 1, Supplier: "PT Sumber", Status: entity.PurchaseOrderStatusDraft, Total: 25000}
+	mockPurchaseOrderRepo.On("Create", ctx, createParam).Return(ePurchaseOrder, nil)
+	param := entity.SavePurchaseOrderParam{
+		Supplier:   "PT Sumber",
+		ProductIDs: []int64{1, 3, 2, 1},
+		Quantities: []int{10, 0, 4, 5},
+	}
+	mockEventPublisher := new(mocks.EventPublisher)
+
+	purchaseOrderUsecase := NewPurchaseOrderUsecase(mockPurchaseOrderRepo, mockProductRepo, mockEventPublisher, mockUnitOfWork)
+	aPurchaseOrder, err := purchaseOrderUsecase.CreatePurchaseOrder(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, ePurchaseOrder, aPurchaseOrder)
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
+}
+
+func Test_UpdatePurchaseOrderStatus_Failed_WhenStatusCannotChange(t *testing.T) {
+	ctx := context.TODO()
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	purchaseOrder := &entity.PurchaseOrder{ID: 1, Status: entity.PurchaseOrderStatusDraft}
+	mockPurchaseOrderRepo.On("GetPurchaseOrderByID", ctx, int64(1)).Return(purchaseOrder, nil)
+	param := entity.UpdatePurchaseOrderStatusParam{Status: entity.PurchaseOrderStatusReceived}
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	purchaseOrderUsecase := NewPurchaseOrderUsecase(mockPurchaseOrderRepo, mockProductRepo, mockEventPublisher, mockUnitOfWork)
+	err := purchaseOrderUsecase.UpdatePurchaseOrderStatus(ctx, 1, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	mockUnitOfWork.AssertNotCalled(t, "Begin", mock.Anything)
+}
+
+func Test_UpdatePurchaseOrderStatus_Failed_WhenChangedConcurrently(t *testing.T) {
+	ctx := context.TODO()
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	purchaseOrder := &entity.PurchaseOrder{ID: 1, Status: entity.PurchaseOrderStatusOrdered}
+	mockPurchaseOrderRepo.On("GetPurchaseOrderByID", ctx, int64(1)).Return(purchaseOrder, nil)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+	mockPurchaseOrderRepo.On("UpdateStatusByID", ctx, int64(1), entity.PurchaseOrderStatusOrdered, entity.PurchaseOrderStatusReceived).Return(false, nil)
+	param := entity.UpdatePurchaseOrderStatusParam{Status: entity.PurchaseOrderStatusReceived}
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	purchaseOrderUsecase := NewPurchaseOrderUsecase(mockPurchaseOrderRepo, mockProductRepo, mockEventPublisher, mockUnitOfWork)
+	err := purchaseOrderUsecase.UpdatePurchaseOrderStatus(ctx, 1, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	mockProductRepo.AssertNotCalled(t, "IncrementProductByIDs", mock.Anything, mock.Anything)
+}
+
+func Test_UpdatePurchaseOrderStatus_Success_WhenReceived(t *testing.T) {
+	ctx := context.TODO()
+	mockPurchaseOrderRepo := new(mocks.PurchaseOrderRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	purchaseOrder := &entity.PurchaseOrder{ID: 1, Status: entity.PurchaseOrderStatusOrdered}
+	items := []*entity.PurchaseOrderItem{
+		{ProductID: 1, Quantity: 15},
+		{ProductID: 2, Quantity: 4},
+	}
+	mockPurchaseOrderRepo.On("GetPurchaseOrderByID", ctx, int64(1)).Return(purchaseOrder, nil)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+	mockPurchaseOrderRepo.On("UpdateStatusByID", ctx, int64(1), entity.PurchaseOrderStatusOrdered, entity.PurchaseOrderStatusReceived).Return(true, nil)
+	mockPurchaseOrderRepo.On("GetItemsByPurchaseOrderID", ctx, int64(1)).Return(items, nil)
+	mockProductRepo.On("IncrementProductByIDs", ctx, map[int64]int{1: 15, 2: 4}).Return(nil)
+	param := entity.UpdatePurchaseOrderStatusParam{Status: entity.PurchaseOrderStatusReceived}
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+
+	purchaseOrderUsecase := NewPurchaseOrderUsecase(mockPurchaseOrderRepo, mockProductRepo, mockEventPublisher, mockUnitOfWork)
+	err := purchaseOrderUsecase.UpdatePurchaseOrderStatus(ctx, 1, param)
+	assert.Nil(t, err)
+	mockProductRepo.AssertCalled(t, "IncrementProductByIDs", ctx, map[int64]int{1: 15, 2: 4})
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
+}
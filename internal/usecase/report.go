@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+// maxReportPeriods keeps a report from being split into more periods than a
+// chart or a table can show, e.g. a year by the hour.
+const maxReportPeriods = 1000
+
+const (
+	defaultStockValuationLimit = 50
+	maxStockValuationLimit     = 100
+)
+
+// heatmapDays is how far back the heatmap looks without dates, whole weeks so
+// that every weekday is counted as often.
+const heatmapDays = 28
+
+var heatmapWeekdays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+type ReportUsecase struct {
+	reportRepository internal.ReportRepository
+	orderRepository  internal.OrderRepository
+	location         *time.Location
+}
+
+func NewReportUsecase(
+	reportRepository internal.ReportRepository,
+	orderRepository internal.OrderRepository,
+	location *time.Location) *ReportUsecase {
+	return &ReportUsecase{reportRepository, orderRepository, location}
+}
+
+func (ru ReportUsecase) GetSalesReport(ctx context.Context, param entity.SalesReportParam) (*entity.SalesReport, error) {
+	if param.StartDate == "" && param.EndDate == "" {
+		param = reportPeriodParam(param, time.Now().In(ru.location))
+	}
+
+	errs := map[string]string{}
+	start, end := reportRange(param.StartDate, param.EndDate, ru.location, errs)
+	if param.Granularity == "" {
+		param.Granularity = entity.ReportGranularityDay
+	}
+
+	switch param.Granularity {
+	case entity.ReportGranularityHour, entity.ReportGranularityDay, entity.ReportGranularityWeek, entity.ReportGranularityMonth:
+	default:
+		errs["Granularity"] = "Granularity must be hour, day, week or month"
+	}
+
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid report period",
+			Errors:  errs,
+		}
+	}
+
+	periods := salesPeriods(start, end, param.Granularity)
+	if len(periods) > maxReportPeriods {
+		return nil, entity.ErrValidation{
+			Message: "Invalid report period",
+			Errors:  map[string]string{"Granularity": "The period is too long for this granularity, choose a coarser one"},
+		}
+	}
+
+	periods, err := ru.reportRepository.GetSalesByPeriods(ctx, periods)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	report := &entity.SalesReport{
+		StartDate:   param.StartDate,
+		EndDate:     param.EndDate,
+		Period:      param.Period,
+		Granularity: param.Granularity,
+		Timezone:    ru.location.String(),
+		Start:       start,
+		End:         end,
+		Periods:     periods,
+		Summary:     entity.SalesPeriod{Label: "Total", Start: start, End: end},
+	}
+	for _, period := range periods {
+		report.Summary.Orders += period.Orders
+		report.Summary.GrossSales += period.GrossSales
+		report.Summary.Discounts += period.Discounts
+		report.Summary.Refunds += period.Refunds
+		report.Summary.NetSales += period.NetSales
+	}
+
+	return report, nil
+}
+
+func (ru ReportUsecase) GetSalesHeatmap(ctx context.Context, param entity.SalesHeatmapParam) (*entity.SalesHeatmap, error) {
+	if param.StartDate == "" && param.EndDate == "" {
+		today := time.Now().In(ru.location)
+		param.StartDate = today.AddDate(0, 0, 1-heatmapDays).Format("2006-01-02")
+		param.EndDate = today.Format("2006-01-02")
+	}
+
+	errs := map[string]string{}
+	start, end := reportRange(param.StartDate, param.EndDate, ru.location, errs)
+	if len(errs) > 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid report period",
+			Errors:  errs,
+		}
+	}
+
+	cells, err := ru.orderRepository.GetOrderHeatmap(ctx, start, end)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	heatmap := &entity.SalesHeatmap{
+		StartDate: param.StartDate,
+		EndDate:   param.EndDate,
+		Timezone:  ru.location.String(),
+		Weekdays:  heatmapWeekdays,
+	}
+	for _, cell := range cells {
+		if cell.Weekday < 0 || cell.Weekday > 6 || cell.Hour < 0 || cell.Hour > 23 {
+			continue
+		}
+
+		heatmap.Orders[cell.Weekday][cell.Hour] += cell.Orders
+		heatmap.Revenue[cell.Weekday][cell.Hour] += cell.Revenue
+		if orders := heatmap.Orders[cell.Weekday][cell.Hour]; orders > heatmap.MaxOrders {
+			heatmap.MaxOrders = orders
+		}
+
+		if revenue := heatmap.Revenue[cell.Weekday][cell.Hour]; revenue > heatmap.MaxRevenue {
+			heatmap.MaxRevenue = revenue
+		}
+	}
+
+	return heatmap, nil
+}
+
+func (ru ReportUsecase) GetStockValuation(ctx context.Context, query entity.StockValuationQuery) (*entity.StockValuation, error) {
+	if query.Cursor < 0 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid stock valuation filter",
+			Errors:  map[string]string{"Cursor": "Cursor must not be negative"},
+		}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultStockValuationLimit
+	}
+
+	if query.Limit > maxStockValuationLimit {
+		query.Limit = maxStockValuationLimit
+	}
+
+	// one extra row tells whether there is a next page
+	fetchQuery := query
+	fetchQuery.Limit = query.Limit + 1
+	values, err := ru.reportRepository.GetStockValues(ctx, fetchQuery)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	valuation := &entity.StockValuation{Category: query.Category, Limit: query.Limit}
+	if len(values) > query.Limit {
+		values = values[:query.Limit]
+		valuation.HasMore = true
+		valuation.NextCursor = values[len(values)-1].ProductID
+	}
+	valuation.Items = values
+
+	if query.Cursor == 0 {
+		summary, err := ru.reportRepository.GetStockValuationSummary(ctx, query.Category)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+
+		valuation.Summary = summary
+	}
+
+	return valuation, nil
+}
+
+// reportRange reads a range of whole days in the store timezone, the end is
+// returned as the midnight after the end date. Invalid dates are added to errs.
+func reportRange(startDate string, endDate string, location *time.Location, errs map[string]string) (time.Time, time.Time) {
+	start, err := time.ParseInLocation("2006-01-02", startDate, location)
+	if err != nil {
+		errs["StartDate"] = "Start date must be formatted as YYYY-MM-DD"
+	}
+
+	lastDay, err := time.ParseInLocation("2006-01-02", endDate, location)
+	if err != nil {
+		errs["EndDate"] = "End date must be formatted as YYYY-MM-DD"
+	} else if lastDay.Before(start) {
+		errs["EndDate"] = "End date must not be before the start date"
+	}
+
+	end := time.Date(lastDay.Year(), lastDay.Month(), lastDay.Day()+1, 0, 0, 0, 0, location)
+	return start, end
+}
+
+// reportPeriodParam fills the dates of a named period ending today, and the
+// granularity that suits it unless one was asked for.
+func reportPeriodParam(param entity.SalesReportParam, today time.Time) entity.SalesReportParam {
+	year, month, day := today.Date()
+	var start time.Time
+	granularity := entity.ReportGranularityDay
+	switch param.Period {
+	case entity.ReportPeriodToday:
+		start = today
+		granularity = entity.ReportGranularityHour
+	case entity.ReportPeriodThisMonth:
+		start = time.Date(year, month, 1, 0, 0, 0, 0, today.Location())
+	case entity.ReportPeriodLast12Months:
+		start = time.Date(year, month-11, 1, 0, 0, 0, 0, today.Location())
+		granularity = entity.ReportGranularityMonth
+	default:
+		param.Period = entity.ReportPeriodLast30Days
+		start = time.Date(year, month, day-29, 0, 0, 0, 0, today.Location())
+	}
+
+	param.StartDate = start.Format("2006-01-02")
+	param.EndDate = today.Format("2006-01-02")
+	if param.Granularity == "" {
+		param.Granularity = granularity
+	}
+
+	return param
+}
+
+// salesPeriods splits [start, end) at the local calendar boundaries of the
+// granularity, weeks start on Monday. The first and last periods are cut to
+// the range, and generation stops just past maxReportPeriods.
+func salesPeriods(start, end time.Time, granularity string) []*entity.SalesPeriod {
+	periods := []*entity.SalesPeriod{}
+	for periodStart := start; periodStart.Before(end) && len(periods) <= maxReportPeriods; {
+		periodEnd := nextPeriodStart(periodStart, granularity)
+		if periodEnd.After(end) {
+			periodEnd = end
+		}
+
+		period := &entity.SalesPeriod{
+			Label: periodLabel(periodStart, granularity),
+			Start: periodStart,
+			End:   periodEnd,
+		}
+		periods = append(periods, period)
+		periodStart = periodEnd
+	}
+
+	return periods
+}
+
+func nextPeriodStart(date time.Time, granularity string) time.Time {
+	year, month, day := date.Date()
+	switch granularity {
+	case entity.ReportGranularityHour:
+		// elapsed hours rather than wall clock hours, so a DST change
+		// neither repeats nor skips a period
+		return date.Add(time.Hour)
+	case entity.ReportGranularityWeek:
+		days := (8 - int(date.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return time.Date(year, month, day+days, 0, 0, 0, 0, date.Location())
+	case entity.ReportGranularityMonth:
+		return time.Date(year, month+1, 1, 0, 0, 0, 0, date.Location())
+	default:
+		return time.Date(year, month, day+1, 0, 0, 0, 0, date.Location())
+	}
+}
+
+func periodLabel(date time.Time, granularity string) string {
+	switch granularity {
+	case entity.ReportGranularityHour:
+		return date.Format("2006-01-02 15:04")
+	case entity.ReportGranularityWeek:
+		return "Week of " + date.Format("2006-01-02")
+	case entity.ReportGranularityMonth:
+		return date.Format("January 2006")
+	default:
+		return date.Format("2006-01-02")
+	}
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+// returnPeriods answers GetSalesByPeriods with the periods it was given.
+func returnPeriods(ctx context.Context, periods []*entity.SalesPeriod) []*entity.SalesPeriod {
+	return periods
+}
+
+func Test_GetSalesReport_Failed_WhenPeriodIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	param := entity.SalesReportParam{
+		StartDate:   "2026-03-10",
+		EndDate:     "2026-03-01",
+		Granularity: "quarter",
+	}
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	report, err := reportUsecase.GetSalesReport(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Contains(t, err.(entity.ErrValidation).Errors, "EndDate")
+	assert.Contains(t, err.(entity.ErrValidation).Errors, "Granularity")
+	assert.Nil(t, report)
+	mockReportRepo.AssertNotCalled(t, "GetSalesByPeriods", mock.Anything, mock.Anything)
+}
+
+func Test_GetSalesReport_Failed_WhenTooManyPeriods(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	param := entity.SalesReportParam{
+		StartDate:   "2026-01-01",
+		EndDate:     "2026-12-31",
+		Granularity: entity.ReportGranularityHour,
+	}
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	report, err := reportUsecase.GetSalesReport(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, report)
+}
+
+func Test_GetSalesReport_Failed_WhenGettingSales(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockReportRepo.On("GetSalesByPeriods", ctx, mock.Anything).Return(nil, errors.New("failed get sales"))
+	param := entity.SalesReportParam{
+		StartDate:   "2026-03-01",
+		EndDate:     "2026-03-31",
+		Granularity: entity.ReportGranularityDay,
+	}
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	report, err := reportUsecase.GetSalesReport(ctx, param)
+	assert.NotNil(t, err)
+	assert.Nil(t, report)
+}
+
+func Test_GetSalesReport_Success_ByWeek(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockReportRepo.
+		On("GetSalesByPeriods", ctx, mock.Anything).
+		Return(func(ctx context.Context, periods []*entity.SalesPeriod) []*entity.SalesPeriod {
+			for _, period := range periods {
+				period.Orders = 2
+				period.GrossSales = 30000
+				period.Discounts = 5000
+				period.NetSales = 25000
+			}
+			return periods
+		}, nil)
+	param := entity.SalesReportParam{
+		StartDate:   "2026-03-04",
+		EndDate:     "2026-03-17",
+		Granularity: entity.ReportGranularityWeek,
+	}
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	report, err := reportUsecase.GetSalesReport(ctx, param)
+	assert.Nil(t, err)
+	// Wednesday 4th to Sunday 8th, the full week of Monday 9th, then Monday
+	// 16th to the end of Tuesday 17th
+	assert.Len(t, report.Periods, 3)
+	assert.Equal(t, time.Date(2026, 3, 4, 0, 0, 0, 0, jakarta), report.Periods[0].Start)
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, jakarta), report.Periods[0].End)
+	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, jakarta), report.Periods[2].Start)
+	assert.Equal(t, time.Date(2026, 3, 18, 0, 0, 0, 0, jakarta), report.Periods[2].End)
+	assert.Equal(t, 6, report.Summary.Orders)
+	assert.Equal(t, 75000, report.Summary.NetSales)
+}
+
+func Test_GetSalesReport_Success_WithPeriod(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockReportRepo.On("GetSalesByPeriods", ctx, mock.Anything).Return(returnPeriods, nil)
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	report, err := reportUsecase.GetSalesReport(ctx, entity.SalesReportParam{Period: entity.ReportPeriodLast12Months})
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ReportGranularityMonth, report.Granularity)
+	assert.Len(t, report.Periods, 12)
+	assert.Equal(t, 1, report.Periods[0].Start.Day())
+	assert.Equal(t, time.Now().In(jakarta).Format("2006-01-02"), report.EndDate)
+}
+
+func Test_SalesPeriods_AcrossDaylightSavingTime(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+
+	// clocks go forward on the last Sunday of March
+	start := time.Date(2026, 3, 29, 0, 0, 0, 0, amsterdam)
+	end := time.Date(2026, 3, 30, 0, 0, 0, 0, amsterdam)
+	hours := salesPeriods(start, end, entity.ReportGranularityHour)
+	days := salesPeriods(start, end, entity.ReportGranularityDay)
+	assert.Len(t, hours, 23)
+	assert.Len(t, days, 1)
+	assert.Equal(t, end, hours[len(hours)-1].End)
+}
+
+func Test_GetStockValuation_Failed_WhenCursorIsNegative(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	valuation, err := reportUsecase.GetStockValuation(ctx, entity.StockValuationQuery{Cursor: -1})
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, valuation)
+	mockReportRepo.AssertNotCalled(t, "GetStockValues", mock.Anything, mock.Anything)
+}
+
+func Test_GetStockValuation_Failed_WhenGettingValues(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockReportRepo.On("GetStockValues", ctx, mock.Anything).Return(nil, errors.New("failed get stock values"))
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	valuation, err := reportUsecase.GetStockValuation(ctx, entity.StockValuationQuery{})
+	assert.NotNil(t, err)
+	assert.Nil(t, valuation)
+}
+
+func Test_GetStockValuation_Success_FirstPage(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	values := []*entity.StockValue{{ProductID: 1}, {ProductID: 2}, {ProductID: 3}}
+	summary := &entity.StockValuationSummary{Products: 3, Units: 30, Value: 90000}
+	fetchQuery := entity.StockValuationQuery{Category: "Drinks", Limit: 3}
+	mockReportRepo.On("GetStockValues", ctx, fetchQuery).Return(values, nil)
+	mockReportRepo.On("GetStockValuationSummary", ctx, "Drinks").Return(summary, nil)
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	valuation, err := reportUsecase.GetStockValuation(ctx, entity.StockValuationQuery{Category: "Drinks", Limit: 2})
+	assert.Nil(t, err)
+	assert.Len(t, valuation.Items, 2)
+	assert.True(t, valuation.HasMore)
+	assert.Equal(t, int64(2), valuation.NextCursor)
+	assert.Equal(t, summary, valuation.Summary)
+}
+
+func Test_GetStockValuation_Success_NextPage(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	values := []*entity.StockValue{{ProductID: 3}}
+	fetchQuery := entity.StockValuationQuery{Cursor: 2, Limit: maxStockValuationLimit + 1}
+	mockReportRepo.On("GetStockValues", ctx, fetchQuery).Return(values, nil)
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	valuation, err := reportUsecase.GetStockValuation(ctx, entity.StockValuationQuery{Cursor: 2, Limit: 500})
+	assert.Nil(t, err)
+	assert.False(t, valuation.HasMore)
+	assert.Nil(t, valuation.Summary)
+	mockReportRepo.AssertNotCalled(t, "GetStockValuationSummary", mock.Anything, mock.Anything)
+}
+
+func Test_GetSalesHeatmap_Failed_WhenPeriodIsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	param := entity.SalesHeatmapParam{StartDate: "2026-03-10", EndDate: "2026-03-01"}
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	heatmap, err := reportUsecase.GetSalesHeatmap(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, heatmap)
+	mockOrderRepo.AssertNotCalled(t, "GetOrderHeatmap", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_GetSalesHeatmap_Failed_WhenGettingOrders(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	mockOrderRepo.On("GetOrderHeatmap", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("failed get heatmap"))
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	heatmap, err := reportUsecase.GetSalesHeatmap(ctx, entity.SalesHeatmapParam{})
+	assert.NotNil(t, err)
+	assert.Nil(t, heatmap)
+}
+
+func Test_GetSalesHeatmap_Success(t *testing.T) {
+	ctx := context.TODO()
+	mockReportRepo := new(mocks.ReportRepository)
+	mockOrderRepo := new(mocks.OrderRepository)
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, jakarta)
+	end := time.Date(2026, 3, 30, 0, 0, 0, 0, jakarta)
+	cells := []*entity.OrderHeatmapCell{
+		{Weekday: 0, Hour: 9, Orders: 4, Revenue: 120000},
+		{Weekday: 5, Hour: 18, Orders: 7, Revenue: 90000},
+	}
+	mockOrderRepo.On("GetOrderHeatmap", ctx, start, end).Return(cells, nil)
+	param := entity.SalesHeatmapParam{StartDate: "2026-03-02", EndDate: "2026-03-29"}
+
+	reportUsecase := NewReportUsecase(mockReportRepo, mockOrderRepo, jakarta)
+	heatmap, err := reportUsecase.GetSalesHeatmap(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, heatmap.Orders[0][9])
+	assert.Equal(t, 90000, heatmap.Revenue[5][18])
+	assert.Equal(t, 7, heatmap.MaxOrders)
+	assert.Equal(t, 120000, heatmap.MaxRevenue)
+	assert.Equal(t, "Monday", heatmap.Weekdays[0])
+}
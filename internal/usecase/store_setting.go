@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+)
+
+type StoreSettingUsecase struct {
+	storeSettingRepository internal.StoreSettingRepository
+}
+
+func NewStoreSettingUsecase(storeSettingRepository internal.StoreSettingRepository) *StoreSettingUsecase {
+	return &StoreSettingUsecase{storeSettingRepository}
+}
+
+func (ssu StoreSettingUsecase) GetStoreSetting(ctx context.Context) (*entity.StoreSetting, error) {
+	setting, err := ssu.storeSettingRepository.GetSetting(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return setting, err
+}
+
+func (ssu StoreSettingUsecase) UpdateStoreSetting(ctx context.Context, param entity.UpdateStoreSettingParam) error {
+	if reason := invoicePatternError(param.InvoicePattern, param.InvoiceReset); reason != "" {
+		return entity.ErrValidation{
+			Message: "Invalid invoice pattern",
+			Errors:  map[string]string{"InvoicePattern": reason},
+		}
+	}
+
+	err := ssu.storeSettingRepository.UpdateSetting(ctx, param)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return err
+}
+
+// invoicePatternError tells why the pattern could issue the same invoice
+// number twice, the sequence restarts every period so the pattern has to
+// tell the periods apart.
+func invoicePatternError(pattern string, reset string) string {
+	if !strings.Contains(pattern, "{seq}") {
+		return "Invoice pattern must contain {seq}"
+	}
+
+	hasFullDate := strings.Contains(pattern, "{YYYYMMDD}")
+	hasYear := hasFullDate || strings.Contains(pattern, "{YYYY}") || strings.Contains(pattern, "{YY}")
+	hasDate := hasFullDate || (hasYear && strings.Contains(pattern, "{MM}") && strings.Contains(pattern, "{DD}"))
+	if reset == entity.InvoiceResetDaily && !hasDate {
+		return "Invoice pattern restarting daily must contain {YYYYMMDD} or {YYYY}, {MM} and {DD}"
+	}
+
+	if reset == entity.InvoiceResetYearly && !hasYear {
+		return "Invoice pattern restarting yearly must contain {YYYY} or {YY}"
+	}
+
+	return ""
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetStoreSetting_Success(t *testing.T) {
+	ctx := context.TODO()
+	eSetting := &entity.StoreSetting{StockPolicy: entity.StockPolicyWarn}
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("GetSetting", ctx).Return(eSetting, nil)
+
+	storeSettingUsecase := NewStoreSettingUsecase(mockStoreSettingRepo)
+	aSetting, err := storeSettingUsecase.GetStoreSetting(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, eSetting, aSetting)
+}
+
+func Test_UpdateStoreSetting_Failed(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.UpdateStoreSettingParam{
+		StoreName:      "Kaseer",
+		StoreCode:      "KSR",
+		InvoicePattern: "INV/{store}/{YYYY}{MM}{DD}/{seq}",
+		InvoiceReset:   entity.InvoiceResetDaily,
+		StockPolicy:    entity.StockPolicyAllow,
+	}
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+	mockStoreSettingRepo.On("UpdateSetting", ctx, param).Return(errors.New("failed update store setting"))
+
+	storeSettingUsecase := NewStoreSettingUsecase(mockStoreSettingRepo)
+	err := storeSettingUsecase.UpdateStoreSetting(ctx, param)
+	assert.NotNil(t, err)
+}
+
+func Test_UpdateStoreSetting_Failed_WhenInvoicePatternRepeatsDaily(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.UpdateStoreSettingParam{
+		StoreName:      "Kaseer",
+		StoreCode:      "KSR",
+		InvoicePattern: "INV/{store}/{YYYY}/{seq}",
+		InvoiceReset:   entity.InvoiceResetDaily,
+		StockPolicy:    entity.StockPolicyBlock,
+	}
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+
+	storeSettingUsecase := NewStoreSettingUsecase(mockStoreSettingRepo)
+	err := storeSettingUsecase.UpdateStoreSetting(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Contains(t, err.(entity.ErrValidation).Errors, "InvoicePattern")
+	mockStoreSettingRepo.AssertNotCalled(t, "UpdateSetting", ctx, param)
+}
+
+func Test_UpdateStoreSetting_Failed_WhenInvoicePatternHasNoSequence(t *testing.T) {
+	ctx := context.TODO()
+	param := entity.UpdateStoreSettingParam{
+		StoreName:      "Kaseer",
+		StoreCode:      "KSR",
+		InvoicePattern: "INV/{store}/{YYYYMMDD}",
+		InvoiceReset:   entity.InvoiceResetDaily,
+		StockPolicy:    entity.StockPolicyBlock,
+	}
+	mockStoreSettingRepo := new(mocks.StoreSettingRepository)
+
+	storeSettingUsecase := NewStoreSettingUsecase(mockStoreSettingRepo)
+	err := storeSettingUsecase.UpdateStoreSetting(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+}
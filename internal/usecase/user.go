@@ -15,12 +15,29 @@ import (
 var stringsHash = strings.Hash
 
 type UserUsecase struct {
-	userRepository internal.UserRepository
-	storage        internal.Storage
+	userRepository     internal.UserRepository
+	auditLogRepository internal.AuditLogRepository
+	storage            internal.Storage
+	eventPublisher     internal.EventPublisher
+	UnitOfWork         internal.UnitOfWork
 }
 
-func NewUserUsecase(userRepository internal.UserRepository, storage internal.Storage) *UserUsecase {
-	return &UserUsecase{userRepository, storage}
+func NewUserUsecase(
+	userRepository internal.UserRepository,
+	auditLogRepository internal.AuditLogRepository,
+	storage internal.Storage,
+	eventPublisher internal.EventPublisher,
+	UnitOfWork internal.UnitOfWork) *UserUsecase {
+	return &UserUsecase{userRepository, auditLogRepository, storage, eventPublisher, UnitOfWork}
+}
+
+func (uu UserUsecase) GetAllUsers(ctx context.Context) ([]*entity.User, error) {
+	users, err := uu.userRepository.GetAllUsers(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return users, err
 }
 
 func (uu UserUsecase) GetUserByID(ctx context.Context, ID int64) (*entity.User, error) {
@@ -39,6 +56,15 @@ func (uu UserUsecase) GetUserByCredential(ctx context.Context, credential entity
 		return nil, err
 	}
 
+	// service accounts authenticate by their API tokens only
+	if user.ServiceAccount {
+		err := entity.ErrInvalidCredential{
+			Message: "Service accounts cannot log in",
+			Err:     nil,
+		}
+		return nil, err
+	}
+
 	hashedPassword := stringsHash(credential.Password)
 	isPasswordEqual := hashedPassword == user.Password
 	if !isPasswordEqual {
@@ -49,6 +75,14 @@ func (uu UserUsecase) GetUserByCredential(ctx context.Context, credential entity
 		return nil, err
 	}
 
+	if err := recordLogin(ctx, uu.auditLogRepository, user); err != nil {
+		return nil, err
+	}
+
+	uu.eventPublisher.Publish(ctx, entity.Event{
+		Name: entity.EventUserLoggedIn,
+		Data: entity.UserLoggedInEvent{User: user, Channel: entity.LoginChannelWeb},
+	})
 	return user, nil
 }
 
@@ -60,22 +94,96 @@ func (uu UserUsecase) SaveUserPhoto(ctx context.Context, user *entity.User, phot
 	return uu.storage.Save(photo, photoDirectory, filename)
 }
 
+// UpdateUser and UpdateUserPassword keep the log of the change along with
+// the change or neither of them.
 func (uu UserUsecase) UpdateUser(ctx context.Context, ID int64, param entity.UpdateUserParam) (bool, error) {
-	isUpdated, err := uu.userRepository.UpdateByID(ctx, ID, param)
+	txContext, err := uu.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	before, err := uu.userRepository.GetUserByIDForUpdate(txContext, ID)
+	if err != nil {
+		log.Println(err.Error())
+		uu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	isUpdated, err := uu.userRepository.UpdateByID(txContext, ID, param)
+	if err != nil {
+		log.Println(err.Error())
+		uu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if !isUpdated {
+		uu.UnitOfWork.Rollback(txContext)
+		return false, nil
+	}
+
+	after, err := uu.userRepository.GetUserByID(txContext, ID)
 	if err != nil {
+		log.Println(err.Error())
+		uu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	changes := auditChanges(before, after)
+	auditLog := newAuditLog(ctx, entity.AuditActionProfileUpdated, entity.AuditEntityUser, ID, changes)
+	if err := uu.auditLogRepository.Create(txContext, auditLog); err != nil {
+		uu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if err := uu.UnitOfWork.Commit(txContext); err != nil {
 		log.Println(err.Error())
 		return false, err
 	}
 
-	return isUpdated, nil
+	return true, nil
 }
 
+// UpdateUserPassword logs the change of the password without its hashes.
 func (uu UserUsecase) UpdateUserPassword(ctx context.Context, ID int64, password string) (bool, error) {
+	txContext, err := uu.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
 	hashedPassword := stringsHash(password)
-	isUpdated, err := uu.userRepository.UpdatePasswordByID(ctx, ID, hashedPassword)
+	isUpdated, err := uu.userRepository.UpdatePasswordByID(txContext, ID, hashedPassword)
 	if err != nil {
 		log.Println(err.Error())
+		uu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if !isUpdated {
+		uu.UnitOfWork.Rollback(txContext)
+		return false, nil
+	}
+
+	auditLog := newAuditLog(ctx, entity.AuditActionPasswordChanged, entity.AuditEntityUser, ID, nil)
+	if err := uu.auditLogRepository.Create(txContext, auditLog); err != nil {
+		uu.UnitOfWork.Rollback(txContext)
+		return false, err
+	}
+
+	if err := uu.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return false, err
 	}
 
-	return isUpdated, err
+	return true, nil
+}
+
+// recordLogin logs the user in as the actor, the request has none yet. A
+// login which cannot be logged is refused.
+func recordLogin(ctx context.Context, auditLogRepository internal.AuditLogRepository, user *entity.User) error {
+	param := newAuditLog(ctx, entity.AuditActionLoggedIn, entity.AuditEntityUser, user.ID, nil)
+	param.ActorID = &user.ID
+	param.ActorName = user.Name
+	return auditLogRepository.Create(ctx, param)
 }
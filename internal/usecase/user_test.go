@@ -9,6 +9,7 @@ import (
 	"github.com/ardafirdausr/kaseer/internal/entity"
 	"github.com/ardafirdausr/kaseer/internal/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var user = entity.User{
@@ -27,8 +28,12 @@ func Test_GetUserByID_Failed(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(nil, errors.New("failed get user by id"))
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	user, err := userUsecase.GetUserByID(ctx, user.ID)
 	assert.NotNil(t, err)
 	assert.Nil(t, user)
@@ -40,8 +45,11 @@ func Test_GetUserByID_Success(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&user, nil)
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	actualUser, err := userUsecase.GetUserByID(ctx, user.ID)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(actualUser, user)
@@ -57,8 +65,12 @@ func Test_GetUserByCredential_Failed_WhenGettingUser(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("GetUserByEmail", ctx, credential.Email).Return(nil, errors.New("failed get user by id"))
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	user, err := userUsecase.GetUserByCredential(ctx, credential)
 	assert.NotNil(t, err)
 	assert.Nil(t, user)
@@ -78,8 +90,12 @@ func Test_GetUserByCredential_Failed_WhenPasswordNotEqual(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("GetUserByEmail", ctx, credential.Email).Return(&user, nil)
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	aUser, err := userUsecase.GetUserByCredential(ctx, credential)
 	assert.NotNil(t, err)
 	assert.Nil(t, aUser)
@@ -100,11 +116,22 @@ func Test_GetUserByCredential_Success(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("GetUserByEmail", ctx, credential.Email).Return(&user, nil)
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	aUser, err := userUsecase.GetUserByCredential(ctx, credential)
 	assert.Nil(t, err)
 	assert.ObjectsAreEqualValues(user, aUser)
+	mockEventPublisher.AssertCalled(t, "Publish", ctx, mock.MatchedBy(func(event entity.Event) bool {
+		return event.Name == entity.EventUserLoggedIn && event.Data.(entity.UserLoggedInEvent).Channel == entity.LoginChannelWeb
+	}))
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		return param.Action == entity.AuditActionLoggedIn && *param.ActorID == user.ID && param.ActorName == user.Name
+	}))
 	stringsHash = oriHash
 }
 
@@ -117,13 +144,51 @@ func Test_UpdateUser_Failed(t *testing.T) {
 	}
 
 	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByIDForUpdate", ctx, user.ID).Return(&user, nil)
 	mockUserRepository.On("UpdateByID", ctx, user.ID, updateParam).Return(false, errors.New("failed to update the user"))
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := userUsecase.UpdateUser(ctx, user.ID, updateParam)
 	assert.NotNil(t, err)
 	assert.False(t, isUpdated)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+}
+
+func Test_UpdateUser_Failed_WhenWritingAuditLog(t *testing.T) {
+	ctx := context.TODO()
+	updateParam := entity.UpdateUserParam{
+		Name:     "John doie",
+		Email:    "newMail@mail.com",
+		PhotoUrl: nil,
+	}
+	updatedUser := user
+	updatedUser.Name = updateParam.Name
+	updatedUser.Email = updateParam.Email
+
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByIDForUpdate", ctx, user.ID).Return(&user, nil)
+	mockUserRepository.On("UpdateByID", ctx, user.ID, updateParam).Return(true, nil)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&updatedUser, nil)
+	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", ctx, mock.Anything).Return(errors.New("failed create audit log"))
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
+
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
+	isUpdated, err := userUsecase.UpdateUser(ctx, user.ID, updateParam)
+	assert.NotNil(t, err)
+	assert.False(t, isUpdated)
+	mockUnitOfWork.AssertCalled(t, "Rollback", ctx)
+	mockUnitOfWork.AssertNotCalled(t, "Commit", mock.Anything)
 }
 
 func Test_UpdateUser_Success(t *testing.T) {
@@ -134,14 +199,35 @@ func Test_UpdateUser_Success(t *testing.T) {
 		PhotoUrl: nil,
 	}
 
+	updatedUser := user
+	updatedUser.Name = updateParam.Name
+	updatedUser.Email = updateParam.Email
+	updatedUser.UpdatedAt = user.UpdatedAt.Add(time.Minute)
+
 	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByIDForUpdate", ctx, user.ID).Return(&user, nil)
 	mockUserRepository.On("UpdateByID", ctx, user.ID, updateParam).Return(true, nil)
+	mockUserRepository.On("GetUserByID", ctx, user.ID).Return(&updatedUser, nil)
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := userUsecase.UpdateUser(ctx, user.ID, updateParam)
 	assert.Nil(t, err)
 	assert.True(t, isUpdated)
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		_, photoChanged := param.Changes["photo_url"]
+		_, updatedAtChanged := param.Changes["updated_at"]
+		return param.Action == entity.AuditActionProfileUpdated &&
+			param.Changes["email"] == entity.AuditChange{Before: user.Email, After: updateParam.Email} &&
+			!photoChanged && !updatedAtChanged
+	}))
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
 }
 
 func Test_UpdateUserPassword_Failed(t *testing.T) {
@@ -154,8 +240,13 @@ func Test_UpdateUserPassword_Failed(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("UpdatePasswordByID", ctx, user.ID, "hashedPassword").Return(false, errors.New("failed to update the user"))
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Rollback", ctx).Return(nil)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := userUsecase.UpdateUserPassword(ctx, user.ID, "new-password")
 	assert.NotNil(t, err)
 	assert.False(t, isUpdated)
@@ -172,10 +263,69 @@ func Test_UpdateUserPassword_Success(t *testing.T) {
 	mockUserRepository := new(mocks.UserRepository)
 	mockUserRepository.On("UpdatePasswordByID", ctx, user.ID, "hashedPassword").Return(true, nil)
 	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
 
-	userUsecase := NewUserUsecase(mockUserRepository, mockStorage)
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
 	isUpdated, err := userUsecase.UpdateUserPassword(ctx, user.ID, "new-password")
 	assert.Nil(t, err)
 	assert.True(t, isUpdated)
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(param entity.CreateAuditLogParam) bool {
+		return param.Action == entity.AuditActionPasswordChanged && len(param.Changes) == 0
+	}))
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
 	stringsHash = oriHash
 }
+
+func Test_GetUserByCredential_Failed_WhenWritingAuditLog(t *testing.T) {
+	oriHash := stringsHash
+	stringsHash = func(v string) string {
+		return user.Password
+	}
+
+	ctx := context.TODO()
+	credential := entity.UserCredential{
+		Email:    user.Email,
+		Password: user.Password,
+	}
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByEmail", ctx, credential.Email).Return(&user, nil)
+	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockAuditLogRepo.On("Create", ctx, mock.Anything).Return(errors.New("failed create audit log"))
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
+	aUser, err := userUsecase.GetUserByCredential(ctx, credential)
+	assert.NotNil(t, err)
+	assert.Nil(t, aUser)
+	mockEventPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	stringsHash = oriHash
+}
+
+func Test_GetUserByCredential_Failed_WhenUserIsAServiceAccount(t *testing.T) {
+	ctx := context.TODO()
+	serviceAccount := user
+	serviceAccount.ServiceAccount = true
+	credential := entity.UserCredential{
+		Email:    serviceAccount.Email,
+		Password: "anything",
+	}
+	mockUserRepository := new(mocks.UserRepository)
+	mockUserRepository.On("GetUserByEmail", ctx, credential.Email).Return(&serviceAccount, nil)
+	mockStorage := new(mocks.Storage)
+	mockAuditLogRepo := new(mocks.AuditLogRepository)
+	mockEventPublisher := new(mocks.EventPublisher)
+	mockEventPublisher.On("Publish", mock.Anything, mock.Anything).Return()
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	userUsecase := NewUserUsecase(mockUserRepository, mockAuditLogRepo, mockStorage, mockEventPublisher, mockUnitOfWork)
+	aUser, err := userUsecase.GetUserByCredential(ctx, credential)
+	assert.IsType(t, entity.ErrInvalidCredential{}, err)
+	assert.Nil(t, aUser)
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/strings"
+)
+
+const voucherCodeLength = 8
+
+var stringsRandomCode = strings.RandomCode
+
+type VoucherUsecase struct {
+	voucherRepository internal.VoucherRepository
+	location          *time.Location
+}
+
+func NewVoucherUsecase(voucherRepository internal.VoucherRepository, location *time.Location) *VoucherUsecase {
+	return &VoucherUsecase{voucherRepository: voucherRepository, location: location}
+}
+
+func (vu VoucherUsecase) GetAllVouchers(ctx context.Context) ([]*entity.Voucher, error) {
+	vouchers, err := vu.voucherRepository.GetAllVouchers(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return vouchers, err
+}
+
+func (vu VoucherUsecase) GetVoucherByID(ctx context.Context, ID int64) (*entity.Voucher, error) {
+	voucher, err := vu.voucherRepository.GetVoucherByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return voucher, err
+}
+
+func (vu VoucherUsecase) GetVoucherRedemptions(ctx context.Context, voucherID int64) ([]*entity.VoucherRedemption, error) {
+	redemptions, err := vu.voucherRepository.GetRedemptionsByVoucherID(ctx, voucherID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return redemptions, err
+}
+
+func (vu VoucherUsecase) GenerateVouchers(ctx context.Context, param entity.GenerateVoucherParam) ([]string, error) {
+	startsAt, err := time.ParseInLocation("2006-01-02", param.StartDate, vu.location)
+	if err != nil {
+		return nil, entity.ErrValidation{
+			Message: "Invalid voucher validity",
+			Errors:  map[string]string{"StartDate": "Start date is not a valid date"},
+		}
+	}
+
+	endsAt, err := time.ParseInLocation("2006-01-02", param.EndDate, vu.location)
+	if err != nil || endsAt.Before(startsAt) {
+		return nil, entity.ErrValidation{
+			Message: "Invalid voucher validity",
+			Errors:  map[string]string{"EndDate": "End date must not be before the start date"},
+		}
+	}
+
+	if param.ValueType == entity.VoucherValueTypePercent && param.Value > 100 {
+		return nil, entity.ErrValidation{
+			Message: "Invalid voucher value",
+			Errors:  map[string]string{"Value": "Percentage value must not exceed 100"},
+		}
+	}
+
+	codes, err := vu.generateUniqueCodes(ctx, param.Prefix, param.Quantity)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// vouchers stay valid until the day after their last day starts, which
+	// is not always 24 hours later when the clocks change
+	expiresAt := time.Date(endsAt.Year(), endsAt.Month(), endsAt.Day()+1, 0, 0, 0, 0, vu.location)
+	createParams := make([]*entity.CreateVoucherParam, 0, len(codes))
+	for _, code := range codes {
+		createParams = append(createParams, &entity.CreateVoucherParam{
+			Code:                  code,
+			ValueType:             param.ValueType,
+			Value:                 param.Value,
+			MinSpend:              param.MinSpend,
+			StartsAt:              startsAt,
+			ExpiresAt:             expiresAt,
+			UsageLimit:            param.UsageLimit,
+			UsageLimitPerCustomer: param.UsageLimitPerCustomer,
+		})
+	}
+
+	if err := vu.voucherRepository.CreateVouchers(ctx, createParams); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateUniqueCodes keeps drawing random codes until it has the requested
+// quantity of codes that are unique within the batch and not yet stored.
+func (vu VoucherUsecase) generateUniqueCodes(ctx context.Context, prefix string, quantity int) ([]string, error) {
+	codeSet := make(map[string]bool, quantity)
+	codes := make([]string, 0, quantity)
+	for attempt := 0; attempt < 5 && len(codes) < quantity; attempt++ {
+		candidates := []string{}
+		for len(codes)+len(candidates) < quantity {
+			random, err := stringsRandomCode(voucherCodeLength)
+			if err != nil {
+				return nil, err
+			}
+
+			code := prefix + random
+			if codeSet[code] {
+				continue
+			}
+
+			codeSet[code] = true
+			candidates = append(candidates, code)
+		}
+
+		existingVouchers, err := vu.voucherRepository.GetVouchersByCodes(ctx, candidates...)
+		if err != nil {
+			return nil, err
+		}
+
+		existingCodes := make(map[string]bool, len(existingVouchers))
+		for _, voucher := range existingVouchers {
+			existingCodes[voucher.Code] = true
+		}
+
+		for _, code := range candidates {
+			if !existingCodes[code] {
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	if len(codes) < quantity {
+		return nil, entity.ErrItemAlreadyExists{Message: "Failed generating unique voucher codes"}
+	}
+
+	return codes, nil
+}
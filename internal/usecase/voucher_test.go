@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var generateVoucherParam = entity.GenerateVoucherParam{
+	Prefix:     "PROMO",
+	Quantity:   2,
+	ValueType:  entity.VoucherValueTypeFixed,
+	Value:      5000,
+	MinSpend:   20000,
+	StartDate:  "2021-06-01",
+	EndDate:    "2021-06-30",
+	UsageLimit: 1,
+}
+
+func stubRandomCodes(codes ...string) func() {
+	original := stringsRandomCode
+	i := 0
+	stringsRandomCode = func(length int) (string, error) {
+		code := codes[i%len(codes)]
+		i++
+		return code, nil
+	}
+	return func() { stringsRandomCode = original }
+}
+
+func Test_GetAllVouchers_Failed(t *testing.T) {
+	ctx := context.TODO()
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetAllVouchers", ctx).Return(nil, errors.New("failed get vouchers"))
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, jakarta)
+	aVouchers, err := voucherUsecase.GetAllVouchers(ctx)
+	assert.NotNil(t, err)
+	assert.Nil(t, aVouchers)
+}
+
+func Test_GetVoucherRedemptions_Success(t *testing.T) {
+	ctx := context.TODO()
+	eRedemptions := []*entity.VoucherRedemption{{ID: 1, VoucherID: 1, OrderID: 1, Discount: 5000}}
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetRedemptionsByVoucherID", ctx, int64(1)).Return(eRedemptions, nil)
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, jakarta)
+	aRedemptions, err := voucherUsecase.GetVoucherRedemptions(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, eRedemptions, aRedemptions)
+}
+
+func Test_GenerateVouchers_Failed_WhenEndDateBeforeStartDate(t *testing.T) {
+	ctx := context.TODO()
+	param := generateVoucherParam
+	param.EndDate = "2021-05-01"
+	mockVoucherRepo := new(mocks.VoucherRepository)
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, jakarta)
+	codes, err := voucherUsecase.GenerateVouchers(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, codes)
+}
+
+func Test_GenerateVouchers_Failed_WhenPercentageExceeds100(t *testing.T) {
+	ctx := context.TODO()
+	param := generateVoucherParam
+	param.ValueType = entity.VoucherValueTypePercent
+	param.Value = 150
+	mockVoucherRepo := new(mocks.VoucherRepository)
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, jakarta)
+	codes, err := voucherUsecase.GenerateVouchers(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, codes)
+}
+
+func Test_GenerateVouchers_Success_RegeneratesTakenCodes(t *testing.T) {
+	defer stubRandomCodes("AAAA", "BBBB", "CCCC")()
+
+	ctx := context.TODO()
+	takenVouchers := []*entity.Voucher{{ID: 9, Code: "PROMOAAAA"}}
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetVouchersByCodes", ctx, "PROMOAAAA", "PROMOBBBB").Return(takenVouchers, nil)
+	mockVoucherRepo.On("GetVouchersByCodes", ctx, "PROMOCCCC").Return([]*entity.Voucher{}, nil)
+	mockVoucherRepo.On("CreateVouchers", ctx, mock.MatchedBy(func(params []*entity.CreateVoucherParam) bool {
+		return len(params) == 2 &&
+			params[0].Code == "PROMOBBBB" &&
+			params[1].Code == "PROMOCCCC" &&
+			params[1].ExpiresAt.Equal(time.Date(2021, 7, 1, 0, 0, 0, 0, jakarta))
+	})).Return(nil)
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, jakarta)
+	codes, err := voucherUsecase.GenerateVouchers(ctx, generateVoucherParam)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PROMOBBBB", "PROMOCCCC"}, codes)
+	mockVoucherRepo.AssertExpectations(t)
+}
+
+func Test_GenerateVouchers_Failed_WhenCreatingVouchers(t *testing.T) {
+	defer stubRandomCodes("AAAA", "BBBB")()
+
+	ctx := context.TODO()
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetVouchersByCodes", ctx, "PROMOAAAA", "PROMOBBBB").Return([]*entity.Voucher{}, nil)
+	mockVoucherRepo.On("CreateVouchers", ctx, mock.Anything).Return(errors.New("failed create vouchers"))
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, jakarta)
+	codes, err := voucherUsecase.GenerateVouchers(ctx, generateVoucherParam)
+	assert.NotNil(t, err)
+	assert.Nil(t, codes)
+}
+
+func Test_GenerateVouchers_Success_AcrossDaylightSavingTime(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Skip("timezone database is not available")
+	}
+
+	defer stubRandomCodes("AAAA", "BBBB")()
+
+	// clocks go back on the last Sunday of October, the day lasts 25 hours
+	ctx := context.TODO()
+	param := generateVoucherParam
+	param.StartDate = "2021-10-01"
+	param.EndDate = "2021-10-31"
+	mockVoucherRepo := new(mocks.VoucherRepository)
+	mockVoucherRepo.On("GetVouchersByCodes", ctx, "PROMOAAAA", "PROMOBBBB").Return([]*entity.Voucher{}, nil)
+	mockVoucherRepo.On("CreateVouchers", ctx, mock.MatchedBy(func(params []*entity.CreateVoucherParam) bool {
+		return len(params) == 2 &&
+			params[0].StartsAt.Equal(time.Date(2021, 10, 1, 0, 0, 0, 0, amsterdam)) &&
+			params[0].ExpiresAt.Equal(time.Date(2021, 11, 1, 0, 0, 0, 0, amsterdam))
+	})).Return(nil)
+
+	voucherUsecase := NewVoucherUsecase(mockVoucherRepo, amsterdam)
+	codes, err := voucherUsecase.GenerateVouchers(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PROMOAAAA", "PROMOBBBB"}, codes)
+	mockVoucherRepo.AssertExpectations(t)
+}
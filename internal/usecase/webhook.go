@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ardafirdausr/kaseer/internal"
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/pkg/webhook"
+)
+
+const webhookSecretPrefix = "whsec_"
+
+// webhookDispatchBatch and webhookDeliveryBatch bound the work of a single
+// run of the dispatcher and of the deliverer.
+const (
+	webhookDispatchBatch = 100
+	webhookDeliveryBatch = 50
+)
+
+// webhookDeliveryLog is how many deliveries the log of a webhook shows.
+const webhookDeliveryLog = 50
+
+// A failed attempt is retried after webhookRetryDelay, doubled on every
+// attempt up to webhookMaxRetryDelay, until webhookMaxAttempts is reached.
+const (
+	webhookRetryDelay    = 30 * time.Second
+	webhookMaxRetryDelay = 6 * time.Hour
+	webhookMaxAttempts   = 10
+)
+
+// webhookErrorLength fits an error in the column of the delivery log, in
+// characters.
+const webhookErrorLength = 255
+
+// webhookRetryAt is when a delivery is attempted again after its attempts.
+func webhookRetryAt(at time.Time, attempts int) time.Time {
+	delay := webhookRetryDelay
+	for i := 1; i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > webhookMaxRetryDelay {
+		delay = webhookMaxRetryDelay
+	}
+
+	return at.Add(delay)
+}
+
+type WebhookUsecase struct {
+	webhookRepository internal.WebhookRepository
+	webhookSender     internal.WebhookSender
+	UnitOfWork        internal.UnitOfWork
+}
+
+func NewWebhookUsecase(
+	webhookRepository internal.WebhookRepository,
+	webhookSender internal.WebhookSender,
+	unitOfWork internal.UnitOfWork) *WebhookUsecase {
+	return &WebhookUsecase{webhookRepository, webhookSender, unitOfWork}
+}
+
+func (wu WebhookUsecase) GetWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	webhooks, err := wu.webhookRepository.GetWebhooks(ctx)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return webhooks, err
+}
+
+func (wu WebhookUsecase) GetWebhook(ctx context.Context, ID int64) (*entity.Webhook, error) {
+	webhook, err := wu.webhookRepository.GetWebhookByID(ctx, ID)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return webhook, err
+}
+
+// canonicalWebhookParam keeps the events of a webhook in the order they are
+// listed and only accepts webhooks posting over HTTP to public addresses.
+func canonicalWebhookParam(param entity.SaveWebhookParam) (entity.SaveWebhookParam, error) {
+	u, err := url.Parse(param.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return param, entity.ErrValidation{
+			Message: "Invalid webhook",
+			Errors:  map[string]string{"URL": "URL must be an http or https URL"},
+		}
+	}
+
+	if err := webhook.CheckHost(u.Hostname()); err != nil {
+		return param, entity.ErrValidation{
+			Message: "Invalid webhook",
+			Errors:  map[string]string{"URL": "URL must point to a public address"},
+		}
+	}
+
+	subscribed := map[string]bool{}
+	for _, event := range param.Events {
+		subscribed[event] = true
+	}
+
+	events := []string{}
+	for _, event := range entity.WebhookEvents {
+		if subscribed[event] {
+			events = append(events, event)
+			delete(subscribed, event)
+		}
+	}
+
+	if len(events) < 1 || len(subscribed) > 0 {
+		return param, entity.ErrValidation{
+			Message: "Invalid webhook",
+			Errors:  map[string]string{"Events": "Events must be some of the listed events"},
+		}
+	}
+
+	param.Events = events
+	return param, nil
+}
+
+func (wu WebhookUsecase) CreateWebhook(ctx context.Context, param entity.SaveWebhookParam) (*entity.Webhook, error) {
+	param, err := canonicalWebhookParam(param)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomHex(24)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	createParam := entity.CreateWebhookParam{
+		SaveWebhookParam: param,
+		Secret:           webhookSecretPrefix + secret,
+	}
+	webhook, err := wu.webhookRepository.Create(ctx, createParam)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (wu WebhookUsecase) UpdateWebhook(ctx context.Context, ID int64, param entity.SaveWebhookParam) error {
+	if _, err := wu.webhookRepository.GetWebhookByID(ctx, ID); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	param, err := canonicalWebhookParam(param)
+	if err != nil {
+		return err
+	}
+
+	if err := wu.webhookRepository.UpdateByID(ctx, ID, param); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (wu WebhookUsecase) DeleteWebhook(ctx context.Context, ID int64) error {
+	if _, err := wu.webhookRepository.GetWebhookByID(ctx, ID); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	if err := wu.webhookRepository.DeleteByID(ctx, ID); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns the latest deliveries of a webhook.
+func (wu WebhookUsecase) GetWebhookDeliveries(ctx context.Context, webhookID int64) ([]*entity.WebhookDelivery, error) {
+	deliveries, err := wu.webhookRepository.GetDeliveriesByWebhookID(ctx, webhookID, webhookDeliveryLog)
+	if err != nil {
+		log.Println(err.Error())
+	}
+
+	return deliveries, err
+}
+
+// RedeliverWebhook queues a delivery again with a fresh set of attempts,
+// whatever came out of the previous ones.
+func (wu WebhookUsecase) RedeliverWebhook(ctx context.Context, webhookID int64, deliveryID int64) error {
+	delivery, err := wu.webhookRepository.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	if delivery.WebhookID != webhookID {
+		return entity.ErrNotFound{Message: "Webhook delivery not found"}
+	}
+
+	param := entity.UpdateWebhookDeliveryParam{
+		Status:         entity.WebhookDeliveryPending,
+		Attempts:       0,
+		NextAttemptAt:  time.Now(),
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+	}
+	if err := wu.webhookRepository.UpdateDeliveryByID(ctx, deliveryID, param); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// DispatchWebhookEvents moves the events of the outbox to the delivery queue
+// of the webhooks subscribed to them, returning how many events it moved.
+// The events stay locked until they are marked, so concurrent dispatchers
+// never queue an event twice.
+func (wu WebhookUsecase) DispatchWebhookEvents(ctx context.Context) (int, error) {
+	txContext, err := wu.UnitOfWork.Begin(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	events, err := wu.webhookRepository.GetUndispatchedEventsForUpdate(txContext, webhookDispatchBatch)
+	if err != nil {
+		wu.UnitOfWork.Rollback(txContext)
+		return 0, err
+	}
+
+	if len(events) < 1 {
+		wu.UnitOfWork.Rollback(txContext)
+		return 0, nil
+	}
+
+	webhooks, err := wu.webhookRepository.GetWebhooks(txContext)
+	if err != nil {
+		wu.UnitOfWork.Rollback(txContext)
+		return 0, err
+	}
+
+	eventIDs := []int64{}
+	deliveries := []*entity.CreateWebhookDeliveryParam{}
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+
+		var body []byte
+		for _, webhook := range webhooks {
+			if !webhook.Active || !webhook.Subscribes(event.Type) {
+				continue
+			}
+
+			if body == nil {
+				body, err = json.Marshal(map[string]interface{}{
+					"id":         event.ID,
+					"type":       event.Type,
+					"created_at": event.CreatedAt,
+					"data":       json.RawMessage(event.Payload),
+				})
+				if err != nil {
+					log.Println(err.Error())
+					wu.UnitOfWork.Rollback(txContext)
+					return 0, err
+				}
+			}
+
+			deliveries = append(deliveries, &entity.CreateWebhookDeliveryParam{
+				WebhookID: webhook.ID,
+				EventID:   event.ID,
+				EventType: event.Type,
+				Body:      string(body),
+			})
+		}
+	}
+
+	if err := wu.webhookRepository.CreateDeliveries(txContext, deliveries); err != nil {
+		wu.UnitOfWork.Rollback(txContext)
+		return 0, err
+	}
+
+	if err := wu.webhookRepository.MarkEventsDispatched(txContext, eventIDs, time.Now()); err != nil {
+		wu.UnitOfWork.Rollback(txContext)
+		return 0, err
+	}
+
+	if err := wu.UnitOfWork.Commit(txContext); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// DeliverWebhooks attempts the deliveries that are due, returning how many
+// it attempted. A receiver acknowledges a delivery with a 2xx status, any
+// other outcome schedules a retry until the attempts run out.
+func (wu WebhookUsecase) DeliverWebhooks(ctx context.Context) (int, error) {
+	deliveries, err := wu.webhookRepository.GetDueDeliveries(ctx, time.Now(), webhookDeliveryBatch)
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	// a delivery which cannot be saved is logged by deliver and attempted
+	// again on the next run, it must not hold back the rest of the batch
+	for _, delivery := range deliveries {
+		wu.deliver(ctx, delivery)
+	}
+
+	return len(deliveries), nil
+}
+
+func (wu WebhookUsecase) deliver(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(delivery.Body)
+	request := entity.WebhookRequest{
+		URL: delivery.WebhookURL,
+		Headers: map[string]string{
+			"Content-Type":       "application/json",
+			"User-Agent":         "Kaseer-Webhook",
+			"X-Kaseer-Event":     delivery.EventType,
+			"X-Kaseer-Delivery":  strconv.FormatInt(delivery.ID, 10),
+			"X-Kaseer-Timestamp": timestamp,
+			"X-Kaseer-Signature": webhook.Sign(delivery.WebhookSecret, timestamp, body),
+		},
+		Body: body,
+	}
+
+	param := entity.UpdateWebhookDeliveryParam{
+		Status:        entity.WebhookDeliverySucceeded,
+		Attempts:      delivery.Attempts + 1,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastAttemptAt: &now,
+	}
+	response, err := wu.webhookSender.Send(ctx, request)
+	if err != nil {
+		param.Error = err.Error()
+	} else {
+		param.ResponseStatus = &response.StatusCode
+		param.ResponseBody = response.Body
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			param.Error = fmt.Sprintf("Receiver answered with status %d", response.StatusCode)
+		}
+	}
+
+	// the error may quote the receiver, it is kept valid for the column
+	param.Error = strings.ToValidUTF8(param.Error, "\uFFFD")
+	if utf8.RuneCountInString(param.Error) > webhookErrorLength {
+		param.Error = string([]rune(param.Error)[:webhookErrorLength])
+	}
+
+	if param.Error != "" {
+		param.Status = entity.WebhookDeliveryPending
+		param.NextAttemptAt = webhookRetryAt(now, param.Attempts)
+		if param.Attempts >= webhookMaxAttempts {
+			param.Status = entity.WebhookDeliveryFailed
+		}
+	}
+
+	if err := wu.webhookRepository.UpdateDeliveryByID(ctx, delivery.ID, param); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+
+	return nil
+}
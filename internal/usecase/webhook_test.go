@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ardafirdausr/kaseer/internal/entity"
+	"github.com/ardafirdausr/kaseer/internal/mocks"
+	"github.com/ardafirdausr/kaseer/internal/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CreateWebhook_Failed_WhenURLIsNotHTTP(t *testing.T) {
+	ctx := context.TODO()
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	param := entity.SaveWebhookParam{
+		URL:    "ftp://example.com/hooks",
+		Events: []string{entity.WebhookEventOrderCreated},
+	}
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	webhook, err := webhookUsecase.CreateWebhook(ctx, param)
+	assert.IsType(t, entity.ErrValidation{}, err)
+	assert.Nil(t, webhook)
+	mockWebhookRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func Test_CreateWebhook_Failed_WhenURLIsInternal(t *testing.T) {
+	urls := []string{
+		"http://localhost:8080/hooks",
+		"http://127.0.0.1/hooks",
+		"http://[::1]/hooks",
+		"http://10.0.0.5/hooks",
+		"http://192.168.1.10/hooks",
+		"http://169.254.169.254/latest/meta-data",
+	}
+
+	for _, u := range urls {
+		t.Run(u, func(t *testing.T) {
+			ctx := context.TODO()
+			mockWebhookRepository := new(mocks.WebhookRepository)
+			mockWebhookSender := new(mocks.WebhookSender)
+			mockUnitOfWork := new(mocks.UnitOfWork)
+			param := entity.SaveWebhookParam{
+				URL:    u,
+				Events: []string{entity.WebhookEventOrderCreated},
+			}
+
+			webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+			webhook, err := webhookUsecase.CreateWebhook(ctx, param)
+			assert.Equal(t, entity.ErrValidation{
+				Message: "Invalid webhook",
+				Errors:  map[string]string{"URL": "URL must point to a public address"},
+			}, err)
+			assert.Nil(t, webhook)
+			mockWebhookRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_CreateWebhook_Success(t *testing.T) {
+	ctx := context.TODO()
+	var created entity.CreateWebhookParam
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("Create", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { created = args.Get(1).(entity.CreateWebhookParam) }).
+		Return(&entity.Webhook{ID: 1}, nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	param := entity.SaveWebhookParam{
+		URL:    "https://example.com/hooks",
+		Events: []string{entity.WebhookEventProductStockLow, entity.WebhookEventOrderCreated},
+		Active: true,
+	}
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	webhook, err := webhookUsecase.CreateWebhook(ctx, param)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), webhook.ID)
+	assert.True(t, strings.HasPrefix(created.Secret, webhookSecretPrefix))
+	assert.Len(t, created.Secret, len(webhookSecretPrefix)+48)
+	assert.Equal(t, []string{entity.WebhookEventOrderCreated, entity.WebhookEventProductStockLow}, created.Events)
+}
+
+func Test_RedeliverWebhook_Failed_WhenDeliveryIsOfAnotherWebhook(t *testing.T) {
+	ctx := context.TODO()
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetDeliveryByID", ctx, int64(3)).Return(&entity.WebhookDelivery{ID: 3, WebhookID: 2}, nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	err := webhookUsecase.RedeliverWebhook(ctx, 1, 3)
+	assert.IsType(t, entity.ErrNotFound{}, err)
+	mockWebhookRepository.AssertNotCalled(t, "UpdateDeliveryByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_DispatchWebhookEvents_Success(t *testing.T) {
+	ctx := context.TODO()
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := []*entity.WebhookEvent{
+		{ID: 7, Type: entity.WebhookEventOrderCreated, Payload: []byte(`{"order":{"id":1}}`), CreatedAt: createdAt},
+		{ID: 8, Type: entity.WebhookEventProductStockLow, Payload: []byte(`{"threshold":5}`), CreatedAt: createdAt},
+	}
+	webhooks := []*entity.Webhook{
+		{ID: 1, Events: []string{entity.WebhookEventOrderCreated}, Active: true},
+		{ID: 2, Events: []string{entity.WebhookEventOrderCreated, entity.WebhookEventProductStockLow}, Active: false},
+	}
+	var deliveries []*entity.CreateWebhookDeliveryParam
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetUndispatchedEventsForUpdate", ctx, webhookDispatchBatch).Return(events, nil)
+	mockWebhookRepository.On("GetWebhooks", ctx).Return(webhooks, nil)
+	mockWebhookRepository.On("CreateDeliveries", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { deliveries = args.Get(1).([]*entity.CreateWebhookDeliveryParam) }).
+		Return(nil)
+	mockWebhookRepository.On("MarkEventsDispatched", ctx, []int64{7, 8}, mock.AnythingOfType("time.Time")).Return(nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+	mockUnitOfWork.On("Begin", ctx).Return(ctx, nil)
+	mockUnitOfWork.On("Commit", ctx).Return(nil)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	dispatched, err := webhookUsecase.DispatchWebhookEvents(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, dispatched)
+
+	// the inactive webhook gets nothing, the stock event has no subscriber
+	if assert.Len(t, deliveries, 1) {
+		assert.Equal(t, int64(1), deliveries[0].WebhookID)
+		assert.Equal(t, int64(7), deliveries[0].EventID)
+
+		var body map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(deliveries[0].Body), &body))
+		assert.Equal(t, entity.WebhookEventOrderCreated, body["type"])
+		assert.Equal(t, map[string]interface{}{"order": map[string]interface{}{"id": float64(1)}}, body["data"])
+	}
+	mockUnitOfWork.AssertCalled(t, "Commit", ctx)
+}
+
+func Test_DeliverWebhooks_Success_WhenReceiverAcknowledges(t *testing.T) {
+	ctx := context.TODO()
+	delivery := &entity.WebhookDelivery{
+		ID:            4,
+		WebhookID:     1,
+		WebhookURL:    "https://example.com/hooks",
+		WebhookSecret: "whsec_secret",
+		EventType:     entity.WebhookEventOrderCreated,
+		Body:          `{"id":7}`,
+		Status:        entity.WebhookDeliveryPending,
+	}
+	var request entity.WebhookRequest
+	var updated entity.UpdateWebhookDeliveryParam
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetDueDeliveries", ctx, mock.AnythingOfType("time.Time"), webhookDeliveryBatch).
+		Return([]*entity.WebhookDelivery{delivery}, nil)
+	mockWebhookRepository.On("UpdateDeliveryByID", ctx, delivery.ID, mock.Anything).
+		Run(func(args mock.Arguments) { updated = args.Get(2).(entity.UpdateWebhookDeliveryParam) }).
+		Return(nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockWebhookSender.On("Send", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { request = args.Get(1).(entity.WebhookRequest) }).
+		Return(&entity.WebhookResponse{StatusCode: 204}, nil)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	delivered, err := webhookUsecase.DeliverWebhooks(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, delivered)
+
+	timestamp := request.Headers["X-Kaseer-Timestamp"]
+	assert.Equal(t, webhook.Sign("whsec_secret", timestamp, []byte(delivery.Body)), request.Headers["X-Kaseer-Signature"])
+	assert.Equal(t, "4", request.Headers["X-Kaseer-Delivery"])
+	assert.Equal(t, entity.WebhookDeliverySucceeded, updated.Status)
+	assert.Equal(t, 1, updated.Attempts)
+	assert.Equal(t, 204, *updated.ResponseStatus)
+	assert.Empty(t, updated.Error)
+}
+
+func Test_DeliverWebhooks_Success_WhenReceiverIsDown(t *testing.T) {
+	ctx := context.TODO()
+	delivery := &entity.WebhookDelivery{ID: 4, Attempts: 2, Status: entity.WebhookDeliveryPending}
+	var updated entity.UpdateWebhookDeliveryParam
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetDueDeliveries", ctx, mock.AnythingOfType("time.Time"), webhookDeliveryBatch).
+		Return([]*entity.WebhookDelivery{delivery}, nil)
+	mockWebhookRepository.On("UpdateDeliveryByID", ctx, delivery.ID, mock.Anything).
+		Run(func(args mock.Arguments) { updated = args.Get(2).(entity.UpdateWebhookDeliveryParam) }).
+		Return(nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockWebhookSender.On("Send", ctx, mock.Anything).Return(nil, errors.New("connection refused"))
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	_, err := webhookUsecase.DeliverWebhooks(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.WebhookDeliveryPending, updated.Status)
+	assert.Equal(t, 3, updated.Attempts)
+	assert.Equal(t, "connection refused", updated.Error)
+	assert.Nil(t, updated.ResponseStatus)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), updated.NextAttemptAt, time.Second)
+}
+
+func Test_DeliverWebhooks_Success_WhenAttemptsRunOut(t *testing.T) {
+	ctx := context.TODO()
+	delivery := &entity.WebhookDelivery{ID: 4, Attempts: webhookMaxAttempts - 1, Status: entity.WebhookDeliveryPending}
+	var updated entity.UpdateWebhookDeliveryParam
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetDueDeliveries", ctx, mock.AnythingOfType("time.Time"), webhookDeliveryBatch).
+		Return([]*entity.WebhookDelivery{delivery}, nil)
+	mockWebhookRepository.On("UpdateDeliveryByID", ctx, delivery.ID, mock.Anything).
+		Run(func(args mock.Arguments) { updated = args.Get(2).(entity.UpdateWebhookDeliveryParam) }).
+		Return(nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockWebhookSender.On("Send", ctx, mock.Anything).Return(&entity.WebhookResponse{StatusCode: 500, Body: "oops"}, nil)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	_, err := webhookUsecase.DeliverWebhooks(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.WebhookDeliveryFailed, updated.Status)
+	assert.Equal(t, webhookMaxAttempts, updated.Attempts)
+	assert.Equal(t, 500, *updated.ResponseStatus)
+	assert.Equal(t, "oops", updated.ResponseBody)
+}
+
+func Test_WebhookRetryAt(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.Add(30*time.Second), webhookRetryAt(at, 1))
+	assert.Equal(t, at.Add(4*time.Minute), webhookRetryAt(at, 4))
+	assert.Equal(t, at.Add(webhookMaxRetryDelay), webhookRetryAt(at, 20))
+}
+
+func Test_DeliverWebhooks_Success_WhenErrorIsCutInACharacter(t *testing.T) {
+	ctx := context.TODO()
+	delivery := &entity.WebhookDelivery{ID: 4, Status: entity.WebhookDeliveryPending}
+	var updated entity.UpdateWebhookDeliveryParam
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetDueDeliveries", ctx, mock.AnythingOfType("time.Time"), webhookDeliveryBatch).
+		Return([]*entity.WebhookDelivery{delivery}, nil)
+	mockWebhookRepository.On("UpdateDeliveryByID", ctx, delivery.ID, mock.Anything).
+		Run(func(args mock.Arguments) { updated = args.Get(2).(entity.UpdateWebhookDeliveryParam) }).
+		Return(nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockWebhookSender.On("Send", ctx, mock.Anything).
+		Return(nil, errors.New(strings.Repeat("é", webhookErrorLength)+"\xff"))
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	_, err := webhookUsecase.DeliverWebhooks(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Repeat("é", webhookErrorLength), updated.Error)
+}
+
+func Test_DeliverWebhooks_Success_WhenADeliveryCannotBeSaved(t *testing.T) {
+	ctx := context.TODO()
+	deliveries := []*entity.WebhookDelivery{
+		{ID: 4, Status: entity.WebhookDeliveryPending},
+		{ID: 5, Status: entity.WebhookDeliveryPending},
+	}
+	mockWebhookRepository := new(mocks.WebhookRepository)
+	mockWebhookRepository.On("GetDueDeliveries", ctx, mock.AnythingOfType("time.Time"), webhookDeliveryBatch).
+		Return(deliveries, nil)
+	mockWebhookRepository.On("UpdateDeliveryByID", ctx, int64(4), mock.Anything).Return(errors.New("Incorrect string value"))
+	mockWebhookRepository.On("UpdateDeliveryByID", ctx, int64(5), mock.Anything).Return(nil)
+	mockWebhookSender := new(mocks.WebhookSender)
+	mockWebhookSender.On("Send", ctx, mock.Anything).Return(&entity.WebhookResponse{StatusCode: 204}, nil)
+	mockUnitOfWork := new(mocks.UnitOfWork)
+
+	webhookUsecase := NewWebhookUsecase(mockWebhookRepository, mockWebhookSender, mockUnitOfWork)
+	delivered, err := webhookUsecase.DeliverWebhooks(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, delivered)
+	mockWebhookRepository.AssertCalled(t, "UpdateDeliveryByID", ctx, int64(5), mock.Anything)
+}
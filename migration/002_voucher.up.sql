@@ -0,0 +1,31 @@
+ALTER TABLE `orders` ADD COLUMN `discount` int(11) NOT NULL DEFAULT 0 AFTER `total`;
+
+CREATE TABLE `vouchers` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `code` varchar(32) NOT NULL,
+  `value_type` enum('fixed','percent') NOT NULL DEFAULT 'fixed',
+  `value` int(11) NOT NULL DEFAULT 0,
+  `min_spend` int(11) NOT NULL DEFAULT 0,
+  `starts_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `expires_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `usage_limit` int(11) NOT NULL DEFAULT 0,
+  `usage_limit_per_customer` int(11) NOT NULL DEFAULT 0,
+  `used_count` int(11) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `code` (`code`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `voucher_redemptions` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `voucher_id` int(11) NOT NULL,
+  `order_id` int(11) NOT NULL,
+  `customer_id` int(11) DEFAULT NULL,
+  `discount` int(11) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_voucher_customer` (`voucher_id`, `customer_id`),
+  FOREIGN KEY `fk_voucher_id` (`voucher_id`) REFERENCES `vouchers`(`id`),
+  FOREIGN KEY `fk_redemption_order_id` (`order_id`) REFERENCES `orders`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
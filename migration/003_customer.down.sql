@@ -0,0 +1,5 @@
+ALTER TABLE `voucher_redemptions` DROP FOREIGN KEY `fk_redemption_customer_id`;
+ALTER TABLE `orders` DROP FOREIGN KEY `fk_order_customer_id`;
+ALTER TABLE `orders` DROP KEY `idx_order_customer`;
+ALTER TABLE `orders` DROP COLUMN `customer_id`;
+DROP TABLE IF EXISTS customers;
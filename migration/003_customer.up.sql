@@ -0,0 +1,20 @@
+CREATE TABLE `customers` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `name` varchar(50) NOT NULL,
+  `phone` varchar(20) NOT NULL DEFAULT '',
+  `email` varchar(50) NOT NULL DEFAULT '',
+  `notes` text NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_customer_phone` (`phone`),
+  KEY `idx_customer_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE `orders`
+  ADD COLUMN `customer_id` int(11) DEFAULT NULL AFTER `id`,
+  ADD KEY `idx_order_customer` (`customer_id`, `created_at`),
+  ADD CONSTRAINT `fk_order_customer_id` FOREIGN KEY (`customer_id`) REFERENCES `customers`(`id`);
+
+ALTER TABLE `voucher_redemptions`
+  ADD CONSTRAINT `fk_redemption_customer_id` FOREIGN KEY (`customer_id`) REFERENCES `customers`(`id`);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS loyalty_entries;
+DROP TABLE IF EXISTS loyalty_multipliers;
+DROP TABLE IF EXISTS loyalty_settings;
+DROP TABLE IF EXISTS order_payments;
+ALTER TABLE `orders` DROP COLUMN `status`;
+ALTER TABLE `products` DROP COLUMN `category`;
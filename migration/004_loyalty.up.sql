@@ -0,0 +1,46 @@
+ALTER TABLE `products` ADD COLUMN `category` varchar(50) NOT NULL DEFAULT '';
+
+ALTER TABLE `orders` ADD COLUMN `status` enum('completed','voided','refunded') NOT NULL DEFAULT 'completed' AFTER `customer_id`;
+
+CREATE TABLE `order_payments` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `order_id` int(11) NOT NULL,
+  `method` varchar(20) NOT NULL,
+  `amount` int(11) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  FOREIGN KEY `fk_payment_order_id` (`order_id`) REFERENCES `orders`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `loyalty_settings` (
+  `id` int(11) NOT NULL,
+  `spend_per_point` int(11) NOT NULL DEFAULT 0,
+  `point_value` int(11) NOT NULL DEFAULT 0,
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO `loyalty_settings` (`id`, `spend_per_point`, `point_value`) VALUES (1, 10000, 100);
+
+CREATE TABLE `loyalty_multipliers` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `category` varchar(50) NOT NULL,
+  `multiplier` decimal(5,2) NOT NULL DEFAULT 1.00,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `category` (`category`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `loyalty_entries` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `customer_id` int(11) NOT NULL,
+  `order_id` int(11) DEFAULT NULL,
+  `type` enum('earn','redeem','clawback','restore') NOT NULL,
+  `points` int(11) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_loyalty_customer` (`customer_id`),
+  KEY `idx_loyalty_order` (`order_id`),
+  FOREIGN KEY `fk_loyalty_customer_id` (`customer_id`) REFERENCES `customers`(`id`),
+  FOREIGN KEY `fk_loyalty_order_id` (`order_id`) REFERENCES `orders`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS prepaid_transactions;
+DROP TABLE IF EXISTS prepaid_cards;
+ALTER TABLE `order_payments` DROP COLUMN `reference`;
+ALTER TABLE `products` DROP COLUMN `is_gift_card`;
@@ -0,0 +1,34 @@
+ALTER TABLE `products` ADD COLUMN `is_gift_card` tinyint(1) NOT NULL DEFAULT 0;
+
+ALTER TABLE `order_payments` ADD COLUMN `reference` varchar(32) NOT NULL DEFAULT '' AFTER `amount`;
+
+CREATE TABLE `prepaid_cards` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `number` varchar(32) NOT NULL,
+  `type` enum('gift_card','store_credit') NOT NULL,
+  `customer_id` int(11) DEFAULT NULL,
+  `order_id` int(11) DEFAULT NULL,
+  `value` int(11) NOT NULL DEFAULT 0,
+  `balance` int(11) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `number` (`number`),
+  KEY `idx_prepaid_card_order` (`order_id`),
+  FOREIGN KEY `fk_prepaid_card_customer_id` (`customer_id`) REFERENCES `customers`(`id`),
+  FOREIGN KEY `fk_prepaid_card_order_id` (`order_id`) REFERENCES `orders`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `prepaid_transactions` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `prepaid_card_id` int(11) NOT NULL,
+  `order_id` int(11) DEFAULT NULL,
+  `type` enum('issue','redeem','refund','cancel') NOT NULL,
+  `amount` int(11) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_prepaid_transaction_card` (`prepaid_card_id`),
+  KEY `idx_prepaid_transaction_order` (`order_id`),
+  FOREIGN KEY `fk_prepaid_transaction_card_id` (`prepaid_card_id`) REFERENCES `prepaid_cards`(`id`),
+  FOREIGN KEY `fk_prepaid_transaction_order_id` (`order_id`) REFERENCES `orders`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
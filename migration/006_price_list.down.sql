@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
+ALTER TABLE `customers` DROP FOREIGN KEY `fk_customer_group_id`;
+ALTER TABLE `customers` DROP COLUMN `customer_group_id`;
+DROP TABLE IF EXISTS customer_groups;
@@ -0,0 +1,34 @@
+CREATE TABLE `customer_groups` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `name` varchar(50) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE `customers` ADD COLUMN `customer_group_id` int(11) DEFAULT NULL AFTER `id`;
+ALTER TABLE `customers` ADD CONSTRAINT `fk_customer_group_id` FOREIGN KEY (`customer_group_id`) REFERENCES `customer_groups`(`id`);
+
+CREATE TABLE `price_lists` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `customer_group_id` int(11) NOT NULL,
+  `name` varchar(50) NOT NULL,
+  `starts_on` date DEFAULT NULL,
+  `ends_on` date DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  FOREIGN KEY `fk_price_list_customer_group_id` (`customer_group_id`) REFERENCES `customer_groups`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `price_list_items` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `price_list_id` int(11) NOT NULL,
+  `product_id` int(11) NOT NULL,
+  `min_quantity` int(11) NOT NULL DEFAULT 1,
+  `price` int(11) NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `price_list_product_quantity` (`price_list_id`, `product_id`, `min_quantity`),
+  FOREIGN KEY `fk_price_list_item_price_list_id` (`price_list_id`) REFERENCES `price_lists`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY `fk_price_list_item_product_id` (`product_id`) REFERENCES `products`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
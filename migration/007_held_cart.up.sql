@@ -0,0 +1,13 @@
+CREATE TABLE `held_carts` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `register` varchar(50) NOT NULL,
+  `label` varchar(50) NOT NULL,
+  `customer_id` int(11) DEFAULT NULL,
+  `total` int(11) NOT NULL DEFAULT 0,
+  `cart` text NOT NULL,
+  `expires_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_held_cart_register` (`register`, `expires_at`),
+  FOREIGN KEY `fk_held_cart_customer_id` (`customer_id`) REFERENCES `customers`(`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
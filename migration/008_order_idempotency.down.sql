@@ -0,0 +1,3 @@
+ALTER TABLE `orders` DROP INDEX `idempotency_key`;
+ALTER TABLE `orders` DROP COLUMN `request_hash`;
+ALTER TABLE `orders` DROP COLUMN `idempotency_key`;
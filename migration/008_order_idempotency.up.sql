@@ -0,0 +1,3 @@
+ALTER TABLE `orders` ADD COLUMN `idempotency_key` varchar(64) DEFAULT NULL;
+ALTER TABLE `orders` ADD COLUMN `request_hash` char(64) NOT NULL DEFAULT '';
+ALTER TABLE `orders` ADD UNIQUE `idempotency_key` (`idempotency_key`);
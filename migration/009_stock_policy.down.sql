@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS negative_stock_sales;
+ALTER TABLE `products` DROP COLUMN `stock_policy`;
+DROP TABLE IF EXISTS store_settings;
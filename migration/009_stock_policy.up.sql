@@ -0,0 +1,26 @@
+CREATE TABLE `store_settings` (
+  `id` int(11) NOT NULL,
+  `stock_policy` enum('block','warn','allow') NOT NULL DEFAULT 'block',
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO `store_settings` (`id`, `stock_policy`) VALUES (1, 'block');
+
+-- empty means the product follows the store policy
+ALTER TABLE `products` ADD COLUMN `stock_policy` enum('','block','warn','allow') NOT NULL DEFAULT '';
+
+CREATE TABLE `negative_stock_sales` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `order_id` int(11) NOT NULL,
+  `product_id` int(11) NOT NULL,
+  `quantity` int(11) NOT NULL,
+  `shortfall` int(11) NOT NULL,
+  `stock_after` int(11) NOT NULL,
+  `reconciled_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_negative_stock_product` (`product_id`),
+  FOREIGN KEY `fk_negative_stock_order_id` (`order_id`) REFERENCES `orders`(`id`),
+  FOREIGN KEY `fk_negative_stock_product_id` (`product_id`) REFERENCES `products`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
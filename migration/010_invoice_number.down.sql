@@ -0,0 +1,7 @@
+ALTER TABLE `orders` DROP INDEX `invoice_number`, DROP COLUMN `invoice_number`;
+DROP TABLE IF EXISTS invoice_sequences;
+ALTER TABLE `store_settings`
+  DROP COLUMN `invoice_reset`,
+  DROP COLUMN `invoice_pattern`,
+  DROP COLUMN `store_code`,
+  DROP COLUMN `store_name`;
@@ -0,0 +1,14 @@
+ALTER TABLE `store_settings`
+  ADD COLUMN `store_name` varchar(100) NOT NULL DEFAULT 'Kaseer' AFTER `id`,
+  ADD COLUMN `store_code` varchar(10) NOT NULL DEFAULT 'KSR' AFTER `store_name`,
+  ADD COLUMN `invoice_pattern` varchar(50) NOT NULL DEFAULT 'INV/{store}/{YYYYMMDD}/{seq}' AFTER `store_code`,
+  ADD COLUMN `invoice_reset` enum('daily','yearly') NOT NULL DEFAULT 'daily' AFTER `invoice_pattern`;
+
+CREATE TABLE `invoice_sequences` (
+  `period` varchar(8) NOT NULL,
+  `last_number` int(11) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`period`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- orders placed before invoice numbers keep a NULL number
+ALTER TABLE `orders` ADD COLUMN `invoice_number` varchar(64) NULL DEFAULT NULL AFTER `id`, ADD UNIQUE `invoice_number` (`invoice_number`);
@@ -0,0 +1,7 @@
+ALTER TABLE `orders` DROP FOREIGN KEY `fk_order_cashier_id`;
+ALTER TABLE `orders`
+  DROP KEY `idx_order_total`,
+  DROP KEY `idx_order_status`,
+  DROP KEY `idx_order_created_at`,
+  DROP KEY `idx_order_cashier`;
+ALTER TABLE `orders` DROP COLUMN `cashier_id`;
@@ -0,0 +1,8 @@
+-- orders placed before cashiers were recorded keep a NULL cashier
+ALTER TABLE `orders`
+  ADD COLUMN `cashier_id` int(11) DEFAULT NULL AFTER `customer_id`,
+  ADD KEY `idx_order_cashier` (`cashier_id`, `created_at`),
+  ADD KEY `idx_order_created_at` (`created_at`),
+  ADD KEY `idx_order_status` (`status`, `created_at`),
+  ADD KEY `idx_order_total` (`total`),
+  ADD CONSTRAINT `fk_order_cashier_id` FOREIGN KEY (`cashier_id`) REFERENCES `users`(`id`);
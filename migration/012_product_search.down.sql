@@ -0,0 +1,6 @@
+ALTER TABLE `products`
+  DROP KEY `ft_product_name`,
+  DROP KEY `idx_product_stock`,
+  DROP KEY `idx_product_price`,
+  DROP KEY `idx_product_name`,
+  DROP KEY `idx_product_category`;
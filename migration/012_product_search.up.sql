@@ -0,0 +1,6 @@
+ALTER TABLE `products`
+  ADD KEY `idx_product_category` (`category`, `name`),
+  ADD KEY `idx_product_name` (`name`),
+  ADD KEY `idx_product_price` (`price`),
+  ADD KEY `idx_product_stock` (`stock`),
+  ADD FULLTEXT KEY `ft_product_name` (`name`);
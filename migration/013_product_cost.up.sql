@@ -0,0 +1,2 @@
+-- what a unit costs the store, margins are counted against the current cost
+ALTER TABLE `products` ADD COLUMN `cost` int(11) NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_associations;
+DROP TABLE IF EXISTS market_basket_runs;
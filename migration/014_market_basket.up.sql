@@ -0,0 +1,25 @@
+CREATE TABLE `market_basket_runs` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `start_date` date NOT NULL,
+  `end_date` date NOT NULL,
+  `min_orders` int(11) NOT NULL,
+  `orders` int(11) NOT NULL DEFAULT 0,
+  `associations` int(11) NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- the rules of the latest run, a pair is kept in both directions because
+-- confidence is not symmetric
+CREATE TABLE `product_associations` (
+  `product_id` int(11) NOT NULL,
+  `associated_product_id` int(11) NOT NULL,
+  `orders` int(11) NOT NULL,
+  `support` double NOT NULL,
+  `confidence` double NOT NULL,
+  `lift` double NOT NULL,
+  PRIMARY KEY (`product_id`, `associated_product_id`),
+  KEY `idx_product_association_lift` (`lift`),
+  FOREIGN KEY `fk_product_association_product_id` (`product_id`) REFERENCES `products`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY `fk_product_association_associated_product_id` (`associated_product_id`) REFERENCES `products`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
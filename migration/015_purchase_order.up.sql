@@ -0,0 +1,22 @@
+CREATE TABLE `purchase_orders` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `supplier` varchar(100) NOT NULL DEFAULT '',
+  `status` varchar(20) NOT NULL DEFAULT 'draft',
+  `notes` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_purchase_order_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `purchase_order_items` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `purchase_order_id` int(11) NOT NULL,
+  `product_id` int(11) NOT NULL,
+  `quantity` int(11) NOT NULL,
+  `cost` int(11) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  UNIQUE `purchase_order_product` (`purchase_order_id`, `product_id`),
+  FOREIGN KEY `fk_purchase_order_item_purchase_order_id` (`purchase_order_id`) REFERENCES `purchase_orders`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY `fk_purchase_order_item_product_id` (`product_id`) REFERENCES `products`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
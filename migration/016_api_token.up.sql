@@ -0,0 +1,18 @@
+ALTER TABLE `users` ADD `service_account` tinyint(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE `api_tokens` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `user_id` int(11) NOT NULL,
+  `name` varchar(50) NOT NULL,
+  `prefix` varchar(16) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL DEFAULT '',
+  `last_used_at` timestamp NULL DEFAULT NULL,
+  `expires_at` timestamp NULL DEFAULT NULL,
+  `revoked_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `api_token_hash` (`token_hash`),
+  KEY `idx_api_token_user_id` (`user_id`),
+  FOREIGN KEY `fk_api_token_user_id` (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
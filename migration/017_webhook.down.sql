@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_events;
+DROP TABLE IF EXISTS webhooks;
+ALTER TABLE `store_settings` DROP COLUMN `low_stock_threshold`;
@@ -0,0 +1,44 @@
+ALTER TABLE `store_settings` ADD COLUMN `low_stock_threshold` int(11) NOT NULL DEFAULT 5 AFTER `stock_policy`;
+
+CREATE TABLE `webhooks` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `url` varchar(255) NOT NULL,
+  `secret` varchar(64) NOT NULL,
+  `events` varchar(255) NOT NULL DEFAULT '',
+  `active` tinyint(1) NOT NULL DEFAULT 1,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- the outbox, written in the transaction of the change an event tells about
+CREATE TABLE `webhook_events` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `type` varchar(50) NOT NULL,
+  `payload` mediumtext NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `dispatched_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_webhook_event_dispatched_at` (`dispatched_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `webhook_deliveries` (
+  `id` int(11) AUTO_INCREMENT NOT NULL,
+  `webhook_id` int(11) NOT NULL,
+  `event_id` int(11) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `body` mediumtext NOT NULL,
+  `status` varchar(20) NOT NULL DEFAULT 'pending',
+  `attempts` int(11) NOT NULL DEFAULT 0,
+  `next_attempt_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `last_attempt_at` timestamp NULL DEFAULT NULL,
+  `response_status` int(11) NULL DEFAULT NULL,
+  `response_body` text NOT NULL,
+  `error` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE `webhook_delivery_event` (`webhook_id`, `event_id`),
+  KEY `idx_webhook_delivery_due` (`status`, `next_attempt_at`),
+  FOREIGN KEY `fk_webhook_delivery_webhook_id` (`webhook_id`) REFERENCES `webhooks`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY `fk_webhook_delivery_event_id` (`event_id`) REFERENCES `webhook_events`(`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,24 @@
+CREATE TABLE `audit_logs` (
+  `id` bigint(20) AUTO_INCREMENT NOT NULL,
+  `actor_id` int(11) NULL DEFAULT NULL,
+  `actor_name` varchar(255) NOT NULL DEFAULT '',
+  `action` varchar(50) NOT NULL,
+  `entity_type` varchar(50) NOT NULL,
+  `entity_id` int(11) NOT NULL,
+  `changes` mediumtext NOT NULL,
+  `ip_address` varchar(45) NOT NULL DEFAULT '',
+  `user_agent` varchar(255) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  KEY `idx_audit_log_entity` (`entity_type`, `entity_id`),
+  KEY `idx_audit_log_actor_id` (`actor_id`),
+  KEY `idx_audit_log_action` (`action`),
+  KEY `idx_audit_log_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- the log is append-only, even for the application's own database user
+CREATE TRIGGER `audit_logs_no_update` BEFORE UPDATE ON `audit_logs` FOR EACH ROW
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit logs are append-only';
+
+CREATE TRIGGER `audit_logs_no_delete` BEFORE DELETE ON `audit_logs` FOR EACH ROW
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit logs are append-only';
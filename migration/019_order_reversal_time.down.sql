@@ -0,0 +1,4 @@
+ALTER TABLE `orders`
+  DROP INDEX `idx_order_refunded_at`,
+  DROP COLUMN `refunded_at`,
+  DROP COLUMN `voided_at`;
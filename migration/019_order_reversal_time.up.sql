@@ -0,0 +1,9 @@
+-- the orders reversed before the time was recorded count as reversed when
+-- they were placed
+ALTER TABLE `orders`
+  ADD COLUMN `voided_at` timestamp NULL DEFAULT NULL AFTER `created_at`,
+  ADD COLUMN `refunded_at` timestamp NULL DEFAULT NULL AFTER `voided_at`,
+  ADD KEY `idx_order_refunded_at` (`refunded_at`);
+
+UPDATE `orders` SET `voided_at` = `created_at` WHERE `status` = 'voided';
+UPDATE `orders` SET `refunded_at` = `created_at` WHERE `status` = 'refunded';